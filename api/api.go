@@ -1,15 +1,23 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
 	"github.com/ONSdigital/dis-bundle-api/application"
 	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
 	"github.com/ONSdigital/dis-bundle-api/pagination"
 	"github.com/ONSdigital/dis-bundle-api/store"
 	auth "github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
@@ -49,10 +57,54 @@ func Setup(ctx context.Context, cfg *config.Config, router *mux.Router, dataStor
 		"/bundles/{bundle-id}/contents",
 		authMiddleware.RequireWithAttributes("bundles:read", paginator.Paginate(api.getBundleContents), api.getDatasetEditionAttributeForBundle),
 	)
+	api.get(
+		"/bundles/{bundle-id}/publish-runs",
+		authMiddleware.Require("bundles:read", pagination.Paginate(paginator, api.getPublishRuns)),
+	)
+	api.get(
+		"/bundles/{bundle-id}/transitions",
+		authMiddleware.Require("bundles:read", api.getBundleTransitions),
+	)
+	api.get(
+		"/bundles/{bundle-id}/approvals",
+		authMiddleware.Require("bundles:read", api.getBundleApprovals),
+	)
+	api.get(
+		"/bundles/{bundle-id}/release-date-check",
+		authMiddleware.Require("bundles:read", api.getReleaseDateCheck),
+	)
+	api.get(
+		"/bundles/{bundle-id}/conflicts",
+		authMiddleware.Require("bundles:read", api.getBundleConflicts),
+	)
+	api.get(
+		"/bundles/{bundle-id}/comments",
+		authMiddleware.Require("bundles:read", pagination.Paginate(paginator, api.getComments)),
+	)
+	api.get(
+		"/bundles/{bundle-id}/contents/{content-id}/comments",
+		authMiddleware.Require("bundles:read", pagination.Paginate(paginator, api.getComments)),
+	)
 	api.get(
 		"/bundle-events",
 		authMiddleware.Require("bundles:read", paginator.Paginate(api.getBundleEvents)),
 	)
+	api.get(
+		"/contents",
+		authMiddleware.Require("bundles:read", pagination.Paginate(paginator, api.getContents)),
+	)
+	api.get(
+		"/search",
+		authMiddleware.Require("bundles:read", pagination.Paginate(paginator, api.searchBundles)),
+	)
+	api.get(
+		"/publish-schedule",
+		authMiddleware.Require("bundles:read", pagination.Paginate(paginator, api.getPublishSchedule)),
+	)
+	api.get(
+		"/release-calendar",
+		authMiddleware.Require("bundles:read", api.getReleaseCalendar),
+	)
 
 	// post
 	api.post(
@@ -63,13 +115,57 @@ func Setup(ctx context.Context, cfg *config.Config, router *mux.Router, dataStor
 		"/bundles/{bundle-id}/contents",
 		authMiddleware.Require("bundles:create", api.postBundleContents),
 	)
+	api.post(
+		"/bundles/{bundle-id}/contents/batch",
+		authMiddleware.Require("bundles:create", api.postBundleContentsBatch),
+	)
+	api.post(
+		"/bundles/{bundle-id}/publish-preflight",
+		authMiddleware.Require("bundles:update", api.postPublishPreflight),
+	)
+	api.post(
+		"/bundles/{bundle-id}/approvals",
+		authMiddleware.Require("bundles:update", api.postBundleApproval),
+	)
+	api.post(
+		"/bundles/{bundle-id}/comments",
+		authMiddleware.Require("bundles:update", api.postComment),
+	)
+	api.post(
+		"/bundles/{bundle-id}/contents/{content-id}/comments",
+		authMiddleware.Require("bundles:update", api.postComment),
+	)
+	api.post(
+		"/bundles/{bundle-id}/comments/{comment-id}/resolve",
+		authMiddleware.Require("bundles:update", api.resolveComment),
+	)
+	api.post(
+		"/bundles/{bundle-id}/contents/{content-id}/comments/{comment-id}/resolve",
+		authMiddleware.Require("bundles:update", api.resolveComment),
+	)
+	api.post(
+		"/bundles/{bundle-id}/comments/{comment-id}/unresolve",
+		authMiddleware.Require("bundles:update", api.unresolveComment),
+	)
+	api.post(
+		"/bundles/{bundle-id}/contents/{content-id}/comments/{comment-id}/unresolve",
+		authMiddleware.Require("bundles:update", api.unresolveComment),
+	)
+	api.post(
+		"/bundles/{bundle-id}/reschedule",
+		authMiddleware.Require("bundles:update", api.rescheduleBundle),
+	)
+	api.post(
+		"/bundles/{bundle-id}/cancel-schedule",
+		authMiddleware.Require("bundles:update", api.cancelBundleSchedule),
+	)
 
 	// put
 	api.put("/bundles/{bundle-id}",
-		authMiddleware.Require("bundles:update", api.putBundle),
+		requireStatePermission(authMiddleware, RouteNamePutBundle, api.putBundle),
 	)
 	api.put("/bundles/{bundle-id}/state",
-		authMiddleware.Require("bundles:update", api.putBundleState),
+		requireStatePermission(authMiddleware, RouteNamePutBundleState, api.putBundleState),
 	)
 
 	// delete
@@ -77,10 +173,19 @@ func Setup(ctx context.Context, cfg *config.Config, router *mux.Router, dataStor
 		"/bundles/{bundle-id}",
 		authMiddleware.Require("bundles:delete", api.deleteBundle),
 	)
+	// registered before /contents/{content-id} so that "batch" is not taken to be a content item ID
+	api.delete(
+		"/bundles/{bundle-id}/contents/batch",
+		authMiddleware.Require("bundles:delete", api.deleteBundleContentsBatch),
+	)
 	api.delete(
 		"/bundles/{bundle-id}/contents/{content-id}",
 		authMiddleware.Require("bundles:delete", api.deleteContentItem),
 	)
+	api.delete(
+		"/bundles/{bundle-id}/approvals",
+		authMiddleware.Require("bundles:update", api.deleteBundleApproval),
+	)
 
 	return api
 }
@@ -105,6 +210,84 @@ func (api *BundleAPI) delete(path string, handler http.HandlerFunc) {
 	api.Router.HandleFunc(path, handler).Methods(http.MethodDelete)
 }
 
+// maxStateRequestBodySize is the most that is read of the body of a request to find the state it asks for
+const maxStateRequestBodySize = 1 << 20
+
+// requireStatePermission requires the bundles:update permission for any change to a bundle, and the bundles:unpublish
+// permission as well to withdraw a published bundle. The request body is only read, to find the requested state, once
+// the caller has the bundles:update permission, and is then restored for the handler.
+func requireStatePermission(authMiddleware auth.Middleware, routeName string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+	requireUnpublish := authMiddleware.Require(statePermission(models.BundleStateWithdrawn), handlerFunc)
+
+	return authMiddleware.Require(statePermission(models.BundleStateDraft), func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxStateRequestBodySize))
+		if err != nil {
+			handleErr(r.Context(), w, r, apierrors.ErrInvalidBody, log.Data{}, routeName)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var stateRequest models.UpdateStateRequest
+		if json.Unmarshal(body, &stateRequest) == nil &&
+			models.BundleState(strings.TrimSpace(stateRequest.State.String())) == models.BundleStateWithdrawn {
+			requireUnpublish(w, r)
+			return
+		}
+
+		handlerFunc(w, r)
+	})
+}
+
+// statePermission returns the permission required to move a bundle to state
+func statePermission(state models.BundleState) string {
+	if state == models.BundleStateWithdrawn {
+		return "bundles:unpublish"
+	}
+	return "bundles:update"
+}
+
+// addPermissionPreconditions adds an unmet precondition to each of the transitions the caller of r does not have the
+// permission to make. Whether the caller has each permission is recorded in permitted, so it is only checked once.
+func (api *BundleAPI) addPermissionPreconditions(r *http.Request, transitions []models.AvailableTransition, permitted map[string]bool) {
+	for index := range transitions {
+		transition := &transitions[index]
+
+		permission := statePermission(transition.State)
+		if _, ok := permitted[permission]; !ok {
+			permitted[permission] = api.hasPermission(r, permission)
+		}
+
+		if !permitted[permission] {
+			transition.AddUnmetPrecondition(fmt.Sprintf("requires the %s permission", permission))
+		}
+	}
+}
+
+// hasPermission returns whether the caller of r has permission, by running the authorisation middleware's check on
+// the request without handling it
+func (api *BundleAPI) hasPermission(r *http.Request, permission string) bool {
+	permitted := false
+	api.authMiddleware.Require(permission, func(http.ResponseWriter, *http.Request) {
+		permitted = true
+	})(&discardResponseWriter{header: http.Header{}}, r)
+	return permitted
+}
+
+// discardResponseWriter is an http.ResponseWriter that discards everything written to it
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *discardResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *discardResponseWriter) WriteHeader(int) {}
+
 // getDatasetEditionAttributeForBundle provides the "dataset_edition" attribute required
 // for conditional preview-team policies to apply to bundle read endpoints.
 func (api *BundleAPI) getDatasetEditionAttributeForBundle(req *http.Request) (map[string]string, error) {
@@ -1,10 +1,14 @@
 package api
 
 import (
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/ONSdigital/dis-bundle-api/store"
+	authorisationMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
 	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
 	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
 	"github.com/gorilla/mux"
@@ -23,13 +27,146 @@ func TestSetup(t *testing.T) {
 			So(hasRoute(api.Router, "/bundles/{bundle-id}", "DELETE"), ShouldBeTrue)
 			So(hasRoute(api.Router, "/bundles/{bundle-id}/contents", "POST"), ShouldBeTrue)
 			So(hasRoute(api.Router, "/bundles/{bundle-id}/contents/{content-id}", "DELETE"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/contents/batch", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/contents/batch", "DELETE"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/publish-runs", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/publish-preflight", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/transitions", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/approvals", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/approvals", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/approvals", "DELETE"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/release-date-check", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/release-calendar", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/conflicts", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/contents", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/search", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/comments", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/comments", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/comments/{comment-id}/resolve", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/comments/{comment-id}/unresolve", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/contents/{content-id}/comments", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/contents/{content-id}/comments", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/contents/{content-id}/comments/{comment-id}/resolve", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/contents/{content-id}/comments/{comment-id}/unresolve", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/reschedule", "POST"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/bundles/{bundle-id}/cancel-schedule", "POST"), ShouldBeTrue)
 			So(hasRoute(api.Router, "/bundle-events", "GET"), ShouldBeTrue)
+			So(hasRoute(api.Router, "/publish-schedule", "GET"), ShouldBeTrue)
 
 			So(hasRoute(api.Router, "/bundles/{bundle-id}/state", "PUT"), ShouldBeTrue)
 		})
 	})
 }
 
+func TestRequireStatePermission(t *testing.T) {
+	Convey("Given a handler wrapped by requireStatePermission", t, func() {
+		var requiredPermissions []string
+		var handled bool
+		var handledBody string
+		deniedPermissions := map[string]bool{}
+		authMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					requiredPermissions = append(requiredPermissions, permission)
+					if deniedPermissions[permission] {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+					handlerFunc(w, r)
+				}
+			},
+		}
+
+		handler := requireStatePermission(authMiddleware, RouteNamePutBundleState, func(w http.ResponseWriter, r *http.Request) {
+			handled = true
+			body := new(strings.Builder)
+			_, err := io.Copy(body, r.Body)
+			So(err, ShouldBeNil)
+			handledBody = body.String()
+		})
+
+		Convey("When a request is made to withdraw a bundle", func() {
+			r := httptest.NewRequest(http.MethodPut, "/bundles/bundle1/state", strings.NewReader(`{"state":"WITHDRAWN"}`))
+			handler(httptest.NewRecorder(), r)
+
+			Convey("Then the bundles:update permission is required, followed by the bundles:unpublish permission", func() {
+				So(requiredPermissions, ShouldResemble, []string{"bundles:update", "bundles:unpublish"})
+			})
+
+			Convey("And the handler can still read the request body", func() {
+				So(handledBody, ShouldEqual, `{"state":"WITHDRAWN"}`)
+			})
+		})
+
+		Convey("When a request is made to update a bundle and withdraw it", func() {
+			r := httptest.NewRequest(http.MethodPut, "/bundles/bundle1", strings.NewReader(`{"title":"bundle","state":" WITHDRAWN "}`))
+			handler(httptest.NewRecorder(), r)
+
+			Convey("Then the bundles:unpublish permission is required", func() {
+				So(requiredPermissions, ShouldResemble, []string{"bundles:update", "bundles:unpublish"})
+			})
+		})
+
+		Convey("When a request is made to withdraw a bundle by a caller without the bundles:unpublish permission", func() {
+			deniedPermissions["bundles:unpublish"] = true
+			r := httptest.NewRequest(http.MethodPut, "/bundles/bundle1/state", strings.NewReader(`{"state":"WITHDRAWN"}`))
+			rec := httptest.NewRecorder()
+			handler(rec, r)
+
+			Convey("Then the request is forbidden and the handler is not called", func() {
+				So(rec.Code, ShouldEqual, http.StatusForbidden)
+				So(handled, ShouldBeFalse)
+			})
+		})
+
+		Convey("When a request is made to move a bundle to any other state", func() {
+			r := httptest.NewRequest(http.MethodPut, "/bundles/bundle1/state", strings.NewReader(`{"state":"PUBLISHED"}`))
+			handler(httptest.NewRecorder(), r)
+
+			Convey("Then only the bundles:update permission is required", func() {
+				So(requiredPermissions, ShouldResemble, []string{"bundles:update"})
+				So(handledBody, ShouldEqual, `{"state":"PUBLISHED"}`)
+			})
+		})
+
+		Convey("When a request is made by a caller without the bundles:update permission", func() {
+			deniedPermissions["bundles:update"] = true
+			body := strings.NewReader(`{"state":"WITHDRAWN"}`)
+			r := httptest.NewRequest(http.MethodPut, "/bundles/bundle1/state", body)
+			rec := httptest.NewRecorder()
+			handler(rec, r)
+
+			Convey("Then the request is forbidden without the request body being read", func() {
+				So(rec.Code, ShouldEqual, http.StatusForbidden)
+				So(requiredPermissions, ShouldResemble, []string{"bundles:update"})
+				So(body.Len(), ShouldEqual, len(`{"state":"WITHDRAWN"}`))
+				So(handled, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the request body is larger than the most that is read", func() {
+			r := httptest.NewRequest(http.MethodPut, "/bundles/bundle1/state", strings.NewReader(strings.Repeat(" ", maxStateRequestBodySize+1)))
+			rec := httptest.NewRecorder()
+			handler(rec, r)
+
+			Convey("Then the request is rejected as a bad request and the handler is not called", func() {
+				So(rec.Code, ShouldEqual, http.StatusBadRequest)
+				So(handled, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the request body is not valid JSON", func() {
+			r := httptest.NewRequest(http.MethodPut, "/bundles/bundle1/state", strings.NewReader(`not json`))
+			handler(httptest.NewRecorder(), r)
+
+			Convey("Then the bundles:update permission is required and the handler rejects the body", func() {
+				So(requiredPermissions, ShouldResemble, []string{"bundles:update"})
+				So(handledBody, ShouldEqual, "not json")
+			})
+		})
+	})
+}
+
 func hasRoute(r *mux.Router, path, method string) bool {
 	var found bool
 	r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
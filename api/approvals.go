@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	RouteNameGetBundleApprovals   = "getBundleApprovals"
+	RouteNamePostBundleApproval   = "postBundleApproval"
+	RouteNameDeleteBundleApproval = "deleteBundleApproval"
+)
+
+// getBundleApprovals lists the approvals recorded against a bundle and how many it needs
+func (api *BundleAPI) getBundleApprovals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	bundleApprovals, err := api.stateMachineBundleAPI.ListApprovals(ctx, bundleID)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameGetBundleApprovals)
+		return
+	}
+
+	approvalsJSON, err := json.Marshal(bundleApprovals)
+	if err != nil {
+		log.Error(ctx, "getBundleApprovals endpoint: failed to marshal approvals to JSON", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(approvalsJSON); err != nil {
+		log.Error(ctx, "getBundleApprovals endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logSuccessfulRequest(ctx, logData, RouteNameGetBundleApprovals)
+}
+
+// postBundleApproval records the caller's approval of a bundle that is in review
+func (api *BundleAPI) postBundleApproval(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNamePostBundleApproval)
+		return
+	}
+
+	approval, err := api.stateMachineBundleAPI.RecordApproval(ctx, bundleID, authEntityData)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNamePostBundleApproval)
+		return
+	}
+
+	approvalJSON, err := json.Marshal(approval)
+	if err != nil {
+		log.Error(ctx, "postBundleApproval endpoint: failed to marshal approval to JSON", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := w.Write(approvalJSON); err != nil {
+		log.Error(ctx, "postBundleApproval endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logData["approval_id"] = approval.ID
+	logSuccessfulRequest(ctx, logData, RouteNamePostBundleApproval)
+}
+
+// deleteBundleApproval withdraws the caller's approval of a bundle that is in review
+func (api *BundleAPI) deleteBundleApproval(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameDeleteBundleApproval)
+		return
+	}
+
+	if err = api.stateMachineBundleAPI.WithdrawApproval(ctx, bundleID, authEntityData); err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameDeleteBundleApproval)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	logSuccessfulRequest(ctx, logData, RouteNameDeleteBundleApproval)
+}
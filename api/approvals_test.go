@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBundleApprovals(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a bundle in review that needs two approvals and has been approved by one user", t, func() {
+		w := httptest.NewRecorder()
+
+		bundle := &models.Bundle{ID: "bundle1", State: models.BundleStateInReview, CreatedBy: &models.User{Email: "creator@ons.gov.uk"}}
+		approvals := []*models.Approval{{ID: "approval1", BundleID: "bundle1", ApprovedBy: &models.User{Email: "first@ons.gov.uk"}}}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				if bundleID != bundle.ID {
+					return nil, apierrors.ErrBundleNotFound
+				}
+				return bundle, nil
+			},
+			ListApprovalsFunc: func(ctx context.Context, bundleID string) ([]*models.Approval, error) {
+				return approvals, nil
+			},
+			CreateApprovalFunc: func(ctx context.Context, approval *models.Approval) error {
+				return nil
+			},
+			DeleteApprovalFunc: func(ctx context.Context, bundleID, approvedBy string) error {
+				return apierrors.ErrApprovalNotFound
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+		bundleAPI.stateMachineBundleAPI.ApprovalPolicy = application.ApprovalPolicy{RequireDifferentCreator: true, Quorum: 2}
+
+		Convey("When GET /bundles/{bundle-id}/approvals is called", func() {
+			r := createRequestWithAuth(http.MethodGet, "/bundles/bundle1/approvals", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK listing the approvals and the quorum", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var bundleApprovals models.BundleApprovals
+				So(json.NewDecoder(w.Body).Decode(&bundleApprovals), ShouldBeNil)
+				So(bundleApprovals.BundleID, ShouldEqual, "bundle1")
+				So(bundleApprovals.Quorum, ShouldEqual, 2)
+				So(bundleApprovals.Items, ShouldHaveLength, 1)
+				So(bundleApprovals.Items[0].ApprovedBy.Email, ShouldEqual, "first@ons.gov.uk")
+			})
+		})
+
+		Convey("When GET /bundles/{bundle-id}/approvals is called for a bundle that does not exist", func() {
+			r := createRequestWithAuth(http.MethodGet, "/bundles/missing/approvals", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/approvals is called by another user", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/approvals", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 201 Created with their approval", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+
+				var approval models.Approval
+				So(json.NewDecoder(w.Body).Decode(&approval), ShouldBeNil)
+				So(approval.BundleID, ShouldEqual, "bundle1")
+				So(approval.ApprovedBy.Email, ShouldEqual, "User123")
+				So(mockedDatastore.CreateApprovalCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/approvals is called by the creator", func() {
+			bundle.CreatedBy.Email = "User123"
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/approvals", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 403 Forbidden", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mockedDatastore.CreateApprovalCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/approvals is called for a bundle that is not in review", func() {
+			bundle.State = models.BundleStateDraft
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/approvals", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 409 Conflict", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+			})
+		})
+
+		Convey("When DELETE /bundles/{bundle-id}/approvals is called by a user who has not approved the bundle", func() {
+			r := createRequestWithAuth(http.MethodDelete, "/bundles/bundle1/approvals", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(mockedDatastore.DeleteApprovalCalls()[0].ApprovedBy, ShouldEqual, "User123")
+			})
+		})
+
+		Convey("When DELETE /bundles/{bundle-id}/approvals is called by a user who has approved the bundle", func() {
+			mockedDatastore.DeleteApprovalFunc = func(ctx context.Context, bundleID, approvedBy string) error {
+				return nil
+			}
+			r := createRequestWithAuth(http.MethodDelete, "/bundles/bundle1/approvals", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 204 No Content", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+			})
+		})
+	})
+}
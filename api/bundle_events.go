@@ -1,22 +1,26 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	"github.com/ONSdigital/dis-bundle-api/utils"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
-func (api *BundleAPI) getBundleEvents(w http.ResponseWriter, r *http.Request, limit, offset int) (events any, totalCount int, eventErrors *models.Error) {
+func (api *BundleAPI) getBundleEvents(w http.ResponseWriter, r *http.Request, limit, offset int) (events any, totalCount int, nextCursor string, eventErrors *models.Error) {
 	ctx := r.Context()
 
 	allowedParams := map[string]bool{
 		"bundle": true,
 		"after":  true,
 		"before": true,
+		"sort":   true,
+		"cursor": true,
 		"limit":  true,
 		"offset": true,
 	}
@@ -71,26 +75,54 @@ func (api *BundleAPI) getBundleEvents(w http.ResponseWriter, r *http.Request, li
 		}
 	}
 
+	sortFields, sortErr := filters.CreateSort(r, filters.EventSortFields)
+	if sortErr != nil {
+		code := models.CodeInvalidParameters
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionMalformedRequest,
+			Source:      sortErr.Source,
+		}
+		validationErrors = append(validationErrors, errInfo)
+	}
+
+	cursor, cursorErr := filters.CreateCursor(r)
+	if cursorErr != nil {
+		code := models.CodeInvalidParameters
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionMalformedRequest,
+			Source:      cursorErr.Source,
+		}
+		validationErrors = append(validationErrors, errInfo)
+	}
+
 	if len(validationErrors) > 0 {
 		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, validationErrors...)
-		return nil, 0, validationErrors[0]
+		return nil, 0, "", validationErrors[0]
 	}
 
-	events, totalCount, err := api.stateMachineBundleAPI.ListBundleEvents(ctx, offset, limit, bundleID, after, before)
+	events, totalCount, nextCursor, err := api.stateMachineBundleAPI.ListBundleEvents(ctx, offset, limit, bundleID, after, before, sortFields, cursor)
+	if errors.Is(err, apierrors.ErrInvalidCursor) {
+		log.Error(ctx, "invalid cursor for bundle events", err)
+		errInfo := models.GetMatchingModelError(err)
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, errInfo)
+		return nil, 0, "", errInfo
+	}
 	if err != nil {
 		code := models.CodeInternalError
 		log.Error(ctx, "failed to get bundle events", err)
 		errInfo := &models.Error{Code: &code, Description: apierrors.ErrorDescriptionInternalError}
 		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
-		return nil, 0, nil
+		return nil, 0, "", nil
 	}
 
 	if totalCount == 0 {
 		code := models.CodeNotFound
 		errInfo := &models.Error{Code: &code, Description: apierrors.ErrorDescriptionNotFound}
 		utils.HandleBundleAPIErr(w, r, http.StatusNotFound, errInfo)
-		return nil, 0, errInfo
+		return nil, 0, "", errInfo
 	}
 
-	return events, totalCount, nil
+	return events, totalCount, nextCursor, nil
 }
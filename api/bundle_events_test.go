@@ -8,7 +8,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
 	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	slackMock "github.com/ONSdigital/dis-bundle-api/slack/mocks"
 	"github.com/ONSdigital/dis-bundle-api/store"
@@ -26,8 +28,8 @@ var testEvent = &models.Event{
 func TestGetBundleEvents_Success(t *testing.T) {
 	Convey("Given a successful request with no query parameters", t, func() {
 		mockDatastore := &storetest.StorerMock{
-			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time) ([]*models.Event, int, error) {
-				return []*models.Event{testEvent}, 1, nil
+			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
+				return []*models.Event{testEvent}, 1, "", nil
 			},
 		}
 
@@ -35,7 +37,7 @@ func TestGetBundleEvents_Success(t *testing.T) {
 		mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{}
 		mockSlackClient := &slackMock.ClienterMock{}
 		stateMachine := &application.StateMachine{}
-		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, application.ApprovalPolicy{}, nil)
 
 		api := &BundleAPI{
 			stateMachineBundleAPI: stateMachineBundleAPI,
@@ -45,7 +47,7 @@ func TestGetBundleEvents_Success(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		Convey("When getBundleEvents is called", func() {
-			events, totalCount, err := api.getBundleEvents(w, req, 20, 0)
+			events, totalCount, _, err := api.getBundleEvents(w, req, 20, 0)
 
 			Convey("Then it should return events successfully", func() {
 				So(err, ShouldBeNil)
@@ -59,9 +61,9 @@ func TestGetBundleEvents_Success(t *testing.T) {
 func TestGetBundleEvents_WithBundleFilter(t *testing.T) {
 	Convey("Given a request with bundle ID filter", t, func() {
 		mockDatastore := &storetest.StorerMock{
-			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time) ([]*models.Event, int, error) {
+			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
 				So(bundleID, ShouldEqual, "test-bundle")
-				return []*models.Event{testEvent}, 1, nil
+				return []*models.Event{testEvent}, 1, "", nil
 			},
 		}
 
@@ -69,7 +71,7 @@ func TestGetBundleEvents_WithBundleFilter(t *testing.T) {
 		mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{}
 		mockSlackClient := &slackMock.ClienterMock{}
 		stateMachine := &application.StateMachine{}
-		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, application.ApprovalPolicy{}, nil)
 
 		api := &BundleAPI{
 			stateMachineBundleAPI: stateMachineBundleAPI,
@@ -79,7 +81,7 @@ func TestGetBundleEvents_WithBundleFilter(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		Convey("When getBundleEvents is called", func() {
-			events, totalCount, err := api.getBundleEvents(w, req, 20, 0)
+			events, totalCount, _, err := api.getBundleEvents(w, req, 20, 0)
 
 			Convey("Then it should filter by bundle ID", func() {
 				So(err, ShouldBeNil)
@@ -93,7 +95,7 @@ func TestGetBundleEvents_WithBundleFilter(t *testing.T) {
 func TestGetBundleEvents_WithDateFilter(t *testing.T) {
 	Convey("Given a request with valid date filters", t, func() {
 		mockDatastore := &storetest.StorerMock{
-			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time) ([]*models.Event, int, error) {
+			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
 				So(after, ShouldNotBeNil)
 				So(before, ShouldNotBeNil)
 				So(after.Year(), ShouldEqual, 2025)
@@ -102,7 +104,7 @@ func TestGetBundleEvents_WithDateFilter(t *testing.T) {
 				So(before.Year(), ShouldEqual, 2025)
 				So(before.Month(), ShouldEqual, 12)
 				So(before.Day(), ShouldEqual, 31)
-				return []*models.Event{testEvent}, 1, nil
+				return []*models.Event{testEvent}, 1, "", nil
 			},
 		}
 
@@ -110,7 +112,7 @@ func TestGetBundleEvents_WithDateFilter(t *testing.T) {
 		mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{}
 		mockSlackClient := &slackMock.ClienterMock{}
 		stateMachine := &application.StateMachine{}
-		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, application.ApprovalPolicy{}, nil)
 
 		api := &BundleAPI{
 			stateMachineBundleAPI: stateMachineBundleAPI,
@@ -120,7 +122,7 @@ func TestGetBundleEvents_WithDateFilter(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		Convey("When getBundleEvents is called", func() {
-			events, totalCount, err := api.getBundleEvents(w, req, 20, 0)
+			events, totalCount, _, err := api.getBundleEvents(w, req, 20, 0)
 
 			Convey("Then it should filter by date range", func() {
 				So(err, ShouldBeNil)
@@ -138,7 +140,7 @@ func TestGetBundleEvents_InvalidDateFormat(t *testing.T) {
 		mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{}
 		mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{}
 		mockSlackClient := &slackMock.ClienterMock{}
-		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, application.ApprovalPolicy{}, nil)
 
 		api := &BundleAPI{
 			stateMachineBundleAPI: stateMachineBundleAPI,
@@ -148,7 +150,7 @@ func TestGetBundleEvents_InvalidDateFormat(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		Convey("When getBundleEvents is called", func() {
-			_, totalCount, err := api.getBundleEvents(w, req, 20, 0)
+			_, totalCount, _, err := api.getBundleEvents(w, req, 20, 0)
 
 			Convey("Then it should handle the error gracefully", func() {
 				So(err, ShouldNotBeNil)
@@ -159,6 +161,92 @@ func TestGetBundleEvents_InvalidDateFormat(t *testing.T) {
 	})
 }
 
+func TestGetBundleEvents_WithSort(t *testing.T) {
+	Convey("Given a request sorted by action and then oldest first", t, func() {
+		mockDatastore := &storetest.StorerMock{
+			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
+				return []*models.Event{testEvent}, 1, "", nil
+			},
+		}
+		stateMachine := &application.StateMachine{}
+		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, &slackMock.ClienterMock{}, "", 0, application.ApprovalPolicy{}, nil)
+
+		api := &BundleAPI{
+			stateMachineBundleAPI: stateMachineBundleAPI,
+		}
+
+		Convey("When getBundleEvents is called", func() {
+			req := httptest.NewRequest("GET", "/bundle-events?sort=action,created_at", http.NoBody)
+			w := httptest.NewRecorder()
+			_, _, _, err := api.getBundleEvents(w, req, 20, 0)
+
+			Convey("Then the sort fields are passed to the datastore", func() {
+				So(err, ShouldBeNil)
+				So(mockDatastore.ListBundleEventsCalls(), ShouldHaveLength, 1)
+				So(mockDatastore.ListBundleEventsCalls()[0].SortFields, ShouldResemble, []filters.SortField{{Field: "action"}, {Field: "created_at"}})
+			})
+		})
+
+		Convey("When getBundleEvents is called with a field that events cannot be sorted by", func() {
+			req := httptest.NewRequest("GET", "/bundle-events?sort=-title", http.NoBody)
+			w := httptest.NewRecorder()
+			_, _, _, err := api.getBundleEvents(w, req, 20, 0)
+
+			Convey("Then it should return a 400 error against the sort parameter", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Source.Parameter, ShouldEqual, "sort")
+				So(w.Code, ShouldEqual, 400)
+				So(mockDatastore.ListBundleEventsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetBundleEvents_WithCursor(t *testing.T) {
+	Convey("Given a datastore that returns a page of events with a cursor for the next page", t, func() {
+		mockDatastore := &storetest.StorerMock{
+			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
+				if *cursor == "unknown" {
+					return nil, 0, "", apierrors.ErrInvalidCursor
+				}
+				return []*models.Event{testEvent}, 2, "next", nil
+			},
+		}
+		stateMachine := &application.StateMachine{}
+		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, &slackMock.ClienterMock{}, "", 0, application.ApprovalPolicy{}, nil)
+
+		api := &BundleAPI{
+			stateMachineBundleAPI: stateMachineBundleAPI,
+		}
+
+		Convey("When getBundleEvents is called with a cursor", func() {
+			req := httptest.NewRequest("GET", "/bundle-events?cursor=current&limit=1", http.NoBody)
+			w := httptest.NewRecorder()
+			events, totalCount, nextCursor, err := api.getBundleEvents(w, req, 1, 0)
+
+			Convey("Then the cursor is passed to the datastore and the next cursor returned", func() {
+				So(err, ShouldBeNil)
+				So(events, ShouldResemble, []*models.Event{testEvent})
+				So(totalCount, ShouldEqual, 2)
+				So(nextCursor, ShouldEqual, "next")
+				So(*mockDatastore.ListBundleEventsCalls()[0].Cursor, ShouldEqual, "current")
+			})
+		})
+
+		Convey("When getBundleEvents is called with a cursor that is not valid for the request", func() {
+			req := httptest.NewRequest("GET", "/bundle-events?cursor=unknown", http.NoBody)
+			w := httptest.NewRecorder()
+			_, _, _, err := api.getBundleEvents(w, req, 20, 0)
+
+			Convey("Then it should return a 400 error against the cursor parameter", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Source.Parameter, ShouldEqual, "cursor")
+				So(w.Code, ShouldEqual, 400)
+			})
+		})
+	})
+}
+
 func TestGetBundleEvents_UnknownParameter(t *testing.T) {
 	Convey("Given a request with unknown query parameter", t, func() {
 		mockDatastore := &storetest.StorerMock{}
@@ -166,7 +254,7 @@ func TestGetBundleEvents_UnknownParameter(t *testing.T) {
 		mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{}
 		mockSlackClient := &slackMock.ClienterMock{}
 		stateMachine := &application.StateMachine{}
-		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, application.ApprovalPolicy{}, nil)
 
 		api := &BundleAPI{
 			stateMachineBundleAPI: stateMachineBundleAPI,
@@ -176,7 +264,7 @@ func TestGetBundleEvents_UnknownParameter(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		Convey("When getBundleEvents is called", func() {
-			_, totalCount, err := api.getBundleEvents(w, req, 20, 0)
+			_, totalCount, _, err := api.getBundleEvents(w, req, 20, 0)
 
 			Convey("Then it should return a 400 error", func() {
 				So(err, ShouldNotBeNil)
@@ -190,8 +278,8 @@ func TestGetBundleEvents_UnknownParameter(t *testing.T) {
 func TestGetBundleEvents_InternalError(t *testing.T) {
 	Convey("Given a request that causes an internal error", t, func() {
 		mockDatastore := &storetest.StorerMock{
-			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time) ([]*models.Event, int, error) {
-				return nil, 0, errors.New("database error")
+			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
+				return nil, 0, "", errors.New("database error")
 			},
 		}
 
@@ -199,7 +287,7 @@ func TestGetBundleEvents_InternalError(t *testing.T) {
 		mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{}
 		mockSlackClient := &slackMock.ClienterMock{}
 		stateMachine := &application.StateMachine{}
-		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, application.ApprovalPolicy{}, nil)
 
 		api := &BundleAPI{
 			stateMachineBundleAPI: stateMachineBundleAPI,
@@ -209,7 +297,7 @@ func TestGetBundleEvents_InternalError(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		Convey("When getBundleEvents is called", func() {
-			events, totalCount, err := api.getBundleEvents(w, req, 20, 0)
+			events, totalCount, _, err := api.getBundleEvents(w, req, 20, 0)
 
 			Convey("Then it should handle the internal error", func() {
 				So(err, ShouldBeNil)
@@ -224,8 +312,8 @@ func TestGetBundleEvents_InternalError(t *testing.T) {
 func TestGetBundleEvents_NoResults(t *testing.T) {
 	Convey("Given a request with no results and no filters", t, func() {
 		mockDatastore := &storetest.StorerMock{
-			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time) ([]*models.Event, int, error) {
-				return []*models.Event{}, 0, nil
+			ListBundleEventsFunc: func(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
+				return []*models.Event{}, 0, "", nil
 			},
 		}
 
@@ -233,7 +321,7 @@ func TestGetBundleEvents_NoResults(t *testing.T) {
 		mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{}
 		mockSlackClient := &slackMock.ClienterMock{}
 		stateMachine := &application.StateMachine{}
-		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := application.Setup(store.Datastore{Backend: mockDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, application.ApprovalPolicy{}, nil)
 
 		api := &BundleAPI{
 			stateMachineBundleAPI: stateMachineBundleAPI,
@@ -243,7 +331,7 @@ func TestGetBundleEvents_NoResults(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		Convey("When getBundleEvents is called", func() {
-			_, totalCount, err := api.getBundleEvents(w, req, 20, 0)
+			_, totalCount, _, err := api.getBundleEvents(w, req, 20, 0)
 
 			Convey("Then it should return a 404 error", func() {
 				So(err, ShouldNotBeNil)
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -29,6 +30,7 @@ const (
 	RouteNamePutBundleState = "putBundleState"
 	RouteNameDeleteBundle   = "deleteBundle"
 
+	RouteNameGetContents        = "getContents"
 	RouteNameGetBundleContents  = "getBundleContents"
 	RouteNamePostBundleContents = "postBundleContents"
 	RouteNameDeleteContentItem  = "deleteContentItem"
@@ -45,7 +47,11 @@ func (api *BundleAPI) getBundles(w http.ResponseWriter, r *http.Request, limit,
 		return nil, models.CreateBadRequestErrorResult(invalidRequestError)
 	}
 
-	bundles, totalCount, err := api.stateMachineBundleAPI.ListBundles(ctx, offset, limit, bundleFilters)
+	bundles, totalCount, nextCursor, err := api.stateMachineBundleAPI.ListBundles(ctx, offset, limit, bundleFilters)
+	if errors.Is(err, errs.ErrInvalidCursor) {
+		log.Error(ctx, "invalid cursor for bundles", err)
+		return nil, models.CreateBadRequestErrorResult(models.GetMatchingModelError(err))
+	}
 	if err != nil {
 		code := models.CodeInternalError
 		log.Error(ctx, "failed to get bundles", err)
@@ -53,6 +59,20 @@ func (api *BundleAPI) getBundles(w http.ResponseWriter, r *http.Request, limit,
 		return nil, models.CreateInternalErrorResult(internalError)
 	}
 
+	if bundleFilters.View.Embeds(filters.EmbedTransitions) {
+		authEntityData, err := api.GetAuthEntityData(r)
+		if err != nil {
+			log.Error(ctx, "failed to get auth entity data for bundle transitions", err)
+			return nil, models.CreateErrorResult(models.GetMatchingModelError(err), errs.GetStatusCodeForErr(err))
+		}
+		if err := api.embedBundleTransitions(r, bundles, authEntityData); err != nil {
+			code := models.CodeInternalError
+			log.Error(ctx, "failed to get bundle transitions", err)
+			internalError := &models.Error{Code: &code, Description: errs.ErrorDescriptionInternalError}
+			return nil, models.CreateInternalErrorResult(internalError)
+		}
+	}
+
 	if totalCount == 0 && bundleFilters.PublishDate != nil {
 		code := models.CodeNotFound
 		log.Warn(ctx, fmt.Sprintf("Request for bundles with publish_date %s produced no results", bundleFilters.PublishDate))
@@ -60,20 +80,42 @@ func (api *BundleAPI) getBundles(w http.ResponseWriter, r *http.Request, limit,
 		return nil, models.CreateNotFoundResult(notFoundError)
 	}
 
-	return models.CreatePaginationSuccessResult(bundles, totalCount), nil
+	return models.CreateCursorPaginationSuccessResult(bundles, totalCount, nextCursor), nil
 }
 
 func (api *BundleAPI) getBundle(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	bundleID, logData := getBundleIDAndLogData(r)
 
-	bundle, err := api.stateMachineBundleAPI.GetBundle(ctx, bundleID)
+	view, filtersErr := filters.CreateBundleView(r)
+	if filtersErr != nil {
+		log.Error(ctx, filtersErr.Error.Error(), errs.ErrInvalidQueryParameter, logData)
+		code := models.CodeInvalidParameters
+		invalidRequestError := &models.Error{Code: &code, Description: errs.ErrorDescriptionMalformedRequest, Source: filtersErr.Source}
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, invalidRequestError)
+		return
+	}
+
+	bundle, err := api.stateMachineBundleAPI.GetBundleView(ctx, bundleID, view)
 	if err != nil {
 		handleErr(ctx, w, r, err, logData, RouteNameGetBundle)
 		return
 	}
 
-	bundleBytes := setETagAndCacheControlHeaders(ctx, w, r, bundle, logData)
+	if view.Embeds(filters.EmbedTransitions) {
+		authEntityData, err := api.GetAuthEntityData(r)
+		if err != nil {
+			handleErr(ctx, w, r, err, logData, RouteNameGetBundle)
+			return
+		}
+		if err := api.embedBundleTransitions(r, []*models.Bundle{bundle}, authEntityData); err != nil {
+			handleErr(ctx, w, r, err, logData, RouteNameGetBundle)
+			return
+		}
+	}
+
+	// the ETag of a bundle is for the whole bundle, so any other view of it is only weakly matched by it
+	bundleBytes := setETagAndCacheControlHeaders(ctx, w, r, bundle, !view.IsFull(), logData)
 
 	_, err = w.Write(bundleBytes)
 	if err != nil {
@@ -109,14 +151,14 @@ func (api *BundleAPI) putBundleState(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bundle, err := api.stateMachineBundleAPI.UpdateBundleState(ctx, bundleID, *etag, stateRequest.State, authEntityData)
+	bundle, err := api.stateMachineBundleAPI.UpdateBundleState(ctx, bundleID, *etag, stateRequest.State, stateRequest.Reason, authEntityData)
 
 	if err != nil {
 		handleErr(ctx, w, r, err, logData, RouteNamePutBundleState)
 		return
 	}
 
-	bundleBytes := setETagAndCacheControlHeaders(ctx, w, r, bundle, logData)
+	bundleBytes := setETagAndCacheControlHeaders(ctx, w, r, bundle, false, logData)
 	if _, err = w.Write(bundleBytes); err != nil {
 		log.Error(ctx, "failed writing bytes to response", err, logData)
 		return
@@ -139,6 +181,21 @@ func getUpdateStateRequestBody(r *http.Request) (*models.UpdateStateRequest, err
 	return stateRequest, nil
 }
 
+// embedBundleTransitions embeds the transitions each of the bundles can make, marking those the caller of r does not
+// have the permission to make
+func (api *BundleAPI) embedBundleTransitions(r *http.Request, bundles []*models.Bundle, authEntityData *models.AuthEntityData) error {
+	if err := api.stateMachineBundleAPI.EmbedBundleTransitions(r.Context(), bundles, authEntityData); err != nil {
+		return err
+	}
+
+	permitted := make(map[string]bool)
+	for _, bundle := range bundles {
+		api.addPermissionPreconditions(r, *bundle.Embedded.Transitions, permitted)
+	}
+
+	return nil
+}
+
 func handleErr(ctx context.Context, w http.ResponseWriter, r *http.Request, err error, logData log.Data, endpoint string) {
 	errorEvent := fmt.Sprintf("%s endpoint: %s", endpoint, err.Error())
 	log.Error(ctx, errorEvent, err, logData)
@@ -169,7 +226,9 @@ func getBundleIDAndContentIDAndLogData(r *http.Request) (bundleID, contentID str
 	return bundleID, contentID, logData
 }
 
-func setETagAndCacheControlHeaders(ctx context.Context, w http.ResponseWriter, r *http.Request, bundle *models.Bundle, logData log.Data) []byte {
+// setETagAndCacheControlHeaders marshals the bundle and sets the headers for it, returning the marshalled bundle.
+// If weak is true the ETag is given as a weak ETag, which cannot be used in an If-Match header.
+func setETagAndCacheControlHeaders(ctx context.Context, w http.ResponseWriter, r *http.Request, bundle *models.Bundle, weak bool, logData log.Data) []byte {
 	bundleBytes, err := json.Marshal(bundle)
 	if err != nil {
 		log.Error(ctx, "failed to marshal bundle into bytes", err, logData)
@@ -186,6 +245,10 @@ func setETagAndCacheControlHeaders(ctx context.Context, w http.ResponseWriter, r
 		ETag = bundle.GenerateETag(&bundleBytes)
 	}
 
+	if weak {
+		ETag = fmt.Sprintf(`W/"%s"`, ETag)
+	}
+
 	dpresponse.SetETag(w, ETag)
 
 	return bundleBytes
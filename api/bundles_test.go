@@ -23,6 +23,7 @@ import (
 	"github.com/ONSdigital/dis-bundle-api/utils"
 
 	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/pagination"
 	"github.com/ONSdigital/dis-bundle-api/store"
 	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
 	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
@@ -252,9 +253,9 @@ func TestGetBundles_Success(t *testing.T) {
 			},
 		}
 
-		bundleFilterFunc := func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, error) {
+		bundleFilterFunc := func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
 			if filters == nil || filters.PublishDate == nil {
-				return defaultBundles, len(defaultBundles), nil
+				return defaultBundles, len(defaultBundles), "", nil
 			}
 
 			var filteredBundles []*models.Bundle
@@ -266,7 +267,7 @@ func TestGetBundles_Success(t *testing.T) {
 				}
 			}
 
-			return filteredBundles, len(filteredBundles), nil
+			return filteredBundles, len(filteredBundles), "", nil
 		}
 
 		Convey("When offset and limit values are default", func() {
@@ -275,8 +276,8 @@ func TestGetBundles_Success(t *testing.T) {
 				w := httptest.NewRecorder()
 
 				mockedDatastore := &storetest.StorerMock{
-					ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, error) {
-						return defaultBundles, len(defaultBundles), nil
+					ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+						return defaultBundles, len(defaultBundles), "", nil
 					},
 				}
 				mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{}
@@ -319,10 +320,10 @@ func TestGetBundles_Success(t *testing.T) {
 			customBundles := defaultBundles[1:]
 
 			mockedDatastore := &storetest.StorerMock{
-				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, error) {
+				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
 					So(offset, ShouldEqual, 1)
 					So(limit, ShouldEqual, 1)
-					return customBundles, len(customBundles), nil
+					return customBundles, len(customBundles), "", nil
 				},
 			}
 			mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{}
@@ -337,6 +338,53 @@ func TestGetBundles_Success(t *testing.T) {
 			})
 		})
 
+		Convey("When state, bundle_type and title filters are supplied", func() {
+			r := httptest.NewRequest(http.MethodGet, "/bundles?state=DRAFT&state=IN_REVIEW&bundle_type=MANUAL&title=manual", http.NoBody)
+			w := httptest.NewRecorder()
+
+			mockedDatastore := &storetest.StorerMock{
+				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+					return defaultBundles[1:], 1, "", nil
+				},
+			}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			successResp, errResp := bundleAPI.getBundles(w, r, 10, 0)
+			Convey("Then the filters are passed to the datastore and the matching bundles are returned", func() {
+				So(errResp, ShouldBeNil)
+				So(successResp.Result.Items, ShouldResemble, defaultBundles[1:])
+
+				So(mockedDatastore.ListBundlesCalls(), ShouldHaveLength, 1)
+				bundleFilters := mockedDatastore.ListBundlesCalls()[0].FiltersMoqParam
+				So(bundleFilters.States, ShouldResemble, []models.BundleState{models.BundleStateDraft, models.BundleStateInReview})
+				So(*bundleFilters.BundleType, ShouldEqual, models.BundleTypeManual)
+				So(*bundleFilters.Title, ShouldEqual, "manual")
+			})
+		})
+
+		Convey("When fields are asked for", func() {
+			r := httptest.NewRequest(http.MethodGet, "/bundles?fields=title", http.NoBody)
+			w := httptest.NewRecorder()
+
+			mockedDatastore := &storetest.StorerMock{
+				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+					return []*models.Bundle{{ID: "bundle1", Title: "Scheduled Bundle 1", ETag: "stored-etag"}}, 1, "", nil
+				},
+			}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			successResp, errResp := bundleAPI.getBundles(w, r, 10, 0)
+			Convey("Then the view is passed to the datastore and each bundle is limited to those fields", func() {
+				So(errResp, ShouldBeNil)
+				So(mockedDatastore.ListBundlesCalls()[0].FiltersMoqParam.View, ShouldResemble, &filters.BundleView{Fields: []string{"title"}})
+
+				itemJSON, err := json.Marshal(successResp.Result.Items[0])
+				So(err, ShouldBeNil)
+				So(string(itemJSON), ShouldEqual, `{"id":"bundle1","title":"Scheduled Bundle 1"}`)
+				So(successResp.Result.Items[0].ETag, ShouldEqual, "stored-etag")
+			})
+		})
+
 		Convey("When no matching bundles are found for the publish date", func() {
 			paramValue := time.Now().UTC().Format(time.RFC3339)
 
@@ -371,8 +419,8 @@ func TestGetBundles_Failure(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			mockedDatastore := &storetest.StorerMock{
-				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, error) {
-					return nil, 0, errors.New("database failure")
+				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+					return nil, 0, "", errors.New("database failure")
 				},
 			}
 			mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{}
@@ -392,8 +440,8 @@ func TestGetBundles_Failure(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			mockedDatastore := &storetest.StorerMock{
-				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, error) {
-					return nil, 0, errors.New("something broke inside")
+				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+					return nil, 0, "", errors.New("something broke inside")
 				},
 			}
 
@@ -408,13 +456,100 @@ func TestGetBundles_Failure(t *testing.T) {
 			})
 		})
 
+		Convey("When an unknown query parameter is supplied", func() {
+			r := httptest.NewRequest(http.MethodGet, "/bundles?state=DRAFT&colour=blue", http.NoBody)
+			w := httptest.NewRecorder()
+
+			mockedDatastore := &storetest.StorerMock{}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			bundleAPI.Router.ServeHTTP(w, r)
+			Convey("Then the status code should be 400 with the parameter as the source", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors, ShouldHaveLength, 1)
+				So(*errList.Errors[0].Code, ShouldEqual, models.CodeInvalidParameters)
+				So(errList.Errors[0].Source.Parameter, ShouldEqual, "colour")
+				So(mockedDatastore.ListBundlesCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a cursor is supplied", func() {
+			r := httptest.NewRequest(http.MethodGet, "/bundles?cursor=abc&limit=1", http.NoBody)
+			w := httptest.NewRecorder()
+
+			mockedDatastore := &storetest.StorerMock{
+				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+					return []*models.Bundle{{ID: "bundle2"}}, 1, "def", nil
+				},
+			}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			bundleAPI.Router.ServeHTTP(w, r)
+			Convey("Then the cursor should be passed to the datastore and the next cursor returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDatastore.ListBundlesCalls(), ShouldHaveLength, 1)
+				So(*mockedDatastore.ListBundlesCalls()[0].FiltersMoqParam.Cursor, ShouldEqual, "abc")
+
+				var page pagination.PaginatedResponse
+				So(json.NewDecoder(w.Body).Decode(&page), ShouldBeNil)
+				So(page.NextCursor, ShouldEqual, "def")
+				So(page.TotalCount, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the cursor is not valid for the request", func() {
+			r := httptest.NewRequest(http.MethodGet, "/bundles?cursor=abc", http.NoBody)
+			w := httptest.NewRecorder()
+
+			mockedDatastore := &storetest.StorerMock{
+				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+					return nil, 0, "", apierrors.ErrInvalidCursor
+				},
+			}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			bundleAPI.Router.ServeHTTP(w, r)
+			Convey("Then the status code should be 400 with the cursor as the source", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors, ShouldHaveLength, 1)
+				So(*errList.Errors[0].Code, ShouldEqual, models.CodeInvalidParameters)
+				So(errList.Errors[0].Description, ShouldEqual, apierrors.ErrorDescriptionInvalidCursor)
+				So(errList.Errors[0].Source.Parameter, ShouldEqual, "cursor")
+			})
+		})
+
+		Convey("When a cursor and an offset are both supplied", func() {
+			r := httptest.NewRequest(http.MethodGet, "/bundles?cursor=abc&offset=1", http.NoBody)
+			w := httptest.NewRecorder()
+
+			mockedDatastore := &storetest.StorerMock{}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			bundleAPI.Router.ServeHTTP(w, r)
+			Convey("Then the status code should be 400 with the offset as the source", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors, ShouldHaveLength, 1)
+				So(strings.TrimSpace(errList.Errors[0].Source.Parameter), ShouldEqual, "offset")
+				So(mockedDatastore.ListBundlesCalls(), ShouldBeEmpty)
+			})
+		})
+
 		Convey("When an invalid publish_date is supplied", func() {
 			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/bundles?%s=%s", filters.PublishDate, "notactuallyadate"), http.NoBody)
 			w := httptest.NewRecorder()
 
 			mockedDatastore := &storetest.StorerMock{
-				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, error) {
-					return nil, 0, nil
+				ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+					return nil, 0, "", nil
 				},
 			}
 			mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{}
@@ -568,6 +703,54 @@ func TestGetBundle_Success(t *testing.T) {
 				So(rec.Header().Get("Cache-Control"), ShouldEqual, "no-store")
 			})
 		})
+		Convey("When only some fields and embedded content items are asked for", func() {
+			req := httptest.NewRequest(http.MethodGet, "/bundles/valid-id?fields=title,state&embed=contents", http.NoBody)
+			rec := httptest.NewRecorder()
+
+			mockStore := &storetest.StorerMock{
+				GetBundleViewFunc: func(ctx context.Context, id string, view *filters.BundleView) (*models.Bundle, error) {
+					bundle := *validBundle
+					bundle.Embedded = &models.BundleEmbedded{Contents: &[]*models.ContentItem{{ID: "content-1", BundleID: validBundle.ID}}}
+					return &bundle, nil
+				},
+			}
+
+			mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{}
+			mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockStore}, mockDatasetAPIClient, mockPermissionsAPIClient, false)
+			bundleAPI.Router.ServeHTTP(rec, req)
+
+			Convey("Then the response has only those fields, with a weak ETag made from the stored ETag of the bundle", func() {
+				So(rec.Code, ShouldEqual, http.StatusOK)
+				So(mockStore.GetBundleViewCalls(), ShouldHaveLength, 1)
+				So(mockStore.GetBundleViewCalls()[0].View, ShouldResemble, &filters.BundleView{
+					Fields: []string{"title", "state"},
+					Embed:  []string{filters.EmbedContents},
+				})
+
+				var response map[string]any
+				So(json.Unmarshal(rec.Body.Bytes(), &response), ShouldBeNil)
+				So(response, ShouldHaveLength, 4)
+				So(response["id"], ShouldEqual, validBundle.ID)
+				So(response["title"], ShouldEqual, validBundle.Title)
+				So(response["state"], ShouldEqual, string(validBundle.State))
+				So(response["embedded"], ShouldNotBeNil)
+
+				So(rec.Header().Get("ETag"), ShouldEqual, `W/"`+validBundle.ETag+`"`)
+			})
+
+			Convey("And only the ETag of the whole bundle can be used in an If-Match header to change the bundle", func() {
+				mockStore.GetBundleFunc = func(ctx context.Context, id string) (*models.Bundle, error) {
+					return validBundle, nil
+				}
+
+				_, err := bundleAPI.stateMachineBundleAPI.GetBundleAndValidateETag(context.Background(), validBundle.ID, rec.Header().Get("ETag"))
+				So(err, ShouldEqual, apierrors.ErrInvalidIfMatchHeader)
+
+				_, err = bundleAPI.stateMachineBundleAPI.GetBundleAndValidateETag(context.Background(), validBundle.ID, validBundle.ETag)
+				So(err, ShouldBeNil)
+			})
+		})
 	})
 }
 
@@ -611,6 +794,28 @@ func TestGetBundle_Failure(t *testing.T) {
 			})
 		})
 
+		Convey("When a field that cannot be selected is asked for", func() {
+			req := httptest.NewRequest(http.MethodGet, "/bundles/valid-id?fields=title,colour", http.NoBody)
+			rec := httptest.NewRecorder()
+
+			mockStore := &storetest.StorerMock{}
+			mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{}
+			mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockStore}, mockDatasetAPIClient, mockPermissionsAPIClient, false)
+			bundleAPI.Router.ServeHTTP(rec, req)
+
+			Convey("Then the response should be 400 with an error against the fields parameter", func() {
+				So(rec.Code, ShouldEqual, http.StatusBadRequest)
+
+				var errResp models.ErrorList
+				err := json.NewDecoder(rec.Body).Decode(&errResp)
+				So(err, ShouldBeNil)
+				So(errResp.Errors, ShouldHaveLength, 1)
+				So(*errResp.Errors[0].Code, ShouldEqual, models.CodeInvalidParameters)
+				So(errResp.Errors[0].Source.Parameter, ShouldEqual, filters.Fields)
+			})
+		})
+
 		Convey("When the request causes an internal error", func() {
 			req := httptest.NewRequest(http.MethodGet, "/bundles/valid-id", http.NoBody)
 			rec := httptest.NewRecorder()
@@ -977,6 +1182,7 @@ func TestPutBundleState_BadRequests(t *testing.T) {
 	UpdateStateRequestBodyValid := models.UpdateStateRequest{State: models.BundleStateApproved}
 	UpdateStateRequestBodyMissingStateField := models.UpdateStateRequest{}
 	UpdateStateRequestBodyInvalidStateField := models.UpdateStateRequest{State: models.BundleState("not-an-actual-state")}
+	UpdateStateRequestBodyMissingReason := models.UpdateStateRequest{State: models.BundleStateDraft}
 	UpdateStateRequestBodyInvalidType := struct {
 		name       string
 		someNumber int
@@ -1001,6 +1207,7 @@ func TestPutBundleState_BadRequests(t *testing.T) {
 		{"With a request body missing the state field", data.bundle.ID, data.bundle.ETag, 400, models.ErrorToModelErrorMap[apierrors.ErrInvalidBody], true, UpdateStateRequestBodyMissingStateField},
 		{"With a request body that has an invalid state field", data.bundle.ID, data.bundle.ETag, 400, models.ErrorToModelErrorMap[apierrors.ErrInvalidBody], true, UpdateStateRequestBodyInvalidStateField},
 		{"With a request body that is not the correct type", data.bundle.ID, data.bundle.ETag, 400, models.ErrorToModelErrorMap[apierrors.ErrInvalidBody], true, UpdateStateRequestBodyInvalidType},
+		{"With a request to send the bundle back to DRAFT without a reason", data.bundle.ID, data.bundle.ETag, 400, models.ErrorToModelErrorMap[apierrors.ErrTransitionReasonRequired], true, UpdateStateRequestBodyMissingReason},
 	}
 
 	for index := range errorTestCases {
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+const (
+	RouteVariableCommentID = "comment-id"
+
+	RouteNameGetComments      = "getComments"
+	RouteNamePostComment      = "postComment"
+	RouteNameResolveComment   = "resolveComment"
+	RouteNameUnresolveComment = "unresolveComment"
+)
+
+// getComments returns a page of the comments on a bundle, or on one of its content items, oldest first
+func (api *BundleAPI) getComments(w http.ResponseWriter, r *http.Request, limit, offset int) (successResult *models.PaginationSuccessResult[models.Comment], errorResult *models.ErrorResult[models.Error]) {
+	ctx := r.Context()
+	bundleID, contentID, _, logData := getCommentRouteVarsAndLogData(r)
+
+	comments, totalCount, err := api.stateMachineBundleAPI.ListComments(ctx, bundleID, contentID, offset, limit)
+	if err != nil {
+		log.Error(ctx, "failed to get comments", err, logData)
+		return nil, models.CreateErrorResult(models.GetMatchingModelError(err), errs.GetStatusCodeForErr(err))
+	}
+
+	logData["total_count"] = totalCount
+	logSuccessfulRequest(ctx, logData, RouteNameGetComments)
+	return models.CreatePaginationSuccessResult(comments, totalCount), nil
+}
+
+// postComment adds the caller's comment, or reply to a comment, to a bundle or one of its content items
+func (api *BundleAPI) postComment(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	bundleID, contentID, _, logData := getCommentRouteVarsAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNamePostComment)
+		return
+	}
+
+	comment, err := models.CreateComment(r.Body)
+	if err != nil {
+		log.Error(ctx, "postComment endpoint: failed to create comment from request body", err, logData)
+		code := models.CodeBadRequest
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionMalformedRequest,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, errInfo)
+		return
+	}
+
+	comment.BundleID = bundleID
+	comment.ContentItemID = contentID
+
+	validationErrs := models.ValidateComment(comment)
+	if len(validationErrs) > 0 {
+		log.Error(ctx, "postComment endpoint: comment validation failed", errs.ErrInvalidBody, logData)
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, validationErrs...)
+		return
+	}
+
+	comment, err = api.stateMachineBundleAPI.AddComment(ctx, comment, authEntityData)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNamePostComment)
+		return
+	}
+
+	logData["comment_id"] = comment.ID
+	writeComment(w, r, comment, http.StatusCreated, logData, RouteNamePostComment)
+}
+
+// resolveComment marks a comment on a bundle or one of its content items as resolved by the caller
+func (api *BundleAPI) resolveComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bundleID, contentID, commentID, logData := getCommentRouteVarsAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameResolveComment)
+		return
+	}
+
+	comment, err := api.stateMachineBundleAPI.ResolveComment(ctx, bundleID, contentID, commentID, authEntityData)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameResolveComment)
+		return
+	}
+
+	writeComment(w, r, comment, http.StatusOK, logData, RouteNameResolveComment)
+}
+
+// unresolveComment reopens a resolved comment on a bundle or one of its content items
+func (api *BundleAPI) unresolveComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bundleID, contentID, commentID, logData := getCommentRouteVarsAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameUnresolveComment)
+		return
+	}
+
+	comment, err := api.stateMachineBundleAPI.UnresolveComment(ctx, bundleID, contentID, commentID, authEntityData)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameUnresolveComment)
+		return
+	}
+
+	writeComment(w, r, comment, http.StatusOK, logData, RouteNameUnresolveComment)
+}
+
+func writeComment(w http.ResponseWriter, r *http.Request, comment *models.Comment, status int, logData log.Data, endpoint string) {
+	ctx := r.Context()
+
+	commentJSON, err := json.Marshal(comment)
+	if err != nil {
+		log.Error(ctx, endpoint+" endpoint: failed to marshal comment to JSON", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if _, err := w.Write(commentJSON); err != nil {
+		log.Error(ctx, endpoint+" endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logSuccessfulRequest(ctx, logData, endpoint)
+}
+
+// getCommentRouteVarsAndLogData returns the bundle ID and comment ID from the route, along with the content item ID if
+// the route is for the comments on a content item
+func getCommentRouteVarsAndLogData(r *http.Request) (bundleID, contentID, commentID string, logData log.Data) {
+	vars := mux.Vars(r)
+	bundleID = vars[RouteVariableBundleID]
+	contentID = vars[RouteVariableContentID]
+	commentID = vars[RouteVariableCommentID]
+
+	logData = log.Data{RouteVariableBundleID: bundleID}
+	if contentID != "" {
+		logData[RouteVariableContentID] = contentID
+	}
+	if commentID != "" {
+		logData[RouteVariableCommentID] = commentID
+	}
+
+	return bundleID, contentID, commentID, logData
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/pagination"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestComments(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a bundle with a comment on it", t, func() {
+		w := httptest.NewRecorder()
+
+		comment := &models.Comment{ID: "comment1", BundleID: "bundle1", Body: "Please check the title", Author: &models.User{Email: "reviewer@ons.gov.uk"}}
+
+		mockedDatastore := &storetest.StorerMock{
+			CheckBundleExistsFunc: func(ctx context.Context, bundleID string) (bool, error) {
+				return bundleID == "bundle1", nil
+			},
+			GetContentItemByBundleIDAndContentItemIDFunc: func(ctx context.Context, bundleID, contentItemID string) (*models.ContentItem, error) {
+				return &models.ContentItem{ID: contentItemID, BundleID: bundleID}, nil
+			},
+			GetCommentFunc: func(ctx context.Context, bundleID, commentID string) (*models.Comment, error) {
+				if commentID != comment.ID {
+					return nil, apierrors.ErrCommentNotFound
+				}
+				return comment, nil
+			},
+			ListCommentsFunc: func(ctx context.Context, bundleID, contentItemID string, offset, limit int) ([]*models.Comment, int, error) {
+				return []*models.Comment{comment}, 1, nil
+			},
+			CreateCommentFunc: func(ctx context.Context, comment *models.Comment) error {
+				return nil
+			},
+			UpdateCommentResolutionFunc: func(ctx context.Context, commentID string, resolvedBy *models.User, resolvedAt *time.Time) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When GET /bundles/{bundle-id}/comments is called", func() {
+			r := createRequestWithAuth(http.MethodGet, "/bundles/bundle1/comments?limit=10", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with a page of comments", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var response pagination.PaginatedResponse
+				So(json.NewDecoder(w.Body).Decode(&response), ShouldBeNil)
+				So(response.TotalCount, ShouldEqual, 1)
+				So(response.Limit, ShouldEqual, 10)
+				So(response.Items, ShouldHaveLength, 1)
+				So(mockedDatastore.ListCommentsCalls()[0].ContentItemID, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When GET /bundles/{bundle-id}/comments is called for a bundle that does not exist", func() {
+			r := createRequestWithAuth(http.MethodGet, "/bundles/missing/comments", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When GET /bundles/{bundle-id}/contents/{content-id}/comments is called", func() {
+			r := createRequestWithAuth(http.MethodGet, "/bundles/bundle1/contents/content1/comments", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the comments on the content item are listed", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDatastore.ListCommentsCalls()[0].ContentItemID, ShouldEqual, "content1")
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/contents/{content-id}/comments is called with a comment", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/contents/content1/comments", bytes.NewBufferString(`{"body": "Wrong edition"}`))
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 201 Created with the comment by the caller", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+
+				var created models.Comment
+				So(json.NewDecoder(w.Body).Decode(&created), ShouldBeNil)
+				So(created.BundleID, ShouldEqual, "bundle1")
+				So(created.ContentItemID, ShouldEqual, "content1")
+				So(created.Body, ShouldEqual, "Wrong edition")
+				So(created.Author.Email, ShouldEqual, "User123")
+				So(mockedDatastore.CreateEventCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/comments is called without a body", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/comments", bytes.NewBufferString(`{"body": "  "}`))
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 400 Bad Request", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, "/body")
+				So(mockedDatastore.CreateCommentCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/comments is called with a reply to a comment that does not exist", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/comments", bytes.NewBufferString(`{"body": "Agreed", "parent_id": "missing"}`))
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 400 Bad Request", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, apierrors.ErrorDescriptionCommentParentNotFound)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/comments/{comment-id}/resolve is called", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/comments/comment1/resolve", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with the comment resolved by the caller", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var resolved models.Comment
+				So(json.NewDecoder(w.Body).Decode(&resolved), ShouldBeNil)
+				So(resolved.Resolved, ShouldBeTrue)
+				So(resolved.ResolvedBy.Email, ShouldEqual, "User123")
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/comments/{comment-id}/unresolve is called for a comment that does not exist", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/comments/missing/unresolve", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
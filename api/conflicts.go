@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const RouteNameGetBundleConflicts = "getBundleConflicts"
+
+// getBundleConflicts lists the content items in a bundle whose datasets are also in other bundles that have not been
+// published
+func (api *BundleAPI) getBundleConflicts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	conflicts, err := api.stateMachineBundleAPI.GetBundleConflicts(ctx, bundleID)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameGetBundleConflicts)
+		return
+	}
+
+	conflictsJSON, err := json.Marshal(conflicts)
+	if err != nil {
+		log.Error(ctx, "getBundleConflicts endpoint: failed to marshal bundle conflicts to JSON", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(conflictsJSON); err != nil {
+		log.Error(ctx, "getBundleConflicts endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logData["conflicts"] = len(conflicts.Conflicts)
+	logSuccessfulRequest(ctx, logData, RouteNameGetBundleConflicts)
+}
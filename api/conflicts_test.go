@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetBundleConflicts(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a bundle containing a dataset edition that is also in another bundle", t, func() {
+		w := httptest.NewRecorder()
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				if bundleID != "bundle1" {
+					return nil, apierrors.ErrBundleNotFound
+				}
+				return &models.Bundle{ID: bundleID}, nil
+			},
+			GetContentItemsByBundleIDFunc: func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
+				return []*models.ContentItem{{ID: "content1", BundleID: bundleID, Metadata: models.Metadata{DatasetID: "cpih", EditionID: "time-series", VersionID: 2}}}, nil
+			},
+			ListContentItemsByDatasetIDsFunc: func(ctx context.Context, datasetIDs []string, excludeBundleID string) ([]*models.ContentItem, error) {
+				return []*models.ContentItem{{ID: "content2", BundleID: "bundle2", Metadata: models.Metadata{DatasetID: "cpih", EditionID: "time-series", VersionID: 3}}}, nil
+			},
+			ListBundlesByIDsFunc: func(ctx context.Context, bundleIDs []string) ([]*models.Bundle, error) {
+				return []*models.Bundle{{ID: "bundle2", Title: "Bundle 2", State: models.BundleStateApproved, BundleType: models.BundleTypeManual}}, nil
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When GET /bundles/{bundle-id}/conflicts is called", func() {
+			r := createRequestWithAuth(http.MethodGet, "/bundles/bundle1/conflicts", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with the conflict with the other bundle", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var response models.BundleConflicts
+				So(json.NewDecoder(w.Body).Decode(&response), ShouldBeNil)
+				So(response.HasConflicts, ShouldBeTrue)
+				So(response.Conflicts, ShouldHaveLength, 1)
+				So(response.Conflicts[0].Type, ShouldEqual, models.ConflictTypeEdition)
+				So(response.Conflicts[0].ConflictingBundle.ID, ShouldEqual, "bundle2")
+				So(response.Conflicts[0].ConflictingBundle.State, ShouldEqual, models.BundleStateApproved)
+			})
+		})
+
+		Convey("When GET /bundles/{bundle-id}/conflicts is called for a bundle that does not exist", func() {
+			r := createRequestWithAuth(http.MethodGet, "/bundles/missing/conflicts", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	dpresponse "github.com/ONSdigital/dp-net/v3/handlers/response"
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	RouteNamePostBundleContentsBatch   = "postBundleContentsBatch"
+	RouteNameDeleteBundleContentsBatch = "deleteBundleContentsBatch"
+)
+
+// postBundleContentsBatch adds several content items to a bundle in one request. Every content item is checked before
+// any is added, so either all of them are added or none are and the problems with each are returned.
+func (api *BundleAPI) postBundleContentsBatch(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNamePostBundleContentsBatch)
+		return
+	}
+
+	batch, err := models.CreateContentItemsBatch(r.Body)
+	if err != nil {
+		log.Error(ctx, "postBundleContentsBatch endpoint: failed to create content items from request body", err, logData)
+		code := models.CodeBadRequest
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionMalformedRequest,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, errInfo)
+		return
+	}
+
+	for _, contentItem := range batch.Items {
+		if contentItem != nil {
+			contentItem.BundleID = bundleID
+		}
+	}
+
+	validationErrs := models.ValidateContentItemsBatch(batch)
+	if len(validationErrs) > 0 {
+		log.Error(ctx, "postBundleContentsBatch endpoint: content items validation failed", apierrors.ErrInvalidBody, logData)
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, validationErrs...)
+		return
+	}
+
+	if !api.checkBundleExistsForBatch(w, r, bundleID, logData) {
+		return
+	}
+
+	batchErrs, err := api.stateMachineBundleAPI.CheckContentItemsBatch(ctx, authEntityData.Headers, batch.Items)
+	if err != nil {
+		log.Error(ctx, "postBundleContentsBatch endpoint: failed to check content items", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+	if len(batchErrs) > 0 {
+		log.Error(ctx, "postBundleContentsBatch endpoint: content items cannot be added to the bundle", nil, logData)
+		utils.HandleBundleAPIErr(w, r, batchErrorStatusCode(batchErrs), batchErrs...)
+		return
+	}
+
+	updatedBundle, err := api.stateMachineBundleAPI.AddContentItems(ctx, bundleID, batch.Items, authEntityData)
+	if err != nil {
+		log.Error(ctx, "postBundleContentsBatch endpoint: failed to add content items", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	batchJSON, err := json.Marshal(batch)
+	if err != nil {
+		log.Error(ctx, "postBundleContentsBatch endpoint: failed to marshal content items to JSON", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	dpresponse.SetETag(w, updatedBundle.ETag)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+
+	w.WriteHeader(http.StatusCreated)
+
+	if _, err := w.Write(batchJSON); err != nil {
+		log.Error(ctx, "postBundleContentsBatch endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logSuccessfulRequest(ctx, logData, RouteNamePostBundleContentsBatch)
+}
+
+// deleteBundleContentsBatch removes several content items from a bundle in one request. Every content item is checked
+// before any is removed, so either all of them are removed or none are and the problems with each are returned.
+func (api *BundleAPI) deleteBundleContentsBatch(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameDeleteBundleContentsBatch)
+		return
+	}
+
+	batch, err := models.CreateContentItemIDsBatch(r.Body)
+	if err != nil {
+		log.Error(ctx, "deleteBundleContentsBatch endpoint: failed to read content item IDs from request body", err, logData)
+		code := models.CodeBadRequest
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionMalformedRequest,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, errInfo)
+		return
+	}
+
+	validationErrs := models.ValidateContentItemIDsBatch(batch)
+	if len(validationErrs) > 0 {
+		log.Error(ctx, "deleteBundleContentsBatch endpoint: content item IDs validation failed", apierrors.ErrInvalidBody, logData)
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, validationErrs...)
+		return
+	}
+
+	if !api.checkBundleExistsForBatch(w, r, bundleID, logData) {
+		return
+	}
+
+	contentItems, batchErrs, err := api.stateMachineBundleAPI.CheckContentItemIDsBatch(ctx, bundleID, batch.IDs)
+	if err != nil {
+		log.Error(ctx, "deleteBundleContentsBatch endpoint: failed to get content items", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+	if len(batchErrs) > 0 {
+		log.Error(ctx, "deleteBundleContentsBatch endpoint: content items cannot be removed from the bundle", nil, logData)
+		utils.HandleBundleAPIErr(w, r, batchErrorStatusCode(batchErrs), batchErrs...)
+		return
+	}
+
+	updatedBundle, err := api.stateMachineBundleAPI.RemoveContentItems(ctx, bundleID, contentItems, authEntityData)
+	if err != nil {
+		log.Error(ctx, "deleteBundleContentsBatch endpoint: failed to remove content items", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	dpresponse.SetETag(w, updatedBundle.ETag)
+	w.WriteHeader(http.StatusNoContent)
+
+	logSuccessfulRequest(ctx, logData, RouteNameDeleteBundleContentsBatch)
+}
+
+// checkBundleExistsForBatch writes an error response and returns false if the bundle does not exist or could not be
+// checked
+func (api *BundleAPI) checkBundleExistsForBatch(w http.ResponseWriter, r *http.Request, bundleID string, logData log.Data) bool {
+	ctx := r.Context()
+
+	bundleExists, err := api.stateMachineBundleAPI.CheckBundleExists(ctx, bundleID)
+	if err != nil {
+		log.Error(ctx, "failed to check if bundle exists", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return false
+	}
+
+	if !bundleExists {
+		log.Error(ctx, "bundle not found", nil, logData)
+		code := models.CodeNotFound
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionNotFound,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusNotFound, errInfo)
+		return false
+	}
+
+	return true
+}
+
+// batchErrorStatusCode returns 404 if any of the content items in a batch do not exist, otherwise 409
+func batchErrorStatusCode(batchErrs []*models.Error) int {
+	for _, batchErr := range batchErrs {
+		if batchErr.Code != nil && *batchErr.Code == models.CodeNotFound {
+			return http.StatusNotFound
+		}
+	}
+
+	return http.StatusConflict
+}
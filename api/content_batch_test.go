@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPostBundleContentsBatch(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a bundle and dataset API with versions of dataset-1 and dataset-2", t, func() {
+		mockedDatastore := &storetest.StorerMock{
+			CheckBundleExistsFunc: func(ctx context.Context, bundleID string) (bool, error) {
+				return bundleID == "bundle-1", nil
+			},
+			CheckContentItemExistsByDatasetEditionVersionFunc: func(ctx context.Context, datasetID, editionID string, versionID int) (bool, error) {
+				return datasetID == "dataset-2", nil
+			},
+			CreateContentItemsFunc: func(ctx context.Context, contentItems []*models.ContentItem) error {
+				return nil
+			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			UpdateBundleETagFunc: func(ctx context.Context, bundleID, email string) (*models.Bundle, error) {
+				return &models.Bundle{ID: bundleID, ETag: "new-etag", BundleType: models.BundleTypeManual}, nil
+			},
+		}
+
+		mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				return datasetAPIModels.Version{
+					Links: &datasetAPIModels.VersionLinks{WebPage: &datasetAPIModels.LinkObject{HRef: "http://localhost/datasets/" + datasetID}},
+				}, nil
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When a batch of two dataset versions is posted", func() {
+			body := `{"items": [
+				{"content_type": "DATASET", "metadata": {"dataset_id": "dataset-1", "edition_id": "edition-1", "version_id": 1}},
+				{"content_type": "DATASET", "metadata": {"dataset_id": "dataset-1", "edition_id": "edition-1", "version_id": 2}}
+			]}`
+			r := httptest.NewRequest(http.MethodPost, "/bundles/bundle-1/contents/batch", strings.NewReader(body))
+			r.Header.Set("Authorization", "test-auth-token")
+			w := httptest.NewRecorder()
+
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should return 201 Created with the content items that were added", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(w.Header().Get("ETag"), ShouldEqual, "new-etag")
+
+				var batch models.ContentItemsBatch
+				So(json.NewDecoder(w.Body).Decode(&batch), ShouldBeNil)
+				So(batch.Items, ShouldHaveLength, 2)
+				So(batch.Items[0].BundleID, ShouldEqual, "bundle-1")
+				So(batch.Items[0].Links.Preview, ShouldEqual, "/datasets/dataset-1")
+				So(batch.Items[1].Links.Edit, ShouldEqual, "/data-admin/series/dataset-1/editions/edition-1/versions/2")
+			})
+
+			Convey("And the content items are created together and the bundle ETag is updated once", func() {
+				So(mockedDatastore.CreateContentItemsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.UpdateBundleETagCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateEventCalls(), ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("When a batch with a dataset version that is already in a bundle is posted", func() {
+			body := `{"items": [
+				{"content_type": "DATASET", "metadata": {"dataset_id": "dataset-1", "edition_id": "edition-1", "version_id": 1}},
+				{"content_type": "DATASET", "metadata": {"dataset_id": "dataset-2", "edition_id": "edition-1", "version_id": 1}}
+			]}`
+			r := httptest.NewRequest(http.MethodPost, "/bundles/bundle-1/contents/batch", strings.NewReader(body))
+			r.Header.Set("Authorization", "test-auth-token")
+			w := httptest.NewRecorder()
+
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should return 409 Conflict against that content item and add none of them", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors, ShouldHaveLength, 1)
+				So(errList.Errors[0].Description, ShouldEqual, apierrors.ErrorDescriptionVersionAlreadyExists)
+				So(errList.Errors[0].Source.Field, ShouldEqual, "/items/1")
+
+				So(mockedDatastore.CreateContentItemsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a batch with an invalid content item is posted", func() {
+			body := `{"items": [{"content_type": "DATASET", "metadata": {"dataset_id": "dataset-1", "version_id": 1}}]}`
+			r := httptest.NewRequest(http.MethodPost, "/bundles/bundle-1/contents/batch", strings.NewReader(body))
+			r.Header.Set("Authorization", "test-auth-token")
+			w := httptest.NewRecorder()
+
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should return 400 Bad Request against the invalid field", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors[0].Source.Field, ShouldEqual, "/items/0/metadata/edition_id")
+			})
+		})
+
+		Convey("When a batch is posted to a bundle that does not exist", func() {
+			body := `{"items": [{"content_type": "DATASET", "metadata": {"dataset_id": "dataset-1", "edition_id": "edition-1", "version_id": 1}}]}`
+			r := httptest.NewRequest(http.MethodPost, "/bundles/bundle-2/contents/batch", strings.NewReader(body))
+			r.Header.Set("Authorization", "test-auth-token")
+			w := httptest.NewRecorder()
+
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should return 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestDeleteBundleContentsBatch(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a bundle with an approved and a published content item", t, func() {
+		approved, published := models.StateApproved, models.StatePublished
+
+		mockedDatastore := &storetest.StorerMock{
+			CheckBundleExistsFunc: func(ctx context.Context, bundleID string) (bool, error) {
+				return true, nil
+			},
+			GetContentItemByBundleIDAndContentItemIDFunc: func(ctx context.Context, bundleID, contentItemID string) (*models.ContentItem, error) {
+				switch contentItemID {
+				case cont1:
+					return &models.ContentItem{ID: contentItemID, BundleID: bundleID, State: &approved}, nil
+				case cont2:
+					return &models.ContentItem{ID: contentItemID, BundleID: bundleID, State: &published}, nil
+				default:
+					return nil, apierrors.ErrContentItemNotFound
+				}
+			},
+			DeleteContentItemsFunc: func(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+				return len(contentItemIDs), nil
+			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			UpdateBundleETagFunc: func(ctx context.Context, bundleID, email string) (*models.Bundle, error) {
+				return &models.Bundle{ID: bundleID, ETag: "new-etag"}, nil
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When the approved content item is deleted in a batch", func() {
+			r := httptest.NewRequest(http.MethodDelete, "/bundles/bundle-1/contents/batch", strings.NewReader(`{"ids": ["content-1"]}`))
+			r.Header.Set("Authorization", "test-auth-token")
+			w := httptest.NewRecorder()
+
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should return 204 No Content and update the bundle ETag", func() {
+				So(w.Code, ShouldEqual, http.StatusNoContent)
+				So(w.Header().Get("ETag"), ShouldEqual, "new-etag")
+				So(mockedDatastore.DeleteContentItemsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.DeleteContentItemsCalls()[0].ContentItemIDs, ShouldResemble, []string{cont1})
+			})
+		})
+
+		Convey("When the approved, published and a missing content item are deleted in a batch", func() {
+			r := httptest.NewRequest(http.MethodDelete, "/bundles/bundle-1/contents/batch", strings.NewReader(`{"ids": ["content-1", "content-2", "content-3"]}`))
+			r.Header.Set("Authorization", "test-auth-token")
+			w := httptest.NewRecorder()
+
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should return 404 Not Found with a problem for each, and delete none of them", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors, ShouldHaveLength, 2)
+				So(errList.Errors[0].Source.Field, ShouldEqual, "/ids/1")
+				So(errList.Errors[1].Source.Field, ShouldEqual, "/ids/2")
+
+				So(mockedDatastore.DeleteContentItemsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
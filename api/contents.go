@@ -2,13 +2,13 @@ package api
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
 	"net/http"
-	"net/url"
-	"strconv"
 	"strings"
 
 	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/content"
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	"github.com/ONSdigital/dis-bundle-api/utils"
 	dpresponse "github.com/ONSdigital/dp-net/v3/handlers/response"
@@ -79,57 +79,21 @@ func (api *BundleAPI) postBundleContents(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	version, err := api.stateMachineBundleAPI.DatasetAPIClient.GetVersion(ctx, authEntityData.Headers, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, strconv.Itoa(contentItem.Metadata.VersionID))
-	if err != nil {
-		switch {
-		case strings.Contains(err.Error(), "dataset not found"):
-			log.Error(ctx, "postBundleContents endpoint: dataset not found in dataset API", nil, logData)
-			code := models.CodeNotFound
-			errInfo := &models.Error{
-				Code:        &code,
-				Description: apierrors.ErrorDescriptionNotFound,
-				Source:      &models.Source{Field: "/metadata/dataset_id"},
-			}
-			utils.HandleBundleAPIErr(w, r, http.StatusNotFound, errInfo)
-			return
-
-		case strings.Contains(err.Error(), "edition not found"):
-			log.Error(ctx, "postBundleContents endpoint: edition not found in dataset API", nil, logData)
-			code := models.CodeNotFound
-			errInfo := &models.Error{
-				Code:        &code,
-				Description: apierrors.ErrorDescriptionNotFound,
-				Source:      &models.Source{Field: "/metadata/edition_id"},
-			}
-			utils.HandleBundleAPIErr(w, r, http.StatusNotFound, errInfo)
-			return
-
-		case strings.Contains(err.Error(), "version not found"):
-			log.Error(ctx, "postBundleContents endpoint: version not found in dataset API", nil, logData)
+	if err = api.stateMachineBundleAPI.DatasetProvider().Validate(ctx, authEntityData.Headers, contentItem); err != nil {
+		var notFoundErr *content.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			log.Error(ctx, "postBundleContents endpoint: content not found", err, logData)
 			code := models.CodeNotFound
 			errInfo := &models.Error{
 				Code:        &code,
 				Description: apierrors.ErrorDescriptionNotFound,
-				Source:      &models.Source{Field: "/metadata/version_id"},
+				Source:      &models.Source{Field: notFoundErr.Field},
 			}
 			utils.HandleBundleAPIErr(w, r, http.StatusNotFound, errInfo)
 			return
-
-		default:
-			log.Error(ctx, "postBundleContents endpoint: failed to get version from dataset API", err, logData)
-			code := models.CodeInternalError
-			errInfo := &models.Error{
-				Code:        &code,
-				Description: apierrors.ErrorDescriptionInternalError,
-			}
-			utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
-			return
 		}
-	}
 
-	versionURL, err := url.Parse(version.Links.WebPage.HRef)
-	if err != nil {
-		log.Error(ctx, "postBundleContents endpoint: failed to parse version URL", err)
+		log.Error(ctx, "postBundleContents endpoint: failed to validate content", err, logData)
 		code := models.CodeInternalError
 		errInfo := &models.Error{
 			Code:        &code,
@@ -138,9 +102,8 @@ func (api *BundleAPI) postBundleContents(w http.ResponseWriter, r *http.Request)
 		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
 		return
 	}
-	contentItem.Links.Edit = fmt.Sprintf("/data-admin/series/%s/editions/%s/versions/%d", contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, contentItem.Metadata.VersionID)
-	contentItem.Links.Preview = versionURL.Path
 
+	// a dataset version can only be in one bundle
 	exists, err := api.stateMachineBundleAPI.CheckContentItemExistsByDatasetEditionVersion(ctx, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, contentItem.Metadata.VersionID)
 	if err != nil {
 		log.Error(ctx, "postBundleContents endpoint: failed to check if content item exists", err, logData)
@@ -176,6 +139,18 @@ func (api *BundleAPI) postBundleContents(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	err = api.stateMachineBundleAPI.InvalidateApprovals(ctx, bundleID)
+	if err != nil {
+		log.Error(ctx, "postBundleContents endpoint: failed to invalidate bundle approvals", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
 	err = api.stateMachineBundleAPI.CreateEvent(ctx, authEntityData, models.ActionCreate, nil, contentItem)
 	logData["action"] = models.ActionCreate
 	if err != nil {
@@ -228,11 +203,12 @@ func (api *BundleAPI) postBundleContents(w http.ResponseWriter, r *http.Request)
 	}
 	log.Info(ctx, "bundle event creation successful", log.Classification(log.ProtectiveMonitoring), logAuth, logData)
 
-	if updatedBundle.BundleType == models.BundleTypeScheduled {
-		err = api.stateMachineBundleAPI.UpdateDatasetVersionReleaseDate(ctx, updatedBundle.ScheduledAt, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, contentItem.Metadata.VersionID, authEntityData.Headers)
-		if err != nil {
-			handleErr(ctx, w, r, err, logData, RouteNamePostBundleContents)
-			return
+	// The content item has been added, so a failure to give its dataset version the bundle's release date is reported
+	// with it rather than failing the request
+	if updatedBundle.BundleType == models.BundleTypeScheduled && updatedBundle.ScheduledAt != nil {
+		contentItem.ReleaseDateSyncFailure = api.stateMachineBundleAPI.SyncContentItemReleaseDate(ctx, updatedBundle.ScheduledAt, contentItem, authEntityData.Headers)
+		if contentItem.ReleaseDateSyncFailure != nil {
+			log.Warn(ctx, "postBundleContents endpoint: failed to update dataset version release date", logData)
 		}
 	}
 
@@ -331,6 +307,18 @@ func (api *BundleAPI) deleteContentItem(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	err = api.stateMachineBundleAPI.InvalidateApprovals(ctx, bundleID)
+	if err != nil {
+		log.Error(ctx, "deleteContentItem endpoint: failed to invalidate bundle approvals", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
 	err = api.stateMachineBundleAPI.CreateEvent(ctx, authEntityData, models.ActionDelete, nil, contentItem)
 	logData["action"] = models.ActionDelete
 	if err != nil {
@@ -387,14 +375,61 @@ func (api *BundleAPI) deleteContentItem(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (api *BundleAPI) getBundleContents(w http.ResponseWriter, r *http.Request, limit, offset int) (contents any, totalCount int, contentErrors *models.Error) {
+// getContents returns a page of the content items in all bundles that match the query parameters, such as every bundle
+// that contains a dataset version, along with the ID, title and state of the bundle each is in
+func (api *BundleAPI) getContents(w http.ResponseWriter, r *http.Request, limit, offset int) (successResult *models.PaginationSuccessResult[models.ContentItem], errorResult *models.ErrorResult[models.Error]) {
+	ctx := r.Context()
+
+	contentFilters, filtersErr := filters.CreateContentFilters(r)
+	if filtersErr != nil {
+		log.Error(ctx, filtersErr.Error.Error(), apierrors.ErrInvalidQueryParameter)
+		code := models.CodeInvalidParameters
+		invalidRequestError := &models.Error{Code: &code, Description: apierrors.ErrorDescriptionMalformedRequest, Source: filtersErr.Source}
+		return nil, models.CreateBadRequestErrorResult(invalidRequestError)
+	}
+
+	contents, totalCount, nextCursor, err := api.stateMachineBundleAPI.ListContents(ctx, offset, limit, contentFilters)
+	if err != nil {
+		log.Error(ctx, "failed to get contents", err)
+		return nil, models.CreateErrorResult(models.GetMatchingModelError(err), apierrors.GetStatusCodeForErr(err))
+	}
+
+	logSuccessfulRequest(ctx, log.Data{"total_count": totalCount}, RouteNameGetContents)
+	return models.CreateCursorPaginationSuccessResult(contents, totalCount, nextCursor), nil
+}
+
+func (api *BundleAPI) getBundleContents(w http.ResponseWriter, r *http.Request, limit, offset int) (contents any, totalCount int, nextCursor string, contentErrors *models.Error) {
 	ctx := r.Context()
 	bundleID, logData := getBundleIDAndLogData(r)
 
 	authEntityData, err := api.GetAuthEntityData(r)
 	if err != nil {
 		errInfo := models.GetMatchingModelError(err)
-		return []*models.ContentItem{}, 0, errInfo
+		return []*models.ContentItem{}, 0, "", errInfo
+	}
+
+	sortFields, sortErr := filters.CreateSort(r, filters.ContentSortFields)
+	if sortErr != nil {
+		log.Error(ctx, sortErr.Error.Error(), apierrors.ErrInvalidQueryParameter, logData)
+		code := models.CodeInvalidParameters
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionMalformedRequest,
+			Source:      sortErr.Source,
+		}
+		return []*models.ContentItem{}, 0, "", errInfo
+	}
+
+	cursor, cursorErr := filters.CreateCursor(r)
+	if cursorErr != nil {
+		log.Error(ctx, cursorErr.Error.Error(), apierrors.ErrInvalidQueryParameter, logData)
+		code := models.CodeInvalidParameters
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: apierrors.ErrorDescriptionMalformedRequest,
+			Source:      cursorErr.Source,
+		}
+		return []*models.ContentItem{}, 0, "", errInfo
 	}
 
 	bundleExists, err := api.stateMachineBundleAPI.CheckBundleExists(ctx, bundleID)
@@ -404,7 +439,7 @@ func (api *BundleAPI) getBundleContents(w http.ResponseWriter, r *http.Request,
 			Code:        &code,
 			Description: apierrors.ErrorDescriptionInternalError,
 		}
-		return []*models.ContentItem{}, 0, errInfo
+		return []*models.ContentItem{}, 0, "", errInfo
 	}
 
 	if !bundleExists {
@@ -413,13 +448,16 @@ func (api *BundleAPI) getBundleContents(w http.ResponseWriter, r *http.Request,
 			Code:        &code,
 			Description: apierrors.ErrorDescriptionNotFound,
 		}
-		return []*models.ContentItem{}, 0, errInfo
+		return []*models.ContentItem{}, 0, "", errInfo
 	}
 
-	bundleContents, totalCount, err := api.stateMachineBundleAPI.GetBundleContents(ctx, bundleID, offset, limit, authEntityData.Headers)
+	bundleContents, totalCount, nextCursor, err := api.stateMachineBundleAPI.GetBundleContents(ctx, bundleID, offset, limit, sortFields, cursor, authEntityData.Headers)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, apierrors.ErrInvalidCursor) {
+			log.Error(ctx, "getBundleContents endpoint: invalid cursor", err, logData)
+			return nil, 0, "", models.GetMatchingModelError(err)
+		} else if strings.Contains(err.Error(), "not found") {
 			log.Error(ctx, "getBundleContents endpoint: dataset not found in dataset API", nil, logData)
 			code := models.CodeNotFound
 			errInfo := &models.Error{
@@ -427,7 +465,7 @@ func (api *BundleAPI) getBundleContents(w http.ResponseWriter, r *http.Request,
 				Description: apierrors.ErrorDescriptionNotFound,
 				Source:      &models.Source{Field: "/metadata/dataset_id"},
 			}
-			return nil, 0, errInfo
+			return nil, 0, "", errInfo
 		} else {
 			log.Error(ctx, "getBundleContents endpoint: failed to get dataset from dataset API", err, logData)
 			code := models.CodeInternalError
@@ -435,8 +473,8 @@ func (api *BundleAPI) getBundleContents(w http.ResponseWriter, r *http.Request,
 				Code:        &code,
 				Description: apierrors.ErrorDescriptionInternalError,
 			}
-			return nil, 0, errInfo
+			return nil, 0, "", errInfo
 		}
 	}
-	return bundleContents, totalCount, nil
+	return bundleContents, totalCount, nextCursor, nil
 }
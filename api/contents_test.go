@@ -7,10 +7,12 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	"github.com/ONSdigital/dis-bundle-api/store"
 	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
@@ -81,6 +83,9 @@ func TestPostBundleContents_Success(t *testing.T) {
 				}
 				return true, nil
 			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
 			CreateContentItemFunc: func(ctx context.Context, contentItem *models.ContentItem) error {
 				if contentItem.BundleID == "bundle-1" &&
 					contentItem.Metadata.DatasetID == "dataset-1" &&
@@ -189,6 +194,10 @@ func TestPostBundleContents_Success(t *testing.T) {
 			Convey("And UpdateDatasetVersionReleaseDate should not be called", func() {
 				So(len(mockDatasetAPIClient.PutVersionCalls()), ShouldEqual, 0)
 			})
+
+			Convey("And the bundle's approvals should be invalidated", func() {
+				So(mockedDatastore.DeleteApprovalsCalls()[0].BundleID, ShouldEqual, "bundle-1")
+			})
 		})
 
 		Convey("When postBundleContents is called with a SCHEDULED bundle", func() {
@@ -231,6 +240,41 @@ func TestPostBundleContents_Success(t *testing.T) {
 	})
 }
 
+func TestPostBundleContents_UnsupportedContentType_Failure(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a POST request to /bundles/{bundle-id}/contents with a content type that is not supported", t, func() {
+		mockedDatastore := &storetest.StorerMock{
+			CheckBundleExistsFunc: func(ctx context.Context, bundleID string) (bool, error) {
+				return true, nil
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When postBundleContents is called", func() {
+			body := `{"content_type": "WAGTAIL_PAGE", "metadata": {"page_id": 42}}`
+			r := httptest.NewRequest("POST", "/bundles/bundle-1/contents", strings.NewReader(body))
+			r.Header.Set("Authorization", "test-auth-token")
+			w := httptest.NewRecorder()
+
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should return a 400 Bad Request against the content type", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors[0].Source.Field, ShouldEqual, "/content_type")
+			})
+
+			Convey("And no content item is created", func() {
+				So(mockedDatastore.CreateContentItemCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func TestPostBundleContents_MalformedJSON_Failure(t *testing.T) {
 	t.Parallel()
 
@@ -875,6 +919,9 @@ func TestPostBundleContents_ParseJWT_Failure(t *testing.T) {
 			CheckContentItemExistsByDatasetEditionVersionFunc: func(ctx context.Context, datasetID, editionID string, versionID int) (bool, error) {
 				return false, nil
 			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
 			CreateContentItemFunc: func(ctx context.Context, contentItem *models.ContentItem) error {
 				return nil
 			},
@@ -938,6 +985,9 @@ func TestPostBundleContents_EventCreation_Failure(t *testing.T) {
 			CheckContentItemExistsByDatasetEditionVersionFunc: func(ctx context.Context, datasetID, editionID string, versionID int) (bool, error) {
 				return false, nil
 			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
 			CreateContentItemFunc: func(ctx context.Context, contentItem *models.ContentItem) error {
 				return nil
 			},
@@ -1060,6 +1110,9 @@ func TestPostBundleContents_UpdateBundleETag_Failure(t *testing.T) {
 			CheckContentItemExistsByDatasetEditionVersionFunc: func(ctx context.Context, datasetID, editionID string, versionID int) (bool, error) {
 				return false, nil
 			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
 			CreateContentItemFunc: func(ctx context.Context, contentItem *models.ContentItem) error {
 				return nil
 			},
@@ -1141,6 +1194,9 @@ func TestPostBundleContents_UpdateDatasetVersionReleaseDate_Failure(t *testing.T
 			CheckContentItemExistsByDatasetEditionVersionFunc: func(ctx context.Context, datasetID, editionID string, versionID int) (bool, error) {
 				return false, nil
 			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
 			CreateContentItemFunc: func(ctx context.Context, contentItem *models.ContentItem) error {
 				return nil
 			},
@@ -1183,25 +1239,26 @@ func TestPostBundleContents_UpdateDatasetVersionReleaseDate_Failure(t *testing.T
 
 		bundleAPI.Router.ServeHTTP(w, r)
 
-		Convey("Then it should return a 500 Internal Server Error status code", func() {
-			So(w.Code, ShouldEqual, 500)
+		Convey("Then the content item is still created with a 201 Created status code", func() {
+			So(w.Code, ShouldEqual, http.StatusCreated)
+			So(mockedDatastore.CreateContentItemCalls(), ShouldHaveLength, 1)
 		})
 
-		Convey("And the response body should contain an error message", func() {
-			var errResp models.ErrorList
-			err := json.NewDecoder(w.Body).Decode(&errResp)
+		Convey("And the failure to update the release date is reported with the content item", func() {
+			var contentItem models.ContentItem
+			err := json.NewDecoder(w.Body).Decode(&contentItem)
 			So(err, ShouldBeNil)
 
-			codeInternalError := models.CodeInternalError
-			expectedErrResp := models.ErrorList{
-				Errors: []*models.Error{
-					{
-						Code:        &codeInternalError,
-						Description: apierrors.ErrorDescriptionInternalError,
-					},
+			So(contentItem.ReleaseDateSyncFailure, ShouldResemble, &models.ReleaseDateSyncFailure{
+				ContentItemID: contentItem.ID,
+				Metadata: models.Metadata{
+					DatasetID: "dataset-1",
+					EditionID: "edition-1",
+					Title:     "Example Content Item",
+					VersionID: 1,
 				},
-			}
-			So(errResp, ShouldResemble, expectedErrResp)
+				Error: "failed to update dataset version release date",
+			})
 		})
 	})
 }
@@ -1224,6 +1281,9 @@ func TestDeleteContentItem_Success(t *testing.T) {
 				}
 				return nil, errors.New("content item not found")
 			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
 			DeleteContentItemFunc: func(ctx context.Context, contentItemID string) error {
 				if contentItemID == cont1 {
 					return nil
@@ -1263,6 +1323,10 @@ func TestDeleteContentItem_Success(t *testing.T) {
 			Convey("And the response body should be empty", func() {
 				So(w.Body.Len(), ShouldEqual, 0)
 			})
+
+			Convey("And the bundle's approvals should be invalidated", func() {
+				So(mockedDatastore.DeleteApprovalsCalls()[0].BundleID, ShouldEqual, "bundle-1")
+			})
 		})
 	})
 }
@@ -1500,6 +1564,9 @@ func TestDeleteContentItem_ParseJWT_Failure(t *testing.T) {
 				}
 				return nil, errors.New("content item not found")
 			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
 			DeleteContentItemFunc: func(ctx context.Context, contentItemID string) error {
 				if contentItemID == cont1 {
 					return nil
@@ -1549,6 +1616,9 @@ func TestDeleteContentItem_CreateEvent_Failure(t *testing.T) {
 				}
 				return nil, apierrors.ErrContentItemNotFound
 			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
 			DeleteContentItemFunc: func(ctx context.Context, contentItemID string) error {
 				if contentItemID == cont1 {
 					return nil
@@ -1641,6 +1711,9 @@ func TestDeleteContentItem_UpdateETag_Failure(t *testing.T) {
 					BundleID: bundleID,
 				}, nil
 			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
 			DeleteContentItemFunc: func(ctx context.Context, contentItemID string) error {
 				return nil
 			},
@@ -1743,8 +1816,8 @@ func TestGetBundleContents_Success(t *testing.T) {
 					State: state,
 				}, nil
 			},
-			ListBundleContentsFunc: func(ctx context.Context, id string, offset, limit int) ([]*models.ContentItem, int, error) {
-				return expectedContents, len(expectedContents), nil
+			ListBundleContentsFunc: func(ctx context.Context, id string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
+				return expectedContents, len(expectedContents), "", nil
 			},
 		}
 
@@ -1779,6 +1852,63 @@ func TestGetBundleContents_Success(t *testing.T) {
 				So(w.Header().Get("Cache-Control"), ShouldEqual, "no-store")
 			})
 		})
+
+		Convey("When the handler is called with a sort param", func() {
+			r := httptest.NewRequest("GET", "/bundles/"+bundleID+"/contents?sort=-version_id", http.NoBody)
+			r.Header.Set("Authorization", "test-auth-token")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should respond 200 OK and pass the sort fields to the datastore", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDatastore.ListBundleContentsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.ListBundleContentsCalls()[0].SortFields, ShouldResemble, []filters.SortField{{Field: "metadata.version_id", Descending: true}})
+			})
+		})
+
+		Convey("When the handler is called with a field that content items cannot be sorted by", func() {
+			r := httptest.NewRequest("GET", "/bundles/"+bundleID+"/contents?sort=colour", http.NoBody)
+			r.Header.Set("Authorization", "test-auth-token")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should respond 400 Bad Request against the sort parameter", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors[0].Source.Parameter, ShouldEqual, "sort")
+				So(mockedDatastore.ListBundleContentsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the handler is called with a cursor", func() {
+			r := httptest.NewRequest("GET", "/bundles/"+bundleID+"/contents?cursor=abc", http.NoBody)
+			r.Header.Set("Authorization", "test-auth-token")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should respond 200 OK and pass the cursor to the datastore", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mockedDatastore.ListBundleContentsCalls(), ShouldHaveLength, 1)
+				So(*mockedDatastore.ListBundleContentsCalls()[0].Cursor, ShouldEqual, "abc")
+			})
+		})
+
+		Convey("When the handler is called with a cursor that is not valid for the request", func() {
+			mockedDatastore.ListBundleContentsFunc = func(ctx context.Context, id string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
+				return nil, 0, "", apierrors.ErrInvalidCursor
+			}
+
+			r := httptest.NewRequest("GET", "/bundles/"+bundleID+"/contents?cursor=abc", http.NoBody)
+			r.Header.Set("Authorization", "test-auth-token")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then it should respond 400 Bad Request against the cursor parameter", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors[0].Source.Parameter, ShouldEqual, "cursor")
+			})
+		})
 	})
 
 	Convey("Given a GET request with custom pagination params", t, func() {
@@ -1826,8 +1956,8 @@ func TestGetBundleContents_Success(t *testing.T) {
 					State: state,
 				}, nil
 			},
-			ListBundleContentsFunc: func(ctx context.Context, id string, offset, limit int) ([]*models.ContentItem, int, error) {
-				return expectedContents, len(expectedContents), nil
+			ListBundleContentsFunc: func(ctx context.Context, id string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
+				return expectedContents, len(expectedContents), "", nil
 			},
 		}
 
@@ -1896,8 +2026,8 @@ func TestGetBundleContents_Success(t *testing.T) {
 					State: state,
 				}, nil
 			},
-			ListBundleContentsFunc: func(ctx context.Context, id string, offset, limit int) ([]*models.ContentItem, int, error) {
-				return originalContents, len(originalContents), nil
+			ListBundleContentsFunc: func(ctx context.Context, id string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
+				return originalContents, len(originalContents), "", nil
 			},
 			CheckBundleExistsFunc: func(ctx context.Context, id string) (bool, error) {
 				return id == bundleID, nil
@@ -2086,8 +2216,8 @@ func TestGetBundleContents_Failure(t *testing.T) {
 					State: state,
 				}, nil
 			},
-			ListBundleContentsFunc: func(ctx context.Context, id string, offset, limit int) ([]*models.ContentItem, int, error) {
-				return originalContents, len(originalContents), nil
+			ListBundleContentsFunc: func(ctx context.Context, id string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
+				return originalContents, len(originalContents), "", nil
 			},
 		}
 
@@ -2131,3 +2261,69 @@ func TestGetBundleContents_Failure(t *testing.T) {
 		})
 	})
 }
+
+func TestGetContents(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a dataset version that is in a bundle", t, func() {
+		w := httptest.NewRecorder()
+
+		mockedDatastore := &storetest.StorerMock{
+			ListContentsFunc: func(ctx context.Context, offset, limit int, contentFilters *filters.ContentFilters) ([]*models.ContentItem, int, string, error) {
+				return []*models.ContentItem{{ID: cont1, BundleID: "bundle-1", Metadata: models.Metadata{DatasetID: dataset2, EditionID: edition2, VersionID: 1}}}, 1, "", nil
+			},
+			ListBundlesByIDsFunc: func(ctx context.Context, bundleIDs []string) ([]*models.Bundle, error) {
+				return []*models.Bundle{{ID: "bundle-1", Title: "Bundle 1", State: models.BundleStateInReview}}, nil
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When GET /contents is called for the dataset version", func() {
+			r := createRequestWithAuth(http.MethodGet, "/contents?dataset_id=dataset-2&edition_id=edition-2&version_id=1&state=APPROVED", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with the content item and the bundle it is in", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var response struct {
+					Items      []models.ContentItem `json:"items"`
+					TotalCount int                  `json:"total_count"`
+				}
+				So(json.NewDecoder(w.Body).Decode(&response), ShouldBeNil)
+				So(response.TotalCount, ShouldEqual, 1)
+				So(response.Items[0].ID, ShouldEqual, cont1)
+				So(response.Items[0].Bundle, ShouldResemble, &models.ContentItemBundle{ID: "bundle-1", Title: "Bundle 1", State: models.BundleStateInReview})
+
+				contentFilters := mockedDatastore.ListContentsCalls()[0].ContentFilters
+				So(*contentFilters.DatasetID, ShouldEqual, dataset2)
+				So(*contentFilters.EditionID, ShouldEqual, edition2)
+				So(*contentFilters.VersionID, ShouldEqual, 1)
+				So(*contentFilters.State, ShouldEqual, models.StateApproved)
+			})
+		})
+
+		Convey("When GET /contents is called with a version_id that is not a number", func() {
+			r := createRequestWithAuth(http.MethodGet, "/contents?dataset_id=dataset-2&version_id=latest", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 400 Bad Request for the parameter", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, `"parameter":"version_id"`)
+				So(mockedDatastore.ListContentsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When GET /contents fails to list the content items", func() {
+			mockedDatastore.ListContentsFunc = func(ctx context.Context, offset, limit int, contentFilters *filters.ContentFilters) ([]*models.ContentItem, int, string, error) {
+				return nil, 0, "", errors.New("database error")
+			}
+			r := createRequestWithAuth(http.MethodGet, "/contents?dataset_id=dataset-2", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 500 Internal Server Error", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
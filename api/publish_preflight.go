@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const RouteNamePostPublishPreflight = "postPublishPreflight"
+
+// postPublishPreflight reports whether publishing a bundle would succeed, without changing anything
+func (api *BundleAPI) postPublishPreflight(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNamePostPublishPreflight)
+		return
+	}
+
+	report, err := api.stateMachineBundleAPI.PublishPreflight(ctx, bundleID, authEntityData)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNamePostPublishPreflight)
+		return
+	}
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		log.Error(ctx, "postPublishPreflight endpoint: failed to marshal preflight report to JSON", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(reportJSON); err != nil {
+		log.Error(ctx, "postPublishPreflight endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logData["ready"] = report.Ready
+	logSuccessfulRequest(ctx, logData, RouteNamePostPublishPreflight)
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPostPublishPreflight(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a POST request to /bundles/{bundle-id}/publish-preflight", t, func() {
+		r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/publish-preflight", http.NoBody)
+		r.Header.Set("Authorization", "Bearer test-auth-token")
+		w := httptest.NewRecorder()
+
+		contentItems := []models.ContentItem{
+			{ID: "content-item-1", BundleID: "bundle1", Metadata: models.Metadata{DatasetID: "dataset1", EditionID: "2025", VersionID: 1}},
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return &models.Bundle{ID: bundleID, State: models.BundleStateApproved}, nil
+			},
+			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				return &contentItems, nil
+			},
+		}
+
+		versionState := datasetAPIModels.ApprovedState
+		mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				version := newTestVersion("version1", datasetID, editionID, 1, versionState)
+				version.Links = &datasetAPIModels.VersionLinks{WebPage: &datasetAPIModels.LinkObject{HRef: "http://publishing.ons.gov.uk/topic-slug/datasets/dataset1/editions/2025/versions/1"}}
+				return *version, nil
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When every check passes", func() {
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with a report showing the bundle is ready", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var report models.PublishPreflightReport
+				So(json.NewDecoder(w.Body).Decode(&report), ShouldBeNil)
+				So(report.BundleID, ShouldEqual, "bundle1")
+				So(report.Ready, ShouldBeTrue)
+				So(report.ContentItems, ShouldHaveLength, 1)
+				So(report.ContentItems[0].Ready, ShouldBeTrue)
+			})
+		})
+
+		Convey("When a content item's version is not approved", func() {
+			versionState = datasetAPIModels.AssociatedState
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with the problem reported against the content item", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var report models.PublishPreflightReport
+				So(json.NewDecoder(w.Body).Decode(&report), ShouldBeNil)
+				So(report.Ready, ShouldBeFalse)
+				So(report.ContentItems[0].Ready, ShouldBeFalse)
+				So(report.ContentItems[0].Errors, ShouldHaveLength, 1)
+				So(report.ContentItems[0].Errors[0].Description, ShouldEqual, apierrors.ErrorDescriptionPreflightVersionNotApproved)
+				So(report.ContentItems[0].Errors[0].Source.Field, ShouldEqual, "/state")
+			})
+		})
+
+		Convey("When the bundle does not exist", func() {
+			mockedDatastore.GetBundleFunc = func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return nil, apierrors.ErrBundleNotFound
+			}
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When the datastore fails", func() {
+			mockedDatastore.GetBundleContentsForBundleFunc = func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				return nil, errors.New("database error")
+			}
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 500 Internal Server Error", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
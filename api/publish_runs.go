@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const RouteNameGetPublishRuns = "getPublishRuns"
+
+// getPublishRuns returns the history of attempts to publish a bundle, most recent first
+func (api *BundleAPI) getPublishRuns(w http.ResponseWriter, r *http.Request, limit, offset int) (successResult *models.PaginationSuccessResult[models.PublishRun], errorResult *models.ErrorResult[models.Error]) {
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	bundleExists, err := api.stateMachineBundleAPI.CheckBundleExists(ctx, bundleID)
+	if err != nil {
+		code := models.CodeInternalError
+		log.Error(ctx, "failed to check if bundle exists", err, logData)
+		internalError := &models.Error{Code: &code, Description: errs.ErrorDescriptionInternalError}
+		return nil, models.CreateInternalErrorResult(internalError)
+	}
+
+	if !bundleExists {
+		code := models.CodeNotFound
+		log.Warn(ctx, "bundle not found", logData)
+		notFoundError := &models.Error{Code: &code, Description: errs.ErrorDescriptionNotFound}
+		return nil, models.CreateNotFoundResult(notFoundError)
+	}
+
+	publishRuns, totalCount, err := api.stateMachineBundleAPI.ListPublishRuns(ctx, bundleID, offset, limit)
+	if err != nil {
+		code := models.CodeInternalError
+		log.Error(ctx, "failed to get publish runs", err, logData)
+		internalError := &models.Error{Code: &code, Description: errs.ErrorDescriptionInternalError}
+		return nil, models.CreateInternalErrorResult(internalError)
+	}
+
+	logData["total_count"] = totalCount
+	logSuccessfulRequest(ctx, logData, RouteNameGetPublishRuns)
+	return models.CreatePaginationSuccessResult(publishRuns, totalCount), nil
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetPublishRuns_Success(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a GET request to /bundles/{bundle-id}/publish-runs for a bundle that has been published", t, func() {
+		startedAt := time.Now().UTC()
+		publishRuns := []*models.PublishRun{
+			{
+				ID:        "run-1",
+				BundleID:  "bundle1",
+				State:     models.PublishRunStateCompleted,
+				StartedAt: &startedAt,
+				Items: []models.PublishRunItem{
+					{ContentItemID: "content-item-1", DatasetID: "dataset1", EditionID: "2025", VersionID: 1, State: models.PublishRunItemStatePublished, Attempts: 1},
+				},
+			},
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			CheckBundleExistsFunc: func(ctx context.Context, bundleID string) (bool, error) {
+				return true, nil
+			},
+			ListPublishRunsFunc: func(ctx context.Context, bundleID string, offset, limit int) ([]*models.PublishRun, int, error) {
+				return publishRuns, len(publishRuns), nil
+			},
+		}
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When getPublishRuns is called", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:29800/bundles/bundle1/publish-runs?offset=5&limit=10", http.NoBody)
+			r = mux.SetURLVars(r, map[string]string{"bundle-id": "bundle1"})
+			w := httptest.NewRecorder()
+
+			successResp, errResp := bundleAPI.getPublishRuns(w, r, 10, 5)
+
+			Convey("Then the publish runs for the bundle are returned", func() {
+				So(errResp, ShouldBeNil)
+				So(successResp.Result.Items, ShouldResemble, publishRuns)
+				So(successResp.Result.TotalCount, ShouldEqual, 1)
+			})
+
+			Convey("And the bundle ID, offset and limit are passed to the datastore", func() {
+				So(mockedDatastore.ListPublishRunsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.ListPublishRunsCalls()[0].BundleID, ShouldEqual, "bundle1")
+				So(mockedDatastore.ListPublishRunsCalls()[0].Offset, ShouldEqual, 5)
+				So(mockedDatastore.ListPublishRunsCalls()[0].Limit, ShouldEqual, 10)
+			})
+		})
+	})
+}
+
+func TestGetPublishRuns_Failure(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a GET request to /bundles/{bundle-id}/publish-runs", t, func() {
+		r := httptest.NewRequest(http.MethodGet, "http://localhost:29800/bundles/bundle1/publish-runs", http.NoBody)
+		r = mux.SetURLVars(r, map[string]string{"bundle-id": "bundle1"})
+		w := httptest.NewRecorder()
+
+		Convey("When the bundle does not exist", func() {
+			mockedDatastore := &storetest.StorerMock{
+				CheckBundleExistsFunc: func(ctx context.Context, bundleID string) (bool, error) {
+					return false, nil
+				},
+			}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			successResp, errResp := bundleAPI.getPublishRuns(w, r, 10, 0)
+
+			Convey("Then a 404 error is returned", func() {
+				So(successResp, ShouldBeNil)
+				So(errResp.HTTPStatusCode, ShouldEqual, http.StatusNotFound)
+				So(errResp.Error.Description, ShouldEqual, apierrors.ErrorDescriptionNotFound)
+				So(mockedDatastore.ListPublishRunsCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When checking the bundle exists fails", func() {
+			mockedDatastore := &storetest.StorerMock{
+				CheckBundleExistsFunc: func(ctx context.Context, bundleID string) (bool, error) {
+					return false, errors.New("database failure")
+				},
+			}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			successResp, errResp := bundleAPI.getPublishRuns(w, r, 10, 0)
+
+			Convey("Then a 500 error is returned", func() {
+				So(successResp, ShouldBeNil)
+				So(errResp.HTTPStatusCode, ShouldEqual, http.StatusInternalServerError)
+				So(errResp.Error.Description, ShouldEqual, apierrors.ErrorDescriptionInternalError)
+			})
+		})
+
+		Convey("When the datastore fails to list the publish runs", func() {
+			mockedDatastore := &storetest.StorerMock{
+				CheckBundleExistsFunc: func(ctx context.Context, bundleID string) (bool, error) {
+					return true, nil
+				},
+				ListPublishRunsFunc: func(ctx context.Context, bundleID string, offset, limit int) ([]*models.PublishRun, int, error) {
+					return nil, 0, errors.New("database failure")
+				},
+			}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			successResp, errResp := bundleAPI.getPublishRuns(w, r, 10, 0)
+
+			Convey("Then a 500 error is returned", func() {
+				So(successResp, ShouldBeNil)
+				So(errResp.HTTPStatusCode, ShouldEqual, http.StatusInternalServerError)
+				So(errResp.Error.Description, ShouldEqual, apierrors.ErrorDescriptionInternalError)
+			})
+		})
+	})
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	RouteNameGetPublishSchedule   = "getPublishSchedule"
+	RouteNameRescheduleBundle     = "rescheduleBundle"
+	RouteNameCancelBundleSchedule = "cancelBundleSchedule"
+)
+
+// getPublishSchedule returns the approved scheduled bundles that are waiting to be published, in the order the
+// scheduler will publish them
+func (api *BundleAPI) getPublishSchedule(w http.ResponseWriter, r *http.Request, limit, offset int) (successResult *models.PaginationSuccessResult[models.Bundle], errorResult *models.ErrorResult[models.Error]) {
+	ctx := r.Context()
+
+	bundles, totalCount, err := api.stateMachineBundleAPI.ListScheduledBundles(ctx, offset, limit)
+	if err != nil {
+		code := models.CodeInternalError
+		log.Error(ctx, "failed to get scheduled bundles", err)
+		internalError := &models.Error{Code: &code, Description: errs.ErrorDescriptionInternalError}
+		return nil, models.CreateInternalErrorResult(internalError)
+	}
+
+	logSuccessfulRequest(ctx, log.Data{"total_count": totalCount}, RouteNameGetPublishSchedule)
+	return models.CreatePaginationSuccessResult(bundles, totalCount), nil
+}
+
+// rescheduleBundle moves a scheduled bundle, and the release dates of its dataset versions, to a new publish date
+func (api *BundleAPI) rescheduleBundle(w http.ResponseWriter, r *http.Request) {
+	defer dphttp.DrainBody(r)
+
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	etag, err := utils.GetETag(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameRescheduleBundle)
+		return
+	}
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameRescheduleBundle)
+		return
+	}
+
+	rescheduleRequest, err := utils.GetRequestBody[models.RescheduleRequest](r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameRescheduleBundle)
+		return
+	}
+
+	validationErrs := models.ValidateRescheduleRequest(rescheduleRequest)
+	if len(validationErrs) > 0 {
+		log.Error(ctx, "rescheduleBundle endpoint: reschedule request validation failed", errs.ErrInvalidBody, logData)
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, validationErrs...)
+		return
+	}
+
+	logData["scheduled_at"] = rescheduleRequest.ScheduledAt
+
+	bundle, err := api.stateMachineBundleAPI.RescheduleBundle(ctx, bundleID, *etag, *rescheduleRequest.ScheduledAt, authEntityData)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameRescheduleBundle)
+		return
+	}
+
+	writeScheduledBundle(w, r, bundle, logData, RouteNameRescheduleBundle)
+}
+
+// cancelBundleSchedule stops a scheduled bundle from being published by the scheduler by converting it to a manual bundle
+func (api *BundleAPI) cancelBundleSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	etag, err := utils.GetETag(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameCancelBundleSchedule)
+		return
+	}
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameCancelBundleSchedule)
+		return
+	}
+
+	bundle, err := api.stateMachineBundleAPI.CancelBundleSchedule(ctx, bundleID, *etag, authEntityData)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameCancelBundleSchedule)
+		return
+	}
+
+	writeScheduledBundle(w, r, bundle, logData, RouteNameCancelBundleSchedule)
+}
+
+func writeScheduledBundle(w http.ResponseWriter, r *http.Request, bundle *models.Bundle, logData log.Data, endpoint string) {
+	ctx := r.Context()
+
+	bundleBytes := setETagAndCacheControlHeaders(ctx, w, r, bundle, false, logData)
+	if bundleBytes == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(bundleBytes); err != nil {
+		log.Error(ctx, endpoint+" endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logSuccessfulRequest(ctx, logData, endpoint)
+}
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/slack"
+	slackMock "github.com/ONSdigital/dis-bundle-api/slack/mocks"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetPublishSchedule_Success(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a GET request to /publish-schedule", t, func() {
+		oneHourLater := time.Now().UTC().Add(time.Hour)
+		scheduledBundles := []*models.Bundle{
+			{
+				ID:          "bundle1",
+				BundleType:  models.BundleTypeScheduled,
+				ScheduledAt: &oneHourLater,
+				State:       models.BundleStateApproved,
+				Title:       "Scheduled Bundle 1",
+			},
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			ListScheduledBundlesFunc: func(ctx context.Context, offset, limit int) ([]*models.Bundle, int, error) {
+				return scheduledBundles, len(scheduledBundles), nil
+			},
+		}
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When getPublishSchedule is called", func() {
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:29800/publish-schedule?offset=5&limit=10", http.NoBody)
+			w := httptest.NewRecorder()
+
+			successResp, errResp := bundleAPI.getPublishSchedule(w, r, 10, 5)
+
+			Convey("Then the scheduled bundles are returned", func() {
+				So(errResp, ShouldBeNil)
+				So(successResp.Result.Items, ShouldResemble, scheduledBundles)
+				So(successResp.Result.TotalCount, ShouldEqual, 1)
+			})
+
+			Convey("And the offset and limit are passed to the datastore", func() {
+				So(mockedDatastore.ListScheduledBundlesCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.ListScheduledBundlesCalls()[0].Offset, ShouldEqual, 5)
+				So(mockedDatastore.ListScheduledBundlesCalls()[0].Limit, ShouldEqual, 10)
+			})
+		})
+	})
+}
+
+func TestGetPublishSchedule_Failure(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a GET request to /publish-schedule", t, func() {
+		Convey("When the datastore returns an error", func() {
+			mockedDatastore := &storetest.StorerMock{
+				ListScheduledBundlesFunc: func(ctx context.Context, offset, limit int) ([]*models.Bundle, int, error) {
+					return nil, 0, errors.New("database failure")
+				},
+			}
+			bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+			r := httptest.NewRequest(http.MethodGet, "http://localhost:29800/publish-schedule", http.NoBody)
+			w := httptest.NewRecorder()
+
+			successResp, errResp := bundleAPI.getPublishSchedule(w, r, 10, 0)
+
+			Convey("Then a 500 error is returned", func() {
+				So(successResp, ShouldBeNil)
+				So(errResp.HTTPStatusCode, ShouldEqual, http.StatusInternalServerError)
+				So(errResp.Error.Description, ShouldEqual, apierrors.ErrorDescriptionInternalError)
+			})
+		})
+	})
+}
+
+func TestRescheduleAndCancelBundleSchedule(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a scheduled bundle", t, func() {
+		w := httptest.NewRecorder()
+		scheduledAt := time.Now().UTC().Add(time.Hour)
+
+		bundle := &models.Bundle{
+			ID:          "bundle1",
+			BundleType:  models.BundleTypeScheduled,
+			ScheduledAt: &scheduledAt,
+			State:       models.BundleStateApproved,
+			Title:       "Scheduled Bundle 1",
+			ETag:        "etag1",
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				if bundleID != bundle.ID {
+					return nil, apierrors.ErrBundleNotFound
+				}
+				return bundle, nil
+			},
+			UpdateBundleFunc: func(ctx context.Context, bundleID string, bundle *models.Bundle) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			GetContentItemsByBundleIDFunc: func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
+				return []*models.ContentItem{{ID: "content1", Metadata: models.Metadata{DatasetID: "dataset1", EditionID: "edition1", VersionID: 1}}}, nil
+			},
+		}
+
+		mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{
+			PutVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string, version datasetAPIModels.Version) (datasetAPIModels.Version, error) {
+				return version, nil
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient, &permissionsAPISDKMock.ClienterMock{}, false)
+		bundleAPI.stateMachineBundleAPI.DataBundleSlackClient = &slackMock.ClienterMock{
+			SendInfoFunc: func(ctx context.Context, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+		}
+
+		Convey("When POST /bundles/{bundle-id}/reschedule is called with a new date", func() {
+			newScheduledAt := scheduledAt.Add(24 * time.Hour).Truncate(time.Second)
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/reschedule", bytes.NewBufferString(`{"scheduled_at": "`+newScheduledAt.Format(time.RFC3339)+`"}`))
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			r.Header.Set("If-Match", "etag1")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with the rescheduled bundle", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldEqual, "etag1")
+
+				var updated models.Bundle
+				So(json.NewDecoder(w.Body).Decode(&updated), ShouldBeNil)
+				So(updated.ScheduledAt.Equal(newScheduledAt), ShouldBeTrue)
+				So(mockDatasetAPIClient.PutVersionCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/reschedule is called without a date", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/reschedule", bytes.NewBufferString(`{}`))
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			r.Header.Set("If-Match", "etag1")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 400 Bad Request", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, apierrors.ErrorDescriptionScheduledAtIsRequired)
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/reschedule is called with malformed JSON", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/reschedule", bytes.NewBufferString(`{`))
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			r.Header.Set("If-Match", "etag1")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 400 Bad Request", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/cancel-schedule is called", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/cancel-schedule", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			r.Header.Set("If-Match", "etag1")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with a manual bundle", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var updated models.Bundle
+				So(json.NewDecoder(w.Body).Decode(&updated), ShouldBeNil)
+				So(updated.BundleType, ShouldEqual, models.BundleTypeManual)
+				So(updated.ScheduledAt, ShouldBeNil)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/cancel-schedule is called for a manual bundle", func() {
+			bundle.BundleType = models.BundleTypeManual
+			r := createRequestWithAuth(http.MethodPost, "/bundles/bundle1/cancel-schedule", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			r.Header.Set("If-Match", "etag1")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 409 Conflict", func() {
+				So(w.Code, ShouldEqual, http.StatusConflict)
+				So(w.Body.String(), ShouldContainSubstring, apierrors.ErrorDescriptionBundleNotScheduled)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/reschedule or /cancel-schedule is called without an If-Match header", func() {
+			for _, path := range []string{"/bundles/bundle1/reschedule", "/bundles/bundle1/cancel-schedule"} {
+				w := httptest.NewRecorder()
+				r := createRequestWithAuth(http.MethodPost, path, bytes.NewBufferString(`{"scheduled_at": "`+scheduledAt.Format(time.RFC3339)+`"}`))
+				r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+				bundleAPI.Router.ServeHTTP(w, r)
+
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			}
+
+			Convey("Then the bundle is not updated", func() {
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/reschedule or /cancel-schedule is called with an out of date ETag", func() {
+			for _, path := range []string{"/bundles/bundle1/reschedule", "/bundles/bundle1/cancel-schedule"} {
+				w := httptest.NewRecorder()
+				r := createRequestWithAuth(http.MethodPost, path, bytes.NewBufferString(`{"scheduled_at": "`+scheduledAt.Format(time.RFC3339)+`"}`))
+				r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+				r.Header.Set("If-Match", "stale-etag")
+				bundleAPI.Router.ServeHTTP(w, r)
+
+				So(w.Code, ShouldEqual, http.StatusConflict)
+			}
+
+			Convey("Then the bundle is not updated", func() {
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+				So(mockDatasetAPIClient.PutVersionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When POST /bundles/{bundle-id}/cancel-schedule is called for a bundle that does not exist", func() {
+			r := createRequestWithAuth(http.MethodPost, "/bundles/missing/cancel-schedule", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			r.Header.Set("If-Match", "etag1")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	RouteNameGetReleaseCalendar = "getReleaseCalendar"
+
+	defaultReleaseCalendarPeriod = 14 * 24 * time.Hour
+	maxReleaseCalendarPeriod     = 92 * 24 * time.Hour
+)
+
+// getReleaseCalendar lists the release slots between the from and to query parameters, along with the bundles
+// scheduled to publish in each of them. The calendar defaults to the next 14 days.
+func (api *BundleAPI) getReleaseCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logData := log.Data{}
+
+	from, to, validationErrs := getReleaseCalendarRange(r)
+	if len(validationErrs) > 0 {
+		log.Error(ctx, "getReleaseCalendar endpoint: invalid query parameters", errs.ErrInvalidQueryParameter, logData)
+		utils.HandleBundleAPIErr(w, r, http.StatusBadRequest, validationErrs...)
+		return
+	}
+
+	logData["from"] = from
+	logData["to"] = to
+
+	releaseCalendar, err := api.stateMachineBundleAPI.GetReleaseCalendar(ctx, from, to)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameGetReleaseCalendar)
+		return
+	}
+
+	releaseCalendarJSON, err := json.Marshal(releaseCalendar)
+	if err != nil {
+		log.Error(ctx, "getReleaseCalendar endpoint: failed to marshal release calendar to JSON", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(releaseCalendarJSON); err != nil {
+		log.Error(ctx, "getReleaseCalendar endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logSuccessfulRequest(ctx, logData, RouteNameGetReleaseCalendar)
+}
+
+// getReleaseCalendarRange returns the from and to query parameters, defaulting to now and 14 days after from
+func getReleaseCalendarRange(r *http.Request) (from, to time.Time, validationErrs []*models.Error) {
+	code := models.CodeInvalidParameters
+
+	from = time.Now().UTC()
+	if fromParam := r.URL.Query().Get("from"); fromParam != "" {
+		fromTime, err := time.Parse(time.RFC3339, fromParam)
+		if err != nil {
+			validationErrs = append(validationErrs, &models.Error{
+				Code:        &code,
+				Description: errs.ErrorDescriptionMalformedRequest,
+				Source:      &models.Source{Parameter: "from"},
+			})
+		}
+		from = fromTime
+	}
+
+	to = from.Add(defaultReleaseCalendarPeriod)
+	if toParam := r.URL.Query().Get("to"); toParam != "" {
+		toTime, err := time.Parse(time.RFC3339, toParam)
+		if err != nil {
+			validationErrs = append(validationErrs, &models.Error{
+				Code:        &code,
+				Description: errs.ErrorDescriptionMalformedRequest,
+				Source:      &models.Source{Parameter: "to"},
+			})
+		}
+		to = toTime
+	}
+
+	if len(validationErrs) == 0 && (!to.After(from) || to.Sub(from) > maxReleaseCalendarPeriod) {
+		validationErrs = append(validationErrs, &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionInvalidReleaseCalendarRange,
+			Source:      &models.Source{Parameter: "to"},
+		})
+	}
+
+	return from, to, validationErrs
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/calendar"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetReleaseCalendar(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a release calendar with weekday slots at 07:00 and 09:30 UK time", t, func() {
+		w := httptest.NewRecorder()
+
+		scheduledAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+		mockedDatastore := &storetest.StorerMock{
+			ListBundlesScheduledBetweenFunc: func(ctx context.Context, from, to time.Time) ([]*models.Bundle, error) {
+				return []*models.Bundle{{ID: "bundle1", Title: "Bundle 1", State: models.BundleStateApproved, BundleType: models.BundleTypeScheduled, ScheduledAt: &scheduledAt}}, nil
+			},
+		}
+
+		releaseCalendar, err := calendar.New(calendar.Config{
+			TimeZone: "Europe/London",
+			Slots:    []string{"07:00", "09:30"},
+			Weekdays: []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+		})
+		So(err, ShouldBeNil)
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+		bundleAPI.stateMachineBundleAPI.ReleaseCalendar = releaseCalendar
+
+		Convey("When GET /release-calendar is called for a day", func() {
+			r := createRequestWithAuth(http.MethodGet, "/release-calendar?from=2026-03-02T00:00:00Z&to=2026-03-03T00:00:00Z", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with the slots on that day and the bundles booked into them", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var response models.ReleaseCalendar
+				So(json.NewDecoder(w.Body).Decode(&response), ShouldBeNil)
+				So(response.Slots, ShouldHaveLength, 2)
+				So(response.Slots[0].Bundles, ShouldBeEmpty)
+				So(response.Slots[1].Bundles, ShouldHaveLength, 1)
+				So(response.Slots[1].Bundles[0].ID, ShouldEqual, "bundle1")
+
+				call := mockedDatastore.ListBundlesScheduledBetweenCalls()[0]
+				So(call.From.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)), ShouldBeTrue)
+				So(call.To.Equal(time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)), ShouldBeTrue)
+			})
+		})
+
+		Convey("When GET /release-calendar is called without from and to", func() {
+			r := createRequestWithAuth(http.MethodGet, "/release-calendar", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the calendar for the next 14 days is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				call := mockedDatastore.ListBundlesScheduledBetweenCalls()[0]
+				So(call.To.Sub(call.From), ShouldEqual, 14*24*time.Hour)
+			})
+		})
+
+		Convey("When GET /release-calendar is called with a malformed from", func() {
+			r := createRequestWithAuth(http.MethodGet, "/release-calendar?from=yesterday", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 400 Bad Request", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, `"parameter":"from"`)
+				So(mockedDatastore.ListBundlesScheduledBetweenCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When GET /release-calendar is called with to before from", func() {
+			r := createRequestWithAuth(http.MethodGet, "/release-calendar?from=2026-03-02T00:00:00Z&to=2026-03-01T00:00:00Z", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 400 Bad Request", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, apierrors.ErrorDescriptionInvalidReleaseCalendarRange)
+			})
+		})
+
+		Convey("When GET /release-calendar is called for more than 92 days", func() {
+			r := createRequestWithAuth(http.MethodGet, "/release-calendar?from=2026-03-02T00:00:00Z&to=2026-07-01T00:00:00Z", http.NoBody)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 400 Bad Request", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
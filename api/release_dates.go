@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const RouteNameGetReleaseDateCheck = "getReleaseDateCheck"
+
+// getReleaseDateCheck lists the content items in a scheduled bundle whose dataset version does not have the bundle's
+// scheduled_at as its release date
+func (api *BundleAPI) getReleaseDateCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameGetReleaseDateCheck)
+		return
+	}
+
+	check, err := api.stateMachineBundleAPI.CheckReleaseDates(ctx, bundleID, authEntityData.Headers)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameGetReleaseDateCheck)
+		return
+	}
+
+	checkJSON, err := json.Marshal(check)
+	if err != nil {
+		log.Error(ctx, "getReleaseDateCheck endpoint: failed to marshal release date check to JSON", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(checkJSON); err != nil {
+		log.Error(ctx, "getReleaseDateCheck endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logData["consistent"] = check.Consistent
+	logSuccessfulRequest(ctx, logData, RouteNameGetReleaseDateCheck)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetReleaseDateCheck(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a scheduled bundle whose content item has a different release date", t, func() {
+		w := httptest.NewRecorder()
+		scheduledAt := time.Date(2025, 7, 1, 9, 30, 0, 0, time.UTC)
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				if bundleID != "bundle1" {
+					return nil, apierrors.ErrBundleNotFound
+				}
+				return &models.Bundle{ID: bundleID, BundleType: models.BundleTypeScheduled, ScheduledAt: &scheduledAt}, nil
+			},
+			GetContentItemsByBundleIDFunc: func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
+				return []*models.ContentItem{{ID: "content1", Metadata: models.Metadata{DatasetID: "dataset1", EditionID: "edition1", VersionID: 1}}}, nil
+			},
+		}
+
+		mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				return datasetAPIModels.Version{ReleaseDate: "2025-06-01T09:30:00.000Z"}, nil
+			},
+		}
+
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When GET /bundles/{bundle-id}/release-date-check is called", func() {
+			r := createRequestWithAuth(http.MethodGet, "/bundles/bundle1/release-date-check", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK with the mismatched content item", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var check models.ReleaseDateCheck
+				So(json.NewDecoder(w.Body).Decode(&check), ShouldBeNil)
+				So(check.Consistent, ShouldBeFalse)
+				So(check.Mismatches, ShouldHaveLength, 1)
+				So(check.Mismatches[0].ContentItemID, ShouldEqual, "content1")
+				So(check.Mismatches[0].ReleaseDate, ShouldEqual, "2025-06-01T09:30:00.000Z")
+			})
+		})
+
+		Convey("When GET /bundles/{bundle-id}/release-date-check is called for a bundle that does not exist", func() {
+			r := createRequestWithAuth(http.MethodGet, "/bundles/missing/release-date-check", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/filters"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	RouteNameSearchBundles = "searchBundles"
+)
+
+// searchBundles returns a page of the bundles whose titles, or the titles of whose content items, match the words in
+// the q query parameter, ranked by how well they match, along with the content items in each that match
+func (api *BundleAPI) searchBundles(w http.ResponseWriter, r *http.Request, limit, offset int) (successResult *models.PaginationSuccessResult[models.BundleSearchResult], errorResult *models.ErrorResult[models.Error]) {
+	ctx := r.Context()
+
+	searchFilters, filtersErr := filters.CreateSearchFilters(r)
+	if filtersErr != nil {
+		log.Error(ctx, filtersErr.Error.Error(), apierrors.ErrInvalidQueryParameter)
+		code := models.CodeInvalidParameters
+		invalidRequestError := &models.Error{Code: &code, Description: apierrors.ErrorDescriptionMalformedRequest, Source: filtersErr.Source}
+		return nil, models.CreateBadRequestErrorResult(invalidRequestError)
+	}
+
+	results, totalCount, err := api.stateMachineBundleAPI.SearchBundles(ctx, searchFilters.Query, offset, limit)
+	if err != nil {
+		log.Error(ctx, "failed to search bundles", err)
+		return nil, models.CreateErrorResult(models.GetMatchingModelError(err), apierrors.GetStatusCodeForErr(err))
+	}
+
+	logSuccessfulRequest(ctx, log.Data{"total_count": totalCount}, RouteNameSearchBundles)
+	return models.CreatePaginationSuccessResult(results, totalCount), nil
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSearchBundles_Success(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a GET request to /search", t, func() {
+		searchResults := []*models.BundleSearchResult{
+			{
+				Bundle:       &models.Bundle{ID: "bundle1", Title: "Consumer prices"},
+				Score:        1.5,
+				TitleMatched: true,
+				MatchedContents: []*models.ContentItem{
+					{ID: "content1", BundleID: "bundle1", Metadata: models.Metadata{Title: "Consumer price inflation"}},
+				},
+			},
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			SearchBundlesFunc: func(ctx context.Context, text string, offset, limit int) ([]*models.BundleSearchResult, int, error) {
+				return searchResults, len(searchResults), nil
+			},
+		}
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When searchBundles is called", func() {
+			r := httptest.NewRequest(http.MethodGet, "/search?q=consumer+prices&offset=5&limit=10", http.NoBody)
+			w := httptest.NewRecorder()
+
+			successResp, errResp := bundleAPI.searchBundles(w, r, 10, 5)
+
+			Convey("Then the ranked search results are returned", func() {
+				So(errResp, ShouldBeNil)
+				So(successResp.Result.Items, ShouldResemble, searchResults)
+				So(successResp.Result.TotalCount, ShouldEqual, 1)
+			})
+
+			Convey("And the text, offset and limit are passed to the datastore", func() {
+				So(mockedDatastore.SearchBundlesCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.SearchBundlesCalls()[0].Text, ShouldEqual, "consumer prices")
+				So(mockedDatastore.SearchBundlesCalls()[0].Offset, ShouldEqual, 5)
+				So(mockedDatastore.SearchBundlesCalls()[0].Limit, ShouldEqual, 10)
+			})
+		})
+	})
+}
+
+func TestSearchBundles_Failure(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a GET request to /search", t, func() {
+		mockedDatastore := &storetest.StorerMock{
+			SearchBundlesFunc: func(ctx context.Context, text string, offset, limit int) ([]*models.BundleSearchResult, int, error) {
+				return nil, 0, errors.New("database failure")
+			},
+		}
+		bundleAPI := GetBundleAPIWithMocks(store.Datastore{Backend: mockedDatastore}, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, false)
+
+		Convey("When the q parameter is missing", func() {
+			r := httptest.NewRequest(http.MethodGet, "/search", http.NoBody)
+			w := httptest.NewRecorder()
+
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the status code should be 400 with q as the source", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var errList models.ErrorList
+				So(json.NewDecoder(w.Body).Decode(&errList), ShouldBeNil)
+				So(errList.Errors, ShouldHaveLength, 1)
+				So(*errList.Errors[0].Code, ShouldEqual, models.CodeInvalidParameters)
+				So(errList.Errors[0].Source.Parameter, ShouldEqual, "q")
+				So(mockedDatastore.SearchBundlesCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the datastore returns an error", func() {
+			r := httptest.NewRequest(http.MethodGet, "/search?q=cpi", http.NoBody)
+			w := httptest.NewRecorder()
+
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the status code should be 500", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const RouteNameGetBundleTransitions = "getBundleTransitions"
+
+// getBundleTransitions lists the states a bundle can move to from its current state, and whether the caller can make
+// each move now
+func (api *BundleAPI) getBundleTransitions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bundleID, logData := getBundleIDAndLogData(r)
+
+	authEntityData, err := api.GetAuthEntityData(r)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameGetBundleTransitions)
+		return
+	}
+
+	bundleTransitions, err := api.stateMachineBundleAPI.GetBundleTransitions(ctx, bundleID, authEntityData)
+	if err != nil {
+		handleErr(ctx, w, r, err, logData, RouteNameGetBundleTransitions)
+		return
+	}
+
+	api.addPermissionPreconditions(r, bundleTransitions.Transitions, make(map[string]bool))
+
+	transitionsJSON, err := json.Marshal(bundleTransitions)
+	if err != nil {
+		log.Error(ctx, "getBundleTransitions endpoint: failed to marshal transitions to JSON", err, logData)
+		code := models.CodeInternalError
+		errInfo := &models.Error{
+			Code:        &code,
+			Description: errs.ErrorDescriptionInternalError,
+		}
+		utils.HandleBundleAPIErr(w, r, http.StatusInternalServerError, errInfo)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(transitionsJSON); err != nil {
+		log.Error(ctx, "getBundleTransitions endpoint: error writing response body", err, logData)
+		return
+	}
+
+	logSuccessfulRequest(ctx, logData, RouteNameGetBundleTransitions)
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/filters"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	authorisationMock "github.com/ONSdigital/dp-authorisation/v2/authorisation/mock"
+	datasetAPISDKMock "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetBundleTransitions(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a GET request to /bundles/{bundle-id}/transitions for a caller without the bundles:unpublish permission", t, func() {
+		r := createRequestWithAuth(http.MethodGet, "/bundles/bundle1/transitions", http.NoBody)
+		r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+		w := httptest.NewRecorder()
+
+		bundle := &models.Bundle{ID: "bundle1", Title: "Bundle 1", State: models.BundleStateApproved}
+		contentItems := []models.ContentItem{
+			{ID: "content-item-1", BundleID: "bundle1", State: new(models.StateApproved)},
+			{ID: "content-item-2", BundleID: "bundle1"},
+			{ID: "content-item-3", BundleID: "bundle1"},
+		}
+		var contentItemsErr error
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				if bundleID != bundle.ID {
+					return nil, apierrors.ErrBundleNotFound
+				}
+				return bundle, nil
+			},
+			GetBundleViewFunc: func(ctx context.Context, bundleID string, view *filters.BundleView) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				return &contentItems, contentItemsErr
+			},
+		}
+		datastore := store.Datastore{Backend: mockedDatastore}
+
+		authMiddleware := &authorisationMock.MiddlewareMock{
+			RequireFunc: func(permission string, handlerFunc http.HandlerFunc) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					if permission == "bundles:unpublish" {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+					handlerFunc(w, r)
+				}
+			},
+			ParseFunc: newAuthMiddlwareMock(false, nil).ParseFunc,
+		}
+
+		bundleAPI := GetBundleAPIWithMocksWithAuthMiddleware(datastore, &datasetAPISDKMock.ClienterMock{}, &permissionsAPISDKMock.ClienterMock{}, authMiddleware, false)
+
+		definition, err := application.LoadWorkflowDefinition("")
+		So(err, ShouldBeNil)
+		bundleAPI.stateMachineBundleAPI.StateMachine, err = application.NewStateMachineFromDefinition(context.Background(), definition, application.DefaultRegistry(), datastore, nil)
+		So(err, ShouldBeNil)
+
+		Convey("When the bundle is approved but some of its content items are not", func() {
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 200 OK listing the states reachable from APPROVED", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var bundleTransitions models.BundleTransitions
+				So(json.NewDecoder(w.Body).Decode(&bundleTransitions), ShouldBeNil)
+				So(bundleTransitions.BundleID, ShouldEqual, "bundle1")
+				So(bundleTransitions.State, ShouldEqual, models.BundleStateApproved)
+				So(bundleTransitions.Transitions, ShouldResemble, []models.AvailableTransition{
+					{State: models.BundleStateDraft, Permitted: true},
+					{State: models.BundleStateInReview, Permitted: true},
+					{State: models.BundleStatePublished, Permitted: false, UnmetPreconditions: []string{"2 content items not approved"}},
+				})
+			})
+		})
+
+		Convey("When the bundle is published", func() {
+			bundle.State = models.BundleStatePublished
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then withdrawing it is listed but not permitted for the caller", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var bundleTransitions models.BundleTransitions
+				So(json.NewDecoder(w.Body).Decode(&bundleTransitions), ShouldBeNil)
+				So(bundleTransitions.Transitions, ShouldResemble, []models.AvailableTransition{
+					{State: models.BundleStateWithdrawn, Permitted: false, UnmetPreconditions: []string{"requires the bundles:unpublish permission"}},
+				})
+			})
+		})
+
+		Convey("When the published bundle is fetched with its transitions embedded", func() {
+			bundle.State = models.BundleStatePublished
+			r = createRequestWithAuth(http.MethodGet, "/bundles/bundle1?fields=state&embed=transitions", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the transitions are embedded in the bundle, marked the same way", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+
+				var response models.Bundle
+				So(json.NewDecoder(w.Body).Decode(&response), ShouldBeNil)
+				So(response.ID, ShouldEqual, "bundle1")
+				So(response.State, ShouldEqual, models.BundleStatePublished)
+				So(response.Title, ShouldBeEmpty)
+				So(*response.Embedded.Transitions, ShouldResemble, []models.AvailableTransition{
+					{State: models.BundleStateWithdrawn, Permitted: false, UnmetPreconditions: []string{"requires the bundles:unpublish permission"}},
+				})
+			})
+		})
+
+		Convey("When the preconditions of its transitions cannot be checked", func() {
+			contentItemsErr = errors.New("database unavailable")
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 500 Internal Server Error rather than an unmet precondition", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+
+		Convey("When the bundle is fetched with its transitions embedded and their preconditions cannot be checked", func() {
+			contentItemsErr = errors.New("database unavailable")
+			r = createRequestWithAuth(http.MethodGet, "/bundles/bundle1?embed=transitions", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 500 Internal Server Error", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+
+		Convey("When the bundle does not exist", func() {
+			r = createRequestWithAuth(http.MethodGet, "/bundles/missing/transitions", http.NoBody)
+			r.Header.Set("Authorization", MockAuthBearerHeaderValue)
+			bundleAPI.Router.ServeHTTP(w, r)
+
+			Convey("Then the response is 404 Not Found", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
@@ -29,11 +29,25 @@ const (
 	ErrorDescriptionBundleTitleAlreadyExist = "A bundle with the same title already exists."
 
 	// Bundle Contents Error Descriptions
-	ErrorDescriptionVersionAlreadyExists = "This edition/version of a series already exists in another bundle."
+	ErrorDescriptionVersionAlreadyExists   = "This edition/version of a series already exists in another bundle."
+	ErrorDescriptionVersionRepeatedInBatch = "This edition/version of a series appears more than once in the request."
 
 	// State Error Descriptions
 	ErrorDescriptionInvalidStateTransition      = "Unable to process request due to invalid state transition."
 	ErrorDescriptionStateNotAllowedToTransition = "state not allowed to transition."
+	ErrorDescriptionContentItemsNotApproved     = "All content items must be approved before the bundle can be published."
+	ErrorDescriptionTransitionReasonRequired    = "A reason is required when sending a bundle in review or approved back to DRAFT."
+
+	// Approval Error Descriptions
+	ErrorDescriptionApproverCreatedBundle = "A bundle must be approved by someone other than the user who created it."
+	ErrorDescriptionApproverEditedBundle  = "A bundle must be approved by someone who has not edited it or its content items."
+	ErrorDescriptionApprovalQuorumNotMet  = "The bundle does not have enough recorded approvals to be approved."
+	ErrorDescriptionApprovalAlreadyExists = "You have already approved this bundle."
+	ErrorDescriptionApprovalNotInReview   = "Approvals can only be recorded or withdrawn while the bundle is in review."
+	ErrorDescriptionBundleHasConflicts    = "The bundle contains datasets that are also in other bundles that have not been published."
+
+	// Comment Error Descriptions
+	ErrorDescriptionCommentParentNotFound = "The comment being replied to does not exist."
 
 	// Header Error Descriptions
 	ErrorDescriptionMissingIfMatchHeader = "Unable to process request due to missing If-Match header."
@@ -43,9 +57,30 @@ const (
 	ErrorDescriptionAccessDenied = "Access denied."
 
 	// Scheduling Error Descriptions
-	ErrorDescriptionScheduledAtIsInPast       = "scheduled_at cannot be in the past."
-	ErrorDescriptionScheduledAtShouldNotBeSet = "scheduled_at should not be set for manual bundles."
-	ErrorDescriptionScheduledAtIsRequired     = "scheduled_at is required for scheduled bundles."
+	ErrorDescriptionScheduledAtIsInPast         = "scheduled_at cannot be in the past."
+	ErrorDescriptionScheduledAtShouldNotBeSet   = "scheduled_at should not be set for manual bundles."
+	ErrorDescriptionScheduledAtIsRequired       = "scheduled_at is required for scheduled bundles."
+	ErrorDescriptionBundleNotScheduled          = "Only a scheduled bundle can be rescheduled or have its schedule cancelled."
+	ErrorDescriptionScheduleNotChangeable       = "The schedule of a bundle can only be changed before it is published."
+	ErrorDescriptionScheduledAtNotReleaseSlot   = "scheduled_at must be one of the release slots in the release calendar."
+	ErrorDescriptionScheduledAtInBlackout       = "scheduled_at cannot be on a blackout date in the release calendar."
+	ErrorDescriptionInvalidReleaseCalendarRange = "to must be after from, and no more than 92 days after it."
+
+	// Pagination Error Descriptions
+	ErrorDescriptionInvalidCursor = "cursor is not valid for this request. Use the next_cursor from a response to the same request."
+
+	// Publish Preflight Error Descriptions
+	ErrorDescriptionPreflightBundleNotPublishable = "The bundle is not in a state that can be published."
+	ErrorDescriptionPreflightNoContentItems       = "The bundle has no content items to publish."
+	ErrorDescriptionPreflightVersionCheckFailed   = "Failed to get the version from dataset API."
+	ErrorDescriptionPreflightVersionNotApproved   = "The version is not approved."
+	ErrorDescriptionPreflightVersionLinksInvalid  = "The version does not have a resolvable web page link."
+	ErrorDescriptionPreflightPolicyNotFound       = "The policy for a preview team does not exist."
+	ErrorDescriptionPreflightPolicyCheckFailed    = "Failed to get the policy for a preview team from permissions API."
+	ErrorDescriptionPreflightPolicyMissingContent = "The policy for a preview team does not give access to the content item."
+
+	// Withdrawal Error Descriptions
+	ErrorDescriptionWithdrawContentItemsFailed = "Failed to withdraw one or more content items. The bundle remains published and the withdrawal can be retried."
 
 	// Marshal/Unmarshal Error Descriptions
 	ErrorDescriptionMarshalJSONObject = "Failed to Marshal bundle resource into bytes."
@@ -73,16 +108,38 @@ var (
 	// Content-Specific
 	ErrContentItemNotFound = errors.New("content item not found")
 
+	// Publish run-Specific
+	ErrPublishRunNotFound = errors.New("publish run not found")
+
+	// Withdrawal-Specific
+	ErrWithdrawContentItemsFailed = errors.New("failed to withdraw one or more content items")
+
 	// Validation
 	ErrMissingParameters      = errors.New("missing required parameters in request")
 	ErrInvalidQueryParameter  = errors.New("invalid query parameter")
 	ErrTooManyQueryParameters = errors.New("too many query parameters provided")
+	ErrInvalidCursor          = errors.New("invalid cursor")
 
 	// State errors
-	ErrExpectedStateOfCreated  = errors.New("expected bundle state to be 'CREATED'")
-	ErrExpectedStateOfApproved = errors.New("expected bundle state to be 'APPROVED'")
-	ErrInvalidTransition       = errors.New("state not allowed to transition")
-	ErrVersionStateNotApproved = errors.New("version state expected to be APPROVED when transitioning bundle to APPROVED")
+	ErrExpectedStateOfCreated   = errors.New("expected bundle state to be 'CREATED'")
+	ErrExpectedStateOfApproved  = errors.New("expected bundle state to be 'APPROVED'")
+	ErrInvalidTransition        = errors.New("state not allowed to transition")
+	ErrVersionStateNotApproved  = errors.New("version state expected to be APPROVED when transitioning bundle to APPROVED")
+	ErrContentItemsNotApproved  = errors.New("content items expected to be APPROVED when transitioning bundle to PUBLISHED")
+	ErrTransitionReasonRequired = errors.New("a reason is required for this state transition")
+
+	// Approval errors
+	ErrApproverCreatedBundle = errors.New("bundle cannot be approved by the user who created it")
+	ErrApproverEditedBundle  = errors.New("bundle cannot be approved by a user who has edited it")
+	ErrApprovalQuorumNotMet  = errors.New("bundle does not have enough approvals")
+	ErrApprovalAlreadyExists = errors.New("approval already recorded for this user")
+	ErrApprovalNotFound      = errors.New("approval not found")
+	ErrApprovalNotInReview   = errors.New("approvals can only be changed while the bundle is in review")
+	ErrBundleHasConflicts    = errors.New("bundle has content items that conflict with other bundles")
+
+	// Comment errors
+	ErrCommentNotFound       = errors.New("comment not found")
+	ErrCommentParentNotFound = errors.New("comment being replied to not found")
 
 	// Parsing errors
 	ErrUnableToParseTime = errors.New("failed to parse time from json body")
@@ -93,9 +150,15 @@ var (
 	ErrInvalidIfMatchHeader = errors.New("etag does not match")
 
 	// Scheduling errors
-	ErrScheduledAtRequired = errors.New("scheduled_at is required for scheduled bundles")
-	ErrScheduledAtSet      = errors.New("scheduled_at should not be set for manual bundles")
-	ErrScheduledAtInPast   = errors.New("scheduled_at cannot be in the past")
+	ErrScheduledAtRequired   = errors.New("scheduled_at is required for scheduled bundles")
+	ErrScheduledAtSet        = errors.New("scheduled_at should not be set for manual bundles")
+	ErrScheduledAtInPast     = errors.New("scheduled_at cannot be in the past")
+	ErrBundleNotScheduled    = errors.New("bundle is not scheduled")
+	ErrScheduleNotChangeable = errors.New("schedule cannot be changed once the bundle has been published")
+
+	// Release calendar errors
+	ErrScheduledAtNotReleaseSlot = errors.New("scheduled_at is not a release slot")
+	ErrScheduledAtInBlackout     = errors.New("scheduled_at is on a blackout date")
 
 	// Role errors
 	ErrInvalidRole = errors.New("invalid role provided")
@@ -115,23 +178,43 @@ var ErrorToStatusCode = map[error]int{
 	ErrMissingParameters:        400,
 	ErrInvalidQueryParameter:    400,
 	ErrTooManyQueryParameters:   400,
+	ErrInvalidCursor:            400,
 	ErrMissingBundleID:          400,
 	ErrInvalidBundleReference:   400,
 	ErrInvalidBundleState:       400,
 	ErrInvalidTransition:        400,
 	ErrMissingIfMatchHeader:     400,
 	ErrBundleTitleAlreadyExists: 400,
+	ErrTransitionReasonRequired: 400,
+	ErrCommentParentNotFound:    400,
+
+	ErrScheduledAtNotReleaseSlot: 400,
+	ErrScheduledAtInBlackout:     400,
 
 	ErrDeleteBundleForbidden:  403,
 	ErrExpectedStateOfCreated: 403,
+	ErrApproverCreatedBundle:  403,
+	ErrApproverEditedBundle:   403,
 
 	ErrBundleNotFound:          404,
 	ErrBundleEventNotFound:     404,
 	ErrBundleHasNoContentItems: 404,
 	ErrContentItemNotFound:     404,
-
-	ErrBundleAlreadyExists:  409,
-	ErrInvalidIfMatchHeader: 409,
+	ErrPublishRunNotFound:      404,
+	ErrApprovalNotFound:        404,
+	ErrCommentNotFound:         404,
+
+	ErrBundleAlreadyExists:     409,
+	ErrInvalidIfMatchHeader:    409,
+	ErrContentItemsNotApproved: 409,
+	ErrApprovalQuorumNotMet:    409,
+	ErrApprovalAlreadyExists:   409,
+	ErrApprovalNotInReview:     409,
+	ErrBundleHasConflicts:      409,
+	ErrBundleNotScheduled:      409,
+	ErrScheduleNotChangeable:   409,
+
+	ErrWithdrawContentItemsFailed: 500,
 }
 
 func GetStatusCodeForErr(err error) int {
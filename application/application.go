@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 	"slices"
 	"strconv"
 	"strings"
@@ -13,6 +12,8 @@ import (
 	"time"
 
 	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/calendar"
+	"github.com/ONSdigital/dis-bundle-api/content"
 	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	"github.com/ONSdigital/dis-bundle-api/slack"
@@ -31,9 +32,20 @@ type StateMachineBundleAPI struct {
 	PermissionsAPIClient  permissionsAPISDK.Clienter
 	DataBundleSlackClient slack.Clienter
 	PreviewServiceURL     string
+	PublishMaxConcurrency int
+	ApprovalPolicy        ApprovalPolicy
+	ReleaseCalendar       *calendar.Calendar
+
+	// PublishDatasetAPIClient is the dataset API client used when publishing and approving bundles, which retries
+	// transient failures. DatasetAPIClient is used if it has not been set up.
+	PublishDatasetAPIClient datasetAPISDK.Clienter
+
+	// PublishRunHeartbeatInterval is how often a publish run is marked as still active while its bundle is being
+	// published, so that it is not treated as stale. Publish runs are not renewed if it is not set.
+	PublishRunHeartbeatInterval time.Duration
 }
 
-func Setup(datastore store.Datastore, stateMachine *StateMachine, datasetAPIClient datasetAPISDK.Clienter, permissionsAPIClient permissionsAPISDK.Clienter, dataBundleSlackClient slack.Clienter, previewServiceURL string) *StateMachineBundleAPI {
+func Setup(datastore store.Datastore, stateMachine *StateMachine, datasetAPIClient datasetAPISDK.Clienter, permissionsAPIClient permissionsAPISDK.Clienter, dataBundleSlackClient slack.Clienter, previewServiceURL string, publishMaxConcurrency int, approvalPolicy ApprovalPolicy, releaseCalendar *calendar.Calendar) *StateMachineBundleAPI {
 	return &StateMachineBundleAPI{
 		Datastore:             datastore,
 		StateMachine:          stateMachine,
@@ -41,21 +53,116 @@ func Setup(datastore store.Datastore, stateMachine *StateMachine, datasetAPIClie
 		PermissionsAPIClient:  permissionsAPIClient,
 		DataBundleSlackClient: dataBundleSlackClient,
 		PreviewServiceURL:     previewServiceURL,
+		PublishMaxConcurrency: publishMaxConcurrency,
+		ApprovalPolicy:        approvalPolicy,
+		ReleaseCalendar:       releaseCalendar,
 	}
 }
 
-func (s *StateMachineBundleAPI) ListBundles(ctx context.Context, offset, limit int, bundleFilters *filters.BundleFilters) ([]*models.Bundle, int, error) {
-	results, totalCount, err := s.Datastore.ListBundles(ctx, offset, limit, bundleFilters)
+// DatasetProvider returns the provider for the dataset versions that content items refer to
+func (s *StateMachineBundleAPI) DatasetProvider() *content.DatasetProvider {
+	return content.NewDatasetProvider(s.DatasetAPIClient)
+}
+
+// PublishDatasetProvider returns the provider for dataset versions used when publishing and approving bundles
+func (s *StateMachineBundleAPI) PublishDatasetProvider() *content.DatasetProvider {
+	if s.PublishDatasetAPIClient == nil {
+		return s.DatasetProvider()
+	}
+
+	return content.NewDatasetProvider(s.PublishDatasetAPIClient)
+}
+
+func (s *StateMachineBundleAPI) ListBundles(ctx context.Context, offset, limit int, bundleFilters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+	results, totalCount, nextCursor, err := s.Datastore.ListBundles(ctx, offset, limit, bundleFilters)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
+	}
+
+	if bundleFilters != nil {
+		applyBundleView(results, bundleFilters.View)
 	}
-	return results, totalCount, nil
+
+	return results, totalCount, nextCursor, nil
+}
+
+// ListContents returns a page of the content items in all bundles that match the filters, each with a summary of the
+// bundle it is in
+func (s *StateMachineBundleAPI) ListContents(ctx context.Context, offset, limit int, contentFilters *filters.ContentFilters) ([]*models.ContentItem, int, string, error) {
+	contents, totalCount, nextCursor, err := s.Datastore.ListContents(ctx, offset, limit, contentFilters)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	bundleIDs := []string{}
+	for _, contentItem := range contents {
+		if !slices.Contains(bundleIDs, contentItem.BundleID) {
+			bundleIDs = append(bundleIDs, contentItem.BundleID)
+		}
+	}
+
+	if len(bundleIDs) == 0 {
+		return contents, totalCount, nextCursor, nil
+	}
+
+	bundles, err := s.Datastore.ListBundlesByIDs(ctx, bundleIDs)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	bundlesByID := make(map[string]*models.Bundle, len(bundles))
+	for _, bundle := range bundles {
+		bundlesByID[bundle.ID] = bundle
+	}
+
+	for _, contentItem := range contents {
+		if bundle, ok := bundlesByID[contentItem.BundleID]; ok {
+			contentItem.Bundle = &models.ContentItemBundle{ID: bundle.ID, Title: bundle.Title, State: bundle.State}
+		}
+	}
+
+	return contents, totalCount, nextCursor, nil
+}
+
+// SearchBundles returns a page of the bundles whose titles, or the titles of whose content items, match the text, ranked
+// by how well they match
+func (s *StateMachineBundleAPI) SearchBundles(ctx context.Context, text string, offset, limit int) ([]*models.BundleSearchResult, int, error) {
+	return s.Datastore.SearchBundles(ctx, text, offset, limit)
 }
 
 func (s *StateMachineBundleAPI) GetBundle(ctx context.Context, bundleID string) (*models.Bundle, error) {
 	return s.Datastore.GetBundle(ctx, bundleID)
 }
 
+// GetBundleView returns a bundle in the representation asked for by the view, or the whole bundle if the view is nil.
+// Transitions are not embedded here, see EmbedBundleTransitions.
+func (s *StateMachineBundleAPI) GetBundleView(ctx context.Context, bundleID string, view *filters.BundleView) (*models.Bundle, error) {
+	if view.IsFull() {
+		return s.Datastore.GetBundle(ctx, bundleID)
+	}
+
+	bundle, err := s.Datastore.GetBundleView(ctx, bundleID, view)
+	if err != nil {
+		return nil, err
+	}
+
+	applyBundleView([]*models.Bundle{bundle}, view)
+
+	return bundle, nil
+}
+
+// applyBundleView limits each of the bundles to the fields in the view. The stored ETag of each bundle is kept, so that
+// a weak ETag for the view of a bundle can be made from it.
+func applyBundleView(bundles []*models.Bundle, view *filters.BundleView) {
+	if view.IsFull() {
+		return
+	}
+
+	for _, bundle := range bundles {
+		bundle.Fields = view.Fields
+	}
+}
+
 func (s *StateMachineBundleAPI) UpdateBundleETag(ctx context.Context, bundleID, email string) (*models.Bundle, error) {
 	return s.Datastore.UpdateBundleETag(ctx, bundleID, email)
 }
@@ -72,12 +179,12 @@ func (s *StateMachineBundleAPI) GetContentItemByBundleIDAndContentItemID(ctx con
 	return s.Datastore.GetContentItemByBundleIDAndContentItemID(ctx, bundleID, contentItemID)
 }
 
-func (s *StateMachineBundleAPI) ListBundleEvents(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time) ([]*models.Event, int, error) {
-	results, totalCount, err := s.Datastore.ListBundleEvents(ctx, offset, limit, bundleID, after, before)
+func (s *StateMachineBundleAPI) ListBundleEvents(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
+	results, totalCount, nextCursor, err := s.Datastore.ListBundleEvents(ctx, offset, limit, bundleID, after, before, sortFields, cursor)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
-	return results, totalCount, nil
+	return results, totalCount, nextCursor, nil
 }
 
 func (s *StateMachineBundleAPI) CheckAllBundleContentsAreApproved(ctx context.Context, bundleID string) (bool, error) {
@@ -143,7 +250,8 @@ func (s *StateMachineBundleAPI) GetBundleAndValidateETag(ctx context.Context, bu
 	return bundle, nil
 }
 
-func (s *StateMachineBundleAPI) UpdateBundleState(ctx context.Context, bundleID, suppliedETag string, targetState models.BundleState, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+// UpdateBundleState moves a bundle to targetState, recording reason as the reason for the transition
+func (s *StateMachineBundleAPI) UpdateBundleState(ctx context.Context, bundleID, suppliedETag string, targetState models.BundleState, reason string, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
 	logData := log.Data{"bundle_id": bundleID}
 	userID := authEntityData.GetUserID()
 
@@ -156,6 +264,11 @@ func (s *StateMachineBundleAPI) UpdateBundleState(ctx context.Context, bundleID,
 	bundle.UpdatedAt = &now
 	bundle.LastUpdatedBy = &models.User{Email: userID}
 
+	bundle.LastTransition, err = newStateTransition(bundle.State, targetState, reason, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
 	updatedBundle, err := s.StateMachine.Transition(ctx, s, bundle, targetState, *authEntityData)
 	if err != nil {
 		log.Error(ctx, "transition failed", err, logData)
@@ -190,6 +303,11 @@ func (s *StateMachineBundleAPI) CreateBundle(ctx context.Context, bundle *models
 		return http.StatusConflict, nil, e, errs.ErrBundleTitleAlreadyExists
 	}
 
+	if err = s.checkReleaseCalendar(bundle); err != nil {
+		log.Error(ctx, "bundle is not scheduled in a release slot", err, log.Data{"scheduled_at": bundle.ScheduledAt})
+		return http.StatusBadRequest, nil, models.GetMatchingModelError(err), err
+	}
+
 	err = s.Datastore.CreateBundle(ctx, bundle)
 	if err != nil {
 		log.Error(ctx, "failed to create bundle", err)
@@ -253,7 +371,7 @@ func (s *StateMachineBundleAPI) DeleteBundle(ctx context.Context, bundleID strin
 		}
 	}
 
-	if bundle.State == models.BundleStatePublished {
+	if bundle.State == models.BundleStatePublished || bundle.State == models.BundleStatePublishFailed {
 		code := models.CodeConflict
 		err := errs.ErrDeleteBundleForbidden
 		e := &models.Error{
@@ -356,11 +474,11 @@ func (s *StateMachineBundleAPI) CheckBundleExistsByTitle(ctx context.Context, ti
 	return exists, nil
 }
 
-func (s *StateMachineBundleAPI) GetBundleContents(ctx context.Context, bundleID string, offset, limit int, authHeaders datasetAPISDK.Headers) ([]*models.ContentItem, int, error) {
+func (s *StateMachineBundleAPI) GetBundleContents(ctx context.Context, bundleID string, offset, limit int, sortFields []filters.SortField, cursor *string, authHeaders datasetAPISDK.Headers) ([]*models.ContentItem, int, string, error) {
 	// Get bundle
 	bundle, err := s.Datastore.GetBundle(ctx, bundleID)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 	bundleState := bundle.State
 
@@ -368,17 +486,17 @@ func (s *StateMachineBundleAPI) GetBundleContents(ctx context.Context, bundleID
 
 	// If bundle is published, return its contents directly
 	if bundleState.String() == models.BundleStatePublished.String() {
-		contentResults, totalCount, err := s.Datastore.ListBundleContents(ctx, bundleID, offset, limit)
+		contentResults, totalCount, nextCursor, err := s.Datastore.ListBundleContents(ctx, bundleID, offset, limit, sortFields, cursor)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, "", err
 		}
-		return contentResults, totalCount, nil
+		return contentResults, totalCount, nextCursor, nil
 	}
 
 	// If bundle is not published, populate state & title by calling dataset API Client
-	contentResults, totalCount, err := s.Datastore.ListBundleContents(ctx, bundleID, offset, limit)
+	contentResults, totalCount, nextCursor, err := s.Datastore.ListBundleContents(ctx, bundleID, offset, limit, sortFields, cursor)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 
 	for _, contentItem := range contentResults {
@@ -389,19 +507,19 @@ func (s *StateMachineBundleAPI) GetBundleContents(ctx context.Context, bundleID
 		dataset, err := s.DatasetAPIClient.GetDataset(ctx, authHeaders, datasetID)
 		if err != nil {
 			log.Error(ctx, "failed to fetch dataset", err, log.Data{"dataset_id": datasetID})
-			return nil, 0, err
+			return nil, 0, "", err
 		}
 
 		version, err := s.DatasetAPIClient.GetVersion(ctx, authHeaders, datasetID, editionID, versionID)
 		if err != nil {
 			log.Error(ctx, "failed to fetch dataset version", err, log.Data{"dataset_id": datasetID, "edition_id": editionID, "version_id": versionID})
-			return nil, 0, err
+			return nil, 0, "", err
 		}
 		contentItem.State = (*models.State)(&version.State)
 		contentItem.Metadata.Title = dataset.Title
 	}
 
-	return contentResults, totalCount, nil
+	return contentResults, totalCount, nextCursor, nil
 }
 
 func (s *StateMachineBundleAPI) PutBundle(ctx context.Context, bundleID string, bundleUpdate *models.Bundle, authEntityData *models.AuthEntityData, eTag string) (*models.Bundle, error) {
@@ -418,6 +536,12 @@ func (s *StateMachineBundleAPI) PutBundle(ctx context.Context, bundleID string,
 		return nil, err
 	}
 
+	lastTransition, err := newStateTransition(originalBundle.State, bundleUpdate.State, bundleUpdate.Reason, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	bundleUpdate.Reason = ""
+
 	if bundleUpdate.Title != originalBundle.Title {
 		exists, err := s.CheckBundleExistsByTitleUpdate(ctx, bundleUpdate.Title, bundleUpdate.ID)
 		if err != nil {
@@ -430,6 +554,13 @@ func (s *StateMachineBundleAPI) PutBundle(ctx context.Context, bundleID string,
 		}
 	}
 
+	if releaseDateChanged(originalBundle, bundleUpdate) {
+		if err := s.checkReleaseCalendar(bundleUpdate); err != nil {
+			log.Error(ctx, "bundle is not scheduled in a release slot", err, logData)
+			return nil, err
+		}
+	}
+
 	if err := s.CreateBundlePolicies(ctx, authEntityData.Headers.AccessToken, bundleUpdate.PreviewTeams, models.RoleDatasetsPreviewer); err != nil {
 		log.Error(ctx, "failed to create bundle policies", err, logData)
 		return nil, errs.ErrBundlePolicyFailedToCreate
@@ -460,6 +591,7 @@ func (s *StateMachineBundleAPI) PutBundle(ctx context.Context, bundleID string,
 	// Set the state to be the previous state to check for the state transition but holds all other updates to the record
 	// the new state is applied in the enter function
 	bundleUpdate.State = originalBundle.State
+	bundleUpdate.LastTransition = lastTransition
 
 	updatedBundle, err := s.StateMachine.Transition(ctx, s, bundleUpdate, nextState, *authEntityData)
 	if err != nil {
@@ -467,6 +599,13 @@ func (s *StateMachineBundleAPI) PutBundle(ctx context.Context, bundleID string,
 		return nil, err
 	}
 	log.Info(ctx, "bundle event creation successful", log.Classification(log.ProtectiveMonitoring), logAuth)
+
+	if releaseDateChanged(originalBundle, updatedBundle) {
+		if err := s.syncBundleReleaseDates(ctx, updatedBundle, authEntityData.Headers); err != nil {
+			return nil, err
+		}
+	}
+
 	return updatedBundle, nil
 }
 
@@ -518,7 +657,7 @@ func (s *StateMachineBundleAPI) UpdateContentItemsWithDatasetInfo(ctx context.Co
 func (s *StateMachineBundleAPI) UpdateDatasetVersionReleaseDate(ctx context.Context, releaseDate *time.Time, datasetID, editionID string, versionID int, authHeaders datasetAPISDK.Headers) error {
 	versionUpdate := datasetAPIModels.Version{
 		Type:        "static",
-		ReleaseDate: releaseDate.UTC().Format("2006-01-02T15:04:05.000Z"),
+		ReleaseDate: releaseDate.UTC().Format(models.ReleaseDateFormat),
 	}
 
 	_, err := s.DatasetAPIClient.PutVersion(ctx, authHeaders, datasetID, editionID, strconv.Itoa(versionID), versionUpdate)
@@ -547,22 +686,13 @@ func removeConditionValuesForBundlePreviewTeam(values []string, toRemove ...stri
 	return values
 }
 
-func PublishContentItems(ctx context.Context, smBundle StateMachineBundleAPI, authEntityData *models.AuthEntityData, contentItem *models.ContentItem, ch chan string, wg *sync.WaitGroup, state, bundleTitle string, errCh chan error) {
+func PublishContentItems(ctx context.Context, smBundle StateMachineBundleAPI, authEntityData *models.AuthEntityData, contentItem *models.ContentItem, ch chan string, wg *sync.WaitGroup, state, bundleTitle, publishRunID string, errCh chan error) {
 	defer wg.Done()
 
-	if err := smBundle.DatasetAPIClient.PutVersionState(ctx, authEntityData.Headers, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, strconv.Itoa(contentItem.Metadata.VersionID), strings.ToLower(state)); err != nil {
+	if err := publishContentItem(ctx, smBundle, authEntityData, contentItem, models.State(state)); err != nil {
 		log.Warn(ctx, fmt.Sprintf("Error occurred transitioning content item for bundle: %s", err.Error()), log.Data{"bundle-id": contentItem.BundleID, "content-item-id": contentItem.ID})
 
-		previewURL := smBundle.PreviewServiceURL + contentItem.Links.Preview
-
-		alarmFields := []slack.Field{
-			{Title: "Bundle ID", Value: contentItem.BundleID},
-			{Title: "Bundle Title", Value: bundleTitle},
-			{Title: "Dataset ID", Value: contentItem.Metadata.DatasetID},
-			{Title: "Edition", Value: contentItem.Metadata.EditionID},
-			{Title: "Version", Value: strconv.Itoa(contentItem.Metadata.VersionID)},
-			{Title: "Preview Link", Value: previewURL},
-		}
+		alarmFields := contentItemAlarmFields(smBundle, contentItem, bundleTitle)
 
 		_, alarmErr := smBundle.DataBundleSlackClient.SendAlarm(ctx, "Bundle content item failed to update", err, alarmFields)
 		if alarmErr != nil {
@@ -575,19 +705,65 @@ func PublishContentItems(ctx context.Context, smBundle StateMachineBundleAPI, au
 			"alarm_fields":    alarmFields,
 		})
 
+		recordPublishRunItem(ctx, smBundle, publishRunID, contentItem, models.PublishRunItemStateFailed, err)
 		errCh <- err
 		return
 	}
 
 	err := UpdateContentItemCreateEvent(ctx, smBundle, authEntityData, contentItem, state)
 	if err != nil {
+		recordPublishRunItem(ctx, smBundle, publishRunID, contentItem, models.PublishRunItemStateFailed, err)
 		errCh <- err
 		return
 	}
 
+	recordPublishRunItem(ctx, smBundle, publishRunID, contentItem, models.PublishRunItemStatePublished, nil)
 	ch <- contentItem.BundleID
 }
 
+// publishContentItem moves a content item's dataset version to state in dataset API
+func publishContentItem(ctx context.Context, smBundle StateMachineBundleAPI, authEntityData *models.AuthEntityData, contentItem *models.ContentItem, state models.State) error {
+	return smBundle.PublishDatasetProvider().Publish(ctx, authEntityData.Headers, contentItem, state)
+}
+
+// contentItemAlarmFields returns the slack fields that identify a content item that failed to update
+func contentItemAlarmFields(smBundle StateMachineBundleAPI, contentItem *models.ContentItem, bundleTitle string) []slack.Field {
+	return []slack.Field{
+		{Title: "Bundle ID", Value: contentItem.BundleID},
+		{Title: "Bundle Title", Value: bundleTitle},
+		{Title: "Dataset ID", Value: contentItem.Metadata.DatasetID},
+		{Title: "Edition", Value: contentItem.Metadata.EditionID},
+		{Title: "Version", Value: strconv.Itoa(contentItem.Metadata.VersionID)},
+		{Title: "Preview Link", Value: smBundle.PreviewServiceURL + contentItem.Links.Preview},
+	}
+}
+
+// unpublishedContentItems returns the content items that have not yet been published
+func unpublishedContentItems(contents *[]models.ContentItem) *[]models.ContentItem {
+	unpublished := make([]models.ContentItem, 0, len(*contents))
+	for index := range *contents {
+		contentItem := (*contents)[index]
+		if contentItem.State != nil && *contentItem.State == models.StatePublished {
+			continue
+		}
+		unpublished = append(unpublished, contentItem)
+	}
+	return &unpublished
+}
+
+// recordPublishRunItem records the outcome of publishing a content item in the publish run.
+// Failing to record the outcome is logged rather than failing the publish, as the item is reconciled if the run is resumed.
+func recordPublishRunItem(ctx context.Context, smBundle StateMachineBundleAPI, publishRunID string, contentItem *models.ContentItem, state models.PublishRunItemState, publishErr error) {
+	lastError := ""
+	if publishErr != nil {
+		lastError = publishErr.Error()
+	}
+
+	if err := smBundle.Datastore.UpdatePublishRunItem(ctx, publishRunID, contentItem.ID, state, lastError); err != nil {
+		log.Error(ctx, "failed to record publish run item", err, log.Data{"publish_run_id": publishRunID, "bundle_id": contentItem.BundleID, "content_item_id": contentItem.ID, "state": state})
+	}
+}
+
 func UpdateContentItemCreateEvent(ctx context.Context, smBundle StateMachineBundleAPI, authEntityData *models.AuthEntityData, contentItem *models.ContentItem, state string) error {
 	if err := smBundle.Datastore.UpdateContentItemState(ctx, contentItem.ID, state); err != nil {
 		return err
@@ -608,12 +784,38 @@ func UpdateContentItemCreateEvent(ctx context.Context, smBundle StateMachineBund
 }
 
 func PublishBundle(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
-	logData := log.Data{"bundle_id": bundle.ID, "bundle_type": bundle.BundleType, "title": bundle.Title}
 	contents, err := smBundle.Datastore.GetBundleContentsForBundle(ctx, bundle.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	// retrying a failed publish only re-attempts the content items that failed
+	if bundle.State == models.BundleStatePublishFailed {
+		contents = unpublishedContentItems(contents)
+	}
+
+	publishRun, err := models.NewPublishRun(bundle.ID, *contents, authEntityData.GetUserEmail())
+	if err != nil {
+		log.Error(ctx, "failed to create publish run", err, log.Data{"bundle_id": bundle.ID})
+		return nil, err
+	}
+
+	if err = smBundle.Datastore.CreatePublishRun(ctx, publishRun); err != nil {
+		log.Error(ctx, "failed to store publish run", err, log.Data{"bundle_id": bundle.ID, "publish_run_id": publishRun.ID})
+		return nil, err
+	}
+
+	return publishBundleRun(ctx, smBundle, bundle, publishRun, contents, authEntityData)
+}
+
+// publishBundleRun publishes the given content items of a bundle, recording the outcome of each in the publish run,
+// then marks the bundle as PUBLISHED, or PUBLISH_FAILED if any content item failed, and completes the publish run
+func publishBundleRun(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, publishRun *models.PublishRun, contents *[]models.ContentItem, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+	logData := log.Data{"bundle_id": bundle.ID, "bundle_type": bundle.BundleType, "title": bundle.Title, "publish_run_id": publishRun.ID}
+
+	stopRenewing := smBundle.holdPublishRun(ctx, publishRun.ID, logData)
+	defer stopRenewing()
+
 	publishStartTime := time.Now()
 	var publishLogFields = make([]slack.Field, 0, 7)
 	publishLogFields = append(publishLogFields, slack.Field{Title: "Bundle ID", Value: bundle.ID}, slack.Field{Title: "Title", Value: bundle.Title}, slack.Field{Title: "Type", Value: bundle.BundleType.String()}, slack.Field{Title: "Number of Content Items", Value: strconv.Itoa(len(*contents))}, slack.Field{Title: "Publish Start Date", Value: publishStartTime.Format(utils.SlackPublishTimeFormat)})
@@ -631,26 +833,14 @@ func PublishBundle(ctx context.Context, smBundle StateMachineBundleAPI, bundle *
 
 	slackMessageRef := <-c1
 
-	var wg sync.WaitGroup
-	ch := make(chan string, len(*contents))
-	errCh := make(chan error, len(*contents))
-
-	for index := range *contents {
-		contentItem := &(*contents)[index]
-		wg.Add(1)
-		go PublishContentItems(ctx, smBundle, authEntityData, contentItem, ch, &wg, models.BundleStatePublished.String(), bundle.Title, errCh)
-	}
-
-	wg.Wait()
-
-	close(errCh)
-	var contentItemErr error
-	for err := range errCh {
-		contentItemErr = err
-		log.Error(ctx, "something went wrong when processing content items", err, logData)
-	}
+	contentItemErr := publishContentItemsInOrder(ctx, smBundle, authEntityData, contents, bundle.Title, publishRun.ID, logData)
+	stopRenewing()
 
 	bundle.State = models.BundleStatePublished
+	if contentItemErr != nil {
+		bundle.State = models.BundleStatePublishFailed
+		bundle.LastTransition = publishFailedTransition(bundle.LastTransition)
+	}
 	bundle.LastUpdatedBy.Email = authEntityData.GetUserEmail()
 
 	updatedBundle, err := smBundle.Datastore.UpdateBundle(ctx, bundle.ID, bundle)
@@ -662,6 +852,16 @@ func PublishBundle(ctx context.Context, smBundle StateMachineBundleAPI, bundle *
 		return nil, err
 	}
 
+	publishRunState := models.PublishRunStateCompleted
+	if contentItemErr != nil {
+		publishRunState = models.PublishRunStateFailed
+	}
+
+	// a run left in progress is picked up as stale and completed when resumed, so there is no need to fail the publish
+	if err = smBundle.Datastore.CompletePublishRun(ctx, publishRun.ID, publishRunState); err != nil {
+		log.Error(ctx, "failed to complete publish run", err, logData)
+	}
+
 	publishEndTime := time.Now()
 	publishLogFields = append(publishLogFields,
 		slack.Field{Title: "Publish End Date", Value: publishEndTime.Format(utils.SlackPublishTimeFormat)},
@@ -698,51 +898,125 @@ func PublishBundle(ctx context.Context, smBundle StateMachineBundleAPI, bundle *
 	return updatedBundle, nil
 }
 
-func checkApprovedAndRefreshContentItems(ctx context.Context, smBundle StateMachineBundleAPI, contents *[]models.ContentItem, authEntityData *models.AuthEntityData) (allApproved bool, err error) {
+// publishContentItemsInOrder publishes the content items marked as dependencies before the other content items, so
+// that nothing is published ahead of content it depends on. If any dependency fails to publish then the other content
+// items are not published. Returns the last error from publishing a content item.
+func publishContentItemsInOrder(ctx context.Context, smBundle StateMachineBundleAPI, authEntityData *models.AuthEntityData, contents *[]models.ContentItem, bundleTitle, publishRunID string, logData log.Data) error {
+	dependencies := make([]*models.ContentItem, 0, len(*contents))
+	others := make([]*models.ContentItem, 0, len(*contents))
 	for index := range *contents {
 		contentItem := &(*contents)[index]
-		versionID := strconv.Itoa(contentItem.Metadata.VersionID)
+		if contentItem.Dependency {
+			dependencies = append(dependencies, contentItem)
+		} else {
+			others = append(others, contentItem)
+		}
+	}
 
-		version, err := smBundle.DatasetAPIClient.GetVersion(ctx, authEntityData.Headers, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, versionID)
-		if err != nil {
-			return false, err
+	if err := publishContentItemsConcurrently(ctx, smBundle, authEntityData, dependencies, bundleTitle, publishRunID, logData); err != nil {
+		if len(others) > 0 {
+			log.Warn(ctx, "dependency content items failed to publish, not publishing the remaining content items", logData)
+		}
+		return err
+	}
+
+	return publishContentItemsConcurrently(ctx, smBundle, authEntityData, others, bundleTitle, publishRunID, logData)
+}
+
+// publishContentItemsConcurrently publishes content items with at most PublishMaxConcurrency being published at once.
+// Returns the last error from publishing a content item.
+func publishContentItemsConcurrently(ctx context.Context, smBundle StateMachineBundleAPI, authEntityData *models.AuthEntityData, contentItems []*models.ContentItem, bundleTitle, publishRunID string, logData log.Data) error {
+	var wg sync.WaitGroup
+	ch := make(chan string, len(contentItems))
+	errCh := make(chan error, len(contentItems))
+
+	wg.Add(len(contentItems))
+	forEachConcurrently(len(contentItems), smBundle.PublishMaxConcurrency, func(index int) {
+		PublishContentItems(ctx, smBundle, authEntityData, contentItems[index], ch, &wg, models.BundleStatePublished.String(), bundleTitle, publishRunID, errCh)
+	})
+
+	wg.Wait()
+
+	close(errCh)
+	var contentItemErr error
+	for err := range errCh {
+		contentItemErr = err
+		log.Error(ctx, "something went wrong when processing content items", err, logData)
+	}
+
+	return contentItemErr
+}
+
+// forEachConcurrently calls fn with each index from 0 to n-1, running at most limit calls at once, and returns once
+// every call has returned. A limit of zero or less runs all of the calls at once.
+func forEachConcurrently(n, limit int, fn func(index int)) {
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+
+	for index := 0; index < n; index++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(index)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// checkApprovedAndRefreshContentItems checks that the versions of all content items are approved in dataset API,
+// refreshing each content item's metadata and links from its version. At most PublishMaxConcurrency content items are
+// checked at once.
+func checkApprovedAndRefreshContentItems(ctx context.Context, smBundle StateMachineBundleAPI, contents *[]models.ContentItem, authEntityData *models.AuthEntityData) (allApproved bool, err error) {
+	approved := make([]bool, len(*contents))
+	itemErrs := make([]error, len(*contents))
+
+	forEachConcurrently(len(*contents), smBundle.PublishMaxConcurrency, func(index int) {
+		approved[index], itemErrs[index] = checkApprovedAndRefreshContentItem(ctx, smBundle, &(*contents)[index], authEntityData)
+	})
+
+	for index := range *contents {
+		if itemErrs[index] != nil {
+			return false, itemErrs[index]
 		}
+	}
 
-		if version.State != datasetAPIModels.ApprovedState {
-			log.Warn(ctx, "Content item not approved", log.Data{"content-item-id": contentItem.ID, "version-state": version.State, "target-state": models.BundleStateApproved})
+	for index := range *contents {
+		if !approved[index] {
 			return false, nil
 		}
+	}
 
-		datasetID := contentItem.Metadata.DatasetID
-		editionID := contentItem.Metadata.EditionID
-		previewLink := contentItem.Links.Preview
+	return true, nil
+}
 
-		if version.Links != nil {
-			if version.Links.Dataset != nil && version.Links.Dataset.ID != "" {
-				datasetID = version.Links.Dataset.ID
-			}
-			if version.Links.Edition != nil && version.Links.Edition.ID != "" {
-				editionID = version.Links.Edition.ID
-			}
-			if version.Links.WebPage != nil && version.Links.WebPage.HRef != "" {
-				webPageURL, parseErr := url.Parse(version.Links.WebPage.HRef)
-				if parseErr != nil {
-					return false, parseErr
-				}
-				previewLink = webPageURL.Path
-			}
-		}
+func checkApprovedAndRefreshContentItem(ctx context.Context, smBundle StateMachineBundleAPI, contentItem *models.ContentItem, authEntityData *models.AuthEntityData) (approved bool, err error) {
+	provider := smBundle.PublishDatasetProvider()
+
+	state, err := provider.GetState(ctx, authEntityData.Headers, contentItem)
+	if err != nil {
+		return false, err
+	}
 
-		editLink := fmt.Sprintf("/data-admin/series/%s/editions/%s/versions/%d", datasetID, editionID, contentItem.Metadata.VersionID)
+	if state != models.StateApproved {
+		log.Warn(ctx, "Content item not approved", log.Data{"content-item-id": contentItem.ID, "content-state": state, "target-state": models.BundleStateApproved})
+		return false, nil
+	}
 
-		contentItem.Metadata.DatasetID = datasetID
-		contentItem.Metadata.EditionID = editionID
-		contentItem.Links.Edit = editLink
-		contentItem.Links.Preview = previewLink
+	if err := provider.BuildLinks(ctx, authEntityData.Headers, contentItem); err != nil {
+		return false, err
+	}
 
-		if err := smBundle.Datastore.UpdateContentItemMetadataAndLinks(ctx, contentItem.ID, datasetID, editionID, editLink, previewLink); err != nil {
-			return false, err
-		}
+	if err := smBundle.Datastore.UpdateContentItemMetadataAndLinks(ctx, contentItem.ID, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, contentItem.Links.Edit, contentItem.Links.Preview); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -816,6 +1090,46 @@ func DraftBundle(ctx context.Context, smBundle StateMachineBundleAPI, bundle *mo
 	return updatedBundle, nil
 }
 
+// newStateTransition returns a record of a bundle moving between the given states, or nil if its state is not
+// changing. Sending a bundle that is in review or approved back to DRAFT is refused unless a reason is given.
+func newStateTransition(fromState, toState models.BundleState, reason, userID string, now time.Time) (*models.StateTransition, error) {
+	if fromState == toState {
+		return nil, nil
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" && transitionRequiresReason(fromState, toState) {
+		return nil, errs.ErrTransitionReasonRequired
+	}
+
+	return &models.StateTransition{
+		FromState:      fromState,
+		ToState:        toState,
+		Reason:         reason,
+		TransitionedBy: &models.User{Email: userID},
+		TransitionedAt: &now,
+	}, nil
+}
+
+// publishFailedTransition returns the transition a bundle made when publishing it failed, given the transition to
+// PUBLISHED that was recorded before it was published. A retry of a failed publish that fails again leaves the bundle in
+// the state it started in, so there is no transition to record and nil is returned.
+func publishFailedTransition(transition *models.StateTransition) *models.StateTransition {
+	if transition == nil || transition.FromState == models.BundleStatePublishFailed {
+		return nil
+	}
+
+	failedTransition := *transition
+	failedTransition.ToState = models.BundleStatePublishFailed
+	return &failedTransition
+}
+
+// transitionRequiresReason reports whether moving a bundle between the given states is a rejection, which must be
+// explained to the bundle's editors
+func transitionRequiresReason(fromState, toState models.BundleState) bool {
+	return toState == models.BundleStateDraft && (fromState == models.BundleStateInReview || fromState == models.BundleStateApproved)
+}
+
 func (s *StateMachineBundleAPI) updateBundleAndCreateEvent(ctx context.Context, bundle *models.Bundle, authEntityData *models.AuthEntityData, logData log.Data) (*models.Bundle, error) {
 	updatedBundle, err := s.Datastore.UpdateBundle(ctx, bundle.ID, bundle)
 	if err != nil {
@@ -830,7 +1144,18 @@ func (s *StateMachineBundleAPI) updateBundleAndCreateEvent(ctx context.Context,
 	}
 	logAuth := log.Auth(identityType, authEntityData.EntityData.UserID)
 
-	if err = s.CreateEvent(ctx, authEntityData, models.ActionUpdate, updatedBundle, nil); err != nil {
+	event, err := models.CreateEventModel(authEntityData.GetUserID(), authEntityData.GetUserEmail(), models.ActionUpdate, updatedBundle, nil)
+	if err != nil {
+		log.Error(ctx, "failed to create event model", err)
+		return nil, err
+	}
+
+	// The bundle only has a last transition if one was recorded by this update
+	if bundle.LastTransition != nil {
+		event.Reason = bundle.LastTransition.Reason
+	}
+
+	if err = s.Datastore.CreateEvent(ctx, event); err != nil {
 		log.Error(ctx, "failed to create event", err, log.Classification(log.ProtectiveMonitoring), logAuth, log.Data{"bundle_id": updatedBundle.ID, "action": models.ActionUpdate})
 		return nil, err
 	}
@@ -3,7 +3,9 @@ package application_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -61,8 +63,8 @@ func TestListBundles(t *testing.T) {
 		}
 
 		mockedDatastore := &storetest.StorerMock{
-			ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, error) {
-				return expectedBundles, len(expectedBundles), nil
+			ListBundlesFunc: func(ctx context.Context, offset, limit int, filters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
+				return expectedBundles, len(expectedBundles), "", nil
 			},
 		}
 
@@ -75,7 +77,7 @@ func TestListBundles(t *testing.T) {
 			bundleFilters := filters.BundleFilters{
 				PublishDate: &now,
 			}
-			results, totalCount, err := stateMachine.ListBundles(ctx, 0, 10, &bundleFilters)
+			results, totalCount, _, err := stateMachine.ListBundles(ctx, 0, 10, &bundleFilters)
 
 			Convey("Then it should return the expected bundles without error", func() {
 				So(err, ShouldBeNil)
@@ -132,6 +134,50 @@ func TestGetBundle(t *testing.T) {
 	})
 }
 
+func TestGetBundleView(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with a mocked datastore", t, func() {
+		ctx := context.Background()
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return &models.Bundle{ID: bundleID, Title: "Example Bundle", ETag: "stored-etag"}, nil
+			},
+			GetBundleViewFunc: func(ctx context.Context, bundleID string, view *filters.BundleView) (*models.Bundle, error) {
+				return &models.Bundle{ID: bundleID, Title: "Example Bundle", ETag: "stored-etag"}, nil
+			},
+		}
+
+		stateMachine := &application.StateMachineBundleAPI{
+			Datastore: store.Datastore{Backend: mockedDatastore},
+		}
+
+		Convey("When GetBundleView is called without a view", func() {
+			result, err := stateMachine.GetBundleView(ctx, bundle123, nil)
+
+			Convey("Then it returns the whole bundle with its stored ETag", func() {
+				So(err, ShouldBeNil)
+				So(mockedDatastore.GetBundleCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.GetBundleViewCalls(), ShouldBeEmpty)
+				So(result.Fields, ShouldBeNil)
+				So(result.ETag, ShouldEqual, "stored-etag")
+			})
+		})
+
+		Convey("When GetBundleView is called with a view", func() {
+			view := &filters.BundleView{Fields: []string{"title"}}
+			result, err := stateMachine.GetBundleView(ctx, bundle123, view)
+
+			Convey("Then it returns the bundle limited to the fields, with its stored ETag", func() {
+				So(err, ShouldBeNil)
+				So(mockedDatastore.GetBundleViewCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.GetBundleViewCalls()[0].View, ShouldEqual, view)
+				So(result.Fields, ShouldResemble, []string{"title"})
+				So(result.ETag, ShouldEqual, "stored-etag")
+			})
+		})
+	})
+}
+
 func TestUpdateBundleETag(t *testing.T) {
 	Convey("Given a StateMachineBundleAPI with a mocked datastore", t, func() {
 		ctx := context.Background()
@@ -743,7 +789,7 @@ func TestGetBundleContents(t *testing.T) {
 				return nil, errors.New("bundle not found")
 			}
 
-			items, total, err := app.GetBundleContents(ctx, "missing-bundle", 0, 10, authHeaders)
+			items, total, _, err := app.GetBundleContents(ctx, "missing-bundle", 0, 10, nil, nil, authHeaders)
 
 			So(err, ShouldNotBeNil)
 			So(items, ShouldBeNil)
@@ -767,16 +813,16 @@ func TestGetBundleContents(t *testing.T) {
 				}, nil
 			}
 
-			mockedDatastore.ListBundleContentsFunc = func(ctx context.Context, id string, offset, limit int) ([]*models.ContentItem, int, error) {
+			mockedDatastore.ListBundleContentsFunc = func(ctx context.Context, id string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
 				return []*models.ContentItem{{
 					ID:          "1",
 					BundleID:    bundle1,
 					ContentType: models.ContentTypeDataset,
 					Metadata:    models.Metadata{DatasetID: "dataset-1"},
-				}}, 1, nil
+				}}, 1, "", nil
 			}
 
-			items, total, err := app.GetBundleContents(ctx, bundle1, 0, 10, authHeaders)
+			items, total, _, err := app.GetBundleContents(ctx, bundle1, 0, 10, nil, nil, authHeaders)
 
 			So(err, ShouldBeNil)
 			So(items, ShouldHaveLength, 1)
@@ -793,13 +839,13 @@ func TestGetBundleContents(t *testing.T) {
 				}, nil
 			}
 
-			mockedDatastore.ListBundleContentsFunc = func(ctx context.Context, id string, offset, limit int) ([]*models.ContentItem, int, error) {
+			mockedDatastore.ListBundleContentsFunc = func(ctx context.Context, id string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
 				return []*models.ContentItem{{
 					ID:          "1",
 					BundleID:    bundle1,
 					ContentType: models.ContentTypeDataset,
 					Metadata:    models.Metadata{DatasetID: "dataset-1", EditionID: "edition-1", VersionID: 1},
-				}}, 1, nil
+				}}, 1, "", nil
 			}
 
 			mockDatasetAPI.GetDatasetFunc = func(ctx context.Context, headers datasetAPISDK.Headers, datasetID string) (datasetAPIModels.Dataset, error) {
@@ -818,7 +864,7 @@ func TestGetBundleContents(t *testing.T) {
 				}, nil
 			}
 
-			items, total, err := app.GetBundleContents(ctx, bundle1, 0, 10, authHeaders)
+			items, total, _, err := app.GetBundleContents(ctx, bundle1, 0, 10, nil, nil, authHeaders)
 
 			So(err, ShouldBeNil)
 			So(items, ShouldHaveLength, 1)
@@ -837,20 +883,20 @@ func TestGetBundleContents(t *testing.T) {
 				}, nil
 			}
 
-			mockedDatastore.ListBundleContentsFunc = func(ctx context.Context, id string, offset, limit int) ([]*models.ContentItem, int, error) {
+			mockedDatastore.ListBundleContentsFunc = func(ctx context.Context, id string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
 				return []*models.ContentItem{{
 					ID:          "1",
 					BundleID:    bundle1,
 					ContentType: models.ContentTypeDataset,
 					Metadata:    models.Metadata{DatasetID: "dataset-1"},
-				}}, 1, nil
+				}}, 1, "", nil
 			}
 
 			mockDatasetAPI.GetDatasetFunc = func(ctx context.Context, headers datasetAPISDK.Headers, datasetID string) (datasetAPIModels.Dataset, error) {
 				return datasetAPIModels.Dataset{}, errors.New("dataset fetch failed")
 			}
 
-			items, total, err := app.GetBundleContents(ctx, bundle1, 0, 10, authHeaders)
+			items, total, _, err := app.GetBundleContents(ctx, bundle1, 0, 10, nil, nil, authHeaders)
 
 			So(err, ShouldNotBeNil)
 			So(items, ShouldBeNil)
@@ -867,11 +913,11 @@ func TestGetBundleContents(t *testing.T) {
 				}, nil
 			}
 
-			mockedDatastore.ListBundleContentsFunc = func(ctx context.Context, id string, offset, limit int) ([]*models.ContentItem, int, error) {
-				return []*models.ContentItem{}, 0, nil
+			mockedDatastore.ListBundleContentsFunc = func(ctx context.Context, id string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
+				return []*models.ContentItem{}, 0, "", nil
 			}
 
-			items, total, err := app.GetBundleContents(ctx, bundle1, 0, 10, authHeaders)
+			items, total, _, err := app.GetBundleContents(ctx, bundle1, 0, 10, nil, nil, authHeaders)
 
 			So(err, ShouldBeNil)
 			So(items, ShouldBeEmpty)
@@ -1058,6 +1104,34 @@ func TestDeleteBundle_Failure_Transition(t *testing.T) {
 	})
 }
 
+func TestDeleteBundle_Failure_PublishFailed(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with a mocked datastore", t, func() {
+		ctx := context.Background()
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, id string) (*models.Bundle, error) {
+				return &models.Bundle{
+					ID:    bundle1,
+					State: models.BundleStatePublishFailed,
+				}, nil
+			},
+		}
+
+		stateMachine := &application.StateMachineBundleAPI{
+			Datastore: store.Datastore{Backend: mockedDatastore},
+		}
+
+		Convey("When DeleteBundle is called with a bundle that is partially published", func() {
+			statusCode, _, err := stateMachine.DeleteBundle(ctx, bundle1, authEntityData)
+
+			Convey("Then it should return a 409 Conflict error", func() {
+				So(statusCode, ShouldEqual, 409)
+				So(err, ShouldEqual, apierrors.ErrDeleteBundleForbidden)
+			})
+		})
+	})
+}
+
 func TestDeleteBundle_Failure_GetBundleContentsForBundle(t *testing.T) {
 	Convey("Given a StateMachineBundleAPI with a mocked datastore", t, func() {
 		ctx := context.Background()
@@ -1588,6 +1662,36 @@ func TestPutBundle_Success(t *testing.T) {
 				So(len(mockedDatastore.CreateEventCalls()), ShouldEqual, 1)
 			})
 		})
+
+		Convey("When PutBundle is called to send a bundle in review back to draft with a reason", func() {
+			currentBundle.State = models.BundleStateInReview
+			bundleUpdate.Reason = " The chart is missing a title "
+
+			result, err := stateMachine.PutBundle(ctx, bundleID, bundleUpdate, authEntityData, currentBundle.ETag)
+
+			Convey("Then the bundle is updated and the reason is recorded in its last transition", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStateDraft)
+				So(result.Reason, ShouldBeEmpty)
+				So(result.LastTransition, ShouldNotBeNil)
+				So(result.LastTransition.FromState, ShouldEqual, models.BundleStateInReview)
+				So(result.LastTransition.ToState, ShouldEqual, models.BundleStateDraft)
+				So(result.LastTransition.Reason, ShouldEqual, "The chart is missing a title")
+				So(result.LastTransition.TransitionedBy.Email, ShouldEqual, userEmail)
+			})
+		})
+
+		Convey("When PutBundle is called to send a bundle in review back to draft without a reason", func() {
+			currentBundle.State = models.BundleStateInReview
+
+			result, err := stateMachine.PutBundle(ctx, bundleID, bundleUpdate, authEntityData, currentBundle.ETag)
+
+			Convey("Then the update is refused as a reason is required", func() {
+				So(result, ShouldBeNil)
+				So(err, ShouldEqual, apierrors.ErrTransitionReasonRequired)
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+			})
+		})
 	})
 }
 
@@ -1694,6 +1798,15 @@ func TestPutBundleState_Success(t *testing.T) {
 			UpdateContentItemStateFunc: func(ctx context.Context, contentItemID, state string) error {
 				return nil
 			},
+			CreatePublishRunFunc: func(ctx context.Context, publishRun *models.PublishRun) error {
+				return nil
+			},
+			UpdatePublishRunItemFunc: func(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error {
+				return nil
+			},
+			CompletePublishRunFunc: func(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+				return nil
+			},
 		}
 
 		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
@@ -1722,7 +1835,7 @@ func TestPutBundleState_Success(t *testing.T) {
 		}
 
 		Convey("When UpdateBundleState is called to publish a bundle which is a valid transition", func() {
-			result, err := stateMachine.UpdateBundleState(ctx, bundleID, currentBundle.ETag, bundleUpdate.State, authEntityData)
+			result, err := stateMachine.UpdateBundleState(ctx, bundleID, currentBundle.ETag, bundleUpdate.State, "", authEntityData)
 			Convey("Then the bundle and it's content items should be published and slack alerts should be sent", func() {
 				So(err, ShouldBeNil)
 				So(result, ShouldNotBeNil)
@@ -1732,6 +1845,20 @@ func TestPutBundleState_Success(t *testing.T) {
 				So(len(mockSlackClient.SendPublishLogCalls()), ShouldEqual, 1)
 				So(len(mockSlackClient.UpdatePublishLogCalls()), ShouldEqual, 1)
 			})
+
+			Convey("And the publish run records each content item as published", func() {
+				So(mockedDatastore.CreatePublishRunCalls(), ShouldHaveLength, 1)
+				publishRun := mockedDatastore.CreatePublishRunCalls()[0].PublishRun
+				So(publishRun.BundleID, ShouldEqual, bundleID)
+				So(publishRun.Items, ShouldHaveLength, 2)
+				So(mockedDatastore.UpdatePublishRunItemCalls(), ShouldHaveLength, 2)
+				for _, call := range mockedDatastore.UpdatePublishRunItemCalls() {
+					So(call.PublishRunID, ShouldEqual, publishRun.ID)
+					So(call.State, ShouldEqual, models.PublishRunItemStatePublished)
+				}
+				So(mockedDatastore.CompletePublishRunCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CompletePublishRunCalls()[0].State, ShouldEqual, models.PublishRunStateCompleted)
+			})
 		})
 	})
 }
@@ -1795,6 +1922,15 @@ func TestPutBundleState_ContentItemFails(t *testing.T) {
 			UpdateContentItemStateFunc: func(ctx context.Context, contentItemID, state string) error {
 				return nil
 			},
+			CreatePublishRunFunc: func(ctx context.Context, publishRun *models.PublishRun) error {
+				return nil
+			},
+			UpdatePublishRunItemFunc: func(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error {
+				return nil
+			},
+			CompletePublishRunFunc: func(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+				return nil
+			},
 		}
 
 		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
@@ -1832,17 +1968,345 @@ func TestPutBundleState_ContentItemFails(t *testing.T) {
 		}
 
 		Convey("When UpdateBundleState is called to publish a bundle which has content items that will fail", func() {
-			result, err := stateMachine.UpdateBundleState(ctx, bundleID, currentBundle.ETag, bundleUpdate.State, authEntityData)
-			Convey("Then the bundle will continue to publish but slack alerts should be sent for the failing content items", func() {
+			result, err := stateMachine.UpdateBundleState(ctx, bundleID, currentBundle.ETag, bundleUpdate.State, "", authEntityData)
+			Convey("Then the bundle will continue to publish but is marked as failed and slack alerts should be sent for the failing content items", func() {
 				So(err, ShouldBeNil)
 				So(result, ShouldNotBeNil)
-				So(result.State, ShouldEqual, models.BundleStatePublished)
+				So(result.State, ShouldEqual, models.BundleStatePublishFailed)
+				So(result.LastTransition.FromState, ShouldEqual, models.BundleStateApproved)
+				So(result.LastTransition.ToState, ShouldEqual, models.BundleStatePublishFailed)
 				So(len(mockedDatastore.UpdateBundleCalls()), ShouldEqual, 1)
 				So(len(mockedDatastore.CreateEventCalls()), ShouldEqual, 1)
 				So(len(mockSlackClient.SendPublishLogCalls()), ShouldEqual, 1)
 				So(len(mockSlackClient.UpdatePublishLogAsAlarmCalls()), ShouldEqual, 1)
 				So(len(mockSlackClient.SendAlarmCalls()), ShouldEqual, 2)
 			})
+
+			Convey("And the publish run records the failed content items and is completed as failed", func() {
+				So(mockedDatastore.UpdatePublishRunItemCalls(), ShouldHaveLength, 2)
+				for _, call := range mockedDatastore.UpdatePublishRunItemCalls() {
+					So(call.State, ShouldEqual, models.PublishRunItemStateFailed)
+					So(call.LastError, ShouldEqual, "state not allowed to transition")
+				}
+				So(mockedDatastore.CompletePublishRunCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CompletePublishRunCalls()[0].State, ShouldEqual, models.PublishRunStateFailed)
+			})
+		})
+	})
+}
+
+func TestPutBundleState_RetryPublishFailed(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with a bundle that failed to publish one of its content items", t, func() {
+		ctx := context.Background()
+
+		currentBundle := &models.Bundle{
+			ID:    bundle123,
+			State: models.BundleStatePublishFailed,
+			ETag:  "old-etag",
+		}
+
+		authEntityData := &models.AuthEntityData{
+			EntityData: &permissionsAPISDK.EntityData{
+				UserID: userEmail,
+			},
+		}
+
+		states := []application.State{application.Published, application.PublishFailed}
+		transitions := []application.Transition{
+			{
+				Label:               "PUBLISHED",
+				TargetState:         application.Published,
+				AllowedSourceStates: []string{"APPROVED", "PUBLISH_FAILED"},
+			},
+		}
+
+		mockContentItems := createMockVersionsAndContentItems(models.BundleStateApproved)
+		publishedState := models.StatePublished
+		mockContentItems[0].State = &publishedState
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return currentBundle, nil
+			},
+			UpdateBundleFunc: func(ctx context.Context, bundleID string, bundle *models.Bundle) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				contentItems := make([]models.ContentItem, len(mockContentItems))
+				for index := range contentItems {
+					contentItems[index] = *mockContentItems[index]
+				}
+				return &contentItems, nil
+			},
+			UpdateContentItemStateFunc: func(ctx context.Context, contentItemID, state string) error {
+				return nil
+			},
+			CreatePublishRunFunc: func(ctx context.Context, publishRun *models.PublishRun) error {
+				return nil
+			},
+			UpdatePublishRunItemFunc: func(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error {
+				return nil
+			},
+			CompletePublishRunFunc: func(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+				return nil
+			},
+		}
+
+		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
+			PutVersionStateFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
+				return nil
+			},
+		}
+
+		mockSlackClient := &slackMock.ClienterMock{
+			SendPublishLogFunc: func(ctx context.Context, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+			UpdatePublishLogFunc: func(ctx context.Context, ref *slack.MessageRef, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+		}
+
+		stateMachine := &application.StateMachineBundleAPI{
+			Datastore:             store.Datastore{Backend: mockedDatastore},
+			StateMachine:          application.NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, nil),
+			DataBundleSlackClient: mockSlackClient,
+			DatasetAPIClient:      mockDatasetAPIClient,
+		}
+
+		Convey("When UpdateBundleState is called to retry publishing the bundle", func() {
+			result, err := stateMachine.UpdateBundleState(ctx, bundle123, currentBundle.ETag, models.BundleStatePublished, "", authEntityData)
+
+			Convey("Then only the content item that failed is published again", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStatePublished)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 1)
+				So(mockDatasetAPIClient.PutVersionStateCalls()[0].DatasetID, ShouldEqual, "dataset-id-2")
+				So(mockedDatastore.CreatePublishRunCalls()[0].PublishRun.Items, ShouldHaveLength, 1)
+			})
+
+			Convey("And the transition from PUBLISH_FAILED to PUBLISHED is recorded", func() {
+				So(result.LastTransition.FromState, ShouldEqual, models.BundleStatePublishFailed)
+				So(result.LastTransition.ToState, ShouldEqual, models.BundleStatePublished)
+			})
+		})
+
+		Convey("When UpdateBundleState is called to retry publishing the bundle and it fails again", func() {
+			mockDatasetAPIClient.PutVersionStateFunc = func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
+				return errors.New("dataset API unavailable")
+			}
+			mockSlackClient.SendAlarmFunc = func(ctx context.Context, summary string, err error, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			}
+			mockSlackClient.UpdatePublishLogAsAlarmFunc = func(ctx context.Context, ref *slack.MessageRef, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			}
+
+			result, err := stateMachine.UpdateBundleState(ctx, bundle123, currentBundle.ETag, models.BundleStatePublished, "", authEntityData)
+
+			Convey("Then the bundle stays PUBLISH_FAILED and no transition to PUBLISHED is recorded", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStatePublishFailed)
+				So(result.LastTransition, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestPutBundleState_PublishOrderAndConcurrency(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with an approved bundle", t, func() {
+		ctx := context.Background()
+
+		currentBundle := &models.Bundle{
+			ID:    bundle123,
+			State: models.BundleStateApproved,
+			ETag:  "old-etag",
+		}
+
+		authEntityData := &models.AuthEntityData{
+			EntityData: &permissionsAPISDK.EntityData{
+				UserID: userEmail,
+			},
+		}
+
+		states := []application.State{application.Approved, application.Published, application.PublishFailed}
+		transitions := []application.Transition{
+			{
+				Label:               "PUBLISHED",
+				TargetState:         application.Published,
+				AllowedSourceStates: []string{"APPROVED", "PUBLISH_FAILED"},
+			},
+		}
+
+		var mockContentItems []models.ContentItem
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return currentBundle, nil
+			},
+			UpdateBundleFunc: func(ctx context.Context, bundleID string, bundle *models.Bundle) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				contentItems := make([]models.ContentItem, len(mockContentItems))
+				copy(contentItems, mockContentItems)
+				return &contentItems, nil
+			},
+			UpdateContentItemStateFunc: func(ctx context.Context, contentItemID, state string) error {
+				return nil
+			},
+			CreatePublishRunFunc: func(ctx context.Context, publishRun *models.PublishRun) error {
+				return nil
+			},
+			UpdatePublishRunItemFunc: func(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error {
+				return nil
+			},
+			CompletePublishRunFunc: func(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+				return nil
+			},
+		}
+
+		var (
+			mu             sync.Mutex
+			inFlight       int
+			maxInFlight    int
+			failingDataset string
+		)
+		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
+			PutVersionStateFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
+				mu.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mu.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+
+				if datasetID == failingDataset {
+					return errors.New("failed to publish version")
+				}
+				return nil
+			},
+		}
+
+		mockSlackClient := &slackMock.ClienterMock{
+			SendPublishLogFunc: func(ctx context.Context, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+			UpdatePublishLogFunc: func(ctx context.Context, ref *slack.MessageRef, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+			UpdatePublishLogAsAlarmFunc: func(ctx context.Context, ref *slack.MessageRef, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+			SendAlarmFunc: func(ctx context.Context, summary string, err error, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+		}
+
+		stateMachine := &application.StateMachineBundleAPI{
+			Datastore:             store.Datastore{Backend: mockedDatastore},
+			StateMachine:          application.NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, nil),
+			DataBundleSlackClient: mockSlackClient,
+			DatasetAPIClient:      mockDatasetAPIClient,
+			PublishMaxConcurrency: 2,
+		}
+
+		Convey("When the bundle has more content items than the concurrency limit and is published", func() {
+			for index := 0; index < 6; index++ {
+				mockContentItems = append(mockContentItems, models.ContentItem{
+					ID:       fmt.Sprintf("content-item-%d", index),
+					BundleID: bundle123,
+					Metadata: models.Metadata{DatasetID: fmt.Sprintf("dataset-id-%d", index), EditionID: "edition-id", VersionID: 1},
+				})
+			}
+
+			result, err := stateMachine.UpdateBundleState(ctx, bundle123, currentBundle.ETag, models.BundleStatePublished, "", authEntityData)
+
+			Convey("Then every content item is published without exceeding the limit", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStatePublished)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 6)
+				So(maxInFlight, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the bundle has a content item marked as a dependency and is published", func() {
+			mockContentItems = []models.ContentItem{
+				{ID: "content-item-1", BundleID: bundle123, Metadata: models.Metadata{DatasetID: "dataset-id-1", EditionID: "edition-id", VersionID: 1}},
+				{ID: "content-item-2", BundleID: bundle123, Metadata: models.Metadata{DatasetID: "dataset-id-2", EditionID: "edition-id", VersionID: 1}},
+				{ID: "content-item-3", BundleID: bundle123, Dependency: true, Metadata: models.Metadata{DatasetID: "dataset-id-3", EditionID: "edition-id", VersionID: 1}},
+			}
+
+			Convey("And the dependency publishes successfully", func() {
+				result, err := stateMachine.UpdateBundleState(ctx, bundle123, currentBundle.ETag, models.BundleStatePublished, "", authEntityData)
+
+				Convey("Then the dependency is published before the other content items", func() {
+					So(err, ShouldBeNil)
+					So(result.State, ShouldEqual, models.BundleStatePublished)
+					So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 3)
+					So(mockDatasetAPIClient.PutVersionStateCalls()[0].DatasetID, ShouldEqual, "dataset-id-3")
+				})
+			})
+
+			Convey("And the dependency fails to publish", func() {
+				failingDataset = "dataset-id-3"
+
+				result, err := stateMachine.UpdateBundleState(ctx, bundle123, currentBundle.ETag, models.BundleStatePublished, "", authEntityData)
+
+				Convey("Then the other content items are not published and the bundle is marked as failed", func() {
+					So(err, ShouldBeNil)
+					So(result.State, ShouldEqual, models.BundleStatePublishFailed)
+					So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 1)
+					So(mockedDatastore.CompletePublishRunCalls()[0].State, ShouldEqual, models.PublishRunStateFailed)
+				})
+			})
+		})
+	})
+}
+
+func TestPutBundleState_PublishFailedCannotBeRequested(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with the service state machine transitions", t, func() {
+		ctx := context.Background()
+
+		currentBundle := &models.Bundle{ID: bundle123, State: models.BundleStateApproved, ETag: "old-etag"}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return currentBundle, nil
+			},
+		}
+
+		states := []application.State{application.Published, application.PublishFailed}
+		transitions := []application.Transition{
+			{
+				Label:               "PUBLISHED",
+				TargetState:         application.Published,
+				AllowedSourceStates: []string{"APPROVED", "PUBLISH_FAILED"},
+			},
+		}
+
+		stateMachine := &application.StateMachineBundleAPI{
+			Datastore:    store.Datastore{Backend: mockedDatastore},
+			StateMachine: application.NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, nil),
+		}
+
+		Convey("When UpdateBundleState is called to move the bundle to PUBLISH_FAILED", func() {
+			result, err := stateMachine.UpdateBundleState(ctx, bundle123, currentBundle.ETag, models.BundleStatePublishFailed, "", &models.AuthEntityData{EntityData: &permissionsAPISDK.EntityData{UserID: userEmail}})
+
+			Convey("Then the transition is rejected", func() {
+				So(err, ShouldEqual, apierrors.ErrInvalidTransition)
+				So(result, ShouldBeNil)
+			})
 		})
 	})
 }
@@ -2365,3 +2829,153 @@ func TestApproveBundle_RefreshesContentItemMetadataAndLinks(t *testing.T) {
 		})
 	})
 }
+
+func TestUpdateBundleState_Rejection(t *testing.T) {
+	Convey("Given a bundle in review", t, func() {
+		ctx := context.Background()
+
+		currentBundle := &models.Bundle{
+			ID:    bundle123,
+			State: models.BundleStateInReview,
+			ETag:  "etag",
+		}
+
+		authEntityData := &models.AuthEntityData{
+			EntityData: &permissionsAPISDK.EntityData{
+				UserID: userEmail,
+			},
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return currentBundle, nil
+			},
+			UpdateBundleFunc: func(ctx context.Context, bundleID string, bundle *models.Bundle) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+		}
+
+		stateMachine := &application.StateMachineBundleAPI{
+			Datastore: store.Datastore{Backend: mockedDatastore},
+			StateMachine: application.NewStateMachine(ctx, []application.State{application.Draft, application.InReview}, []application.Transition{
+				{Label: "DRAFT", TargetState: application.Draft, AllowedSourceStates: []string{"IN_REVIEW"}},
+			}, store.Datastore{Backend: mockedDatastore}, nil),
+		}
+
+		Convey("When it is sent back to DRAFT with a reason", func() {
+			result, err := stateMachine.UpdateBundleState(ctx, bundle123, "etag", models.BundleStateDraft, " Table 3 is missing a footnote ", authEntityData)
+
+			Convey("Then the transition and its reason are recorded on the bundle", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStateDraft)
+				So(result.LastTransition.FromState, ShouldEqual, models.BundleStateInReview)
+				So(result.LastTransition.ToState, ShouldEqual, models.BundleStateDraft)
+				So(result.LastTransition.Reason, ShouldEqual, "Table 3 is missing a footnote")
+				So(result.LastTransition.TransitionedBy.Email, ShouldEqual, userEmail)
+				So(result.LastTransition.TransitionedAt, ShouldNotBeNil)
+			})
+
+			Convey("And the reason is recorded on the update event", func() {
+				So(mockedDatastore.CreateEventCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateEventCalls()[0].Event.Reason, ShouldEqual, "Table 3 is missing a footnote")
+			})
+		})
+
+		Convey("When it is sent back to DRAFT without a reason", func() {
+			result, err := stateMachine.UpdateBundleState(ctx, bundle123, "etag", models.BundleStateDraft, "  ", authEntityData)
+
+			Convey("Then ErrTransitionReasonRequired is returned and the bundle is not updated", func() {
+				So(result, ShouldBeNil)
+				So(err, ShouldEqual, apierrors.ErrTransitionReasonRequired)
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestListContents(t *testing.T) {
+	Convey("Given content items in a bundle that exists and a bundle that does not", t, func() {
+		ctx := context.Background()
+		datasetID := "dataset-1"
+
+		mockedDatastore := &storetest.StorerMock{
+			ListContentsFunc: func(ctx context.Context, offset, limit int, contentFilters *filters.ContentFilters) ([]*models.ContentItem, int, string, error) {
+				return []*models.ContentItem{
+					{ID: "content-item-1", BundleID: bundle1},
+					{ID: "content-item-2", BundleID: bundle1},
+					{ID: "content-item-3", BundleID: "deleted-bundle"},
+				}, 3, "", nil
+			},
+			ListBundlesByIDsFunc: func(ctx context.Context, bundleIDs []string) ([]*models.Bundle, error) {
+				return []*models.Bundle{{ID: bundle1, Title: "Bundle 1", State: models.BundleStateApproved}}, nil
+			},
+		}
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{Datastore: store.Datastore{Backend: mockedDatastore}}
+
+		Convey("When ListContents is called", func() {
+			contents, totalCount, _, err := stateMachineBundleAPI.ListContents(ctx, 0, 10, &filters.ContentFilters{DatasetID: &datasetID})
+
+			Convey("Then each content item has a summary of the bundle it is in, where that bundle exists", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 3)
+				So(contents[0].Bundle, ShouldResemble, &models.ContentItemBundle{ID: bundle1, Title: "Bundle 1", State: models.BundleStateApproved})
+				So(contents[1].Bundle, ShouldResemble, contents[0].Bundle)
+				So(contents[2].Bundle, ShouldBeNil)
+				So(mockedDatastore.ListBundlesByIDsCalls()[0].BundleIDs, ShouldResemble, []string{bundle1, "deleted-bundle"})
+			})
+		})
+	})
+}
+
+func TestPublishDatasetProvider(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with a separate dataset API client for publishing and approving", t, func() {
+		ctx := context.Background()
+		getVersion := func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+			return datasetAPIModels.Version{State: "approved"}, nil
+		}
+		datasetAPIClient := &datasetAPIMocks.ClienterMock{GetVersionFunc: getVersion}
+		publishDatasetAPIClient := &datasetAPIMocks.ClienterMock{GetVersionFunc: getVersion}
+
+		stateMachineBundleAPI := application.Setup(store.Datastore{}, nil, datasetAPIClient, nil, nil, "", 0, application.ApprovalPolicy{}, nil)
+		stateMachineBundleAPI.PublishDatasetAPIClient = publishDatasetAPIClient
+
+		contentItem := &models.ContentItem{
+			ContentType: models.ContentTypeDataset,
+			Metadata:    models.Metadata{DatasetID: "dataset-1", EditionID: "edition-1", VersionID: 1},
+		}
+
+		Convey("When the publish dataset provider gets the state of a content item", func() {
+			_, err := stateMachineBundleAPI.PublishDatasetProvider().GetState(ctx, datasetAPISDK.Headers{}, contentItem)
+			So(err, ShouldBeNil)
+
+			Convey("Then the publish dataset API client is used", func() {
+				So(publishDatasetAPIClient.GetVersionCalls(), ShouldHaveLength, 1)
+				So(datasetAPIClient.GetVersionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the dataset provider gets the state of a content item", func() {
+			_, err := stateMachineBundleAPI.DatasetProvider().GetState(ctx, datasetAPISDK.Headers{}, contentItem)
+			So(err, ShouldBeNil)
+
+			Convey("Then the dataset API client is used", func() {
+				So(datasetAPIClient.GetVersionCalls(), ShouldHaveLength, 1)
+				So(publishDatasetAPIClient.GetVersionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the publish dataset API client has not been set up", func() {
+			stateMachineBundleAPI.PublishDatasetAPIClient = nil
+
+			_, err := stateMachineBundleAPI.PublishDatasetProvider().GetState(ctx, datasetAPISDK.Headers{}, contentItem)
+			So(err, ShouldBeNil)
+
+			Convey("Then the dataset API client is used", func() {
+				So(datasetAPIClient.GetVersionCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
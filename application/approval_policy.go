@@ -0,0 +1,131 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Names of the guards registered by DefaultRegistry to enforce the approval policy
+const (
+	GuardNameFourEyesApproval = "four_eyes_approval"
+	GuardNameApprovalQuorum   = "approval_quorum"
+	GuardNameNoConflicts      = "no_conflicts"
+)
+
+// ApprovalPolicy defines who may approve a bundle, and how many of them must do so
+type ApprovalPolicy struct {
+	// RequireDifferentCreator stops the user who created a bundle from approving it
+	RequireDifferentCreator bool
+	// RequireDifferentEditors stops anyone who has created, updated or deleted the bundle or its content items from
+	// approving it
+	RequireDifferentEditors bool
+	// ExemptServiceIdentities lets services approve bundles regardless of the policy
+	ExemptServiceIdentities bool
+	// Quorum is the number of approvals a bundle needs before it can be approved
+	Quorum int
+	// QuorumByBundleType overrides Quorum for bundles of the given types
+	QuorumByBundleType map[string]int
+	// QuorumByManagedBy overrides Quorum for bundles managed by the given systems
+	QuorumByManagedBy map[string]int
+	// BlockOnConflicts stops a bundle from being approved while any of its datasets are in other bundles that have not
+	// been published
+	BlockOnConflicts bool
+}
+
+// QuorumFor returns the number of approvals the bundle needs, which is the highest quorum that applies to it and never
+// less than one
+func (p ApprovalPolicy) QuorumFor(bundle *models.Bundle) int {
+	quorum := max(p.Quorum, 1)
+
+	if byBundleType, ok := p.QuorumByBundleType[bundle.BundleType.String()]; ok {
+		quorum = max(quorum, byBundleType)
+	}
+
+	if byManagedBy, ok := p.QuorumByManagedBy[bundle.ManagedBy.String()]; ok {
+		quorum = max(quorum, byManagedBy)
+	}
+
+	return quorum
+}
+
+// FourEyesApproval refuses to approve a bundle if the approver is not allowed to by the approval policy
+func FourEyesApproval(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) error {
+	policy := smBundle.ApprovalPolicy
+
+	if policy.ExemptServiceIdentities && authEntityData.IsServiceAuth {
+		return nil
+	}
+
+	approver := authEntityData.GetUserID()
+
+	if policy.RequireDifferentCreator && bundle.CreatedBy != nil && bundle.CreatedBy.Email == approver {
+		return &PreconditionError{Err: apierrors.ErrApproverCreatedBundle, Description: "bundle must be approved by someone other than its creator"}
+	}
+
+	if policy.RequireDifferentEditors {
+		editors, err := smBundle.Datastore.ListBundleEditors(ctx, bundle.ID)
+		if err != nil {
+			log.Error(ctx, "failed to list bundle editors", err, log.Data{"bundle_id": bundle.ID})
+			return err
+		}
+
+		if slices.Contains(editors, approver) {
+			return &PreconditionError{Err: apierrors.ErrApproverEditedBundle, Description: "bundle must be approved by someone who has not edited it"}
+		}
+	}
+
+	return nil
+}
+
+// ApprovalQuorum refuses to approve a bundle until enough different users have approved it. The user approving the
+// bundle counts towards the quorum, along with everyone who has recorded an approval of it.
+func ApprovalQuorum(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) error {
+	quorum := smBundle.ApprovalPolicy.QuorumFor(bundle)
+	if quorum <= 1 {
+		return nil
+	}
+
+	approvals, err := smBundle.Datastore.ListApprovals(ctx, bundle.ID)
+	if err != nil {
+		log.Error(ctx, "failed to list bundle approvals", err, log.Data{"bundle_id": bundle.ID})
+		return err
+	}
+
+	approvers := []string{authEntityData.GetUserID()}
+	for _, approval := range approvals {
+		if approval.ApprovedBy != nil && !slices.Contains(approvers, approval.ApprovedBy.Email) {
+			approvers = append(approvers, approval.ApprovedBy.Email)
+		}
+	}
+
+	if len(approvers) < quorum {
+		return &PreconditionError{Err: apierrors.ErrApprovalQuorumNotMet, Description: fmt.Sprintf("%d of %d required approvals", len(approvers), quorum)}
+	}
+
+	return nil
+}
+
+// NoConflicts refuses to approve a bundle while any of its datasets are in other bundles that have not been published,
+// if the approval policy blocks approval on conflicts
+func NoConflicts(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) error {
+	if !smBundle.ApprovalPolicy.BlockOnConflicts {
+		return nil
+	}
+
+	conflicts, err := smBundle.findBundleConflicts(ctx, bundle.ID)
+	if err != nil {
+		log.Error(ctx, "failed to find bundle conflicts", err, log.Data{"bundle_id": bundle.ID})
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		return &PreconditionError{Err: apierrors.ErrBundleHasConflicts, Description: fmt.Sprintf("%d conflicts with other bundles", len(conflicts))}
+	}
+
+	return nil
+}
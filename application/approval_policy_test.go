@@ -0,0 +1,182 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFourEyesApproval(t *testing.T) {
+	Convey("Given a bundle created by one user and edited by another", t, func() {
+		ctx := context.Background()
+		bundle := &models.Bundle{ID: bundle123, State: models.BundleStateInReview, CreatedBy: &models.User{Email: "creator@ons.gov.uk"}}
+
+		mockedDatastore := &storetest.StorerMock{
+			ListBundleEditorsFunc: func(ctx context.Context, bundleID string) ([]string, error) {
+				return []string{"creator@ons.gov.uk", "editor@ons.gov.uk"}, nil
+			},
+		}
+		smBundle := application.StateMachineBundleAPI{
+			Datastore:      store.Datastore{Backend: mockedDatastore},
+			ApprovalPolicy: application.ApprovalPolicy{RequireDifferentCreator: true, RequireDifferentEditors: true, ExemptServiceIdentities: true},
+		}
+
+		approverAuth := func(userID string, isServiceAuth bool) *models.AuthEntityData {
+			return &models.AuthEntityData{EntityData: &permissionsAPISDK.EntityData{UserID: userID}, IsServiceAuth: isServiceAuth}
+		}
+
+		Convey("When the creator approves it", func() {
+			err := application.FourEyesApproval(ctx, smBundle, bundle, approverAuth("creator@ons.gov.uk", false))
+
+			Convey("Then the approval is refused because they created it", func() {
+				So(errors.Is(err, apierrors.ErrApproverCreatedBundle), ShouldBeTrue)
+				So(mockedDatastore.ListBundleEditorsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an editor approves it", func() {
+			err := application.FourEyesApproval(ctx, smBundle, bundle, approverAuth("editor@ons.gov.uk", false))
+
+			Convey("Then the approval is refused because they edited it", func() {
+				So(errors.Is(err, apierrors.ErrApproverEditedBundle), ShouldBeTrue)
+				So(mockedDatastore.ListBundleEditorsCalls()[0].BundleID, ShouldEqual, bundle123)
+			})
+		})
+
+		Convey("When an editor approves it and editors are not excluded by the policy", func() {
+			smBundle.ApprovalPolicy.RequireDifferentEditors = false
+			err := application.FourEyesApproval(ctx, smBundle, bundle, approverAuth("editor@ons.gov.uk", false))
+
+			Convey("Then the approval is allowed without looking up the editors", func() {
+				So(err, ShouldBeNil)
+				So(mockedDatastore.ListBundleEditorsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When someone who has not touched it approves it", func() {
+			err := application.FourEyesApproval(ctx, smBundle, bundle, approverAuth("reviewer@ons.gov.uk", false))
+
+			Convey("Then the approval is allowed", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey("When a service with the creator's identity approves it", func() {
+			Convey("Then the approval is allowed if services are exempt", func() {
+				So(application.FourEyesApproval(ctx, smBundle, bundle, approverAuth("creator@ons.gov.uk", true)), ShouldBeNil)
+			})
+
+			Convey("Then the approval is refused if services are not exempt", func() {
+				smBundle.ApprovalPolicy.ExemptServiceIdentities = false
+				err := application.FourEyesApproval(ctx, smBundle, bundle, approverAuth("creator@ons.gov.uk", true))
+				So(errors.Is(err, apierrors.ErrApproverCreatedBundle), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the editors cannot be looked up", func() {
+			errDatastore := errors.New("database error")
+			mockedDatastore.ListBundleEditorsFunc = func(ctx context.Context, bundleID string) ([]string, error) {
+				return nil, errDatastore
+			}
+			err := application.FourEyesApproval(ctx, smBundle, bundle, approverAuth("reviewer@ons.gov.uk", false))
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldEqual, errDatastore)
+			})
+		})
+	})
+}
+
+func TestApprovalPolicy_QuorumFor(t *testing.T) {
+	Convey("Given an approval policy with quorums for a bundle type and a managing system", t, func() {
+		policy := application.ApprovalPolicy{
+			Quorum:             2,
+			QuorumByBundleType: map[string]int{models.BundleTypeScheduled.String(): 3},
+			QuorumByManagedBy:  map[string]int{models.ManagedByWagtail.String(): 4, models.ManagedByDataAdmin.String(): 1},
+		}
+
+		Convey("Then the highest quorum that applies to a bundle is used", func() {
+			So(policy.QuorumFor(&models.Bundle{BundleType: models.BundleTypeManual, ManagedBy: models.ManagedByDataAdmin}), ShouldEqual, 2)
+			So(policy.QuorumFor(&models.Bundle{BundleType: models.BundleTypeScheduled, ManagedBy: models.ManagedByDataAdmin}), ShouldEqual, 3)
+			So(policy.QuorumFor(&models.Bundle{BundleType: models.BundleTypeScheduled, ManagedBy: models.ManagedByWagtail}), ShouldEqual, 4)
+		})
+
+		Convey("Then a bundle always needs at least one approval", func() {
+			So(application.ApprovalPolicy{}.QuorumFor(&models.Bundle{}), ShouldEqual, 1)
+		})
+	})
+}
+
+func TestApprovalQuorum(t *testing.T) {
+	Convey("Given a bundle that needs three approvals and has been approved by two users", t, func() {
+		ctx := context.Background()
+		bundle := &models.Bundle{ID: bundle123, State: models.BundleStateInReview}
+
+		approvals := []*models.Approval{
+			{BundleID: bundle123, ApprovedBy: &models.User{Email: "first@ons.gov.uk"}},
+			{BundleID: bundle123, ApprovedBy: &models.User{Email: "second@ons.gov.uk"}},
+		}
+		mockedDatastore := &storetest.StorerMock{
+			ListApprovalsFunc: func(ctx context.Context, bundleID string) ([]*models.Approval, error) {
+				return approvals, nil
+			},
+		}
+		smBundle := application.StateMachineBundleAPI{
+			Datastore:      store.Datastore{Backend: mockedDatastore},
+			ApprovalPolicy: application.ApprovalPolicy{Quorum: 3},
+		}
+
+		approverAuth := func(userID string) *models.AuthEntityData {
+			return &models.AuthEntityData{EntityData: &permissionsAPISDK.EntityData{UserID: userID}}
+		}
+
+		Convey("When a third user approves it", func() {
+			err := application.ApprovalQuorum(ctx, smBundle, bundle, approverAuth("third@ons.gov.uk"))
+
+			Convey("Then the approval is allowed", func() {
+				So(err, ShouldBeNil)
+				So(mockedDatastore.ListApprovalsCalls()[0].BundleID, ShouldEqual, bundle123)
+			})
+		})
+
+		Convey("When one of the users who has already approved it approves it", func() {
+			err := application.ApprovalQuorum(ctx, smBundle, bundle, approverAuth("first@ons.gov.uk"))
+
+			Convey("Then the approval is refused as the quorum has not been met", func() {
+				So(errors.Is(err, apierrors.ErrApprovalQuorumNotMet), ShouldBeTrue)
+				So(err.Error(), ShouldEqual, "2 of 3 required approvals")
+			})
+		})
+
+		Convey("When the bundle only needs one approval", func() {
+			smBundle.ApprovalPolicy.Quorum = 1
+			err := application.ApprovalQuorum(ctx, smBundle, bundle, approverAuth("first@ons.gov.uk"))
+
+			Convey("Then the approval is allowed without looking up the approvals", func() {
+				So(err, ShouldBeNil)
+				So(mockedDatastore.ListApprovalsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the approvals cannot be looked up", func() {
+			errDatastore := errors.New("database error")
+			mockedDatastore.ListApprovalsFunc = func(ctx context.Context, bundleID string) ([]*models.Approval, error) {
+				return nil, errDatastore
+			}
+			err := application.ApprovalQuorum(ctx, smBundle, bundle, approverAuth("third@ons.gov.uk"))
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldEqual, errDatastore)
+			})
+		})
+	})
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// ListApprovals returns the approvals recorded against a bundle, along with the number of approvals it needs
+func (s *StateMachineBundleAPI) ListApprovals(ctx context.Context, bundleID string) (*models.BundleApprovals, error) {
+	bundle, err := s.Datastore.GetBundle(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := s.Datastore.ListApprovals(ctx, bundle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BundleApprovals{
+		BundleID: bundle.ID,
+		Quorum:   s.ApprovalPolicy.QuorumFor(bundle),
+		Items:    approvals,
+	}, nil
+}
+
+// RecordApproval records the caller's approval of a bundle that is in review. The caller must be allowed to approve the
+// bundle by the approval policy, and may only approve it once.
+func (s *StateMachineBundleAPI) RecordApproval(ctx context.Context, bundleID string, authEntityData *models.AuthEntityData) (*models.Approval, error) {
+	logData := log.Data{"bundle_id": bundleID}
+
+	bundle, err := s.Datastore.GetBundle(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if bundle.State != models.BundleStateInReview {
+		return nil, apierrors.ErrApprovalNotInReview
+	}
+
+	if err = FourEyesApproval(ctx, *s, bundle, authEntityData); err != nil {
+		var preconditionErr *PreconditionError
+		if errors.As(err, &preconditionErr) {
+			return nil, preconditionErr.Err
+		}
+		return nil, err
+	}
+
+	approvals, err := s.Datastore.ListApprovals(ctx, bundle.ID)
+	if err != nil {
+		log.Error(ctx, "failed to list bundle approvals", err, logData)
+		return nil, err
+	}
+
+	approver := authEntityData.GetUserID()
+	if slices.ContainsFunc(approvals, func(approval *models.Approval) bool {
+		return approval.ApprovedBy != nil && approval.ApprovedBy.Email == approver
+	}) {
+		return nil, apierrors.ErrApprovalAlreadyExists
+	}
+
+	approval, err := models.NewApproval(bundle.ID, approver)
+	if err != nil {
+		log.Error(ctx, "failed to create approval model", err, logData)
+		return nil, err
+	}
+
+	if err = s.Datastore.CreateApproval(ctx, approval); err != nil {
+		log.Error(ctx, "failed to create approval", err, logData)
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// WithdrawApproval removes the caller's approval of a bundle that is in review
+func (s *StateMachineBundleAPI) WithdrawApproval(ctx context.Context, bundleID string, authEntityData *models.AuthEntityData) error {
+	bundle, err := s.Datastore.GetBundle(ctx, bundleID)
+	if err != nil {
+		return err
+	}
+
+	if bundle.State != models.BundleStateInReview {
+		return apierrors.ErrApprovalNotInReview
+	}
+
+	return s.Datastore.DeleteApproval(ctx, bundle.ID, authEntityData.GetUserID())
+}
+
+// InvalidateApprovals removes every approval recorded against a bundle, so that a bundle whose contents have changed
+// since it was approved must be approved again
+func (s *StateMachineBundleAPI) InvalidateApprovals(ctx context.Context, bundleID string) error {
+	invalidated, err := s.Datastore.DeleteApprovals(ctx, bundleID)
+	if err != nil {
+		return err
+	}
+
+	if invalidated > 0 {
+		log.Info(ctx, "bundle approvals invalidated", log.Data{"bundle_id": bundleID, "invalidated": invalidated})
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestApprovals(t *testing.T) {
+	Convey("Given a bundle in review that needs two approvals and has been approved by one user", t, func() {
+		ctx := context.Background()
+		bundle := &models.Bundle{ID: bundle123, State: models.BundleStateInReview, BundleType: models.BundleTypeScheduled, CreatedBy: &models.User{Email: "creator@ons.gov.uk"}}
+		approvals := []*models.Approval{{ID: "approval-1", BundleID: bundle123, ApprovedBy: &models.User{Email: "first@ons.gov.uk"}}}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				if bundleID != bundle123 {
+					return nil, apierrors.ErrBundleNotFound
+				}
+				return bundle, nil
+			},
+			ListApprovalsFunc: func(ctx context.Context, bundleID string) ([]*models.Approval, error) {
+				return approvals, nil
+			},
+			CreateApprovalFunc: func(ctx context.Context, approval *models.Approval) error {
+				return nil
+			},
+			DeleteApprovalFunc: func(ctx context.Context, bundleID, approvedBy string) error {
+				return nil
+			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return len(approvals), nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore: store.Datastore{Backend: mockedDatastore},
+			ApprovalPolicy: application.ApprovalPolicy{
+				RequireDifferentCreator: true,
+				Quorum:                  1,
+				QuorumByBundleType:      map[string]int{models.BundleTypeScheduled.String(): 2},
+			},
+		}
+
+		approverAuth := func(userID string) *models.AuthEntityData {
+			return &models.AuthEntityData{EntityData: &permissionsAPISDK.EntityData{UserID: userID}}
+		}
+
+		Convey("When the approvals are listed", func() {
+			bundleApprovals, err := stateMachineBundleAPI.ListApprovals(ctx, bundle123)
+
+			Convey("Then the recorded approvals are returned with the bundle's quorum", func() {
+				So(err, ShouldBeNil)
+				So(bundleApprovals.BundleID, ShouldEqual, bundle123)
+				So(bundleApprovals.Quorum, ShouldEqual, 2)
+				So(bundleApprovals.Items, ShouldResemble, approvals)
+			})
+		})
+
+		Convey("When another user records an approval", func() {
+			approval, err := stateMachineBundleAPI.RecordApproval(ctx, bundle123, approverAuth("second@ons.gov.uk"))
+
+			Convey("Then their approval is stored", func() {
+				So(err, ShouldBeNil)
+				So(approval.BundleID, ShouldEqual, bundle123)
+				So(approval.ApprovedBy.Email, ShouldEqual, "second@ons.gov.uk")
+				So(approval.ApprovedAt, ShouldNotBeNil)
+				So(mockedDatastore.CreateApprovalCalls()[0].Approval, ShouldEqual, approval)
+			})
+		})
+
+		Convey("When the user who has already approved it records another approval", func() {
+			_, err := stateMachineBundleAPI.RecordApproval(ctx, bundle123, approverAuth("first@ons.gov.uk"))
+
+			Convey("Then ErrApprovalAlreadyExists is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrApprovalAlreadyExists)
+				So(mockedDatastore.CreateApprovalCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the creator records an approval", func() {
+			_, err := stateMachineBundleAPI.RecordApproval(ctx, bundle123, approverAuth("creator@ons.gov.uk"))
+
+			Convey("Then the approval policy refuses it", func() {
+				So(err, ShouldEqual, apierrors.ErrApproverCreatedBundle)
+				So(mockedDatastore.CreateApprovalCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the bundle is not in review", func() {
+			bundle.State = models.BundleStateDraft
+
+			Convey("Then approvals cannot be recorded or withdrawn", func() {
+				_, err := stateMachineBundleAPI.RecordApproval(ctx, bundle123, approverAuth("second@ons.gov.uk"))
+				So(err, ShouldEqual, apierrors.ErrApprovalNotInReview)
+
+				err = stateMachineBundleAPI.WithdrawApproval(ctx, bundle123, approverAuth("first@ons.gov.uk"))
+				So(err, ShouldEqual, apierrors.ErrApprovalNotInReview)
+				So(mockedDatastore.DeleteApprovalCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a user withdraws their approval", func() {
+			err := stateMachineBundleAPI.WithdrawApproval(ctx, bundle123, approverAuth("first@ons.gov.uk"))
+
+			Convey("Then their approval is removed", func() {
+				So(err, ShouldBeNil)
+				So(mockedDatastore.DeleteApprovalCalls()[0].BundleID, ShouldEqual, bundle123)
+				So(mockedDatastore.DeleteApprovalCalls()[0].ApprovedBy, ShouldEqual, "first@ons.gov.uk")
+			})
+		})
+
+		Convey("When the approvals are invalidated", func() {
+			err := stateMachineBundleAPI.InvalidateApprovals(ctx, bundle123)
+
+			Convey("Then every approval of the bundle is removed", func() {
+				So(err, ShouldBeNil)
+				So(mockedDatastore.DeleteApprovalsCalls()[0].BundleID, ShouldEqual, bundle123)
+			})
+		})
+
+		Convey("When the approvals cannot be invalidated", func() {
+			errDatastore := errors.New("database error")
+			mockedDatastore.DeleteApprovalsFunc = func(ctx context.Context, bundleID string) (int, error) {
+				return 0, errDatastore
+			}
+
+			Convey("Then the error is returned", func() {
+				So(stateMachineBundleAPI.InvalidateApprovals(ctx, bundle123), ShouldEqual, errDatastore)
+			})
+		})
+
+		Convey("When the bundle does not exist", func() {
+			_, err := stateMachineBundleAPI.ListApprovals(ctx, "missing")
+
+			Convey("Then ErrBundleNotFound is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrBundleNotFound)
+			})
+		})
+	})
+}
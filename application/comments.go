@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// ListComments returns a page of the comments on a bundle, or on one of its content items if contentItemID is set
+func (s *StateMachineBundleAPI) ListComments(ctx context.Context, bundleID, contentItemID string, offset, limit int) ([]*models.Comment, int, error) {
+	if err := s.checkCommentTargetExists(ctx, bundleID, contentItemID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.Datastore.ListComments(ctx, bundleID, contentItemID, offset, limit)
+}
+
+// AddComment adds the caller's comment to the bundle or content item it is for. A reply must be to a comment on the
+// same bundle or content item.
+func (s *StateMachineBundleAPI) AddComment(ctx context.Context, comment *models.Comment, authEntityData *models.AuthEntityData) (*models.Comment, error) {
+	logData := log.Data{"bundle_id": comment.BundleID, "content_item_id": comment.ContentItemID, "comment_id": comment.ID}
+
+	if err := s.checkCommentTargetExists(ctx, comment.BundleID, comment.ContentItemID); err != nil {
+		return nil, err
+	}
+
+	if comment.ParentID != "" {
+		parent, err := s.Datastore.GetComment(ctx, comment.BundleID, comment.ParentID)
+		if err != nil {
+			if err == apierrors.ErrCommentNotFound {
+				return nil, apierrors.ErrCommentParentNotFound
+			}
+			return nil, err
+		}
+
+		if parent.ContentItemID != comment.ContentItemID {
+			return nil, apierrors.ErrCommentParentNotFound
+		}
+	}
+
+	now := time.Now()
+	comment.Author = &models.User{Email: authEntityData.GetUserID()}
+	comment.CreatedAt = &now
+
+	if err := s.Datastore.CreateComment(ctx, comment); err != nil {
+		log.Error(ctx, "failed to create comment", err, logData)
+		return nil, err
+	}
+
+	if err := s.createCommentEvent(ctx, authEntityData, models.ActionCreate, comment); err != nil {
+		log.Error(ctx, "failed to create event for comment", err, logData)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// ResolveComment marks a comment as resolved by the caller
+func (s *StateMachineBundleAPI) ResolveComment(ctx context.Context, bundleID, contentItemID, commentID string, authEntityData *models.AuthEntityData) (*models.Comment, error) {
+	return s.updateCommentResolution(ctx, bundleID, contentItemID, commentID, true, authEntityData)
+}
+
+// UnresolveComment reopens a comment that has been resolved
+func (s *StateMachineBundleAPI) UnresolveComment(ctx context.Context, bundleID, contentItemID, commentID string, authEntityData *models.AuthEntityData) (*models.Comment, error) {
+	return s.updateCommentResolution(ctx, bundleID, contentItemID, commentID, false, authEntityData)
+}
+
+func (s *StateMachineBundleAPI) updateCommentResolution(ctx context.Context, bundleID, contentItemID, commentID string, resolved bool, authEntityData *models.AuthEntityData) (*models.Comment, error) {
+	logData := log.Data{"bundle_id": bundleID, "content_item_id": contentItemID, "comment_id": commentID, "resolved": resolved}
+
+	comment, err := s.Datastore.GetComment(ctx, bundleID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.ContentItemID != contentItemID {
+		return nil, apierrors.ErrCommentNotFound
+	}
+
+	if comment.Resolved == resolved {
+		return comment, nil
+	}
+
+	comment.Resolved = resolved
+	comment.ResolvedBy = nil
+	comment.ResolvedAt = nil
+
+	if resolved {
+		now := time.Now()
+		comment.ResolvedBy = &models.User{Email: authEntityData.GetUserID()}
+		comment.ResolvedAt = &now
+	}
+
+	if err = s.Datastore.UpdateCommentResolution(ctx, comment.ID, comment.ResolvedBy, comment.ResolvedAt); err != nil {
+		log.Error(ctx, "failed to update comment resolution", err, logData)
+		return nil, err
+	}
+
+	if err = s.createCommentEvent(ctx, authEntityData, models.ActionUpdate, comment); err != nil {
+		log.Error(ctx, "failed to create event for comment", err, logData)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *StateMachineBundleAPI) checkCommentTargetExists(ctx context.Context, bundleID, contentItemID string) error {
+	if contentItemID != "" {
+		_, err := s.Datastore.GetContentItemByBundleIDAndContentItemID(ctx, bundleID, contentItemID)
+		return err
+	}
+
+	bundleExists, err := s.Datastore.CheckBundleExists(ctx, bundleID)
+	if err != nil {
+		return err
+	}
+
+	if !bundleExists {
+		return apierrors.ErrBundleNotFound
+	}
+
+	return nil
+}
+
+func (s *StateMachineBundleAPI) createCommentEvent(ctx context.Context, authEntityData *models.AuthEntityData, action models.Action, comment *models.Comment) error {
+	event, err := models.CreateCommentEventModel(authEntityData.GetUserID(), authEntityData.GetUserEmail(), action, comment)
+	if err != nil {
+		return err
+	}
+
+	return s.Datastore.CreateEvent(ctx, event)
+}
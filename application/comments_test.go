@@ -0,0 +1,166 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestComments(t *testing.T) {
+	Convey("Given a bundle with a comment on it and a comment on one of its content items", t, func() {
+		ctx := context.Background()
+		comments := map[string]*models.Comment{
+			"comment-1": {ID: "comment-1", BundleID: bundle123, Body: "Please check the title"},
+			"comment-2": {ID: "comment-2", BundleID: bundle123, ContentItemID: "content-item-1", Body: "Wrong edition"},
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			CheckBundleExistsFunc: func(ctx context.Context, bundleID string) (bool, error) {
+				return bundleID == bundle123, nil
+			},
+			GetContentItemByBundleIDAndContentItemIDFunc: func(ctx context.Context, bundleID, contentItemID string) (*models.ContentItem, error) {
+				if bundleID != bundle123 || contentItemID != "content-item-1" {
+					return nil, apierrors.ErrContentItemNotFound
+				}
+				return &models.ContentItem{ID: contentItemID, BundleID: bundleID}, nil
+			},
+			GetCommentFunc: func(ctx context.Context, bundleID, commentID string) (*models.Comment, error) {
+				comment, ok := comments[commentID]
+				if !ok || comment.BundleID != bundleID {
+					return nil, apierrors.ErrCommentNotFound
+				}
+				return comment, nil
+			},
+			ListCommentsFunc: func(ctx context.Context, bundleID, contentItemID string, offset, limit int) ([]*models.Comment, int, error) {
+				return []*models.Comment{comments["comment-1"]}, 1, nil
+			},
+			CreateCommentFunc: func(ctx context.Context, comment *models.Comment) error {
+				return nil
+			},
+			UpdateCommentResolutionFunc: func(ctx context.Context, commentID string, resolvedBy *models.User, resolvedAt *time.Time) error {
+				return nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{Datastore: store.Datastore{Backend: mockedDatastore}}
+		authEntityData := &models.AuthEntityData{EntityData: &permissionsAPISDK.EntityData{UserID: "reviewer@ons.gov.uk"}}
+
+		Convey("When the comments on the bundle are listed", func() {
+			items, totalCount, err := stateMachineBundleAPI.ListComments(ctx, bundle123, "", 0, 10)
+
+			Convey("Then the page of comments is returned", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 1)
+				So(items[0].ID, ShouldEqual, "comment-1")
+				So(mockedDatastore.ListCommentsCalls()[0].ContentItemID, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the comments on a bundle that does not exist are listed", func() {
+			_, _, err := stateMachineBundleAPI.ListComments(ctx, "missing", "", 0, 10)
+
+			Convey("Then ErrBundleNotFound is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrBundleNotFound)
+				So(mockedDatastore.ListCommentsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a reply is added to the comment on the content item", func() {
+			comment := &models.Comment{ID: "comment-3", BundleID: bundle123, ContentItemID: "content-item-1", ParentID: "comment-2", Body: "Fixed"}
+			added, err := stateMachineBundleAPI.AddComment(ctx, comment, authEntityData)
+
+			Convey("Then it is stored with the caller as its author and a CREATE event is recorded", func() {
+				So(err, ShouldBeNil)
+				So(added.Author.Email, ShouldEqual, "reviewer@ons.gov.uk")
+				So(added.CreatedAt, ShouldNotBeNil)
+				So(mockedDatastore.CreateCommentCalls()[0].Comment, ShouldEqual, comment)
+
+				event := mockedDatastore.CreateEventCalls()[0].Event
+				So(event.Action, ShouldEqual, models.ActionCreate)
+				So(event.Resource, ShouldEqual, "/bundles/"+bundle123+"/contents/content-item-1/comments/comment-3")
+				So(event.Comment, ShouldEqual, comment)
+			})
+		})
+
+		Convey("When a comment on the bundle replies to a comment on the content item", func() {
+			comment := &models.Comment{ID: "comment-3", BundleID: bundle123, ParentID: "comment-2", Body: "Fixed"}
+			_, err := stateMachineBundleAPI.AddComment(ctx, comment, authEntityData)
+
+			Convey("Then ErrCommentParentNotFound is returned and nothing is stored", func() {
+				So(err, ShouldEqual, apierrors.ErrCommentParentNotFound)
+				So(mockedDatastore.CreateCommentCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a comment replies to a comment that does not exist", func() {
+			comment := &models.Comment{ID: "comment-3", BundleID: bundle123, ParentID: "missing", Body: "Fixed"}
+			_, err := stateMachineBundleAPI.AddComment(ctx, comment, authEntityData)
+
+			Convey("Then ErrCommentParentNotFound is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrCommentParentNotFound)
+			})
+		})
+
+		Convey("When a comment is added to a content item that is not in the bundle", func() {
+			comment := &models.Comment{ID: "comment-3", BundleID: bundle123, ContentItemID: "content-item-2", Body: "Fixed"}
+			_, err := stateMachineBundleAPI.AddComment(ctx, comment, authEntityData)
+
+			Convey("Then ErrContentItemNotFound is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrContentItemNotFound)
+			})
+		})
+
+		Convey("When the comment on the bundle is resolved", func() {
+			resolved, err := stateMachineBundleAPI.ResolveComment(ctx, bundle123, "", "comment-1", authEntityData)
+
+			Convey("Then who resolved it is recorded and an UPDATE event is recorded", func() {
+				So(err, ShouldBeNil)
+				So(resolved.Resolved, ShouldBeTrue)
+				So(resolved.ResolvedBy.Email, ShouldEqual, "reviewer@ons.gov.uk")
+				So(mockedDatastore.UpdateCommentResolutionCalls()[0].ResolvedBy, ShouldEqual, resolved.ResolvedBy)
+				So(mockedDatastore.CreateEventCalls()[0].Event.Action, ShouldEqual, models.ActionUpdate)
+			})
+
+			Convey("And when it is unresolved", func() {
+				unresolved, err := stateMachineBundleAPI.UnresolveComment(ctx, bundle123, "", "comment-1", authEntityData)
+
+				Convey("Then the resolution is cleared", func() {
+					So(err, ShouldBeNil)
+					So(unresolved.Resolved, ShouldBeFalse)
+					So(unresolved.ResolvedBy, ShouldBeNil)
+					So(mockedDatastore.UpdateCommentResolutionCalls()[1].ResolvedBy, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey("When a comment that is not resolved is unresolved", func() {
+			_, err := stateMachineBundleAPI.UnresolveComment(ctx, bundle123, "", "comment-1", authEntityData)
+
+			Convey("Then nothing is changed", func() {
+				So(err, ShouldBeNil)
+				So(mockedDatastore.UpdateCommentResolutionCalls(), ShouldBeEmpty)
+				So(mockedDatastore.CreateEventCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the comment on the content item is resolved through the bundle", func() {
+			_, err := stateMachineBundleAPI.ResolveComment(ctx, bundle123, "", "comment-2", authEntityData)
+
+			Convey("Then ErrCommentNotFound is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrCommentNotFound)
+			})
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+)
+
+// GetBundleConflicts lists the content items in a bundle whose datasets are also in other bundles that have not been
+// published, along with the state and schedule of those bundles
+func (s *StateMachineBundleAPI) GetBundleConflicts(ctx context.Context, bundleID string) (*models.BundleConflicts, error) {
+	if _, err := s.Datastore.GetBundle(ctx, bundleID); err != nil {
+		return nil, err
+	}
+
+	conflicts, err := s.findBundleConflicts(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BundleConflicts{
+		BundleID:     bundleID,
+		HasConflicts: len(conflicts) > 0,
+		Conflicts:    conflicts,
+	}, nil
+}
+
+// findBundleConflicts returns a conflict for each pair of a content item in the bundle and a content item for the same
+// dataset in another bundle that has not been published
+func (s *StateMachineBundleAPI) findBundleConflicts(ctx context.Context, bundleID string) ([]*models.BundleConflict, error) {
+	conflicts := []*models.BundleConflict{}
+
+	contentItems, err := s.Datastore.GetContentItemsByBundleID(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	datasetIDs := []string{}
+	for _, contentItem := range contentItems {
+		if !slices.Contains(datasetIDs, contentItem.Metadata.DatasetID) {
+			datasetIDs = append(datasetIDs, contentItem.Metadata.DatasetID)
+		}
+	}
+
+	if len(datasetIDs) == 0 {
+		return conflicts, nil
+	}
+
+	otherContentItems, err := s.Datastore.ListContentItemsByDatasetIDs(ctx, datasetIDs, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	otherBundleIDs := []string{}
+	for _, otherContentItem := range otherContentItems {
+		if !slices.Contains(otherBundleIDs, otherContentItem.BundleID) {
+			otherBundleIDs = append(otherBundleIDs, otherContentItem.BundleID)
+		}
+	}
+
+	if len(otherBundleIDs) == 0 {
+		return conflicts, nil
+	}
+
+	otherBundles, err := s.Datastore.ListBundlesByIDs(ctx, otherBundleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	unpublishedBundles := make(map[string]*models.Bundle, len(otherBundles))
+	for _, otherBundle := range otherBundles {
+		if otherBundle.State != models.BundleStatePublished && otherBundle.State != models.BundleStateWithdrawn {
+			unpublishedBundles[otherBundle.ID] = otherBundle
+		}
+	}
+
+	for _, contentItem := range contentItems {
+		for _, otherContentItem := range otherContentItems {
+			otherBundle, ok := unpublishedBundles[otherContentItem.BundleID]
+			if !ok {
+				continue
+			}
+
+			if conflict := models.NewBundleConflict(contentItem, otherContentItem, otherBundle); conflict != nil {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	return conflicts, nil
+}
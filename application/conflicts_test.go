@@ -0,0 +1,99 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBundleConflicts(t *testing.T) {
+	Convey("Given a bundle whose datasets are also in a draft bundle and a published bundle", t, func() {
+		ctx := context.Background()
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return &models.Bundle{ID: bundleID, State: models.BundleStateInReview}, nil
+			},
+			GetContentItemsByBundleIDFunc: func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
+				return []*models.ContentItem{
+					{ID: "content-item-1", BundleID: bundleID, Metadata: models.Metadata{DatasetID: "cpih", EditionID: "time-series", VersionID: 2}},
+					{ID: "content-item-2", BundleID: bundleID, Metadata: models.Metadata{DatasetID: "gdp", EditionID: "2025", VersionID: 1}},
+				}, nil
+			},
+			ListContentItemsByDatasetIDsFunc: func(ctx context.Context, datasetIDs []string, excludeBundleID string) ([]*models.ContentItem, error) {
+				return []*models.ContentItem{
+					{ID: "content-item-3", BundleID: "draft-bundle", Metadata: models.Metadata{DatasetID: "cpih", EditionID: "2026", VersionID: 1}},
+					{ID: "content-item-4", BundleID: "published-bundle", Metadata: models.Metadata{DatasetID: "gdp", EditionID: "2025", VersionID: 2}},
+				}, nil
+			},
+			ListBundlesByIDsFunc: func(ctx context.Context, bundleIDs []string) ([]*models.Bundle, error) {
+				return []*models.Bundle{
+					{ID: "draft-bundle", State: models.BundleStateDraft, BundleType: models.BundleTypeManual},
+					{ID: "published-bundle", State: models.BundleStatePublished, BundleType: models.BundleTypeManual},
+				}, nil
+			},
+		}
+
+		smBundle := application.StateMachineBundleAPI{Datastore: store.Datastore{Backend: mockedDatastore}}
+		bundle := &models.Bundle{ID: bundle123, State: models.BundleStateInReview}
+		authEntityData := &models.AuthEntityData{EntityData: &permissionsAPISDK.EntityData{UserID: userEmail}}
+
+		Convey("When the conflicts of the bundle are found", func() {
+			conflicts, err := smBundle.GetBundleConflicts(ctx, bundle123)
+
+			Convey("Then only the conflict with the bundle that has not been published is returned", func() {
+				So(err, ShouldBeNil)
+				So(conflicts.HasConflicts, ShouldBeTrue)
+				So(conflicts.Conflicts, ShouldHaveLength, 1)
+				So(conflicts.Conflicts[0].Type, ShouldEqual, models.ConflictTypeDataset)
+				So(conflicts.Conflicts[0].ContentItemID, ShouldEqual, "content-item-1")
+				So(conflicts.Conflicts[0].ConflictingBundle.ID, ShouldEqual, "draft-bundle")
+
+				call := mockedDatastore.ListContentItemsByDatasetIDsCalls()[0]
+				So(call.DatasetIDs, ShouldResemble, []string{"cpih", "gdp"})
+				So(call.ExcludeBundleID, ShouldEqual, bundle123)
+			})
+		})
+
+		Convey("When the bundle is approved and the approval policy does not block on conflicts", func() {
+			err := application.NoConflicts(ctx, smBundle, bundle, authEntityData)
+
+			Convey("Then the approval is allowed without looking for conflicts", func() {
+				So(err, ShouldBeNil)
+				So(mockedDatastore.GetContentItemsByBundleIDCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the bundle is approved and the approval policy blocks on conflicts", func() {
+			smBundle.ApprovalPolicy.BlockOnConflicts = true
+			err := application.NoConflicts(ctx, smBundle, bundle, authEntityData)
+
+			Convey("Then the approval is refused", func() {
+				So(errors.Is(err, apierrors.ErrBundleHasConflicts), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the bundle has no content items", func() {
+			mockedDatastore.GetContentItemsByBundleIDFunc = func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
+				return []*models.ContentItem{}, nil
+			}
+			conflicts, err := smBundle.GetBundleConflicts(ctx, bundle123)
+
+			Convey("Then there are no conflicts and other bundles are not looked up", func() {
+				So(err, ShouldBeNil)
+				So(conflicts.HasConflicts, ShouldBeFalse)
+				So(conflicts.Conflicts, ShouldBeEmpty)
+				So(mockedDatastore.ListContentItemsByDatasetIDsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
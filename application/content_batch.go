@@ -0,0 +1,335 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/content"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// CheckContentItemsBatch checks that the content each content item in a batch refers to exists, setting the content
+// item's links, and that no dataset version is already in a bundle or is repeated in the batch. Problems with content
+// items are returned against the field within the items array, and an error is only returned if a check could not be
+// made. At most PublishMaxConcurrency content items are checked at once.
+func (s *StateMachineBundleAPI) CheckContentItemsBatch(ctx context.Context, headers datasetAPISDK.Headers, contentItems []*models.ContentItem) ([]*models.Error, error) {
+	itemErrs := make([]*models.Error, len(contentItems))
+	checkErrs := make([]error, len(contentItems))
+
+	forEachConcurrently(len(contentItems), s.PublishMaxConcurrency, func(index int) {
+		itemErrs[index], checkErrs[index] = s.checkContentItemForBatch(ctx, headers, contentItems[index])
+	})
+
+	if err := errors.Join(checkErrs...); err != nil {
+		return nil, err
+	}
+
+	var batchErrs []*models.Error
+	seen := make(map[string]bool, len(contentItems))
+	for i, contentItem := range contentItems {
+		if itemErrs[i] != nil {
+			batchErrs = append(batchErrs, models.BatchItemError(i, itemErrs[i]))
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%d", contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, contentItem.Metadata.VersionID)
+		if seen[key] {
+			batchErrs = append(batchErrs, models.BatchItemError(i, models.CreateModelError(models.CodeConflict, apierrors.ErrorDescriptionVersionRepeatedInBatch)))
+		}
+		seen[key] = true
+	}
+
+	return batchErrs, nil
+}
+
+// checkContentItemForBatch checks that the dataset version a content item refers to exists in dataset API and is not
+// already in a bundle
+func (s *StateMachineBundleAPI) checkContentItemForBatch(ctx context.Context, headers datasetAPISDK.Headers, contentItem *models.ContentItem) (*models.Error, error) {
+	if err := s.DatasetProvider().Validate(ctx, headers, contentItem); err != nil {
+		var notFoundErr *content.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			code := models.CodeNotFound
+			return &models.Error{Code: &code, Description: apierrors.ErrorDescriptionNotFound, Source: &models.Source{Field: notFoundErr.Field}}, nil
+		}
+		return nil, err
+	}
+
+	exists, err := s.Datastore.CheckContentItemExistsByDatasetEditionVersion(ctx, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, contentItem.Metadata.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return models.CreateModelError(models.CodeConflict, apierrors.ErrorDescriptionVersionAlreadyExists), nil
+	}
+
+	return nil, nil
+}
+
+// AddContentItems adds the checked content items in a batch to a bundle together, creating an event for each. The
+// bundle's ETag is updated once, and the updated bundle is returned. If any step fails after the content items have
+// been stored then what has been done is rolled back, so that none of them are added. For a scheduled bundle, a failure
+// to give a dataset version the bundle's release date is reported against its content item rather than returned.
+func (s *StateMachineBundleAPI) AddContentItems(ctx context.Context, bundleID string, contentItems []*models.ContentItem, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+	logData := log.Data{"bundle_id": bundleID, "count": len(contentItems)}
+
+	if err := s.Datastore.CreateContentItems(ctx, contentItems); err != nil {
+		return nil, err
+	}
+
+	progress := &contentItemsBatchProgress{}
+	updatedBundle, err := s.addContentItemsToBundle(ctx, bundleID, contentItems, authEntityData, progress)
+	if err != nil {
+		log.Error(ctx, "failed to add content items to bundle, rolling back", err, logData)
+		if rollbackErr := s.rollBackAddedContentItems(ctx, bundleID, contentItems, authEntityData, progress); rollbackErr != nil {
+			log.Error(ctx, "failed to roll back content items added to bundle", rollbackErr, logData)
+			return nil, errors.Join(err, rollbackErr)
+		}
+		return nil, err
+	}
+
+	if updatedBundle.BundleType == models.BundleTypeScheduled && updatedBundle.ScheduledAt != nil {
+		for _, contentItem := range contentItems {
+			contentItem.ReleaseDateSyncFailure = s.SyncContentItemReleaseDate(ctx, updatedBundle.ScheduledAt, contentItem, authEntityData.Headers)
+			if contentItem.ReleaseDateSyncFailure != nil {
+				log.Warn(ctx, "failed to update dataset version release date", log.Data{"bundle_id": bundleID, "content_item_id": contentItem.ID})
+			}
+		}
+	}
+
+	log.Info(ctx, "content items added to bundle", logData)
+	return updatedBundle, nil
+}
+
+// contentItemsBatchProgress records how far adding or removing a batch of content items got, so that it can be rolled
+// back
+type contentItemsBatchProgress struct {
+	// events is how many content items have had an event created for them
+	events int
+	// policies is how many content items have had the policy conditions of the preview teams updated, including one
+	// that was being updated when a failure happened
+	policies int
+	// bundle is the bundle after its ETag was updated, which is needed to update policy conditions
+	bundle *models.Bundle
+}
+
+// addContentItemsToBundle makes the changes that follow storing the content items in a batch, recording its progress.
+// The approvals of the bundle are invalidated last.
+func (s *StateMachineBundleAPI) addContentItemsToBundle(ctx context.Context, bundleID string, contentItems []*models.ContentItem, authEntityData *models.AuthEntityData, progress *contentItemsBatchProgress) (*models.Bundle, error) {
+	for _, contentItem := range contentItems {
+		if err := s.CreateEvent(ctx, authEntityData, models.ActionCreate, nil, contentItem); err != nil {
+			return nil, err
+		}
+		progress.events++
+	}
+
+	updatedBundle, err := s.Datastore.UpdateBundleETag(ctx, bundleID, authEntityData.GetUserEmail())
+	if err != nil {
+		return nil, err
+	}
+	progress.bundle = updatedBundle
+
+	for _, contentItem := range contentItems {
+		progress.policies++
+		if err := s.AddPolicyConditionsForContentItem(ctx, authEntityData.Headers.AccessToken, updatedBundle, contentItem); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.CreateEvent(ctx, authEntityData, models.ActionUpdate, updatedBundle, nil); err != nil {
+		return nil, err
+	}
+
+	// approvals cannot be restored by a roll back, so they are only invalidated once nothing else can fail
+	if err := s.InvalidateApprovals(ctx, bundleID); err != nil {
+		return nil, err
+	}
+
+	return updatedBundle, nil
+}
+
+// rollBackAddedContentItems undoes adding the content items in a batch as far as progress records. The content items
+// are removed again, along with any policy conditions added for them, and an event is created for each content item
+// whose addition was recorded so that the history of the bundle stays true. Every step is tried, and the errors of
+// any that fail are returned together.
+func (s *StateMachineBundleAPI) rollBackAddedContentItems(ctx context.Context, bundleID string, contentItems []*models.ContentItem, authEntityData *models.AuthEntityData, progress *contentItemsBatchProgress) error {
+	var rollbackErrs []error
+
+	if progress.bundle != nil {
+		for _, contentItem := range contentItems[:progress.policies] {
+			rollbackErrs = append(rollbackErrs, s.RemovePolicyConditionsForContentItem(ctx, authEntityData.Headers.AccessToken, progress.bundle, contentItem))
+		}
+	}
+
+	if _, err := s.Datastore.DeleteContentItems(ctx, bundleID, contentItemIDs(contentItems)); err != nil {
+		rollbackErrs = append(rollbackErrs, err)
+	}
+
+	for _, contentItem := range contentItems[:progress.events] {
+		rollbackErrs = append(rollbackErrs, s.CreateEvent(ctx, authEntityData, models.ActionDelete, nil, contentItem))
+	}
+
+	return errors.Join(rollbackErrs...)
+}
+
+// CheckContentItemIDsBatch gets the content items in a bundle with the given IDs. A content item that is not in the
+// bundle, or that has been published, is returned as a problem against its field within the ids array, and an error
+// is only returned if a content item could not be got.
+func (s *StateMachineBundleAPI) CheckContentItemIDsBatch(ctx context.Context, bundleID string, contentItemIDs []string) ([]*models.ContentItem, []*models.Error, error) {
+	contentItems := make([]*models.ContentItem, 0, len(contentItemIDs))
+	var batchErrs []*models.Error
+
+	for i, contentItemID := range contentItemIDs {
+		source := &models.Source{Field: fmt.Sprintf("/ids/%d", i)}
+
+		contentItem, err := s.Datastore.GetContentItemByBundleIDAndContentItemID(ctx, bundleID, contentItemID)
+		if err != nil {
+			if errors.Is(err, apierrors.ErrContentItemNotFound) {
+				code := models.CodeNotFound
+				batchErrs = append(batchErrs, &models.Error{Code: &code, Description: apierrors.ErrorDescriptionNotFound, Source: source})
+				continue
+			}
+			return nil, nil, err
+		}
+
+		if contentItem.State != nil && *contentItem.State == models.StatePublished {
+			code := models.CodeConflict
+			batchErrs = append(batchErrs, &models.Error{Code: &code, Description: apierrors.ErrorDescriptionConflict, Source: source})
+			continue
+		}
+
+		contentItems = append(contentItems, contentItem)
+	}
+
+	return contentItems, batchErrs, nil
+}
+
+// RemoveContentItems removes the checked content items in a batch from a bundle together, creating an event for each.
+// The bundle's ETag is updated once, and the updated bundle is returned. A content item that has already been removed
+// from the bundle, e.g. by another request, is left out. If any step fails after the content items have been deleted
+// then what has been done is rolled back, so that none of them are removed.
+func (s *StateMachineBundleAPI) RemoveContentItems(ctx context.Context, bundleID string, contentItems []*models.ContentItem, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+	logData := log.Data{"bundle_id": bundleID, "count": len(contentItems)}
+
+	deletedContentItems, err := s.deleteContentItems(ctx, bundleID, contentItems)
+	if err != nil {
+		return nil, err
+	}
+	if len(deletedContentItems) != len(contentItems) {
+		log.Warn(ctx, "some content items had already been removed from the bundle", log.Data{"bundle_id": bundleID, "requested": len(contentItems), "deleted": len(deletedContentItems)})
+	}
+
+	progress := &contentItemsBatchProgress{}
+	updatedBundle, err := s.removeContentItemsFromBundle(ctx, bundleID, deletedContentItems, authEntityData, progress)
+	if err != nil {
+		log.Error(ctx, "failed to remove content items from bundle, rolling back", err, logData)
+		if rollbackErr := s.rollBackRemovedContentItems(ctx, deletedContentItems, authEntityData, progress); rollbackErr != nil {
+			log.Error(ctx, "failed to roll back content items removed from bundle", rollbackErr, logData)
+			return nil, errors.Join(err, rollbackErr)
+		}
+		return nil, err
+	}
+
+	log.Info(ctx, "content items removed from bundle", logData)
+	return updatedBundle, nil
+}
+
+// deleteContentItems deletes each of the content items from the bundle, returning those that were deleted so that only
+// they are stored again by a roll back. If a content item cannot be deleted then those already deleted are stored
+// again and the error is returned.
+func (s *StateMachineBundleAPI) deleteContentItems(ctx context.Context, bundleID string, contentItems []*models.ContentItem) ([]*models.ContentItem, error) {
+	deletedContentItems := make([]*models.ContentItem, 0, len(contentItems))
+
+	for _, contentItem := range contentItems {
+		deleted, err := s.Datastore.DeleteContentItems(ctx, bundleID, []string{contentItem.ID})
+		if err != nil {
+			if len(deletedContentItems) > 0 {
+				if createErr := s.Datastore.CreateContentItems(ctx, deletedContentItems); createErr != nil {
+					return nil, errors.Join(err, createErr)
+				}
+			}
+			return nil, err
+		}
+
+		if deleted > 0 {
+			deletedContentItems = append(deletedContentItems, contentItem)
+		}
+	}
+
+	return deletedContentItems, nil
+}
+
+// removeContentItemsFromBundle makes the changes that follow deleting the content items in a batch, recording its
+// progress. The approvals of the bundle are invalidated last.
+func (s *StateMachineBundleAPI) removeContentItemsFromBundle(ctx context.Context, bundleID string, contentItems []*models.ContentItem, authEntityData *models.AuthEntityData, progress *contentItemsBatchProgress) (*models.Bundle, error) {
+	for _, contentItem := range contentItems {
+		if err := s.CreateEvent(ctx, authEntityData, models.ActionDelete, nil, contentItem); err != nil {
+			return nil, err
+		}
+		progress.events++
+	}
+
+	updatedBundle, err := s.Datastore.UpdateBundleETag(ctx, bundleID, authEntityData.GetUserEmail())
+	if err != nil {
+		return nil, err
+	}
+	progress.bundle = updatedBundle
+
+	for _, contentItem := range contentItems {
+		progress.policies++
+		if err := s.RemovePolicyConditionsForContentItem(ctx, authEntityData.Headers.AccessToken, updatedBundle, contentItem); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.CreateEvent(ctx, authEntityData, models.ActionUpdate, updatedBundle, nil); err != nil {
+		return nil, err
+	}
+
+	// approvals cannot be restored by a roll back, so they are only invalidated once nothing else can fail
+	if err := s.InvalidateApprovals(ctx, bundleID); err != nil {
+		return nil, err
+	}
+
+	return updatedBundle, nil
+}
+
+// rollBackRemovedContentItems undoes removing the content items in a batch as far as progress records. The content
+// items are stored again, along with any policy conditions removed for them, and an event is created for each content
+// item whose removal was recorded so that the history of the bundle stays true. Every step is tried, and the errors of
+// any that fail are returned together.
+func (s *StateMachineBundleAPI) rollBackRemovedContentItems(ctx context.Context, contentItems []*models.ContentItem, authEntityData *models.AuthEntityData, progress *contentItemsBatchProgress) error {
+	var rollbackErrs []error
+
+	if len(contentItems) > 0 {
+		if err := s.Datastore.CreateContentItems(ctx, contentItems); err != nil {
+			rollbackErrs = append(rollbackErrs, err)
+		}
+	}
+
+	if progress.bundle != nil {
+		for _, contentItem := range contentItems[:progress.policies] {
+			rollbackErrs = append(rollbackErrs, s.AddPolicyConditionsForContentItem(ctx, authEntityData.Headers.AccessToken, progress.bundle, contentItem))
+		}
+	}
+
+	for _, contentItem := range contentItems[:progress.events] {
+		rollbackErrs = append(rollbackErrs, s.CreateEvent(ctx, authEntityData, models.ActionCreate, nil, contentItem))
+	}
+
+	return errors.Join(rollbackErrs...)
+}
+
+// contentItemIDs returns the IDs of contentItems
+func contentItemIDs(contentItems []*models.ContentItem) []string {
+	ids := make([]string, len(contentItems))
+	for i, contentItem := range contentItems {
+		ids[i] = contentItem.ID
+	}
+
+	return ids
+}
@@ -0,0 +1,455 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPIMocks "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newBatchDatasetContentItem(id, datasetID string) *models.ContentItem {
+	return &models.ContentItem{
+		ID:          id,
+		BundleID:    bundle1,
+		ContentType: models.ContentTypeDataset,
+		Metadata:    models.Metadata{DatasetID: datasetID, EditionID: "time-series", VersionID: 1},
+	}
+}
+
+func TestCheckContentItemsBatch(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI where dataset2 does not exist and dataset3 is already in a bundle", t, func() {
+		ctx := context.Background()
+
+		mockedDatastore := &storetest.StorerMock{
+			CheckContentItemExistsByDatasetEditionVersionFunc: func(ctx context.Context, datasetID, editionID string, versionID int) (bool, error) {
+				return datasetID == "dataset3", nil
+			},
+		}
+		mockDatasetAPI := &datasetAPIMocks.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				if datasetID == "dataset2" {
+					return datasetAPIModels.Version{}, errors.New("dataset not found")
+				}
+				return datasetAPIModels.Version{
+					Links: &datasetAPIModels.VersionLinks{WebPage: &datasetAPIModels.LinkObject{HRef: "http://localhost/datasets/" + datasetID}},
+				}, nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore:             store.Datastore{Backend: mockedDatastore},
+			DatasetAPIClient:      mockDatasetAPI,
+			PublishMaxConcurrency: 2,
+		}
+
+		Convey("When a batch of content items that can all be added is checked", func() {
+			contentItems := []*models.ContentItem{
+				newBatchDatasetContentItem("content-1", "dataset1"),
+				newBatchDatasetContentItem("content-2", "dataset4"),
+			}
+			batchErrs, err := stateMachineBundleAPI.CheckContentItemsBatch(ctx, datasetAPISDK.Headers{}, contentItems)
+
+			Convey("Then no problems are returned and each content item is linked to its content", func() {
+				So(err, ShouldBeNil)
+				So(batchErrs, ShouldBeEmpty)
+				So(contentItems[0].Links.Preview, ShouldEqual, "/datasets/dataset1")
+				So(contentItems[1].Links.Preview, ShouldEqual, "/datasets/dataset4")
+			})
+		})
+
+		Convey("When a batch with missing, existing and repeated dataset versions is checked", func() {
+			contentItems := []*models.ContentItem{
+				newBatchDatasetContentItem("content-1", "dataset1"),
+				newBatchDatasetContentItem("content-2", "dataset2"),
+				newBatchDatasetContentItem("content-3", "dataset3"),
+				newBatchDatasetContentItem("content-4", "dataset1"),
+			}
+			batchErrs, err := stateMachineBundleAPI.CheckContentItemsBatch(ctx, datasetAPISDK.Headers{}, contentItems)
+
+			Convey("Then a problem is returned against each of those content items", func() {
+				So(err, ShouldBeNil)
+				So(batchErrs, ShouldHaveLength, 3)
+
+				So(*batchErrs[0].Code, ShouldEqual, models.CodeNotFound)
+				So(batchErrs[0].Source.Field, ShouldEqual, "/items/1/metadata/dataset_id")
+
+				So(*batchErrs[1].Code, ShouldEqual, models.CodeConflict)
+				So(batchErrs[1].Description, ShouldEqual, apierrors.ErrorDescriptionVersionAlreadyExists)
+				So(batchErrs[1].Source.Field, ShouldEqual, "/items/2")
+
+				So(*batchErrs[2].Code, ShouldEqual, models.CodeConflict)
+				So(batchErrs[2].Description, ShouldEqual, apierrors.ErrorDescriptionVersionRepeatedInBatch)
+				So(batchErrs[2].Source.Field, ShouldEqual, "/items/3")
+			})
+		})
+	})
+}
+
+func TestAddContentItems(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with a mocked datastore", t, func() {
+		ctx := context.Background()
+
+		mockedDatastore := &storetest.StorerMock{
+			CreateContentItemsFunc: func(ctx context.Context, contentItems []*models.ContentItem) error {
+				return nil
+			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			UpdateBundleETagFunc: func(ctx context.Context, bundleID, email string) (*models.Bundle, error) {
+				return &models.Bundle{ID: bundleID, ETag: "new-etag", BundleType: models.BundleTypeManual}, nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore: store.Datastore{Backend: mockedDatastore},
+		}
+
+		contentItems := []*models.ContentItem{
+			newBatchDatasetContentItem("content-1", "dataset1"),
+			newBatchDatasetContentItem("content-2", "dataset2"),
+		}
+
+		Convey("When AddContentItems is called", func() {
+			updatedBundle, err := stateMachineBundleAPI.AddContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then the content items are created together and the bundle ETag is updated once", func() {
+				So(err, ShouldBeNil)
+				So(updatedBundle.ETag, ShouldEqual, "new-etag")
+				So(mockedDatastore.CreateContentItemsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateContentItemsCalls()[0].ContentItems, ShouldHaveLength, 2)
+				So(mockedDatastore.UpdateBundleETagCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.DeleteApprovalsCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("And an event is created for each content item and one for the bundle", func() {
+				events := mockedDatastore.CreateEventCalls()
+				So(events, ShouldHaveLength, 3)
+				So(events[0].Event.ContentItem.ID, ShouldEqual, "content-1")
+				So(events[1].Event.ContentItem.ID, ShouldEqual, "content-2")
+				So(events[2].Event.Bundle.ID, ShouldEqual, bundle1)
+			})
+		})
+
+		Convey("When AddContentItems is called and the content items cannot be created", func() {
+			mockedDatastore.CreateContentItemsFunc = func(ctx context.Context, contentItems []*models.ContentItem) error {
+				return errors.New("database error")
+			}
+			_, err := stateMachineBundleAPI.AddContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then the error is returned and nothing else is changed", func() {
+				So(err, ShouldNotBeNil)
+				So(mockedDatastore.CreateEventCalls(), ShouldBeEmpty)
+				So(mockedDatastore.UpdateBundleETagCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When AddContentItems is called and the event for the second content item cannot be created", func() {
+			mockedDatastore.CreateEventFunc = func(ctx context.Context, event *models.Event) error {
+				if event.Action == models.ActionCreate && event.ContentItem.ID == "content-2" {
+					return errors.New("database error")
+				}
+				return nil
+			}
+			mockedDatastore.DeleteContentItemsFunc = func(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+				return len(contentItemIDs), nil
+			}
+			_, err := stateMachineBundleAPI.AddContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then the error is returned and the content items are removed again", func() {
+				So(err, ShouldNotBeNil)
+				So(mockedDatastore.DeleteContentItemsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.DeleteContentItemsCalls()[0].ContentItemIDs, ShouldResemble, []string{"content-1", "content-2"})
+				So(mockedDatastore.UpdateBundleETagCalls(), ShouldBeEmpty)
+			})
+
+			Convey("And the addition of the first content item is undone in the bundle's events", func() {
+				events := mockedDatastore.CreateEventCalls()
+				So(events, ShouldHaveLength, 3)
+				So(events[2].Event.Action, ShouldEqual, models.ActionDelete)
+				So(events[2].Event.ContentItem.ID, ShouldEqual, "content-1")
+			})
+		})
+
+		Convey("When AddContentItems is called and the approvals of the bundle cannot be invalidated", func() {
+			mockedDatastore.DeleteApprovalsFunc = func(ctx context.Context, bundleID string) (int, error) {
+				return 0, errors.New("approvals error")
+			}
+			mockedDatastore.DeleteContentItemsFunc = func(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+				return len(contentItemIDs), nil
+			}
+			_, err := stateMachineBundleAPI.AddContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then the approvals are invalidated after every other change, and the error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "approvals error")
+				So(mockedDatastore.DeleteApprovalsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.UpdateBundleETagCalls(), ShouldHaveLength, 1)
+			})
+
+			Convey("And the content items are removed again and their addition is undone in the bundle's events", func() {
+				So(mockedDatastore.DeleteContentItemsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.DeleteContentItemsCalls()[0].ContentItemIDs, ShouldResemble, []string{"content-1", "content-2"})
+
+				events := mockedDatastore.CreateEventCalls()
+				So(events, ShouldHaveLength, 5)
+				So(events[3].Event.Action, ShouldEqual, models.ActionDelete)
+				So(events[3].Event.ContentItem.ID, ShouldEqual, "content-1")
+				So(events[4].Event.Action, ShouldEqual, models.ActionDelete)
+				So(events[4].Event.ContentItem.ID, ShouldEqual, "content-2")
+			})
+		})
+
+		Convey("When AddContentItems is called and the content items cannot be removed again after a failure", func() {
+			mockedDatastore.UpdateBundleETagFunc = func(ctx context.Context, bundleID, email string) (*models.Bundle, error) {
+				return nil, errors.New("etag error")
+			}
+			mockedDatastore.DeleteContentItemsFunc = func(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+				return 0, errors.New("delete error")
+			}
+			_, err := stateMachineBundleAPI.AddContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then both errors are returned and the rest of the roll back is still done", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "etag error")
+				So(err.Error(), ShouldContainSubstring, "delete error")
+				So(mockedDatastore.CreateEventCalls(), ShouldHaveLength, 4)
+			})
+
+			Convey("And the approvals of the bundle are not invalidated", func() {
+				So(mockedDatastore.DeleteApprovalsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCheckContentItemIDsBatch(t *testing.T) {
+	Convey("Given a bundle with an approved and a published content item", t, func() {
+		ctx := context.Background()
+		approved, published := models.StateApproved, models.StatePublished
+
+		mockedDatastore := &storetest.StorerMock{
+			GetContentItemByBundleIDAndContentItemIDFunc: func(ctx context.Context, bundleID, contentItemID string) (*models.ContentItem, error) {
+				switch contentItemID {
+				case "content-1":
+					return &models.ContentItem{ID: contentItemID, BundleID: bundleID, State: &approved}, nil
+				case "content-2":
+					return &models.ContentItem{ID: contentItemID, BundleID: bundleID, State: &published}, nil
+				default:
+					return nil, apierrors.ErrContentItemNotFound
+				}
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore: store.Datastore{Backend: mockedDatastore},
+		}
+
+		Convey("When CheckContentItemIDsBatch is called with the approved content item", func() {
+			contentItems, batchErrs, err := stateMachineBundleAPI.CheckContentItemIDsBatch(ctx, bundle1, []string{"content-1"})
+
+			Convey("Then the content item is returned", func() {
+				So(err, ShouldBeNil)
+				So(batchErrs, ShouldBeEmpty)
+				So(contentItems, ShouldHaveLength, 1)
+				So(contentItems[0].ID, ShouldEqual, "content-1")
+			})
+		})
+
+		Convey("When CheckContentItemIDsBatch is called with the published and a missing content item", func() {
+			_, batchErrs, err := stateMachineBundleAPI.CheckContentItemIDsBatch(ctx, bundle1, []string{"content-1", "content-2", "content-3"})
+
+			Convey("Then a problem is returned against each of them", func() {
+				So(err, ShouldBeNil)
+				So(batchErrs, ShouldHaveLength, 2)
+				So(*batchErrs[0].Code, ShouldEqual, models.CodeConflict)
+				So(batchErrs[0].Source.Field, ShouldEqual, "/ids/1")
+				So(*batchErrs[1].Code, ShouldEqual, models.CodeNotFound)
+				So(batchErrs[1].Source.Field, ShouldEqual, "/ids/2")
+			})
+		})
+	})
+}
+
+func TestRemoveContentItems(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with a mocked datastore", t, func() {
+		ctx := context.Background()
+
+		mockedDatastore := &storetest.StorerMock{
+			DeleteContentItemsFunc: func(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+				return len(contentItemIDs), nil
+			},
+			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+				return 0, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			UpdateBundleETagFunc: func(ctx context.Context, bundleID, email string) (*models.Bundle, error) {
+				return &models.Bundle{ID: bundleID, ETag: "new-etag"}, nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore: store.Datastore{Backend: mockedDatastore},
+		}
+
+		Convey("When RemoveContentItems is called", func() {
+			contentItems := []*models.ContentItem{
+				newBatchDatasetContentItem("content-1", "dataset1"),
+				newBatchDatasetContentItem("content-2", "dataset2"),
+			}
+			updatedBundle, err := stateMachineBundleAPI.RemoveContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then each content item is deleted, with an event for each and the bundle ETag updated once", func() {
+				So(err, ShouldBeNil)
+				So(updatedBundle.ETag, ShouldEqual, "new-etag")
+				So(mockedDatastore.DeleteContentItemsCalls(), ShouldHaveLength, 2)
+				So(mockedDatastore.DeleteContentItemsCalls()[0].ContentItemIDs, ShouldResemble, []string{"content-1"})
+				So(mockedDatastore.DeleteContentItemsCalls()[1].ContentItemIDs, ShouldResemble, []string{"content-2"})
+				So(mockedDatastore.UpdateBundleETagCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateEventCalls(), ShouldHaveLength, 3)
+				So(mockedDatastore.CreateEventCalls()[0].Event.Action, ShouldEqual, models.ActionDelete)
+			})
+		})
+
+		Convey("When RemoveContentItems is called and the bundle ETag cannot be updated", func() {
+			mockedDatastore.UpdateBundleETagFunc = func(ctx context.Context, bundleID, email string) (*models.Bundle, error) {
+				return nil, errors.New("etag error")
+			}
+			mockedDatastore.CreateContentItemsFunc = func(ctx context.Context, contentItems []*models.ContentItem) error {
+				return nil
+			}
+			contentItems := []*models.ContentItem{
+				newBatchDatasetContentItem("content-1", "dataset1"),
+				newBatchDatasetContentItem("content-2", "dataset2"),
+			}
+			_, err := stateMachineBundleAPI.RemoveContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then the error is returned and the content items are stored again", func() {
+				So(err, ShouldNotBeNil)
+				So(mockedDatastore.CreateContentItemsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateContentItemsCalls()[0].ContentItems, ShouldResemble, contentItems)
+			})
+
+			Convey("And the removal of each content item is undone in the bundle's events", func() {
+				events := mockedDatastore.CreateEventCalls()
+				So(events, ShouldHaveLength, 4)
+				So(events[2].Event.Action, ShouldEqual, models.ActionCreate)
+				So(events[2].Event.ContentItem.ID, ShouldEqual, "content-1")
+				So(events[3].Event.Action, ShouldEqual, models.ActionCreate)
+				So(events[3].Event.ContentItem.ID, ShouldEqual, "content-2")
+			})
+
+			Convey("And the approvals of the bundle are not invalidated", func() {
+				So(mockedDatastore.DeleteApprovalsCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When RemoveContentItems is called and the approvals of the bundle cannot be invalidated", func() {
+			mockedDatastore.DeleteApprovalsFunc = func(ctx context.Context, bundleID string) (int, error) {
+				return 0, errors.New("approvals error")
+			}
+			mockedDatastore.CreateContentItemsFunc = func(ctx context.Context, contentItems []*models.ContentItem) error {
+				return nil
+			}
+			contentItems := []*models.ContentItem{
+				newBatchDatasetContentItem("content-1", "dataset1"),
+				newBatchDatasetContentItem("content-2", "dataset2"),
+			}
+			_, err := stateMachineBundleAPI.RemoveContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then the approvals are invalidated after every other change, and the error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "approvals error")
+				So(mockedDatastore.UpdateBundleETagCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateEventCalls()[2].Event.Bundle.ID, ShouldEqual, bundle1)
+			})
+
+			Convey("And the content items are stored again and their removal is undone in the bundle's events", func() {
+				So(mockedDatastore.CreateContentItemsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateContentItemsCalls()[0].ContentItems, ShouldResemble, contentItems)
+
+				events := mockedDatastore.CreateEventCalls()
+				So(events, ShouldHaveLength, 5)
+				So(events[3].Event.Action, ShouldEqual, models.ActionCreate)
+				So(events[3].Event.ContentItem.ID, ShouldEqual, "content-1")
+				So(events[4].Event.Action, ShouldEqual, models.ActionCreate)
+				So(events[4].Event.ContentItem.ID, ShouldEqual, "content-2")
+			})
+		})
+
+		Convey("When RemoveContentItems is called for a content item that has already been removed, and the bundle ETag cannot be updated", func() {
+			mockedDatastore.DeleteContentItemsFunc = func(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+				if contentItemIDs[0] == "content-2" {
+					return 0, nil
+				}
+				return len(contentItemIDs), nil
+			}
+			mockedDatastore.UpdateBundleETagFunc = func(ctx context.Context, bundleID, email string) (*models.Bundle, error) {
+				return nil, errors.New("etag error")
+			}
+			mockedDatastore.CreateContentItemsFunc = func(ctx context.Context, contentItems []*models.ContentItem) error {
+				return nil
+			}
+			contentItems := []*models.ContentItem{
+				newBatchDatasetContentItem("content-1", "dataset1"),
+				newBatchDatasetContentItem("content-2", "dataset2"),
+			}
+			_, err := stateMachineBundleAPI.RemoveContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then only the content item that was deleted is stored again", func() {
+				So(err, ShouldNotBeNil)
+				So(mockedDatastore.CreateContentItemsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateContentItemsCalls()[0].ContentItems, ShouldResemble, contentItems[:1])
+			})
+
+			Convey("And only its removal is recorded and undone in the bundle's events", func() {
+				events := mockedDatastore.CreateEventCalls()
+				So(events, ShouldHaveLength, 2)
+				So(events[0].Event.Action, ShouldEqual, models.ActionDelete)
+				So(events[0].Event.ContentItem.ID, ShouldEqual, "content-1")
+				So(events[1].Event.Action, ShouldEqual, models.ActionCreate)
+				So(events[1].Event.ContentItem.ID, ShouldEqual, "content-1")
+			})
+		})
+
+		Convey("When RemoveContentItems is called and the second content item cannot be deleted", func() {
+			mockedDatastore.DeleteContentItemsFunc = func(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+				if contentItemIDs[0] == "content-2" {
+					return 0, errors.New("delete error")
+				}
+				return len(contentItemIDs), nil
+			}
+			mockedDatastore.CreateContentItemsFunc = func(ctx context.Context, contentItems []*models.ContentItem) error {
+				return nil
+			}
+			contentItems := []*models.ContentItem{
+				newBatchDatasetContentItem("content-1", "dataset1"),
+				newBatchDatasetContentItem("content-2", "dataset2"),
+			}
+			_, err := stateMachineBundleAPI.RemoveContentItems(ctx, bundle1, contentItems, authEntityData)
+
+			Convey("Then the error is returned, the first content item is stored again and nothing else is changed", func() {
+				So(err, ShouldNotBeNil)
+				So(mockedDatastore.CreateContentItemsCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateContentItemsCalls()[0].ContentItems, ShouldResemble, contentItems[:1])
+				So(mockedDatastore.CreateEventCalls(), ShouldBeEmpty)
+				So(mockedDatastore.UpdateBundleETagCalls(), ShouldBeEmpty)
+				So(mockedDatastore.DeleteApprovalsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
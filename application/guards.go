@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+)
+
+// Names of the guards registered by DefaultRegistry
+const (
+	GuardNameHasContentItems      = "has_content_items"
+	GuardNameContentItemsApproved = "content_items_approved"
+)
+
+// PreconditionError is returned by a guard when a bundle does not meet a precondition of a transition. Err is returned
+// to a caller attempting the transition, and Description describes the unmet precondition for listing transitions.
+type PreconditionError struct {
+	Err         error
+	Description string
+}
+
+func (e *PreconditionError) Error() string {
+	return e.Description
+}
+
+func (e *PreconditionError) Unwrap() error {
+	return e.Err
+}
+
+// HasContentItems refuses a transition if the bundle has no content items
+func HasContentItems(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) error {
+	contents, err := smBundle.Datastore.GetBundleContentsForBundle(ctx, bundle.ID)
+	if err != nil {
+		return err
+	}
+
+	if contents == nil || len(*contents) == 0 {
+		return &PreconditionError{Err: apierrors.ErrBundleHasNoContentItems, Description: "bundle has no content items"}
+	}
+
+	return nil
+}
+
+// ContentItemsApproved refuses a transition if any of the bundle's content items are neither approved nor published
+func ContentItemsApproved(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) error {
+	contents, err := smBundle.Datastore.GetBundleContentsForBundle(ctx, bundle.ID)
+	if err != nil {
+		return err
+	}
+
+	notApproved := 0
+	for index := range *contents {
+		state := (*contents)[index].State
+		if state == nil || (*state != models.StateApproved && *state != models.StatePublished) {
+			notApproved++
+		}
+	}
+
+	if notApproved > 0 {
+		return &PreconditionError{Err: apierrors.ErrContentItemsNotApproved, Description: fmt.Sprintf("%d content items not approved", notApproved)}
+	}
+
+	return nil
+}
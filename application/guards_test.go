@@ -0,0 +1,73 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGuards(t *testing.T) {
+	Convey("Given a bundle and its content items", t, func() {
+		ctx := context.Background()
+		bundle := &models.Bundle{ID: bundle123, State: models.BundleStateApproved}
+		contentItems := []models.ContentItem{
+			{ID: "content-item-1", BundleID: bundle123, State: new(models.StateApproved)},
+			{ID: "content-item-2", BundleID: bundle123, State: new(models.StatePublished)},
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				return &contentItems, nil
+			},
+		}
+		smBundle := application.StateMachineBundleAPI{Datastore: store.Datastore{Backend: mockedDatastore}}
+
+		Convey("When every content item is approved or published", func() {
+			Convey("Then both guards allow the transition", func() {
+				So(application.HasContentItems(ctx, smBundle, bundle, &models.AuthEntityData{}), ShouldBeNil)
+				So(application.ContentItemsApproved(ctx, smBundle, bundle, &models.AuthEntityData{}), ShouldBeNil)
+			})
+		})
+
+		Convey("When some content items are not approved", func() {
+			contentItems = append(contentItems, models.ContentItem{ID: "content-item-3"}, models.ContentItem{ID: "content-item-4"})
+			err := application.ContentItemsApproved(ctx, smBundle, bundle, &models.AuthEntityData{})
+
+			Convey("Then ContentItemsApproved describes how many are not approved", func() {
+				So(err.Error(), ShouldEqual, "2 content items not approved")
+				So(errors.Is(err, apierrors.ErrContentItemsNotApproved), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the bundle has no content items", func() {
+			contentItems = []models.ContentItem{}
+			err := application.HasContentItems(ctx, smBundle, bundle, &models.AuthEntityData{})
+
+			Convey("Then HasContentItems refuses the transition", func() {
+				So(errors.Is(err, apierrors.ErrBundleHasNoContentItems), ShouldBeTrue)
+			})
+
+			Convey("And a transition guarded by it returns the underlying error to the caller", func() {
+				stateMachine := application.NewStateMachine(ctx, nil, []application.Transition{{
+					Label:               "APPROVED",
+					TargetState:         application.Approved,
+					AllowedSourceStates: []string{"IN_REVIEW"},
+					Guards:              []application.Guard{application.HasContentItems},
+				}}, smBundle.Datastore, nil)
+				smBundle.StateMachine = stateMachine
+
+				updatedBundle, err := stateMachine.Transition(ctx, &smBundle, &models.Bundle{ID: bundle123, State: models.BundleStateInReview}, models.BundleStateApproved, models.AuthEntityData{})
+				So(updatedBundle, ShouldBeNil)
+				So(err, ShouldEqual, apierrors.ErrBundleHasNoContentItems)
+			})
+		})
+	})
+}
@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/content"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	permissionsAPIModels "github.com/ONSdigital/dp-permissions-api/models"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// PublishPreflight runs the checks made when a bundle is published against dataset API and permissions API, and
+// reports any problems that would stop each content item being published. Nothing is changed.
+func (s *StateMachineBundleAPI) PublishPreflight(ctx context.Context, bundleID string, authEntityData *models.AuthEntityData) (*models.PublishPreflightReport, error) {
+	bundle, err := s.Datastore.GetBundle(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.PublishPreflightReport{
+		BundleID:     bundle.ID,
+		State:        bundle.State,
+		Ready:        true,
+		ContentItems: []models.PublishPreflightContentItem{},
+	}
+
+	if !s.StateMachine.CanTransition(bundle.State, models.BundleStatePublished) {
+		report.AddError(models.CodeConflict, apierrors.ErrorDescriptionPreflightBundleNotPublishable, "/state")
+	}
+
+	contents, err := s.Datastore.GetBundleContentsForBundle(ctx, bundle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	contents = unpublishedContentItems(contents)
+	if len(*contents) == 0 {
+		report.AddError(models.CodeNotFound, apierrors.ErrorDescriptionPreflightNoContentItems, "/contents")
+		return report, nil
+	}
+
+	results := make([]models.PublishPreflightContentItem, len(*contents))
+	forEachConcurrently(len(*contents), s.PublishMaxConcurrency, func(index int) {
+		results[index] = s.preflightContentItem(ctx, &(*contents)[index], authEntityData)
+	})
+
+	s.preflightPreviewTeamPolicies(ctx, bundle, contents, authEntityData, report, results)
+
+	for index := range results {
+		report.AddContentItem(results[index])
+	}
+
+	return report, nil
+}
+
+// preflightContentItem checks that a content item's dataset version exists in dataset API, is approved, and has a
+// preview link that can be resolved
+func (s *StateMachineBundleAPI) preflightContentItem(ctx context.Context, contentItem *models.ContentItem, authEntityData *models.AuthEntityData) models.PublishPreflightContentItem {
+	result := models.NewPublishPreflightContentItem(contentItem)
+	logData := log.Data{"bundle_id": contentItem.BundleID, "content_item_id": contentItem.ID, "content_type": contentItem.ContentType}
+
+	provider := s.PublishDatasetProvider()
+
+	state, err := provider.GetState(ctx, authEntityData.Headers, contentItem)
+	if err != nil {
+		var notFoundErr *content.NotFoundError
+		if errors.As(err, &notFoundErr) {
+			result.AddError(models.CodeNotFound, apierrors.ErrorDescriptionNotFound, notFoundErr.Field)
+		} else {
+			log.Error(ctx, "publish preflight: failed to get state of content", err, logData)
+			result.AddError(models.CodeInternalError, apierrors.ErrorDescriptionPreflightVersionCheckFailed, "")
+		}
+		return result
+	}
+
+	if state != models.StateApproved {
+		result.AddError(models.CodeConflict, apierrors.ErrorDescriptionPreflightVersionNotApproved, "/state")
+	}
+
+	// refresh a copy so that nothing is changed on the content item itself
+	refreshed := *contentItem
+	if err := provider.BuildLinks(ctx, authEntityData.Headers, &refreshed); err != nil || refreshed.Links.Preview == "" {
+		result.AddError(models.CodeConflict, apierrors.ErrorDescriptionPreflightVersionLinksInvalid, "/links/preview")
+	}
+
+	return result
+}
+
+// preflightPreviewTeamPolicies checks that the policy for each of the bundle's preview teams exists and gives access to
+// every content item. Missing policies are reported against the bundle, and missing access against the content item.
+func (s *StateMachineBundleAPI) preflightPreviewTeamPolicies(ctx context.Context, bundle *models.Bundle, contents *[]models.ContentItem, authEntityData *models.AuthEntityData, report *models.PublishPreflightReport, results []models.PublishPreflightContentItem) {
+	if bundle.PreviewTeams == nil {
+		return
+	}
+
+	for teamIndex, team := range *bundle.PreviewTeams {
+		field := fmt.Sprintf("/preview_teams/%d/id", teamIndex)
+
+		policy, err := s.getPreviewTeamPolicy(ctx, authEntityData.Headers.AccessToken, team.ID)
+		if err != nil {
+			log.Error(ctx, "publish preflight: failed to get preview team policy", err, log.Data{"bundle_id": bundle.ID, "preview_team_id": team.ID})
+			report.AddError(models.CodeInternalError, apierrors.ErrorDescriptionPreflightPolicyCheckFailed, field)
+			continue
+		}
+
+		if policy == nil {
+			report.AddError(models.CodeNotFound, apierrors.ErrorDescriptionPreflightPolicyNotFound, field)
+			continue
+		}
+
+		for index := range *contents {
+			contentItem := &(*contents)[index]
+			datasetID := contentItem.Metadata.DatasetID
+			if !slices.Contains(policy.Condition.Values, datasetID) || !slices.Contains(policy.Condition.Values, datasetID+"/"+contentItem.Metadata.EditionID) {
+				results[index].AddError(models.CodeConflict, apierrors.ErrorDescriptionPreflightPolicyMissingContent, field)
+			}
+		}
+	}
+}
+
+// getPreviewTeamPolicy returns the policy for a preview team, or nil if it does not exist
+func (s *StateMachineBundleAPI) getPreviewTeamPolicy(ctx context.Context, authToken, teamID string) (*permissionsAPIModels.Policy, error) {
+	policy, err := s.PermissionsAPIClient.GetPolicy(ctx, teamID, permissionsAPISDK.Headers{Authorization: authToken})
+	if err != nil {
+		// as in CheckPolicyExists, the SDK only reports a missing policy through the error message
+		if strings.Contains(err.Error(), strconv.Itoa(http.StatusNotFound)) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,172 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPIMocks "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPIModels "github.com/ONSdigital/dp-permissions-api/models"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+	permissionsAPISDKMock "github.com/ONSdigital/dp-permissions-api/sdk/mocks"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPublishPreflight(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with an approved bundle that has a preview team", t, func() {
+		ctx := context.Background()
+
+		bundle := &models.Bundle{
+			ID:           bundle123,
+			State:        models.BundleStateApproved,
+			PreviewTeams: &[]models.PreviewTeam{{ID: "team-1"}},
+		}
+
+		mockContentItems := createMockVersionsAndContentItems(models.BundleStateApproved)
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				contentItems := make([]models.ContentItem, len(mockContentItems))
+				for index := range contentItems {
+					contentItems[index] = *mockContentItems[index]
+				}
+				return &contentItems, nil
+			},
+		}
+
+		versionStates := map[string]string{
+			"dataset-id-1": datasetAPIModels.ApprovedState,
+			"dataset-id-2": datasetAPIModels.ApprovedState,
+		}
+		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				state, ok := versionStates[datasetID]
+				if !ok {
+					return datasetAPIModels.Version{}, errors.New("version not found")
+				}
+				return datasetAPIModels.Version{
+					State: state,
+					Links: &datasetAPIModels.VersionLinks{WebPage: &datasetAPIModels.LinkObject{HRef: "http://localhost/datasets/" + datasetID}},
+				}, nil
+			},
+		}
+
+		policyValues := []string{"dataset-id-1", "dataset-id-1/edition-id-1", "dataset-id-2", "dataset-id-2/edition-id-2"}
+		mockPermissionsAPIClient := &permissionsAPISDKMock.ClienterMock{
+			GetPolicyFunc: func(ctx context.Context, id string, headers permissionsAPISDK.Headers) (*permissionsAPIModels.Policy, error) {
+				return &permissionsAPIModels.Policy{ID: id, Condition: permissionsAPIModels.Condition{Values: policyValues}}, nil
+			},
+		}
+
+		transitions := []application.Transition{
+			{
+				Label:               "PUBLISHED",
+				TargetState:         application.Published,
+				AllowedSourceStates: []string{"APPROVED", "PUBLISH_FAILED"},
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore:            store.Datastore{Backend: mockedDatastore},
+			StateMachine:         application.NewStateMachine(ctx, []application.State{application.Published}, transitions, store.Datastore{Backend: mockedDatastore}, nil),
+			DatasetAPIClient:     mockDatasetAPIClient,
+			PermissionsAPIClient: mockPermissionsAPIClient,
+		}
+
+		Convey("When every check passes", func() {
+			report, err := stateMachineBundleAPI.PublishPreflight(ctx, bundle123, authEntityData)
+
+			Convey("Then the bundle is reported as ready", func() {
+				So(err, ShouldBeNil)
+				So(report.Ready, ShouldBeTrue)
+				So(report.Errors, ShouldBeEmpty)
+				So(report.ContentItems, ShouldHaveLength, 2)
+				So(report.ContentItems[0].Ready, ShouldBeTrue)
+				So(report.ContentItems[1].Ready, ShouldBeTrue)
+			})
+		})
+
+		Convey("When the bundle is not in a state that can be published", func() {
+			bundle.State = models.BundleStateDraft
+
+			report, err := stateMachineBundleAPI.PublishPreflight(ctx, bundle123, authEntityData)
+
+			Convey("Then the problem is reported against the bundle", func() {
+				So(err, ShouldBeNil)
+				So(report.Ready, ShouldBeFalse)
+				So(report.Errors, ShouldHaveLength, 1)
+				So(report.Errors[0].Description, ShouldEqual, apierrors.ErrorDescriptionPreflightBundleNotPublishable)
+				So(report.Errors[0].Source.Field, ShouldEqual, "/state")
+			})
+		})
+
+		Convey("When a content item's version does not exist", func() {
+			delete(versionStates, "dataset-id-2")
+
+			report, err := stateMachineBundleAPI.PublishPreflight(ctx, bundle123, authEntityData)
+
+			Convey("Then the problem is reported against that content item only", func() {
+				So(err, ShouldBeNil)
+				So(report.Ready, ShouldBeFalse)
+				So(report.ContentItems[0].Ready, ShouldBeTrue)
+				So(report.ContentItems[1].Ready, ShouldBeFalse)
+				So(*report.ContentItems[1].Errors[0].Code, ShouldEqual, models.CodeNotFound)
+				So(report.ContentItems[1].Errors[0].Source.Field, ShouldEqual, "/metadata/version_id")
+			})
+		})
+
+		Convey("When the preview team's policy does not give access to a content item", func() {
+			policyValues = policyValues[:2]
+
+			report, err := stateMachineBundleAPI.PublishPreflight(ctx, bundle123, authEntityData)
+
+			Convey("Then the problem is reported against that content item", func() {
+				So(err, ShouldBeNil)
+				So(report.Ready, ShouldBeFalse)
+				So(report.ContentItems[0].Ready, ShouldBeTrue)
+				So(report.ContentItems[1].Errors, ShouldHaveLength, 1)
+				So(report.ContentItems[1].Errors[0].Description, ShouldEqual, apierrors.ErrorDescriptionPreflightPolicyMissingContent)
+				So(report.ContentItems[1].Errors[0].Source.Field, ShouldEqual, "/preview_teams/0/id")
+			})
+		})
+
+		Convey("When the preview team's policy does not exist", func() {
+			mockPermissionsAPIClient.GetPolicyFunc = func(ctx context.Context, id string, headers permissionsAPISDK.Headers) (*permissionsAPIModels.Policy, error) {
+				return nil, errors.New("unexpected status code 404")
+			}
+
+			report, err := stateMachineBundleAPI.PublishPreflight(ctx, bundle123, authEntityData)
+
+			Convey("Then the problem is reported against the bundle", func() {
+				So(err, ShouldBeNil)
+				So(report.Ready, ShouldBeFalse)
+				So(report.Errors, ShouldHaveLength, 1)
+				So(report.Errors[0].Description, ShouldEqual, apierrors.ErrorDescriptionPreflightPolicyNotFound)
+			})
+		})
+
+		Convey("When the bundle does not exist", func() {
+			mockedDatastore.GetBundleFunc = func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return nil, apierrors.ErrBundleNotFound
+			}
+
+			report, err := stateMachineBundleAPI.PublishPreflight(ctx, bundle123, authEntityData)
+
+			Convey("Then the error is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrBundleNotFound)
+				So(report, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,169 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// ListPublishRuns returns the publish runs for a bundle, most recent first
+func (s *StateMachineBundleAPI) ListPublishRuns(ctx context.Context, bundleID string, offset, limit int) ([]*models.PublishRun, int, error) {
+	return s.Datastore.ListPublishRuns(ctx, bundleID, offset, limit)
+}
+
+// ClaimStalePublishRun claims the oldest in progress publish run that has not been updated within staleTimeout.
+// Returns nil if there is no stale run.
+func (s *StateMachineBundleAPI) ClaimStalePublishRun(ctx context.Context, staleTimeout time.Duration) (*models.PublishRun, error) {
+	now := time.Now()
+	return s.Datastore.ClaimStalePublishRun(ctx, now.Add(-staleTimeout), now)
+}
+
+// ResumePublishRun completes an interrupted publish run. Content items that were published before the run was
+// interrupted are reconciled against dataset API rather than published again, and the remaining items are published.
+func (s *StateMachineBundleAPI) ResumePublishRun(ctx context.Context, publishRun *models.PublishRun, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+	logData := log.Data{"bundle_id": publishRun.BundleID, "publish_run_id": publishRun.ID}
+
+	bundle, err := s.Datastore.GetBundle(ctx, publishRun.BundleID)
+	if err != nil {
+		log.Error(ctx, "failed to get bundle for publish run", err, logData)
+		if err == apierrors.ErrBundleNotFound {
+			s.completePublishRun(ctx, publishRun.ID, models.PublishRunStateFailed, logData)
+		}
+		return nil, err
+	}
+
+	logData["bundle_state"] = bundle.State
+
+	switch bundle.State {
+	case models.BundleStatePublished:
+		log.Info(ctx, "bundle already published, completing publish run", logData)
+		s.completePublishRun(ctx, publishRun.ID, publishRunStateFromItems(publishRun), logData)
+		return bundle, nil
+	case models.BundleStateApproved, models.BundleStatePublishFailed:
+	default:
+		log.Warn(ctx, "bundle is no longer waiting to be published, abandoning publish run", logData)
+		s.completePublishRun(ctx, publishRun.ID, models.PublishRunStateFailed, logData)
+		return nil, apierrors.ErrInvalidTransition
+	}
+
+	contents, err := s.Datastore.GetBundleContentsForBundle(ctx, bundle.ID)
+	if err != nil {
+		log.Error(ctx, "failed to get bundle contents for publish run", err, logData)
+		return nil, err
+	}
+
+	contents = unpublishedContentItems(contents)
+
+	remaining := make([]models.ContentItem, 0, len(*contents))
+	for index := range *contents {
+		contentItem := &(*contents)[index]
+
+		published, err := s.reconcilePublishRunItem(ctx, publishRun, contentItem, authEntityData)
+		if err != nil {
+			log.Warn(ctx, "failed to reconcile publish run item, it will be published again", log.Data{"bundle_id": bundle.ID, "publish_run_id": publishRun.ID, "content_item_id": contentItem.ID, "error": err.Error()})
+		}
+
+		if !published {
+			remaining = append(remaining, *contentItem)
+		}
+	}
+
+	logData["remaining_content_items"] = len(remaining)
+	log.Info(ctx, "resuming publish run", logData)
+
+	now := time.Now()
+	bundle.UpdatedAt = &now
+	bundle.LastUpdatedBy = &models.User{Email: authEntityData.GetUserEmail()}
+
+	lastTransition, err := newStateTransition(bundle.State, models.BundleStatePublished, "", authEntityData.GetUserID(), now)
+	if err != nil {
+		return nil, err
+	}
+	bundle.LastTransition = lastTransition
+
+	return publishBundleRun(ctx, *s, bundle, publishRun, &remaining, authEntityData)
+}
+
+// reconcilePublishRunItem returns whether a content item has already been published. An item the run has not recorded
+// as published is checked in dataset API, and if its version was published before the run was
+// interrupted then the content item and the publish run are brought up to date.
+func (s *StateMachineBundleAPI) reconcilePublishRunItem(ctx context.Context, publishRun *models.PublishRun, contentItem *models.ContentItem, authEntityData *models.AuthEntityData) (bool, error) {
+	if item := publishRun.GetItem(contentItem.ID); item != nil && item.State == models.PublishRunItemStatePublished {
+		return true, nil
+	}
+
+	state, err := s.PublishDatasetProvider().GetState(ctx, authEntityData.Headers, contentItem)
+	if err != nil {
+		return false, err
+	}
+
+	if state != models.StatePublished {
+		return false, nil
+	}
+
+	if err := UpdateContentItemCreateEvent(ctx, *s, authEntityData, contentItem, models.BundleStatePublished.String()); err != nil {
+		return false, err
+	}
+
+	recordPublishRunItem(ctx, *s, publishRun.ID, contentItem, models.PublishRunItemStatePublished, nil)
+
+	return true, nil
+}
+
+// holdPublishRun marks a publish run as still active every PublishRunHeartbeatInterval while its bundle is being
+// published, so that the run is not treated as stale and resumed by another instance. The returned function stops
+// renewing the run and waits for any renewal in progress to finish. It is safe to call more than once.
+func (s *StateMachineBundleAPI) holdPublishRun(ctx context.Context, publishRunID string, logData log.Data) (stop func()) {
+	if s.PublishRunHeartbeatInterval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(s.PublishRunHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.Datastore.RenewPublishRun(ctx, publishRunID); err != nil {
+					log.Error(ctx, "failed to renew publish run being published", err, logData)
+				}
+			}
+		}
+	}()
+
+	var stopOnce sync.Once
+	return func() {
+		stopOnce.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+func (s *StateMachineBundleAPI) completePublishRun(ctx context.Context, publishRunID string, state models.PublishRunState, logData log.Data) {
+	if err := s.Datastore.CompletePublishRun(ctx, publishRunID, state); err != nil {
+		log.Error(ctx, "failed to complete publish run", err, logData)
+	}
+}
+
+// publishRunStateFromItems returns COMPLETED if every item in the run was published, otherwise FAILED
+func publishRunStateFromItems(publishRun *models.PublishRun) models.PublishRunState {
+	for i := range publishRun.Items {
+		if publishRun.Items[i].State != models.PublishRunItemStatePublished {
+			return models.PublishRunStateFailed
+		}
+	}
+	return models.PublishRunStateCompleted
+}
@@ -0,0 +1,215 @@
+package application_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/slack"
+	slackMock "github.com/ONSdigital/dis-bundle-api/slack/mocks"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPIMocks "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClaimStalePublishRun(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with a mocked datastore", t, func() {
+		ctx := context.Background()
+
+		expectedPublishRun := &models.PublishRun{ID: "run-1", BundleID: bundle123}
+
+		mockedDatastore := &storetest.StorerMock{
+			ClaimStalePublishRunFunc: func(ctx context.Context, staleBefore, now time.Time) (*models.PublishRun, error) {
+				return expectedPublishRun, nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore: store.Datastore{Backend: mockedDatastore},
+		}
+
+		Convey("When ClaimStalePublishRun is called", func() {
+			before := time.Now()
+			result, err := stateMachineBundleAPI.ClaimStalePublishRun(ctx, time.Minute)
+
+			Convey("Then runs not updated within the stale timeout are claimed as of the current time", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldEqual, expectedPublishRun)
+				So(mockedDatastore.ClaimStalePublishRunCalls(), ShouldHaveLength, 1)
+				call := mockedDatastore.ClaimStalePublishRunCalls()[0]
+				So(call.Now, ShouldHappenOnOrAfter, before)
+				So(call.Now.Sub(call.StaleBefore), ShouldEqual, time.Minute)
+			})
+		})
+	})
+}
+
+func TestResumePublishRun(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with mocked dependencies", t, func() {
+		ctx := context.Background()
+		serviceAuthEntityData := models.CreateServiceAuthEntityData("service-token")
+
+		bundle := &models.Bundle{ID: bundle123, State: models.BundleStateApproved}
+		mockContentItems := createMockVersionsAndContentItems(models.BundleStateApproved)
+
+		contentItems := make([]models.ContentItem, len(mockContentItems))
+		for index := range contentItems {
+			contentItems[index] = *mockContentItems[index]
+		}
+
+		publishRun, err := models.NewPublishRun(bundle123, contentItems, userEmail)
+		So(err, ShouldBeNil)
+		publishRun.Items[0].State = models.PublishRunItemStatePublished
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				contents := make([]models.ContentItem, len(contentItems))
+				copy(contents, contentItems)
+				return &contents, nil
+			},
+			UpdateBundleFunc: func(ctx context.Context, bundleID string, bundle *models.Bundle) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			UpdateContentItemStateFunc: func(ctx context.Context, contentItemID, state string) error {
+				return nil
+			},
+			UpdatePublishRunItemFunc: func(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error {
+				return nil
+			},
+			CompletePublishRunFunc: func(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+				return nil
+			},
+		}
+
+		versionState := datasetAPIModels.ApprovedState
+		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				return datasetAPIModels.Version{State: versionState}, nil
+			},
+			PutVersionStateFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
+				return nil
+			},
+		}
+
+		mockSlackClient := &slackMock.ClienterMock{
+			SendPublishLogFunc: func(ctx context.Context, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+			UpdatePublishLogFunc: func(ctx context.Context, ref *slack.MessageRef, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore:             store.Datastore{Backend: mockedDatastore},
+			DataBundleSlackClient: mockSlackClient,
+			DatasetAPIClient:      mockDatasetAPIClient,
+		}
+
+		Convey("When a run is resumed that was interrupted before its remaining item was published", func() {
+			result, err := stateMachineBundleAPI.ResumePublishRun(ctx, publishRun, serviceAuthEntityData)
+
+			Convey("Then only the remaining item is published", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStatePublished)
+				So(mockDatasetAPIClient.GetVersionCalls(), ShouldHaveLength, 1)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 1)
+				So(mockDatasetAPIClient.PutVersionStateCalls()[0].DatasetID, ShouldEqual, "dataset-id-2")
+			})
+
+			Convey("And the run is completed", func() {
+				So(mockedDatastore.UpdatePublishRunItemCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.UpdatePublishRunItemCalls()[0].ContentItemID, ShouldEqual, "another-valid-content-item")
+				So(mockedDatastore.CompletePublishRunCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CompletePublishRunCalls()[0].State, ShouldEqual, models.PublishRunStateCompleted)
+			})
+		})
+
+		Convey("When a run is resumed that was interrupted after the remaining item was published in dataset API", func() {
+			versionState = datasetAPIModels.PublishedState
+
+			result, err := stateMachineBundleAPI.ResumePublishRun(ctx, publishRun, serviceAuthEntityData)
+
+			Convey("Then the item is reconciled rather than published again", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStatePublished)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 0)
+				So(mockedDatastore.UpdateContentItemStateCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.UpdateContentItemStateCalls()[0].ContentItemID, ShouldEqual, "another-valid-content-item")
+				So(mockedDatastore.UpdatePublishRunItemCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.UpdatePublishRunItemCalls()[0].State, ShouldEqual, models.PublishRunItemStatePublished)
+			})
+		})
+
+		Convey("When a run is resumed and publishing the remaining item takes longer than the heartbeat interval", func() {
+			var renewals atomic.Int32
+			mockedDatastore.RenewPublishRunFunc = func(ctx context.Context, publishRunID string) error {
+				renewals.Add(1)
+				return nil
+			}
+			mockDatasetAPIClient.PutVersionStateFunc = func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
+				time.Sleep(50 * time.Millisecond)
+				return nil
+			}
+			stateMachineBundleAPI.PublishRunHeartbeatInterval = 5 * time.Millisecond
+
+			_, err := stateMachineBundleAPI.ResumePublishRun(ctx, publishRun, serviceAuthEntityData)
+			So(err, ShouldBeNil)
+			renewalsWhenPublished := renewals.Load()
+
+			Convey("Then the run is renewed while it is being published, so that it is not treated as stale", func() {
+				So(renewalsWhenPublished, ShouldBeGreaterThan, 0)
+				for _, call := range mockedDatastore.RenewPublishRunCalls() {
+					So(call.PublishRunID, ShouldEqual, publishRun.ID)
+				}
+			})
+
+			Convey("And the run is no longer renewed once it has been published", func() {
+				time.Sleep(20 * time.Millisecond)
+				So(renewals.Load(), ShouldEqual, renewalsWhenPublished)
+			})
+		})
+
+		Convey("When a run is resumed for a bundle that has already been published", func() {
+			bundle.State = models.BundleStatePublished
+
+			result, err := stateMachineBundleAPI.ResumePublishRun(ctx, publishRun, serviceAuthEntityData)
+
+			Convey("Then nothing is published and the run is completed according to its recorded items", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldEqual, bundle)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 0)
+				So(mockedDatastore.CompletePublishRunCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CompletePublishRunCalls()[0].State, ShouldEqual, models.PublishRunStateFailed)
+			})
+		})
+
+		Convey("When a run is resumed for a bundle that is no longer approved", func() {
+			bundle.State = models.BundleStateDraft
+
+			result, err := stateMachineBundleAPI.ResumePublishRun(ctx, publishRun, serviceAuthEntityData)
+
+			Convey("Then the run is abandoned", func() {
+				So(err, ShouldEqual, apierrors.ErrInvalidTransition)
+				So(result, ShouldBeNil)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 0)
+				So(mockedDatastore.CompletePublishRunCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CompletePublishRunCalls()[0].State, ShouldEqual, models.PublishRunStateFailed)
+			})
+		})
+	})
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+)
+
+// GetReleaseCalendar returns the release slots from from to to, along with the bundles scheduled to publish between
+// them. Bundles that are not scheduled for a release slot are listed separately.
+func (s *StateMachineBundleAPI) GetReleaseCalendar(ctx context.Context, from, to time.Time) (*models.ReleaseCalendar, error) {
+	bundles, err := s.Datastore.ListBundlesScheduledBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseCalendar := models.NewReleaseCalendar(from, to, s.ReleaseCalendar.Location().String(), s.ReleaseCalendar.BlackoutDates(from, to), s.ReleaseCalendar.Slots(from, to))
+	for _, bundle := range bundles {
+		releaseCalendar.AddBundle(bundle)
+	}
+
+	return releaseCalendar, nil
+}
+
+// checkReleaseCalendar returns an error if the release calendar is enforced and a scheduled bundle is not scheduled to
+// publish in one of its release slots
+func (s *StateMachineBundleAPI) checkReleaseCalendar(bundle *models.Bundle) error {
+	if s.ReleaseCalendar == nil || !s.ReleaseCalendar.Enforced() {
+		return nil
+	}
+
+	if bundle.BundleType != models.BundleTypeScheduled || bundle.ScheduledAt == nil {
+		return nil
+	}
+
+	return s.ReleaseCalendar.Check(*bundle.ScheduledAt)
+}
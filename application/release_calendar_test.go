@@ -0,0 +1,113 @@
+package application_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/calendar"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReleaseCalendar(t *testing.T) {
+	Convey("Given an enforced release calendar with weekday slots at 07:00 and 09:30 UK time", t, func() {
+		ctx := context.Background()
+
+		releaseCalendar, err := calendar.New(calendar.Config{
+			Enforced:      true,
+			TimeZone:      "Europe/London",
+			Slots:         []string{"07:00", "09:30"},
+			Weekdays:      []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			BlackoutDates: []string{"2026-03-04"},
+		})
+		So(err, ShouldBeNil)
+
+		inSlot := time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)
+		notInSlot := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+		mockedDatastore := &storetest.StorerMock{
+			CheckBundleExistsByTitleFunc: func(ctx context.Context, title string) (bool, error) {
+				return false, nil
+			},
+			ListBundlesScheduledBetweenFunc: func(ctx context.Context, from, to time.Time) ([]*models.Bundle, error) {
+				return []*models.Bundle{
+					{ID: "bundle-1", Title: "In a slot", State: models.BundleStateApproved, BundleType: models.BundleTypeScheduled, ScheduledAt: &inSlot},
+					{ID: "bundle-2", Title: "Not in a slot", State: models.BundleStateDraft, BundleType: models.BundleTypeScheduled, ScheduledAt: &notInSlot},
+				}, nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore:       store.Datastore{Backend: mockedDatastore},
+			ReleaseCalendar: releaseCalendar,
+		}
+		authEntityData := &models.AuthEntityData{EntityData: &permissionsAPISDK.EntityData{UserID: userEmail}}
+
+		Convey("When the release calendar for a week is requested", func() {
+			from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+			result, err := stateMachineBundleAPI.GetReleaseCalendar(ctx, from, from.AddDate(0, 0, 7))
+
+			Convey("Then each bundle is listed in the slot it is scheduled for, or as unslotted", func() {
+				So(err, ShouldBeNil)
+				So(result.TimeZone, ShouldEqual, "Europe/London")
+				So(result.BlackoutDates, ShouldResemble, []string{"2026-03-04"})
+				So(result.Slots, ShouldHaveLength, 8)
+				So(result.Slots[0].Bundles, ShouldHaveLength, 1)
+				So(result.Slots[0].Bundles[0].ID, ShouldEqual, "bundle-1")
+				So(result.UnslottedBundles, ShouldHaveLength, 1)
+				So(result.UnslottedBundles[0].ID, ShouldEqual, "bundle-2")
+			})
+		})
+
+		Convey("When a bundle is created that is not scheduled for a release slot", func() {
+			bundle := &models.Bundle{ID: "bundle-3", Title: "New bundle", BundleType: models.BundleTypeScheduled, ScheduledAt: &notInSlot}
+			status, _, modelErr, err := stateMachineBundleAPI.CreateBundle(ctx, bundle, authEntityData)
+
+			Convey("Then it is rejected as a bad request and not stored", func() {
+				So(err, ShouldEqual, apierrors.ErrScheduledAtNotReleaseSlot)
+				So(status, ShouldEqual, http.StatusBadRequest)
+				So(modelErr.Source.Field, ShouldEqual, "/scheduled_at")
+				So(mockedDatastore.CreateBundleCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a scheduled bundle is rescheduled to a blackout date", func() {
+			mockedDatastore.GetBundleFunc = func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return &models.Bundle{ID: bundleID, BundleType: models.BundleTypeScheduled, ScheduledAt: &inSlot, State: models.BundleStateDraft, ETag: "etag1"}, nil
+			}
+			_, err := stateMachineBundleAPI.RescheduleBundle(ctx, bundle123, "etag1", time.Date(2026, 3, 4, 7, 0, 0, 0, time.UTC), authEntityData)
+
+			Convey("Then ErrScheduledAtInBlackout is returned and the bundle is not updated", func() {
+				So(err, ShouldEqual, apierrors.ErrScheduledAtInBlackout)
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a manual bundle is created while the calendar is enforced", func() {
+			bundle := &models.Bundle{ID: "bundle-4", Title: "Manual bundle", BundleType: models.BundleTypeManual}
+			mockedDatastore.CreateBundleFunc = func(ctx context.Context, bundle *models.Bundle) error {
+				return nil
+			}
+			mockedDatastore.GetBundleFunc = func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return bundle, nil
+			}
+			mockedDatastore.CreateEventFunc = func(ctx context.Context, event *models.Event) error {
+				return nil
+			}
+			status, _, _, err := stateMachineBundleAPI.CreateBundle(ctx, bundle, authEntityData)
+
+			Convey("Then the calendar is not checked", func() {
+				So(err, ShouldBeNil)
+				So(status, ShouldEqual, http.StatusCreated)
+			})
+		})
+	})
+}
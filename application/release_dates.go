@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// SyncContentItemReleaseDate gives the dataset version of a content item the release date releaseDate. A failure is
+// returned rather than an error, so that it can be reported against the content item without failing the request.
+func (s *StateMachineBundleAPI) SyncContentItemReleaseDate(ctx context.Context, releaseDate *time.Time, contentItem *models.ContentItem, authHeaders datasetAPISDK.Headers) *models.ReleaseDateSyncFailure {
+	err := s.UpdateDatasetVersionReleaseDate(ctx, releaseDate, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, contentItem.Metadata.VersionID, authHeaders)
+	if err != nil {
+		return models.NewReleaseDateSyncFailure(contentItem, err)
+	}
+
+	return nil
+}
+
+// CheckReleaseDates compares the scheduled_at of a bundle with the release date of the dataset version of each of its
+// content items. A bundle that is not scheduled has no release date to compare, so is always consistent.
+func (s *StateMachineBundleAPI) CheckReleaseDates(ctx context.Context, bundleID string, authHeaders datasetAPISDK.Headers) (*models.ReleaseDateCheck, error) {
+	bundle, err := s.Datastore.GetBundle(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := models.NewReleaseDateCheck(bundle)
+	if bundle.BundleType != models.BundleTypeScheduled || bundle.ScheduledAt == nil {
+		return check, nil
+	}
+
+	contentItems, err := s.Datastore.GetContentItemsByBundleID(ctx, bundleID)
+	if err != nil {
+		log.Error(ctx, "failed to get content items to check release dates", err, log.Data{"bundle_id": bundleID})
+		return nil, err
+	}
+
+	for _, contentItem := range contentItems {
+		version, err := s.DatasetAPIClient.GetVersion(ctx, authHeaders, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, strconv.Itoa(contentItem.Metadata.VersionID))
+		if err != nil {
+			check.AddMismatch(contentItem, "", err)
+			continue
+		}
+
+		if !models.ReleaseDateMatches(version.ReleaseDate, *bundle.ScheduledAt) {
+			check.AddMismatch(contentItem, version.ReleaseDate, nil)
+		}
+	}
+
+	return check, nil
+}
+
+// syncBundleReleaseDates gives the dataset version of each content item in a scheduled bundle the bundle's scheduled_at
+// as its release date, and records any content items that could not be updated against the bundle
+func (s *StateMachineBundleAPI) syncBundleReleaseDates(ctx context.Context, bundle *models.Bundle, authHeaders datasetAPISDK.Headers) error {
+	contentItems, err := s.Datastore.GetContentItemsByBundleID(ctx, bundle.ID)
+	if err != nil {
+		log.Error(ctx, "failed to get content items to update release dates", err, log.Data{"bundle_id": bundle.ID})
+		return err
+	}
+
+	for _, contentItem := range contentItems {
+		if failure := s.SyncContentItemReleaseDate(ctx, bundle.ScheduledAt, contentItem, authHeaders); failure != nil {
+			bundle.ReleaseDateSyncFailures = append(bundle.ReleaseDateSyncFailures, failure)
+		}
+	}
+
+	return nil
+}
+
+// releaseDateChanged reports whether an update to a bundle has given it a new release date that its content items need
+func releaseDateChanged(originalBundle, updatedBundle *models.Bundle) bool {
+	if updatedBundle.BundleType != models.BundleTypeScheduled || updatedBundle.ScheduledAt == nil || !scheduleIsChangeable(updatedBundle.State) {
+		return false
+	}
+
+	if originalBundle.BundleType != models.BundleTypeScheduled || originalBundle.ScheduledAt == nil {
+		return true
+	}
+
+	return !originalBundle.ScheduledAt.Equal(*updatedBundle.ScheduledAt)
+}
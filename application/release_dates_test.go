@@ -0,0 +1,195 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPIMocks "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPutBundle_SyncsReleaseDates(t *testing.T) {
+	Convey("Given a scheduled bundle with content items", t, func() {
+		ctx := context.Background()
+		scheduledAt := time.Now().Add(24 * time.Hour).UTC()
+
+		currentBundle := &models.Bundle{
+			ID:          bundle123,
+			BundleType:  models.BundleTypeScheduled,
+			ScheduledAt: &scheduledAt,
+			State:       models.BundleStateDraft,
+			ETag:        "old-etag",
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return currentBundle, nil
+			},
+			UpdateBundleFunc: func(ctx context.Context, bundleID string, bundle *models.Bundle) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			GetContentItemsByBundleIDFunc: func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
+				return createMockVersionsAndContentItems(models.BundleStateDraft), nil
+			},
+		}
+
+		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
+			PutVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string, version datasetAPIModels.Version) (datasetAPIModels.Version, error) {
+				if datasetID == "dataset-id-2" {
+					return datasetAPIModels.Version{}, errors.New("request failed")
+				}
+				return version, nil
+			},
+		}
+
+		states := []application.State{application.Draft}
+		transitions := []application.Transition{
+			{
+				Label:               "DRAFT",
+				TargetState:         application.Draft,
+				AllowedSourceStates: []string{"DRAFT"},
+			},
+		}
+
+		stateMachine := &application.StateMachineBundleAPI{
+			Datastore:        store.Datastore{Backend: mockedDatastore},
+			StateMachine:     application.NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient),
+			DatasetAPIClient: mockDatasetAPIClient,
+		}
+
+		authEntityData := &models.AuthEntityData{
+			EntityData: &permissionsAPISDK.EntityData{UserID: userEmail},
+			Headers:    datasetAPISDK.Headers{AccessToken: "test-token"},
+		}
+
+		Convey("When the bundle is updated with a new scheduled_at", func() {
+			newScheduledAt := scheduledAt.Add(time.Hour)
+			bundleUpdate := &models.Bundle{ID: bundle123, BundleType: models.BundleTypeScheduled, ScheduledAt: &newScheduledAt, State: models.BundleStateDraft}
+
+			result, err := stateMachine.PutBundle(ctx, bundle123, bundleUpdate, authEntityData, currentBundle.ETag)
+
+			Convey("Then the new release date is given to each dataset version and the failures are reported", func() {
+				So(err, ShouldBeNil)
+				So(mockDatasetAPIClient.PutVersionCalls(), ShouldHaveLength, 2)
+				So(mockDatasetAPIClient.PutVersionCalls()[0].Version.ReleaseDate, ShouldEqual, newScheduledAt.Format(models.ReleaseDateFormat))
+				So(result.ReleaseDateSyncFailures, ShouldHaveLength, 1)
+				So(result.ReleaseDateSyncFailures[0].ContentItemID, ShouldEqual, "another-valid-content-item")
+				So(result.ReleaseDateSyncFailures[0].Metadata.DatasetID, ShouldEqual, "dataset-id-2")
+			})
+		})
+
+		Convey("When the bundle is updated without changing its scheduled_at", func() {
+			unchangedScheduledAt := scheduledAt
+			bundleUpdate := &models.Bundle{ID: bundle123, BundleType: models.BundleTypeScheduled, ScheduledAt: &unchangedScheduledAt, State: models.BundleStateDraft}
+
+			result, err := stateMachine.PutBundle(ctx, bundle123, bundleUpdate, authEntityData, currentBundle.ETag)
+
+			Convey("Then the dataset versions are not updated", func() {
+				So(err, ShouldBeNil)
+				So(result.ReleaseDateSyncFailures, ShouldBeEmpty)
+				So(mockedDatastore.GetContentItemsByBundleIDCalls(), ShouldBeEmpty)
+				So(mockDatasetAPIClient.PutVersionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the bundle is changed to a manual bundle", func() {
+			bundleUpdate := &models.Bundle{ID: bundle123, BundleType: models.BundleTypeManual, State: models.BundleStateDraft}
+
+			_, err := stateMachine.PutBundle(ctx, bundle123, bundleUpdate, authEntityData, currentBundle.ETag)
+
+			Convey("Then the dataset versions are not updated", func() {
+				So(err, ShouldBeNil)
+				So(mockDatasetAPIClient.PutVersionCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCheckReleaseDates(t *testing.T) {
+	Convey("Given a scheduled bundle with content items", t, func() {
+		ctx := context.Background()
+		scheduledAt := time.Date(2025, 7, 1, 9, 30, 0, 0, time.UTC)
+
+		bundle := &models.Bundle{ID: bundle123, BundleType: models.BundleTypeScheduled, ScheduledAt: &scheduledAt}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			GetContentItemsByBundleIDFunc: func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
+				return createMockVersionsAndContentItems(models.BundleStateApproved), nil
+			},
+		}
+
+		releaseDates := map[string]string{
+			"dataset-id-1": "2025-07-01T09:30:00.000Z",
+			"dataset-id-2": "2025-06-01T09:30:00.000Z",
+		}
+
+		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				releaseDate, ok := releaseDates[datasetID]
+				if !ok {
+					return datasetAPIModels.Version{}, errors.New("version not found")
+				}
+				return datasetAPIModels.Version{ReleaseDate: releaseDate}, nil
+			},
+		}
+
+		stateMachine := &application.StateMachineBundleAPI{
+			Datastore:        store.Datastore{Backend: mockedDatastore},
+			DatasetAPIClient: mockDatasetAPIClient,
+		}
+
+		Convey("When the release dates are checked", func() {
+			check, err := stateMachine.CheckReleaseDates(ctx, bundle123, datasetAPISDK.Headers{})
+
+			Convey("Then the content item whose dataset version has a different release date is listed", func() {
+				So(err, ShouldBeNil)
+				So(check.Consistent, ShouldBeFalse)
+				So(check.Mismatches, ShouldHaveLength, 1)
+				So(check.Mismatches[0].ContentItemID, ShouldEqual, "another-valid-content-item")
+				So(check.Mismatches[0].ReleaseDate, ShouldEqual, "2025-06-01T09:30:00.000Z")
+			})
+		})
+
+		Convey("When the release dates are checked and a dataset version cannot be read", func() {
+			delete(releaseDates, "dataset-id-2")
+
+			check, err := stateMachine.CheckReleaseDates(ctx, bundle123, datasetAPISDK.Headers{})
+
+			Convey("Then the content item is listed with the error", func() {
+				So(err, ShouldBeNil)
+				So(check.Mismatches, ShouldHaveLength, 1)
+				So(check.Mismatches[0].Error, ShouldEqual, "version not found")
+			})
+		})
+
+		Convey("When the release dates of a manual bundle are checked", func() {
+			bundle.BundleType = models.BundleTypeManual
+			bundle.ScheduledAt = nil
+
+			check, err := stateMachine.CheckReleaseDates(ctx, bundle123, datasetAPISDK.Headers{})
+
+			Convey("Then it is consistent and dataset API is not called", func() {
+				So(err, ShouldBeNil)
+				So(check.Consistent, ShouldBeTrue)
+				So(check.Mismatches, ShouldBeEmpty)
+				So(mockDatasetAPIClient.GetVersionCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
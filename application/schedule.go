@@ -0,0 +1,185 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/slack"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// ListScheduledBundles returns the approved scheduled bundles waiting to be published, earliest first
+func (s *StateMachineBundleAPI) ListScheduledBundles(ctx context.Context, offset, limit int) ([]*models.Bundle, int, error) {
+	return s.Datastore.ListScheduledBundles(ctx, offset, limit)
+}
+
+// ClaimDueScheduledBundle claims the next approved scheduled bundle that is due to be published on behalf of owner.
+// Returns nil if there is no bundle due.
+func (s *StateMachineBundleAPI) ClaimDueScheduledBundle(ctx context.Context, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+	return s.Datastore.ClaimDueScheduledBundle(ctx, time.Now(), owner, lockDuration)
+}
+
+// RenewScheduledBundleClaim extends owner's claim on a scheduled bundle for another lockDuration. It returns false if
+// owner no longer holds the claim.
+func (s *StateMachineBundleAPI) RenewScheduledBundleClaim(ctx context.Context, bundleID, owner string, lockDuration time.Duration) (bool, error) {
+	return s.Datastore.RenewScheduledBundleClaim(ctx, bundleID, owner, time.Now().Add(lockDuration))
+}
+
+// ReleaseScheduledBundleClaim removes owner's claim on a scheduled bundle, if it still holds it
+func (s *StateMachineBundleAPI) ReleaseScheduledBundleClaim(ctx context.Context, bundleID, owner string) error {
+	return s.Datastore.ReleaseScheduledBundleClaim(ctx, bundleID, owner)
+}
+
+// PublishScheduledBundle transitions a claimed scheduled bundle to PUBLISHED
+func (s *StateMachineBundleAPI) PublishScheduledBundle(ctx context.Context, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+	logData := log.Data{"bundle_id": bundle.ID, "scheduled_at": bundle.ScheduledAt}
+
+	now := time.Now()
+	userID := authEntityData.GetUserID()
+	bundle.UpdatedAt = &now
+	bundle.LastUpdatedBy = &models.User{Email: userID}
+
+	lastTransition, err := newStateTransition(bundle.State, models.BundleStatePublished, "", userID, now)
+	if err != nil {
+		return nil, err
+	}
+	bundle.LastTransition = lastTransition
+
+	updatedBundle, err := s.StateMachine.Transition(ctx, s, bundle, models.BundleStatePublished, *authEntityData)
+	if err != nil {
+		log.Error(ctx, "scheduled publish transition failed", err, logData)
+		return nil, err
+	}
+
+	return updatedBundle, nil
+}
+
+// RescheduleBundle moves a scheduled bundle to a new release date, which is also set as the release date of the dataset
+// version of every content item in the bundle. Any dataset versions that could not be updated are listed on the returned
+// bundle; rescheduling the bundle to the same date again retries them. The bundle is only rescheduled if suppliedETag
+// matches its ETag.
+func (s *StateMachineBundleAPI) RescheduleBundle(ctx context.Context, bundleID, suppliedETag string, scheduledAt time.Time, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+	logData := log.Data{"bundle_id": bundleID, "scheduled_at": scheduledAt}
+
+	bundle, err := s.getScheduledBundle(ctx, bundleID, suppliedETag)
+	if err != nil {
+		return nil, err
+	}
+
+	previousScheduledAt := bundle.ScheduledAt
+	bundle.ScheduledAt = &scheduledAt
+
+	if err = s.checkReleaseCalendar(bundle); err != nil {
+		log.Error(ctx, "bundle is not rescheduled to a release slot", err, logData)
+		return nil, err
+	}
+
+	updatedBundle, err := s.updateBundleSchedule(ctx, bundle, authEntityData, logData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.syncBundleReleaseDates(ctx, updatedBundle, authEntityData.Headers); err != nil {
+		return nil, err
+	}
+
+	s.sendScheduleChangedNotification(ctx, "Bundle rescheduled", updatedBundle, previousScheduledAt, authEntityData, logData)
+
+	return updatedBundle, nil
+}
+
+// CancelBundleSchedule converts a scheduled bundle into a manual bundle, so that it is no longer published by the
+// scheduler. The release dates already set on the dataset versions of its content items are left unchanged. The schedule
+// is only cancelled if suppliedETag matches the bundle's ETag.
+func (s *StateMachineBundleAPI) CancelBundleSchedule(ctx context.Context, bundleID, suppliedETag string, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+	logData := log.Data{"bundle_id": bundleID}
+
+	bundle, err := s.getScheduledBundle(ctx, bundleID, suppliedETag)
+	if err != nil {
+		return nil, err
+	}
+
+	previousScheduledAt := bundle.ScheduledAt
+	bundle.BundleType = models.BundleTypeManual
+	bundle.ScheduledAt = nil
+
+	updatedBundle, err := s.updateBundleSchedule(ctx, bundle, authEntityData, logData)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sendScheduleChangedNotification(ctx, "Bundle schedule cancelled", updatedBundle, previousScheduledAt, authEntityData, logData)
+
+	return updatedBundle, nil
+}
+
+// getScheduledBundle returns a scheduled bundle whose schedule can still be changed, if suppliedETag matches its ETag
+func (s *StateMachineBundleAPI) getScheduledBundle(ctx context.Context, bundleID, suppliedETag string) (*models.Bundle, error) {
+	bundle, err := s.GetBundleAndValidateETag(ctx, bundleID, suppliedETag)
+	if err != nil {
+		return nil, err
+	}
+
+	if bundle.BundleType != models.BundleTypeScheduled {
+		return nil, apierrors.ErrBundleNotScheduled
+	}
+
+	if !scheduleIsChangeable(bundle.State) {
+		return nil, apierrors.ErrScheduleNotChangeable
+	}
+
+	return bundle, nil
+}
+
+// scheduleIsChangeable reports whether a bundle in state has not yet been published, so can still have its schedule changed
+func scheduleIsChangeable(state models.BundleState) bool {
+	switch state {
+	case models.BundleStateDraft, models.BundleStateInReview, models.BundleStateApproved:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *StateMachineBundleAPI) updateBundleSchedule(ctx context.Context, bundle *models.Bundle, authEntityData *models.AuthEntityData, logData log.Data) (*models.Bundle, error) {
+	now := time.Now()
+	bundle.UpdatedAt = &now
+	bundle.LastUpdatedBy = &models.User{Email: authEntityData.GetUserID()}
+
+	// The state of the bundle is not changing, so there is no transition to record
+	bundle.LastTransition = nil
+
+	updatedBundle, err := s.updateBundleAndCreateEvent(ctx, bundle, authEntityData, logData)
+	if err != nil {
+		log.Error(ctx, "failed to update bundle schedule", err, logData)
+		return nil, err
+	}
+
+	return updatedBundle, nil
+}
+
+func (s *StateMachineBundleAPI) sendScheduleChangedNotification(ctx context.Context, summary string, bundle *models.Bundle, previousScheduledAt *time.Time, authEntityData *models.AuthEntityData, logData log.Data) {
+	fields := []slack.Field{
+		{Title: "Bundle ID", Value: bundle.ID},
+		{Title: "Title", Value: bundle.Title},
+		{Title: "Changed By", Value: authEntityData.GetUserEmail()},
+	}
+
+	if previousScheduledAt != nil {
+		fields = append(fields, slack.Field{Title: "Previous Publish Date", Value: previousScheduledAt.Format(utils.SlackPublishTimeFormat)})
+	}
+
+	if bundle.ScheduledAt != nil {
+		fields = append(fields, slack.Field{Title: "New Publish Date", Value: bundle.ScheduledAt.Format(utils.SlackPublishTimeFormat)})
+	}
+
+	logData["slack_fields"] = fields
+
+	log.Info(ctx, "sending slack notification: "+summary, logData)
+	if _, err := s.DataBundleSlackClient.SendInfo(ctx, summary, fields); err != nil {
+		log.Error(ctx, "failed to send slack notification: "+summary, err, logData)
+	}
+}
@@ -0,0 +1,324 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/slack"
+	slackMock "github.com/ONSdigital/dis-bundle-api/slack/mocks"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPIMocks "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClaimDueScheduledBundle(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with a mocked datastore", t, func() {
+		ctx := context.Background()
+
+		expectedBundle := &models.Bundle{ID: bundle123, State: models.BundleStateApproved}
+
+		mockedDatastore := &storetest.StorerMock{
+			ClaimDueScheduledBundleFunc: func(ctx context.Context, now time.Time, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+				return expectedBundle, nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore: store.Datastore{Backend: mockedDatastore},
+		}
+
+		Convey("When ClaimDueScheduledBundle is called", func() {
+			before := time.Now()
+			result, err := stateMachineBundleAPI.ClaimDueScheduledBundle(ctx, "owner-1", time.Minute)
+
+			Convey("Then the bundle is claimed as of the current time for the given owner", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldEqual, expectedBundle)
+				So(mockedDatastore.ClaimDueScheduledBundleCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.ClaimDueScheduledBundleCalls()[0].Now, ShouldHappenOnOrAfter, before)
+				So(mockedDatastore.ClaimDueScheduledBundleCalls()[0].Owner, ShouldEqual, "owner-1")
+				So(mockedDatastore.ClaimDueScheduledBundleCalls()[0].LockDuration, ShouldEqual, time.Minute)
+			})
+		})
+	})
+}
+
+func TestPublishScheduledBundle(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with mocked dependencies", t, func() {
+		ctx := context.Background()
+		serviceAuthEntityData := models.CreateServiceAuthEntityData("service-token")
+
+		states := []application.State{application.Draft, application.Approved, application.Published}
+		transitions := []application.Transition{
+			{
+				Label:               "PUBLISHED",
+				TargetState:         application.Published,
+				AllowedSourceStates: []string{"APPROVED"},
+			},
+		}
+
+		mockContentItems := createMockVersionsAndContentItems(models.BundleStateApproved)
+
+		mockedDatastore := &storetest.StorerMock{
+			UpdateBundleFunc: func(ctx context.Context, bundleID string, bundle *models.Bundle) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				contentItems := make([]models.ContentItem, len(mockContentItems))
+				for index := range contentItems {
+					contentItems[index] = *mockContentItems[index]
+				}
+				return &contentItems, nil
+			},
+			UpdateContentItemStateFunc: func(ctx context.Context, contentItemID, state string) error {
+				return nil
+			},
+			CreatePublishRunFunc: func(ctx context.Context, publishRun *models.PublishRun) error {
+				return nil
+			},
+			UpdatePublishRunItemFunc: func(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error {
+				return nil
+			},
+			CompletePublishRunFunc: func(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+				return nil
+			},
+		}
+
+		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
+			PutVersionStateFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
+				return nil
+			},
+		}
+
+		mockSlackClient := &slackMock.ClienterMock{
+			SendPublishLogFunc: func(ctx context.Context, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+			UpdatePublishLogFunc: func(ctx context.Context, ref *slack.MessageRef, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore:             store.Datastore{Backend: mockedDatastore},
+			StateMachine:          application.NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, nil),
+			DataBundleSlackClient: mockSlackClient,
+			DatasetAPIClient:      mockDatasetAPIClient,
+		}
+
+		Convey("When PublishScheduledBundle is called for an approved bundle", func() {
+			scheduledAt := time.Now().Add(-time.Minute)
+			previousTransition := &models.StateTransition{FromState: models.BundleStateInReview, ToState: models.BundleStateApproved, Reason: "earlier reason"}
+			bundle := &models.Bundle{ID: bundle123, BundleType: models.BundleTypeScheduled, ScheduledAt: &scheduledAt, State: models.BundleStateApproved, LastTransition: previousTransition}
+
+			result, err := stateMachineBundleAPI.PublishScheduledBundle(ctx, bundle, serviceAuthEntityData)
+
+			Convey("Then the bundle is published by the service identity", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStatePublished)
+				So(result.LastUpdatedBy.Email, ShouldEqual, models.ServiceIdentity)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 2)
+				So(mockDatasetAPIClient.PutVersionStateCalls()[0].Headers.AccessToken, ShouldEqual, "service-token")
+			})
+
+			Convey("And the publication is recorded as the bundle's last transition", func() {
+				So(result.LastTransition.FromState, ShouldEqual, models.BundleStateApproved)
+				So(result.LastTransition.ToState, ShouldEqual, models.BundleStatePublished)
+				So(result.LastTransition.Reason, ShouldBeEmpty)
+
+				for _, call := range mockedDatastore.CreateEventCalls() {
+					So(call.Event.Reason, ShouldBeEmpty)
+				}
+			})
+		})
+
+		Convey("When PublishScheduledBundle is called for a bundle that is no longer approved", func() {
+			bundle := &models.Bundle{ID: bundle123, BundleType: models.BundleTypeScheduled, State: models.BundleStateDraft}
+
+			result, err := stateMachineBundleAPI.PublishScheduledBundle(ctx, bundle, serviceAuthEntityData)
+
+			Convey("Then the transition is rejected and nothing is published", func() {
+				So(err, ShouldEqual, apierrors.ErrInvalidTransition)
+				So(result, ShouldBeNil)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestRescheduleAndCancelBundleSchedule(t *testing.T) {
+	Convey("Given a scheduled bundle with content items", t, func() {
+		ctx := context.Background()
+		previousScheduledAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		newScheduledAt := previousScheduledAt.Add(48 * time.Hour)
+
+		bundle := &models.Bundle{
+			ID:          bundle123,
+			Title:       "Scheduled bundle",
+			BundleType:  models.BundleTypeScheduled,
+			State:       models.BundleStateApproved,
+			ScheduledAt: &previousScheduledAt,
+			ETag:        "etag1",
+			LastTransition: &models.StateTransition{
+				FromState: models.BundleStateInReview,
+				ToState:   models.BundleStateApproved,
+				Reason:    "stale reason",
+			},
+		}
+
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				if bundleID != bundle123 {
+					return nil, apierrors.ErrBundleNotFound
+				}
+				return bundle, nil
+			},
+			UpdateBundleFunc: func(ctx context.Context, bundleID string, bundle *models.Bundle) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				return nil
+			},
+			GetContentItemsByBundleIDFunc: func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
+				return createMockVersionsAndContentItems(models.BundleStateApproved), nil
+			},
+		}
+
+		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
+			PutVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string, version datasetAPIModels.Version) (datasetAPIModels.Version, error) {
+				return version, nil
+			},
+		}
+
+		mockSlackClient := &slackMock.ClienterMock{
+			SendInfoFunc: func(ctx context.Context, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+		}
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{
+			Datastore:             store.Datastore{Backend: mockedDatastore},
+			DataBundleSlackClient: mockSlackClient,
+			DatasetAPIClient:      mockDatasetAPIClient,
+		}
+		authEntityData := &models.AuthEntityData{EntityData: &permissionsAPISDK.EntityData{UserID: "publisher@ons.gov.uk"}}
+
+		Convey("When the bundle is rescheduled", func() {
+			updatedBundle, err := stateMachineBundleAPI.RescheduleBundle(ctx, bundle123, "etag1", newScheduledAt, authEntityData)
+
+			Convey("Then the bundle and the release date of every dataset version are moved to the new date", func() {
+				So(err, ShouldBeNil)
+				So(updatedBundle.BundleType, ShouldEqual, models.BundleTypeScheduled)
+				So(*updatedBundle.ScheduledAt, ShouldEqual, newScheduledAt)
+				So(updatedBundle.LastUpdatedBy.Email, ShouldEqual, "publisher@ons.gov.uk")
+				So(updatedBundle.LastTransition, ShouldBeNil)
+				So(updatedBundle.ReleaseDateSyncFailures, ShouldBeEmpty)
+
+				So(mockDatasetAPIClient.PutVersionCalls(), ShouldHaveLength, 2)
+				for _, call := range mockDatasetAPIClient.PutVersionCalls() {
+					So(call.Version.ReleaseDate, ShouldEqual, newScheduledAt.Format("2006-01-02T15:04:05.000Z"))
+				}
+			})
+
+			Convey("And an UPDATE event without a reason is recorded and Slack is notified", func() {
+				So(mockedDatastore.CreateEventCalls(), ShouldHaveLength, 1)
+				So(mockedDatastore.CreateEventCalls()[0].Event.Action, ShouldEqual, models.ActionUpdate)
+				So(mockedDatastore.CreateEventCalls()[0].Event.Reason, ShouldBeEmpty)
+
+				So(mockSlackClient.SendInfoCalls(), ShouldHaveLength, 1)
+				So(mockSlackClient.SendInfoCalls()[0].Summary, ShouldEqual, "Bundle rescheduled")
+			})
+		})
+
+		Convey("When the bundle is rescheduled and a dataset version cannot be updated", func() {
+			mockDatasetAPIClient.PutVersionFunc = func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string, version datasetAPIModels.Version) (datasetAPIModels.Version, error) {
+				if datasetID == "dataset-id-2" {
+					return datasetAPIModels.Version{}, errors.New("request failed")
+				}
+				return version, nil
+			}
+
+			updatedBundle, err := stateMachineBundleAPI.RescheduleBundle(ctx, bundle123, "etag1", newScheduledAt, authEntityData)
+
+			Convey("Then the bundle is rescheduled and the content item that failed is reported against it", func() {
+				So(err, ShouldBeNil)
+				So(*updatedBundle.ScheduledAt, ShouldEqual, newScheduledAt)
+				So(mockDatasetAPIClient.PutVersionCalls(), ShouldHaveLength, 2)
+				So(updatedBundle.ReleaseDateSyncFailures, ShouldHaveLength, 1)
+				So(updatedBundle.ReleaseDateSyncFailures[0].ContentItemID, ShouldEqual, "another-valid-content-item")
+				So(updatedBundle.ReleaseDateSyncFailures[0].Error, ShouldEqual, "request failed")
+			})
+		})
+
+		Convey("When the schedule of the bundle is cancelled", func() {
+			updatedBundle, err := stateMachineBundleAPI.CancelBundleSchedule(ctx, bundle123, "etag1", authEntityData)
+
+			Convey("Then the bundle becomes a manual bundle and the dataset versions are left unchanged", func() {
+				So(err, ShouldBeNil)
+				So(updatedBundle.BundleType, ShouldEqual, models.BundleTypeManual)
+				So(updatedBundle.ScheduledAt, ShouldBeNil)
+				So(updatedBundle.State, ShouldEqual, models.BundleStateApproved)
+				So(mockDatasetAPIClient.PutVersionCalls(), ShouldBeEmpty)
+				So(mockSlackClient.SendInfoCalls()[0].Summary, ShouldEqual, "Bundle schedule cancelled")
+			})
+		})
+
+		Convey("When the bundle is a manual bundle", func() {
+			bundle.BundleType = models.BundleTypeManual
+			bundle.ScheduledAt = nil
+
+			_, rescheduleErr := stateMachineBundleAPI.RescheduleBundle(ctx, bundle123, "etag1", newScheduledAt, authEntityData)
+			_, cancelErr := stateMachineBundleAPI.CancelBundleSchedule(ctx, bundle123, "etag1", authEntityData)
+
+			Convey("Then ErrBundleNotScheduled is returned and nothing is updated", func() {
+				So(rescheduleErr, ShouldEqual, apierrors.ErrBundleNotScheduled)
+				So(cancelErr, ShouldEqual, apierrors.ErrBundleNotScheduled)
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the bundle has already been published", func() {
+			bundle.State = models.BundleStatePublished
+
+			_, err := stateMachineBundleAPI.RescheduleBundle(ctx, bundle123, "etag1", newScheduledAt, authEntityData)
+
+			Convey("Then ErrScheduleNotChangeable is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrScheduleNotChangeable)
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the bundle has changed since it was fetched", func() {
+			_, rescheduleErr := stateMachineBundleAPI.RescheduleBundle(ctx, bundle123, "stale-etag", newScheduledAt, authEntityData)
+			_, cancelErr := stateMachineBundleAPI.CancelBundleSchedule(ctx, bundle123, "stale-etag", authEntityData)
+
+			Convey("Then ErrInvalidIfMatchHeader is returned and nothing is updated", func() {
+				So(rescheduleErr, ShouldEqual, apierrors.ErrInvalidIfMatchHeader)
+				So(cancelErr, ShouldEqual, apierrors.ErrInvalidIfMatchHeader)
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+				So(mockDatasetAPIClient.PutVersionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the bundle does not exist", func() {
+			_, err := stateMachineBundleAPI.CancelBundleSchedule(ctx, "missing", "etag1", authEntityData)
+
+			Convey("Then ErrBundleNotFound is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrBundleNotFound)
+			})
+		})
+	})
+}
@@ -3,16 +3,20 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/ONSdigital/dis-bundle-api/apierrors"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	"github.com/ONSdigital/dis-bundle-api/store"
 	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/log.go/v2/log"
 )
 
 type StateMachine struct {
 	states           map[string]State
-	transitions      map[string][]string
+	transitions      map[string]Transition
+	targets          []string
 	datastore        store.Datastore
 	datasetAPIClient datasetAPISDK.Clienter
 }
@@ -21,13 +25,21 @@ type Transition struct {
 	Label               string
 	TargetState         State
 	AllowedSourceStates []string
+	Guards              []Guard
 }
 
 type State struct {
 	Name      string
-	EnterFunc func(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error)
+	EnterFunc EnterAction
 }
 
+// EnterAction is run when a bundle moves into a state, and returns the bundle as it is after the move
+type EnterAction func(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error)
+
+// Guard is checked before a transition is made. Returning an error stops the transition, and the error is returned to
+// the caller.
+type Guard func(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) error
+
 func (s State) String() string {
 	return s.Name
 }
@@ -38,14 +50,22 @@ func NewStateMachine(ctx context.Context, states []State, transitions []Transiti
 		statesMap[state.String()] = state
 	}
 
-	transitionsMap := make(map[string][]string)
+	transitionsMap := make(map[string]Transition)
+	targets := make([]string, 0, len(transitions))
 	for _, transition := range transitions {
-		transitionsMap[transition.TargetState.String()] = transition.AllowedSourceStates
+		if _, ok := transitionsMap[transition.TargetState.String()]; !ok {
+			targets = append(targets, transition.TargetState.String())
+		}
+		transitionsMap[transition.TargetState.String()] = transition
+		if _, ok := statesMap[transition.TargetState.String()]; !ok {
+			statesMap[transition.TargetState.String()] = transition.TargetState
+		}
 	}
 
 	StateMachine := &StateMachine{
 		states:           statesMap,
 		transitions:      transitionsMap,
+		targets:          targets,
 		datastore:        datastore,
 		datasetAPIClient: datasetAPIClient,
 	}
@@ -53,48 +73,86 @@ func NewStateMachine(ctx context.Context, states []State, transitions []Transiti
 	return StateMachine
 }
 
-func castStateToState(state string) (*State, bool) {
-	switch s := state; s {
-	case "PUBLISHED":
-		return &Published, true
-	case "IN_REVIEW":
-		return &InReview, true
-	case "APPROVED":
-		return &Approved, true
-	case "DRAFT":
-		return &Draft, true
-	default:
-		return nil, false
+func (sm *StateMachine) Transition(ctx context.Context, stateMachineBundleAPI *StateMachineBundleAPI, currentBundle *models.Bundle, targetState models.BundleState, authEntityData models.AuthEntityData) (*models.Bundle, error) {
+	if !sm.CanTransition(currentBundle.State, targetState) {
+		return nil, apierrors.ErrInvalidTransition
 	}
-}
 
-func (sm *StateMachine) Transition(ctx context.Context, stateMachineBundleAPI *StateMachineBundleAPI, currentBundle *models.Bundle, targetState models.BundleState, authEntityData models.AuthEntityData) (*models.Bundle, error) {
-	match := false
-	var nextState *State
-	var ok bool
-
-	for state, transitions := range sm.transitions {
-		if state == targetState.String() {
-			for i := range transitions {
-				if currentBundle.State.String() == transitions[i] {
-					match = true
-					nextState, ok = castStateToState(targetState.String())
-					if !ok {
-						return nil, errors.New("incorrect state value")
-					}
-					break
-				}
-			}
-		}
+	transition := sm.transitions[targetState.String()]
+	nextState, ok := sm.states[transition.TargetState.String()]
+	if !ok || nextState.EnterFunc == nil {
+		return nil, errors.New("incorrect state value")
 	}
 
-	if !match {
-		return nil, apierrors.ErrInvalidTransition
+	logData := log.Data{"bundle_id": currentBundle.ID, "transition": transition.Label, "from": currentBundle.State, "to": nextState.Name}
+
+	if guardErrs := checkGuards(ctx, stateMachineBundleAPI, transition, currentBundle, &authEntityData, false); len(guardErrs) > 0 {
+		log.Warn(ctx, "transition refused by guard", log.Data{"bundle_id": currentBundle.ID, "transition": transition.Label, "error": guardErrs[0].Error()})
+
+		var preconditionErr *PreconditionError
+		if errors.As(guardErrs[0], &preconditionErr) {
+			return nil, preconditionErr.Err
+		}
+		return nil, guardErrs[0]
 	}
 
+	log.Info(ctx, "transitioning bundle", logData)
+
 	updatedBundle, err := nextState.EnterFunc(ctx, *stateMachineBundleAPI, currentBundle, &authEntityData)
 	if err != nil {
 		return nil, err
 	}
 	return updatedBundle, nil
 }
+
+// CanTransition returns whether a bundle in currentState is allowed to transition to targetState
+func (sm *StateMachine) CanTransition(currentState, targetState models.BundleState) bool {
+	transition, ok := sm.transitions[targetState.String()]
+	return ok && slices.Contains(transition.AllowedSourceStates, currentState.String())
+}
+
+// AvailableTransitions returns the transitions that can be made from a bundle's current state. Each transition's guards
+// are checked in the same way as by Transition, and any that refuse it with a *PreconditionError are reported as unmet
+// preconditions. Any other error from a guard means the preconditions could not be checked, and is returned wrapped so
+// that it is not mistaken for an error about the bundle itself.
+func (sm *StateMachine) AvailableTransitions(ctx context.Context, stateMachineBundleAPI *StateMachineBundleAPI, bundle *models.Bundle, authEntityData models.AuthEntityData) ([]models.AvailableTransition, error) {
+	available := make([]models.AvailableTransition, 0, len(sm.targets))
+	for _, target := range sm.targets {
+		targetState := models.BundleState(target)
+		if !sm.CanTransition(bundle.State, targetState) {
+			continue
+		}
+
+		availableTransition := models.AvailableTransition{
+			State:     targetState,
+			Permitted: true,
+		}
+
+		for _, err := range checkGuards(ctx, stateMachineBundleAPI, sm.transitions[target], bundle, &authEntityData, true) {
+			var preconditionErr *PreconditionError
+			if !errors.As(err, &preconditionErr) {
+				return nil, fmt.Errorf("failed to check preconditions of transition to %s: %w", targetState, err)
+			}
+			availableTransition.AddUnmetPrecondition(preconditionErr.Error())
+		}
+
+		available = append(available, availableTransition)
+	}
+
+	return available, nil
+}
+
+// checkGuards runs the guards of a transition and returns the errors from those that refuse it. Unless all is true,
+// checking stops at the first refusal.
+func checkGuards(ctx context.Context, stateMachineBundleAPI *StateMachineBundleAPI, transition Transition, bundle *models.Bundle, authEntityData *models.AuthEntityData, all bool) []error {
+	var guardErrs []error
+	for _, guard := range transition.Guards {
+		if err := guard(ctx, *stateMachineBundleAPI, bundle, authEntityData); err != nil {
+			guardErrs = append(guardErrs, err)
+			if !all {
+				break
+			}
+		}
+	}
+	return guardErrs
+}
@@ -151,7 +151,7 @@ func TestTransition_Success(t *testing.T) {
 		}
 
 		stateMachine := NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient)
-		stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, ApprovalPolicy{}, nil)
 
 		bundle, err := stateMachine.Transition(ctx, stateMachineBundleAPI, currentBundleWithStateDraft, bundleUpdateWithStateInReview.State, *authEntityData)
 
@@ -204,7 +204,7 @@ func TestTransition_Success(t *testing.T) {
 		}
 
 		stateMachine := NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient)
-		stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, ApprovalPolicy{}, nil)
 
 		bundle, err := stateMachine.Transition(ctx, stateMachineBundleAPI, currentBundleWithStateInReview, bundleUpdateWithStateApproved.State, *authEntityData)
 
@@ -235,6 +235,15 @@ func TestTransition_Success(t *testing.T) {
 		mockedDatastore.UpdateContentItemStateFunc = func(ctx context.Context, contentItemID, state string) error {
 			return nil
 		}
+		mockedDatastore.CreatePublishRunFunc = func(ctx context.Context, publishRun *models.PublishRun) error {
+			return nil
+		}
+		mockedDatastore.UpdatePublishRunItemFunc = func(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error {
+			return nil
+		}
+		mockedDatastore.CompletePublishRunFunc = func(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+			return nil
+		}
 
 		mockDatasetAPIClient := &datasetAPISDKMock.ClienterMock{
 			PutVersionStateFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
@@ -252,7 +261,7 @@ func TestTransition_Success(t *testing.T) {
 		}
 
 		stateMachine := NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient)
-		stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, ApprovalPolicy{}, nil)
 		bundle, err := stateMachine.Transition(ctx, stateMachineBundleAPI, currentBundleWithStateApproved, bundleUpdateWithStatePublished.State, *authEntityData)
 
 		Convey("Then the transition should be successful", func() {
@@ -274,7 +283,7 @@ func TestTransition_Success(t *testing.T) {
 			},
 		}
 		stateMachine := NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient)
-		stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+		stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, ApprovalPolicy{}, nil)
 		bundle, err := stateMachine.Transition(ctx, stateMachineBundleAPI, currentBundleWithStateInReview, bundleUpdateWithStateDraft.State, *authEntityData)
 		Convey("Then the transition should be successful", func() {
 			So(err, ShouldBeNil)
@@ -297,7 +306,7 @@ func TestTransition_failure(t *testing.T) {
 	mockSlackClient := &slackMock.ClienterMock{}
 
 	stateMachine := NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, mockDatasetAPIClient)
-	stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "")
+	stateMachineBundleAPI := Setup(store.Datastore{Backend: mockedDatastore}, stateMachine, mockDatasetAPIClient, mockPermissionsAPIClient, mockSlackClient, "", 0, ApprovalPolicy{}, nil)
 
 	authEntityData := &models.AuthEntityData{
 		EntityData: &permissionsAPISDK.EntityData{
@@ -16,6 +16,18 @@ var Approved = State{
 	EnterFunc: ApproveBundle,
 }
 
+// PublishFailed is entered by PublishBundle when content items fail to publish, rather than by a requested transition.
+// Transitioning the bundle to PUBLISHED again retries the content items that failed.
+var PublishFailed = State{
+	Name: "PUBLISH_FAILED",
+}
+
+// Withdrawn is entered by rolling back a published bundle, which reverts each content item's version in dataset API
+var Withdrawn = State{
+	Name:      "WITHDRAWN",
+	EnterFunc: WithdrawBundle,
+}
+
 var Draft = State{
 	Name:      "DRAFT",
 	EnterFunc: DraftBundle,
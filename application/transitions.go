@@ -0,0 +1,48 @@
+package application
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+)
+
+// GetBundleTransitions returns the states a bundle can move to from its current state, with any preconditions of each
+// transition that the bundle does not currently meet
+func (s *StateMachineBundleAPI) GetBundleTransitions(ctx context.Context, bundleID string, authEntityData *models.AuthEntityData) (*models.BundleTransitions, error) {
+	bundle, err := s.Datastore.GetBundle(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions, err := s.StateMachine.AvailableTransitions(ctx, s, bundle, *authEntityData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BundleTransitions{
+		BundleID:    bundle.ID,
+		State:       bundle.State,
+		Transitions: transitions,
+	}, nil
+}
+
+// EmbedBundleTransitions embeds the transitions each of the bundles can make from its current state, with any
+// preconditions of each that the bundle does not currently meet. The bundles must be whole for their guards to be checked.
+func (s *StateMachineBundleAPI) EmbedBundleTransitions(ctx context.Context, bundles []*models.Bundle, authEntityData *models.AuthEntityData) error {
+	for _, bundle := range bundles {
+		if bundle.Embedded == nil {
+			bundle.Embedded = &models.BundleEmbedded{}
+		}
+
+		transitions, err := s.StateMachine.AvailableTransitions(ctx, s, bundle, *authEntityData)
+		if err != nil {
+			return err
+		}
+		if transitions == nil {
+			transitions = []models.AvailableTransition{}
+		}
+		bundle.Embedded.Transitions = &transitions
+	}
+
+	return nil
+}
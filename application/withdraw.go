@@ -0,0 +1,121 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/slack"
+	"github.com/ONSdigital/dis-bundle-api/utils"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// WithdrawBundle rolls back a published bundle. The version of each published content item is reverted to approved in
+// dataset API and the result for each content item is recorded as a WITHDRAW event. If any content item fails to
+// revert then the bundle remains PUBLISHED, so that the withdrawal can be retried for the content items that failed.
+func WithdrawBundle(ctx context.Context, smBundle StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+	logData := log.Data{"bundle_id": bundle.ID, "bundle_type": bundle.BundleType, "title": bundle.Title}
+
+	contents, err := smBundle.Datastore.GetBundleContentsForBundle(ctx, bundle.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// retrying a withdrawal only reverts the content items that are still published
+	contents = publishedContentItems(contents)
+
+	withdrawStartTime := time.Now()
+	withdrawLogFields := []slack.Field{
+		{Title: "Bundle ID", Value: bundle.ID},
+		{Title: "Title", Value: bundle.Title},
+		{Title: "Number of Content Items", Value: strconv.Itoa(len(*contents))},
+		{Title: "Withdrawn By", Value: authEntityData.GetUserEmail()},
+		{Title: "Withdrawal Start Date", Value: withdrawStartTime.Format(utils.SlackPublishTimeFormat)},
+	}
+	logData["slack_fields"] = withdrawLogFields
+
+	log.Info(ctx, "sending slack notification: Bundle withdrawal started", logData)
+	slackMessageRef, err := smBundle.DataBundleSlackClient.SendPublishLog(ctx, "Bundle withdrawal started", withdrawLogFields)
+	if err != nil {
+		log.Error(ctx, "failed to send slack notification: Bundle withdrawal started", err, logData)
+	}
+
+	withdrawErrs := make([]error, len(*contents))
+	forEachConcurrently(len(*contents), smBundle.PublishMaxConcurrency, func(index int) {
+		withdrawErrs[index] = withdrawContentItem(ctx, smBundle, authEntityData, &(*contents)[index])
+	})
+
+	failed := 0
+	for index := range *contents {
+		if withdrawErrs[index] != nil {
+			failed++
+			log.Error(ctx, "failed to withdraw content item", withdrawErrs[index], log.Data{"bundle_id": bundle.ID, "content_item_id": (*contents)[index].ID})
+		}
+	}
+
+	withdrawEndTime := time.Now()
+	withdrawLogFields = append(withdrawLogFields,
+		slack.Field{Title: "Withdrawal End Date", Value: withdrawEndTime.Format(utils.SlackPublishTimeFormat)},
+		slack.Field{Title: "Duration", Value: fmt.Sprintf("%.4f seconds", withdrawEndTime.Sub(withdrawStartTime).Seconds())},
+	)
+	logData["slack_fields"] = withdrawLogFields
+
+	if failed > 0 {
+		withdrawLogFields = append(withdrawLogFields, slack.Field{Title: "Failed Content Items", Value: strconv.Itoa(failed)})
+		log.Info(ctx, "updating slack notification: Bundle withdrawal completed with errors", logData)
+		if _, err = smBundle.DataBundleSlackClient.UpdatePublishLogAsAlarm(ctx, slackMessageRef, "Bundle withdrawal completed with errors", withdrawLogFields); err != nil {
+			log.Error(ctx, "failed to update slack notification: Bundle withdrawal completed with errors", err, logData)
+		}
+		return nil, errs.ErrWithdrawContentItemsFailed
+	}
+
+	bundle.State = models.BundleStateWithdrawn
+	bundle.LastUpdatedBy.Email = authEntityData.GetUserEmail()
+
+	updatedBundle, err := smBundle.updateBundleAndCreateEvent(ctx, bundle, authEntityData, logData)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "updating slack notification: Bundle withdrawn", logData)
+	if _, err = smBundle.DataBundleSlackClient.UpdatePublishLog(ctx, slackMessageRef, "Bundle withdrawn", withdrawLogFields); err != nil {
+		log.Error(ctx, "failed to update slack notification: Bundle withdrawn", err, logData)
+	}
+
+	return updatedBundle, nil
+}
+
+// withdrawContentItem reverts the dataset version of a content item to approved in dataset API and records the result as a
+// WITHDRAW event. The event holds the content item as it is after the attempt, so a content item that failed to revert
+// is still PUBLISHED.
+func withdrawContentItem(ctx context.Context, smBundle StateMachineBundleAPI, authEntityData *models.AuthEntityData, contentItem *models.ContentItem) error {
+	withdrawErr := publishContentItem(ctx, smBundle, authEntityData, contentItem, models.StateApproved)
+	if withdrawErr == nil {
+		withdrawErr = smBundle.Datastore.UpdateContentItemState(ctx, contentItem.ID, models.StateApproved.String())
+	}
+
+	if withdrawErr == nil {
+		contentItem.State = new(models.StateApproved)
+	}
+
+	if err := smBundle.CreateEvent(ctx, authEntityData, models.ActionWithdraw, nil, contentItem); err != nil {
+		log.Error(ctx, "failed to create event", err, log.Data{"bundle_id": contentItem.BundleID, "content_item_id": contentItem.ID, "action": models.ActionWithdraw})
+	}
+
+	return withdrawErr
+}
+
+// publishedContentItems returns the content items that have been published
+func publishedContentItems(contents *[]models.ContentItem) *[]models.ContentItem {
+	published := make([]models.ContentItem, 0, len(*contents))
+	for index := range *contents {
+		contentItem := (*contents)[index]
+		if contentItem.State != nil && *contentItem.State == models.StatePublished {
+			published = append(published, contentItem)
+		}
+	}
+	return &published
+}
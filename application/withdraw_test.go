@@ -0,0 +1,184 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/slack"
+	slackMock "github.com/ONSdigital/dis-bundle-api/slack/mocks"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPIMocks "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	permissionsAPISDK "github.com/ONSdigital/dp-permissions-api/sdk"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPutBundleState_Withdraw(t *testing.T) {
+	Convey("Given a StateMachineBundleAPI with a published bundle", t, func() {
+		ctx := context.Background()
+
+		currentBundle := &models.Bundle{
+			ID:    bundle123,
+			State: models.BundleStatePublished,
+			ETag:  "old-etag",
+		}
+
+		authEntityData := &models.AuthEntityData{
+			EntityData: &permissionsAPISDK.EntityData{
+				UserID: userEmail,
+			},
+		}
+
+		states := []application.State{application.Published, application.Withdrawn}
+		transitions := []application.Transition{
+			{
+				Label:               "WITHDRAWN",
+				TargetState:         application.Withdrawn,
+				AllowedSourceStates: []string{"PUBLISHED"},
+			},
+		}
+
+		mockContentItems := createMockVersionsAndContentItems(models.BundleStatePublished)
+
+		var (
+			mu     sync.Mutex
+			events []models.Event
+		)
+		mockedDatastore := &storetest.StorerMock{
+			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
+				return currentBundle, nil
+			},
+			UpdateBundleFunc: func(ctx context.Context, bundleID string, bundle *models.Bundle) (*models.Bundle, error) {
+				return bundle, nil
+			},
+			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, *event)
+				return nil
+			},
+			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
+				contentItems := make([]models.ContentItem, len(mockContentItems))
+				for index := range contentItems {
+					contentItems[index] = *mockContentItems[index]
+				}
+				return &contentItems, nil
+			},
+			UpdateContentItemStateFunc: func(ctx context.Context, contentItemID, state string) error {
+				return nil
+			},
+		}
+
+		failingDataset := ""
+		mockDatasetAPIClient := &datasetAPIMocks.ClienterMock{
+			PutVersionStateFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
+				if datasetID == failingDataset {
+					return errors.New("failed to revert version")
+				}
+				return nil
+			},
+		}
+
+		mockSlackClient := &slackMock.ClienterMock{
+			SendPublishLogFunc: func(ctx context.Context, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+			UpdatePublishLogFunc: func(ctx context.Context, ref *slack.MessageRef, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+			UpdatePublishLogAsAlarmFunc: func(ctx context.Context, ref *slack.MessageRef, summary string, fields []slack.Field) (*slack.MessageRef, error) {
+				return &slack.MessageRef{}, nil
+			},
+		}
+
+		stateMachine := &application.StateMachineBundleAPI{
+			Datastore:             store.Datastore{Backend: mockedDatastore},
+			StateMachine:          application.NewStateMachine(ctx, states, transitions, store.Datastore{Backend: mockedDatastore}, nil),
+			DataBundleSlackClient: mockSlackClient,
+			DatasetAPIClient:      mockDatasetAPIClient,
+		}
+
+		withdrawEvents := func() []models.Event {
+			var result []models.Event
+			for index := range events {
+				if events[index].Action == models.ActionWithdraw {
+					result = append(result, events[index])
+				}
+			}
+			return result
+		}
+
+		Convey("When UpdateBundleState is called to withdraw the bundle", func() {
+			result, err := stateMachine.UpdateBundleState(ctx, bundle123, currentBundle.ETag, models.BundleStateWithdrawn, "", authEntityData)
+
+			Convey("Then every content item's version is reverted to approved", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStateWithdrawn)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 2)
+				So(mockDatasetAPIClient.PutVersionStateCalls()[0].State, ShouldEqual, "approved")
+				So(mockedDatastore.UpdateContentItemStateCalls(), ShouldHaveLength, 2)
+				So(mockedDatastore.UpdateContentItemStateCalls()[0].State, ShouldEqual, models.StateApproved.String())
+			})
+
+			Convey("And the result for each content item is recorded as an event", func() {
+				So(withdrawEvents(), ShouldHaveLength, 2)
+				for _, event := range withdrawEvents() {
+					So(*event.ContentItem.State, ShouldEqual, models.StateApproved)
+				}
+			})
+
+			Convey("And Slack is notified that the bundle was withdrawn", func() {
+				So(mockSlackClient.SendPublishLogCalls()[0].Summary, ShouldEqual, "Bundle withdrawal started")
+				So(mockSlackClient.UpdatePublishLogCalls()[0].Summary, ShouldEqual, "Bundle withdrawn")
+			})
+		})
+
+		Convey("When a content item fails to revert", func() {
+			failingDataset = "dataset-id-2"
+
+			result, err := stateMachine.UpdateBundleState(ctx, bundle123, currentBundle.ETag, models.BundleStateWithdrawn, "", authEntityData)
+
+			Convey("Then the bundle remains published and an error is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrWithdrawContentItemsFailed)
+				So(result, ShouldBeNil)
+				So(mockedDatastore.UpdateBundleCalls(), ShouldHaveLength, 0)
+			})
+
+			Convey("And the failure is recorded against the content item, which is still published", func() {
+				So(withdrawEvents(), ShouldHaveLength, 2)
+				for _, event := range withdrawEvents() {
+					expectedState := models.StateApproved
+					if event.ContentItem.Metadata.DatasetID == failingDataset {
+						expectedState = models.StatePublished
+					}
+					So(*event.ContentItem.State, ShouldEqual, expectedState)
+				}
+			})
+
+			Convey("And Slack is alerted", func() {
+				So(mockSlackClient.UpdatePublishLogAsAlarmCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the withdrawal is retried after some content items were reverted", func() {
+			approvedState := models.StateApproved
+			mockContentItems[0].State = &approvedState
+
+			result, err := stateMachine.UpdateBundleState(ctx, bundle123, currentBundle.ETag, models.BundleStateWithdrawn, "", authEntityData)
+
+			Convey("Then only the content items that are still published are reverted", func() {
+				So(err, ShouldBeNil)
+				So(result.State, ShouldEqual, models.BundleStateWithdrawn)
+				So(mockDatasetAPIClient.PutVersionStateCalls(), ShouldHaveLength, 1)
+				So(mockDatasetAPIClient.PutVersionStateCalls()[0].DatasetID, ShouldEqual, "dataset-id-2")
+			})
+		})
+	})
+}
@@ -0,0 +1,257 @@
+package application
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ONSdigital/dis-bundle-api/store"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// Names of the enter actions registered by DefaultRegistry
+const (
+	ActionNameDraft    = "draft"
+	ActionNameReview   = "review"
+	ActionNameApprove  = "approve"
+	ActionNamePublish  = "publish"
+	ActionNameWithdraw = "withdraw"
+)
+
+//go:embed workflow.yaml
+var defaultWorkflowDefinition []byte
+
+// WorkflowDefinition describes the states of the bundle workflow and the transitions between them. It is loaded from
+// YAML, or JSON as a subset of YAML, and refers to enter actions and guards by the names they are registered with.
+type WorkflowDefinition struct {
+	InitialState string                 `yaml:"initial_state"`
+	States       []StateDefinition      `yaml:"states"`
+	Transitions  []TransitionDefinition `yaml:"transitions"`
+}
+
+// StateDefinition describes a state in the workflow. A state either has an enter action, which is run when a bundle
+// transitions to it, or is entered by the enter action of another state.
+type StateDefinition struct {
+	Name        string `yaml:"name"`
+	EnterAction string `yaml:"enter_action"`
+	EnteredBy   string `yaml:"entered_by"`
+}
+
+// TransitionDefinition describes how a bundle may move to a target state
+type TransitionDefinition struct {
+	Label   string   `yaml:"label"`
+	Target  string   `yaml:"target"`
+	Sources []string `yaml:"sources"`
+	Guards  []string `yaml:"guards"`
+}
+
+// Registry holds the enter actions and guards that a workflow definition can refer to by name
+type Registry struct {
+	actions map[string]EnterAction
+	guards  map[string]Guard
+}
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		actions: make(map[string]EnterAction),
+		guards:  make(map[string]Guard),
+	}
+}
+
+// DefaultRegistry returns a Registry holding the enter actions and guards used by the default workflow
+func DefaultRegistry() *Registry {
+	registry := NewRegistry()
+	registry.RegisterAction(ActionNameDraft, DraftBundle)
+	registry.RegisterAction(ActionNameReview, ReviewBundle)
+	registry.RegisterAction(ActionNameApprove, ApproveBundle)
+	registry.RegisterAction(ActionNamePublish, PublishBundle)
+	registry.RegisterAction(ActionNameWithdraw, WithdrawBundle)
+	registry.RegisterGuard(GuardNameHasContentItems, HasContentItems)
+	registry.RegisterGuard(GuardNameContentItemsApproved, ContentItemsApproved)
+	registry.RegisterGuard(GuardNameFourEyesApproval, FourEyesApproval)
+	registry.RegisterGuard(GuardNameApprovalQuorum, ApprovalQuorum)
+	registry.RegisterGuard(GuardNameNoConflicts, NoConflicts)
+	return registry
+}
+
+// RegisterAction registers an enter action under name, replacing any action already registered with that name
+func (r *Registry) RegisterAction(name string, action EnterAction) {
+	r.actions[name] = action
+}
+
+// RegisterGuard registers a guard under name, replacing any guard already registered with that name
+func (r *Registry) RegisterGuard(name string, guard Guard) {
+	r.guards[name] = guard
+}
+
+// ParseWorkflowDefinition parses a workflow definition from YAML or JSON
+func ParseWorkflowDefinition(data []byte) (*WorkflowDefinition, error) {
+	var definition WorkflowDefinition
+	if err := yaml.Unmarshal(data, &definition); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow definition: %w", err)
+	}
+	return &definition, nil
+}
+
+// LoadWorkflowDefinition loads the workflow definition from the file at path, or the default workflow definition if
+// path is empty
+func LoadWorkflowDefinition(path string) (*WorkflowDefinition, error) {
+	if path == "" {
+		return ParseWorkflowDefinition(defaultWorkflowDefinition)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow definition: %w", err)
+	}
+
+	return ParseWorkflowDefinition(data)
+}
+
+// Validate checks that the definition is complete and consistent: every state it refers to is defined, every enter
+// action and guard it names is registered, and every state can be reached from the initial state. All of the problems
+// found are returned together.
+func (d *WorkflowDefinition) Validate(registry *Registry) error {
+	var errs []error
+
+	states := make(map[string]StateDefinition, len(d.States))
+	for _, state := range d.States {
+		if state.Name == "" {
+			errs = append(errs, errors.New("state has no name"))
+			continue
+		}
+		if _, ok := states[state.Name]; ok {
+			errs = append(errs, fmt.Errorf("state %q is defined more than once", state.Name))
+			continue
+		}
+		states[state.Name] = state
+	}
+
+	if _, ok := states[d.InitialState]; !ok {
+		errs = append(errs, fmt.Errorf("initial state %q is not defined", d.InitialState))
+	}
+
+	for _, state := range d.States {
+		switch {
+		case state.EnterAction != "" && state.EnteredBy != "":
+			errs = append(errs, fmt.Errorf("state %q has both an enter action and entered_by", state.Name))
+		case state.EnterAction != "":
+			if _, ok := registry.actions[state.EnterAction]; !ok {
+				errs = append(errs, fmt.Errorf("state %q has unknown enter action %q", state.Name, state.EnterAction))
+			}
+		case state.EnteredBy != "":
+			if _, ok := states[state.EnteredBy]; !ok {
+				errs = append(errs, fmt.Errorf("state %q is entered by unknown state %q", state.Name, state.EnteredBy))
+			}
+		}
+	}
+
+	targets := make(map[string]bool, len(d.Transitions))
+	for _, transition := range d.Transitions {
+		target, ok := states[transition.Target]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("transition %q has unknown target state %q", transition.Label, transition.Target))
+		case target.EnterAction == "":
+			errs = append(errs, fmt.Errorf("transition %q targets state %q which has no enter action", transition.Label, transition.Target))
+		case targets[transition.Target]:
+			errs = append(errs, fmt.Errorf("state %q is the target of more than one transition", transition.Target))
+		}
+		targets[transition.Target] = true
+
+		if len(transition.Sources) == 0 {
+			errs = append(errs, fmt.Errorf("transition %q has no source states", transition.Label))
+		}
+		for _, source := range transition.Sources {
+			if _, ok := states[source]; !ok {
+				errs = append(errs, fmt.Errorf("transition %q has unknown source state %q", transition.Label, source))
+			}
+		}
+
+		for _, guard := range transition.Guards {
+			if _, ok := registry.guards[guard]; !ok {
+				errs = append(errs, fmt.Errorf("transition %q has unknown guard %q", transition.Label, guard))
+			}
+		}
+	}
+
+	reachable := d.reachableStates()
+	for _, state := range d.States {
+		if state.Name != "" && !reachable[state.Name] {
+			errs = append(errs, fmt.Errorf("state %q cannot be reached from initial state %q", state.Name, d.InitialState))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reachableStates returns the states that a bundle can reach from the initial state, either through a transition or
+// by being entered by another state's enter action
+func (d *WorkflowDefinition) reachableStates() map[string]bool {
+	next := make(map[string][]string)
+	for _, transition := range d.Transitions {
+		for _, source := range transition.Sources {
+			next[source] = append(next[source], transition.Target)
+		}
+	}
+	for _, state := range d.States {
+		if state.EnteredBy != "" {
+			next[state.EnteredBy] = append(next[state.EnteredBy], state.Name)
+		}
+	}
+
+	reachable := map[string]bool{d.InitialState: true}
+	queue := []string{d.InitialState}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, state := range next[current] {
+			if !reachable[state] {
+				reachable[state] = true
+				queue = append(queue, state)
+			}
+		}
+	}
+
+	return reachable
+}
+
+// NewStateMachineFromDefinition validates a workflow definition and returns a state machine for it, resolving the
+// enter actions and guards it names from registry
+func NewStateMachineFromDefinition(ctx context.Context, definition *WorkflowDefinition, registry *Registry, datastore store.Datastore, datasetAPIClient datasetAPISDK.Clienter) (*StateMachine, error) {
+	if err := definition.Validate(registry); err != nil {
+		return nil, fmt.Errorf("invalid workflow definition: %w", err)
+	}
+
+	states := make([]State, 0, len(definition.States))
+	statesByName := make(map[string]State, len(definition.States))
+	for _, stateDefinition := range definition.States {
+		state := State{Name: stateDefinition.Name}
+		if stateDefinition.EnterAction != "" {
+			state.EnterFunc = registry.actions[stateDefinition.EnterAction]
+		}
+		states = append(states, state)
+		statesByName[state.Name] = state
+	}
+
+	transitions := make([]Transition, 0, len(definition.Transitions))
+	for _, transitionDefinition := range definition.Transitions {
+		guards := make([]Guard, 0, len(transitionDefinition.Guards))
+		for _, name := range transitionDefinition.Guards {
+			guards = append(guards, registry.guards[name])
+		}
+
+		transitions = append(transitions, Transition{
+			Label:               transitionDefinition.Label,
+			TargetState:         statesByName[transitionDefinition.Target],
+			AllowedSourceStates: transitionDefinition.Sources,
+			Guards:              guards,
+		})
+	}
+
+	return NewStateMachine(ctx, states, transitions, datastore, datasetAPIClient), nil
+}
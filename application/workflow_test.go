@@ -0,0 +1,190 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/store"
+	storetest "github.com/ONSdigital/dis-bundle-api/store/datastoretest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testWorkflowDefinition = `
+initial_state: DRAFT
+states:
+  - name: DRAFT
+    enter_action: draft
+  - name: APPROVED
+    enter_action: approve
+transitions:
+  - label: DRAFT
+    target: DRAFT
+    sources: [APPROVED]
+  - label: APPROVED
+    target: APPROVED
+    sources: [DRAFT]
+`
+
+func TestLoadWorkflowDefinition(t *testing.T) {
+	Convey("When the default workflow definition is loaded", t, func() {
+		definition, err := application.LoadWorkflowDefinition("")
+
+		Convey("Then it is valid for the default registry", func() {
+			So(err, ShouldBeNil)
+			So(definition.InitialState, ShouldEqual, models.BundleStateDraft.String())
+			So(definition.Validate(application.DefaultRegistry()), ShouldBeNil)
+		})
+
+		Convey("And it allows the transitions the service has always allowed", func() {
+			stateMachine, err := application.NewStateMachineFromDefinition(context.Background(), definition, application.DefaultRegistry(), store.Datastore{}, nil)
+			So(err, ShouldBeNil)
+			So(stateMachine.CanTransition(models.BundleStateInReview, models.BundleStateApproved), ShouldBeTrue)
+			So(stateMachine.CanTransition(models.BundleStatePublishFailed, models.BundleStatePublished), ShouldBeTrue)
+			So(stateMachine.CanTransition(models.BundleStatePublished, models.BundleStateWithdrawn), ShouldBeTrue)
+			So(stateMachine.CanTransition(models.BundleStateDraft, models.BundleStatePublished), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a workflow definition file in JSON", t, func() {
+		path := filepath.Join(t.TempDir(), "workflow.json")
+		json := `{"initial_state": "DRAFT", "states": [{"name": "DRAFT", "enter_action": "draft"}], "transitions": [{"label": "DRAFT", "target": "DRAFT", "sources": ["DRAFT"]}]}`
+		So(os.WriteFile(path, []byte(json), 0o600), ShouldBeNil)
+
+		Convey("When it is loaded", func() {
+			definition, err := application.LoadWorkflowDefinition(path)
+
+			Convey("Then the definition is parsed", func() {
+				So(err, ShouldBeNil)
+				So(definition.States, ShouldHaveLength, 1)
+				So(definition.Transitions[0].Sources, ShouldResemble, []string{"DRAFT"})
+			})
+		})
+	})
+
+	Convey("When a workflow definition file that does not exist is loaded", t, func() {
+		_, err := application.LoadWorkflowDefinition(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestWorkflowDefinition_Validate(t *testing.T) {
+	Convey("Given a valid workflow definition", t, func() {
+		definition, err := application.ParseWorkflowDefinition([]byte(testWorkflowDefinition))
+		So(err, ShouldBeNil)
+		So(definition.Validate(application.DefaultRegistry()), ShouldBeNil)
+
+		Convey("When a transition names a guard that is not registered", func() {
+			definition.Transitions[1].Guards = []string{"unknown_guard"}
+
+			Convey("Then validation fails", func() {
+				err := definition.Validate(application.DefaultRegistry())
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `transition "APPROVED" has unknown guard "unknown_guard"`)
+			})
+		})
+
+		Convey("When a state names an enter action that is not registered", func() {
+			definition.States[1].EnterAction = "unknown_action"
+
+			Convey("Then validation fails", func() {
+				err := definition.Validate(application.DefaultRegistry())
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `state "APPROVED" has unknown enter action "unknown_action"`)
+			})
+		})
+
+		Convey("When a transition targets a state with no enter action", func() {
+			definition.States[1].EnterAction = ""
+
+			Convey("Then validation fails", func() {
+				err := definition.Validate(application.DefaultRegistry())
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `transition "APPROVED" targets state "APPROVED" which has no enter action`)
+			})
+		})
+
+		Convey("When a state cannot be reached from the initial state", func() {
+			definition.States = append(definition.States, application.StateDefinition{Name: "PUBLISHED", EnterAction: "publish"})
+
+			Convey("Then validation fails", func() {
+				err := definition.Validate(application.DefaultRegistry())
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `state "PUBLISHED" cannot be reached from initial state "DRAFT"`)
+			})
+		})
+
+		Convey("When a transition refers to a state that is not defined", func() {
+			definition.Transitions[0].Sources = append(definition.Transitions[0].Sources, "IN_REVIEW")
+
+			Convey("Then validation fails", func() {
+				err := definition.Validate(application.DefaultRegistry())
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `transition "DRAFT" has unknown source state "IN_REVIEW"`)
+			})
+		})
+
+		Convey("When a state machine is created from an invalid definition", func() {
+			definition.InitialState = "IN_REVIEW"
+			stateMachine, err := application.NewStateMachineFromDefinition(context.Background(), definition, application.DefaultRegistry(), store.Datastore{}, nil)
+
+			Convey("Then an error is returned", func() {
+				So(stateMachine, ShouldBeNil)
+				So(err.Error(), ShouldContainSubstring, `initial state "IN_REVIEW" is not defined`)
+			})
+		})
+	})
+
+	Convey("When invalid YAML is parsed", t, func() {
+		_, err := application.ParseWorkflowDefinition([]byte("states: ["))
+
+		Convey("Then an error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestNewStateMachineFromDefinition_Guards(t *testing.T) {
+	Convey("Given a state machine whose transition to APPROVED has a guard", t, func() {
+		ctx := context.Background()
+		errRefused := errors.New("refused")
+
+		definition, err := application.ParseWorkflowDefinition([]byte(testWorkflowDefinition))
+		So(err, ShouldBeNil)
+		definition.Transitions[1].Guards = []string{"refuse"}
+
+		registry := application.DefaultRegistry()
+		guardCalls := 0
+		registry.RegisterGuard("refuse", func(ctx context.Context, smBundle application.StateMachineBundleAPI, bundle *models.Bundle, authEntityData *models.AuthEntityData) error {
+			guardCalls++
+			return errRefused
+		})
+
+		mockedDatastore := &storetest.StorerMock{}
+		datastore := store.Datastore{Backend: mockedDatastore}
+		stateMachine, err := application.NewStateMachineFromDefinition(ctx, definition, registry, datastore, nil)
+		So(err, ShouldBeNil)
+
+		stateMachineBundleAPI := &application.StateMachineBundleAPI{Datastore: datastore, StateMachine: stateMachine}
+
+		Convey("When a draft bundle is transitioned to APPROVED", func() {
+			bundle := &models.Bundle{ID: "bundle-1", State: models.BundleStateDraft}
+			updatedBundle, err := stateMachine.Transition(ctx, stateMachineBundleAPI, bundle, models.BundleStateApproved, models.AuthEntityData{})
+
+			Convey("Then the guard's error is returned and the enter action is not run", func() {
+				So(updatedBundle, ShouldBeNil)
+				So(err, ShouldEqual, errRefused)
+				So(guardCalls, ShouldEqual, 1)
+				So(mockedDatastore.UpdateBundleCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,207 @@
+// Package calendar holds the release calendar, which defines the slots that bundles can be scheduled to publish in and
+// the dates on which nothing can be published
+package calendar
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	// Embed the time zone database so that the calendar's time zone can be loaded wherever the service runs
+	_ "time/tzdata"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+)
+
+// DateFormat is the format of the blackout dates in Config, and of the dates returned by Calendar.BlackoutDates
+const DateFormat = "2006-01-02"
+
+// Config defines the release calendar
+type Config struct {
+	// Enforced refuses a scheduled_at that is not a release slot, rather than only listing the slots
+	Enforced bool
+	// TimeZone is the IANA time zone the slots and blackout dates are in, such as "Europe/London"
+	TimeZone string
+	// Slots are the times of day, as "15:04", that bundles can be published at
+	Slots []string
+	// Weekdays are the days of the week, such as "Monday", that have release slots
+	Weekdays []string
+	// BlackoutDates are the dates, as "2006-01-02", or inclusive ranges of dates, as "2006-01-02/2006-01-02", on which
+	// nothing can be published
+	BlackoutDates []string
+}
+
+// Calendar is a release calendar, which can check whether a bundle can be scheduled to publish at a given time
+type Calendar struct {
+	enforced bool
+	location *time.Location
+	slots    []slot
+	weekdays []time.Weekday
+	blackout []period
+}
+
+// slot is a time of day that bundles can be published at
+type slot struct {
+	hour, minute int
+}
+
+// period is an inclusive range of dates, formatted with DateFormat so that they can be compared as strings
+type period struct {
+	from, to string
+}
+
+// New returns the release calendar defined by config
+func New(config Config) (*Calendar, error) {
+	location, err := time.LoadLocation(config.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release calendar time zone %q: %w", config.TimeZone, err)
+	}
+
+	calendar := &Calendar{
+		enforced: config.Enforced,
+		location: location,
+	}
+
+	for _, value := range config.Slots {
+		slotTime, err := time.Parse("15:04", strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid release calendar slot %q: %w", value, err)
+		}
+		calendar.slots = append(calendar.slots, slot{hour: slotTime.Hour(), minute: slotTime.Minute()})
+	}
+	slices.SortFunc(calendar.slots, func(a, b slot) int {
+		return (a.hour*60 + a.minute) - (b.hour*60 + b.minute)
+	})
+
+	for _, value := range config.Weekdays {
+		weekday, err := parseWeekday(value)
+		if err != nil {
+			return nil, err
+		}
+		calendar.weekdays = append(calendar.weekdays, weekday)
+	}
+
+	for _, value := range config.BlackoutDates {
+		blackout, err := parsePeriod(value)
+		if err != nil {
+			return nil, err
+		}
+		calendar.blackout = append(calendar.blackout, blackout)
+	}
+
+	return calendar, nil
+}
+
+// Enforced reports whether a scheduled_at must be a release slot
+func (c *Calendar) Enforced() bool {
+	return c.enforced
+}
+
+// Location returns the time zone of the calendar
+func (c *Calendar) Location() *time.Location {
+	return c.location
+}
+
+// Check returns an error if a bundle cannot be published at scheduledAt, either because it is on a blackout date or
+// because it is not one of the release slots
+func (c *Calendar) Check(scheduledAt time.Time) error {
+	local := scheduledAt.In(c.location)
+
+	if c.isBlackout(local) {
+		return apierrors.ErrScheduledAtInBlackout
+	}
+
+	if !slices.Contains(c.weekdays, local.Weekday()) || local.Second() != 0 || local.Nanosecond() != 0 {
+		return apierrors.ErrScheduledAtNotReleaseSlot
+	}
+
+	if !slices.Contains(c.slots, slot{hour: local.Hour(), minute: local.Minute()}) {
+		return apierrors.ErrScheduledAtNotReleaseSlot
+	}
+
+	return nil
+}
+
+// Slots returns the release slots from from, inclusive, to to, exclusive, in time order. Slots on blackout dates are left
+// out.
+func (c *Calendar) Slots(from, to time.Time) []time.Time {
+	slots := []time.Time{}
+
+	for day := startOfDay(from.In(c.location)); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !slices.Contains(c.weekdays, day.Weekday()) || c.isBlackout(day) {
+			continue
+		}
+
+		for _, s := range c.slots {
+			slotTime := time.Date(day.Year(), day.Month(), day.Day(), s.hour, s.minute, 0, 0, c.location)
+			if !slotTime.Before(from) && slotTime.Before(to) {
+				slots = append(slots, slotTime)
+			}
+		}
+	}
+
+	return slots
+}
+
+// BlackoutDates returns the blackout dates from from to to, formatted with DateFormat
+func (c *Calendar) BlackoutDates(from, to time.Time) []string {
+	dates := []string{}
+
+	for day := startOfDay(from.In(c.location)); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if c.isBlackout(day) {
+			dates = append(dates, day.Format(DateFormat))
+		}
+	}
+
+	return dates
+}
+
+func (c *Calendar) isBlackout(local time.Time) bool {
+	date := local.Format(DateFormat)
+
+	for _, blackout := range c.blackout {
+		if date >= blackout.from && date <= blackout.to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(strings.TrimSpace(value), weekday.String()) {
+			return weekday, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid release calendar weekday %q", value)
+}
+
+func parsePeriod(value string) (period, error) {
+	from, to, isRange := strings.Cut(strings.TrimSpace(value), "/")
+	if !isRange {
+		to = from
+	}
+
+	fromDate, err := time.Parse(DateFormat, from)
+	if err != nil {
+		return period{}, fmt.Errorf("invalid release calendar blackout date %q: %w", value, err)
+	}
+
+	toDate, err := time.Parse(DateFormat, to)
+	if err != nil {
+		return period{}, fmt.Errorf("invalid release calendar blackout date %q: %w", value, err)
+	}
+
+	if toDate.Before(fromDate) {
+		return period{}, fmt.Errorf("invalid release calendar blackout date %q: range ends before it starts", value)
+	}
+
+	return period{from: fromDate.Format(DateFormat), to: toDate.Format(DateFormat)}, nil
+}
@@ -0,0 +1,84 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCalendar(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a release calendar with weekday slots at 07:00 and 09:30 UK time and some blackout dates", t, func() {
+		calendar, err := New(Config{
+			Enforced:      true,
+			TimeZone:      "Europe/London",
+			Slots:         []string{"09:30", "07:00"},
+			Weekdays:      []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			BlackoutDates: []string{"2026-03-04", "2026-06-02/2026-06-03"},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("When a time in a slot is checked", func() {
+			Convey("Then it is allowed in both GMT and BST", func() {
+				So(calendar.Check(time.Date(2026, 3, 2, 7, 0, 0, 0, time.UTC)), ShouldBeNil)
+				So(calendar.Check(time.Date(2026, 6, 1, 8, 30, 0, 0, time.UTC)), ShouldBeNil)
+			})
+		})
+
+		Convey("When a time that is not a slot is checked", func() {
+			Convey("Then ErrScheduledAtNotReleaseSlot is returned", func() {
+				So(calendar.Check(time.Date(2026, 6, 1, 7, 0, 0, 0, time.UTC)), ShouldEqual, apierrors.ErrScheduledAtNotReleaseSlot)
+				So(calendar.Check(time.Date(2026, 3, 2, 7, 0, 1, 0, time.UTC)), ShouldEqual, apierrors.ErrScheduledAtNotReleaseSlot)
+				So(calendar.Check(time.Date(2026, 3, 7, 7, 0, 0, 0, time.UTC)), ShouldEqual, apierrors.ErrScheduledAtNotReleaseSlot)
+			})
+		})
+
+		Convey("When a slot on a blackout date is checked", func() {
+			Convey("Then ErrScheduledAtInBlackout is returned", func() {
+				So(calendar.Check(time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC)), ShouldEqual, apierrors.ErrScheduledAtInBlackout)
+				So(calendar.Check(time.Date(2026, 6, 3, 6, 0, 0, 0, time.UTC)), ShouldEqual, apierrors.ErrScheduledAtInBlackout)
+			})
+		})
+
+		Convey("When the slots in a week are listed", func() {
+			from := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+			slots := calendar.Slots(from, from.AddDate(0, 0, 7))
+
+			Convey("Then the weekday slots after from are returned in order, without the blackout date", func() {
+				So(slots, ShouldHaveLength, 8)
+				So(slots[0].Equal(time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)), ShouldBeTrue)
+				So(slots[1].Equal(time.Date(2026, 3, 3, 7, 0, 0, 0, time.UTC)), ShouldBeTrue)
+				So(slots[3].Equal(time.Date(2026, 3, 5, 7, 0, 0, 0, time.UTC)), ShouldBeTrue)
+				So(slots[7].Equal(time.Date(2026, 3, 9, 7, 0, 0, 0, time.UTC)), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the blackout dates in June are listed", func() {
+			from := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+			dates := calendar.BlackoutDates(from, from.AddDate(0, 1, 0))
+
+			Convey("Then every date in the blackout period is returned", func() {
+				So(dates, ShouldResemble, []string{"2026-06-02", "2026-06-03"})
+			})
+		})
+	})
+
+	Convey("When a release calendar is created with an invalid config", t, func() {
+		Convey("Then an error is returned", func() {
+			_, err := New(Config{TimeZone: "Europe/Nowhere"})
+			So(err, ShouldNotBeNil)
+
+			_, err = New(Config{TimeZone: "UTC", Slots: []string{"7am"}})
+			So(err, ShouldNotBeNil)
+
+			_, err = New(Config{TimeZone: "UTC", Weekdays: []string{"Funday"}})
+			So(err, ShouldNotBeNil)
+
+			_, err = New(Config{TimeZone: "UTC", BlackoutDates: []string{"2026-06-03/2026-06-02"}})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -18,22 +18,44 @@ type AuthConfig = authorisation.Config
 
 // Config represents service configuration for dis-bundle-api
 type Config struct {
-	BindAddr                   string        `envconfig:"BIND_ADDR"`
-	DatasetAPIURL              string        `envconfig:"DATASET_API_URL"`
-	GracefulShutdownTimeout    time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
-	HealthCheckInterval        time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
-	HealthCheckCriticalTimeout time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
-	OTBatchTimeout             time.Duration `encconfig:"OTEL_BATCH_TIMEOUT"`
-	OTExporterOTLPEndpoint     string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
-	OTServiceName              string        `envconfig:"OTEL_SERVICE_NAME"`
-	OtelEnabled                bool          `envconfig:"OTEL_ENABLED"`
-	DefaultMaxLimit            int           `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
-	DefaultLimit               int           `envconfig:"DEFAULT_LIMIT"`
-	DefaultOffset              int           `envconfig:"DEFAULT_OFFSET"`
-	EnablePermissionsAuth      bool          `envconfig:"ENABLE_PERMISSIONS_AUTH"`
-	ZebedeeURL                 string        `envconfig:"ZEBEDEE_URL"`
-	ZebedeeClientTimeout       time.Duration `envconfig:"ZEBEDEE_CLIENT_TIMEOUT"`
-	PreviewServiceURL          string        `envconfig:"PREVIEW_SERVICE_URL"`
+	BindAddr                        string         `envconfig:"BIND_ADDR"`
+	DatasetAPIURL                   string         `envconfig:"DATASET_API_URL"`
+	GracefulShutdownTimeout         time.Duration  `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
+	HealthCheckInterval             time.Duration  `envconfig:"HEALTHCHECK_INTERVAL"`
+	HealthCheckCriticalTimeout      time.Duration  `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
+	OTBatchTimeout                  time.Duration  `encconfig:"OTEL_BATCH_TIMEOUT"`
+	OTExporterOTLPEndpoint          string         `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTServiceName                   string         `envconfig:"OTEL_SERVICE_NAME"`
+	OtelEnabled                     bool           `envconfig:"OTEL_ENABLED"`
+	DefaultMaxLimit                 int            `envconfig:"DEFAULT_MAXIMUM_LIMIT"`
+	DefaultLimit                    int            `envconfig:"DEFAULT_LIMIT"`
+	DefaultOffset                   int            `envconfig:"DEFAULT_OFFSET"`
+	EnablePermissionsAuth           bool           `envconfig:"ENABLE_PERMISSIONS_AUTH"`
+	ZebedeeURL                      string         `envconfig:"ZEBEDEE_URL"`
+	ZebedeeClientTimeout            time.Duration  `envconfig:"ZEBEDEE_CLIENT_TIMEOUT"`
+	PreviewServiceURL               string         `envconfig:"PREVIEW_SERVICE_URL"`
+	ServiceAuthToken                string         `envconfig:"SERVICE_AUTH_TOKEN" json:"-"`
+	SchedulerEnabled                bool           `envconfig:"SCHEDULER_ENABLED"`
+	SchedulerPollInterval           time.Duration  `envconfig:"SCHEDULER_POLL_INTERVAL"`
+	SchedulerLockDuration           time.Duration  `envconfig:"SCHEDULER_LOCK_DURATION"`
+	PublishRunStaleTimeout          time.Duration  `envconfig:"PUBLISH_RUN_STALE_TIMEOUT"`
+	PublishMaxConcurrency           int            `envconfig:"PUBLISH_MAX_CONCURRENCY"`
+	DatasetAPIRetryMaxAttempts      int            `envconfig:"DATASET_API_RETRY_MAX_ATTEMPTS"`
+	DatasetAPIRetryInitialBackoff   time.Duration  `envconfig:"DATASET_API_RETRY_INITIAL_BACKOFF"`
+	DatasetAPIRetryMaxBackoff       time.Duration  `envconfig:"DATASET_API_RETRY_MAX_BACKOFF"`
+	StateMachineDefinitionPath      string         `envconfig:"STATE_MACHINE_DEFINITION_PATH"`
+	ApprovalRequireDifferentCreator bool           `envconfig:"APPROVAL_REQUIRE_DIFFERENT_CREATOR"`
+	ApprovalRequireDifferentEditors bool           `envconfig:"APPROVAL_REQUIRE_DIFFERENT_EDITORS"`
+	ApprovalExemptServiceIdentities bool           `envconfig:"APPROVAL_EXEMPT_SERVICE_IDENTITIES"`
+	ApprovalQuorum                  int            `envconfig:"APPROVAL_QUORUM"`
+	ApprovalQuorumByBundleType      map[string]int `envconfig:"APPROVAL_QUORUM_BY_BUNDLE_TYPE"`
+	ApprovalQuorumByManagedBy       map[string]int `envconfig:"APPROVAL_QUORUM_BY_MANAGED_BY"`
+	ApprovalBlockOnConflicts        bool           `envconfig:"APPROVAL_BLOCK_ON_CONFLICTS"`
+	ReleaseCalendarEnforced         bool           `envconfig:"RELEASE_CALENDAR_ENFORCED"`
+	ReleaseCalendarTimeZone         string         `envconfig:"RELEASE_CALENDAR_TIME_ZONE"`
+	ReleaseCalendarSlots            []string       `envconfig:"RELEASE_CALENDAR_SLOTS"`
+	ReleaseCalendarWeekdays         []string       `envconfig:"RELEASE_CALENDAR_WEEKDAYS"`
+	ReleaseCalendarBlackoutDates    []string       `envconfig:"RELEASE_CALENDAR_BLACKOUT_DATES"`
 	MongoConfig
 	AuthConfig                                *authorisation.Config
 	DataBundlePublicationServiceSlackEnabled  bool   `envconfig:"DATA_BUNDLE_PUBLICATION_SERVICE_SLACK_ENABLED"`
@@ -47,6 +69,9 @@ const (
 	BundlesCollection        = "BundlesCollection"
 	BundleEventsCollection   = "BundleEventsCollection"
 	BundleContentsCollection = "BundleContentsCollection"
+	PublishRunsCollection    = "PublishRunsCollection"
+	ApprovalsCollection      = "ApprovalsCollection"
+	CommentsCollection       = "CommentsCollection"
 )
 
 // Get returns the default config with any modifications through environment
@@ -57,28 +82,50 @@ func Get() (*Config, error) {
 	}
 
 	cfg = &Config{
-		BindAddr:                   ":29800",
-		DatasetAPIURL:              "http://localhost:22000",
-		GracefulShutdownTimeout:    5 * time.Second,
-		HealthCheckInterval:        30 * time.Second,
-		HealthCheckCriticalTimeout: 90 * time.Second,
-		OTBatchTimeout:             5 * time.Second,
-		OTExporterOTLPEndpoint:     "localhost:4317",
-		OTServiceName:              "dis-bundle-api",
-		OtelEnabled:                false,
-		DefaultMaxLimit:            1000,
-		DefaultLimit:               20,
-		DefaultOffset:              0,
-		ZebedeeURL:                 "http://localhost:8082",
-		ZebedeeClientTimeout:       30 * time.Second,
-		PreviewServiceURL:          "",
+		BindAddr:                        ":29800",
+		DatasetAPIURL:                   "http://localhost:22000",
+		GracefulShutdownTimeout:         5 * time.Second,
+		HealthCheckInterval:             30 * time.Second,
+		HealthCheckCriticalTimeout:      90 * time.Second,
+		OTBatchTimeout:                  5 * time.Second,
+		OTExporterOTLPEndpoint:          "localhost:4317",
+		OTServiceName:                   "dis-bundle-api",
+		OtelEnabled:                     false,
+		DefaultMaxLimit:                 1000,
+		DefaultLimit:                    20,
+		DefaultOffset:                   0,
+		ZebedeeURL:                      "http://localhost:8082",
+		ZebedeeClientTimeout:            30 * time.Second,
+		PreviewServiceURL:               "",
+		ServiceAuthToken:                "",
+		SchedulerEnabled:                false,
+		SchedulerPollInterval:           30 * time.Second,
+		SchedulerLockDuration:           5 * time.Minute,
+		PublishRunStaleTimeout:          5 * time.Minute,
+		PublishMaxConcurrency:           10,
+		DatasetAPIRetryMaxAttempts:      3,
+		DatasetAPIRetryInitialBackoff:   200 * time.Millisecond,
+		DatasetAPIRetryMaxBackoff:       5 * time.Second,
+		StateMachineDefinitionPath:      "",
+		ApprovalRequireDifferentCreator: false,
+		ApprovalRequireDifferentEditors: false,
+		ApprovalExemptServiceIdentities: true,
+		ApprovalQuorum:                  1,
+		ApprovalQuorumByBundleType:      map[string]int{},
+		ApprovalQuorumByManagedBy:       map[string]int{},
+		ApprovalBlockOnConflicts:        false,
+		ReleaseCalendarEnforced:         false,
+		ReleaseCalendarTimeZone:         "Europe/London",
+		ReleaseCalendarSlots:            []string{"07:00", "09:30"},
+		ReleaseCalendarWeekdays:         []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+		ReleaseCalendarBlackoutDates:    []string{},
 		MongoConfig: MongoConfig{
 			MongoDriverConfig: mongodriver.MongoDriverConfig{
 				ClusterEndpoint:               "localhost:27017",
 				Username:                      "",
 				Password:                      "",
 				Database:                      "bundles",
-				Collections:                   map[string]string{BundlesCollection: "bundles", BundleEventsCollection: "bundle_events", BundleContentsCollection: "bundle_contents"},
+				Collections:                   map[string]string{BundlesCollection: "bundles", BundleEventsCollection: "bundle_events", BundleContentsCollection: "bundle_contents", PublishRunsCollection: "bundle_publish_runs", ApprovalsCollection: "bundle_approvals", CommentsCollection: "bundle_comments"},
 				ReplicaSet:                    "",
 				IsStrongReadConcernEnabled:    false,
 				IsWriteConcernMajorityEnabled: true,
@@ -37,6 +37,28 @@ func TestSpec(t *testing.T) {
 				So(cfg.ZebedeeURL, ShouldEqual, "http://localhost:8082")
 				So(cfg.ZebedeeClientTimeout, ShouldEqual, 30*time.Second)
 				So(cfg.PreviewServiceURL, ShouldEqual, "")
+				So(cfg.ServiceAuthToken, ShouldEqual, "")
+				So(cfg.SchedulerEnabled, ShouldBeFalse)
+				So(cfg.SchedulerPollInterval, ShouldEqual, 30*time.Second)
+				So(cfg.SchedulerLockDuration, ShouldEqual, 5*time.Minute)
+				So(cfg.PublishRunStaleTimeout, ShouldEqual, 5*time.Minute)
+				So(cfg.PublishMaxConcurrency, ShouldEqual, 10)
+				So(cfg.DatasetAPIRetryMaxAttempts, ShouldEqual, 3)
+				So(cfg.DatasetAPIRetryInitialBackoff, ShouldEqual, 200*time.Millisecond)
+				So(cfg.DatasetAPIRetryMaxBackoff, ShouldEqual, 5*time.Second)
+				So(cfg.StateMachineDefinitionPath, ShouldEqual, "")
+				So(cfg.ApprovalRequireDifferentCreator, ShouldBeFalse)
+				So(cfg.ApprovalRequireDifferentEditors, ShouldBeFalse)
+				So(cfg.ApprovalExemptServiceIdentities, ShouldBeTrue)
+				So(cfg.ApprovalQuorum, ShouldEqual, 1)
+				So(cfg.ApprovalQuorumByBundleType, ShouldBeEmpty)
+				So(cfg.ApprovalQuorumByManagedBy, ShouldBeEmpty)
+				So(cfg.ApprovalBlockOnConflicts, ShouldBeFalse)
+				So(cfg.ReleaseCalendarEnforced, ShouldBeFalse)
+				So(cfg.ReleaseCalendarTimeZone, ShouldEqual, "Europe/London")
+				So(cfg.ReleaseCalendarSlots, ShouldResemble, []string{"07:00", "09:30"})
+				So(cfg.ReleaseCalendarWeekdays, ShouldResemble, []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"})
+				So(cfg.ReleaseCalendarBlackoutDates, ShouldBeEmpty)
 
 				So(cfg.ClusterEndpoint, ShouldEqual, "localhost:27017")
 				So(cfg.Username, ShouldEqual, "")
@@ -46,6 +68,9 @@ func TestSpec(t *testing.T) {
 					BundlesCollection:        "bundles",
 					BundleEventsCollection:   "bundle_events",
 					BundleContentsCollection: "bundle_contents",
+					PublishRunsCollection:    "bundle_publish_runs",
+					ApprovalsCollection:      "bundle_approvals",
+					CommentsCollection:       "bundle_comments",
 				})
 				So(cfg.ReplicaSet, ShouldEqual, "")
 				So(cfg.IsStrongReadConcernEnabled, ShouldBeFalse)
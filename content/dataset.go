@@ -0,0 +1,123 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+)
+
+// DatasetProvider is the provider for dataset content items, which refer to a version of a dataset in dataset API
+type DatasetProvider struct {
+	client datasetAPISDK.Clienter
+}
+
+// NewDatasetProvider returns a DatasetProvider that uses client to call dataset API
+func NewDatasetProvider(client datasetAPISDK.Clienter) *DatasetProvider {
+	return &DatasetProvider{client: client}
+}
+
+// Validate checks that the content item's version exists in dataset API, and links the content item to the version
+func (p *DatasetProvider) Validate(ctx context.Context, headers datasetAPISDK.Headers, contentItem *models.ContentItem) error {
+	version, err := p.getVersion(ctx, headers, contentItem)
+	if err != nil {
+		return err
+	}
+
+	if version.Links == nil || version.Links.WebPage == nil {
+		return fmt.Errorf("version %d has no web page link", contentItem.Metadata.VersionID)
+	}
+
+	versionURL, err := url.Parse(version.Links.WebPage.HRef)
+	if err != nil {
+		return err
+	}
+
+	contentItem.Links.Edit = editLink(contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, contentItem.Metadata.VersionID)
+	contentItem.Links.Preview = versionURL.Path
+
+	return nil
+}
+
+// GetState returns the state of the content item's version in dataset API
+func (p *DatasetProvider) GetState(ctx context.Context, headers datasetAPISDK.Headers, contentItem *models.ContentItem) (models.State, error) {
+	version, err := p.getVersion(ctx, headers, contentItem)
+	if err != nil {
+		return "", err
+	}
+
+	return models.State(strings.ToUpper(version.State)), nil
+}
+
+// Publish moves the content item's version to state in dataset API
+func (p *DatasetProvider) Publish(ctx context.Context, headers datasetAPISDK.Headers, contentItem *models.ContentItem, state models.State) error {
+	return p.client.PutVersionState(ctx, headers, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, strconv.Itoa(contentItem.Metadata.VersionID), strings.ToLower(state.String()))
+}
+
+// BuildLinks updates the content item's dataset and edition IDs and its links from the links on its dataset API version
+func (p *DatasetProvider) BuildLinks(ctx context.Context, headers datasetAPISDK.Headers, contentItem *models.ContentItem) error {
+	version, err := p.getVersion(ctx, headers, contentItem)
+	if err != nil {
+		return err
+	}
+
+	return refreshContentItemFromVersion(contentItem, &version)
+}
+
+// getVersion gets the content item's version from dataset API, returning a *NotFoundError against the metadata field
+// for whichever of the dataset, edition or version does not exist
+func (p *DatasetProvider) getVersion(ctx context.Context, headers datasetAPISDK.Headers, contentItem *models.ContentItem) (datasetAPIModels.Version, error) {
+	version, err := p.client.GetVersion(ctx, headers, contentItem.Metadata.DatasetID, contentItem.Metadata.EditionID, strconv.Itoa(contentItem.Metadata.VersionID))
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "dataset not found"):
+			return version, &NotFoundError{Field: "/metadata/dataset_id", Err: err}
+		case strings.Contains(err.Error(), "edition not found"):
+			return version, &NotFoundError{Field: "/metadata/edition_id", Err: err}
+		case strings.Contains(err.Error(), "version not found"):
+			return version, &NotFoundError{Field: "/metadata/version_id", Err: err}
+		default:
+			return version, err
+		}
+	}
+
+	return version, nil
+}
+
+func refreshContentItemFromVersion(contentItem *models.ContentItem, version *datasetAPIModels.Version) error {
+	datasetID := contentItem.Metadata.DatasetID
+	editionID := contentItem.Metadata.EditionID
+	previewLink := contentItem.Links.Preview
+
+	if version.Links != nil {
+		if version.Links.Dataset != nil && version.Links.Dataset.ID != "" {
+			datasetID = version.Links.Dataset.ID
+		}
+		if version.Links.Edition != nil && version.Links.Edition.ID != "" {
+			editionID = version.Links.Edition.ID
+		}
+		if version.Links.WebPage != nil && version.Links.WebPage.HRef != "" {
+			webPageURL, err := url.Parse(version.Links.WebPage.HRef)
+			if err != nil {
+				return err
+			}
+			previewLink = webPageURL.Path
+		}
+	}
+
+	contentItem.Metadata.DatasetID = datasetID
+	contentItem.Metadata.EditionID = editionID
+	contentItem.Links.Edit = editLink(datasetID, editionID, contentItem.Metadata.VersionID)
+	contentItem.Links.Preview = previewLink
+
+	return nil
+}
+
+func editLink(datasetID, editionID string, versionID int) string {
+	return fmt.Sprintf("/data-admin/series/%s/editions/%s/versions/%d", datasetID, editionID, versionID)
+}
@@ -0,0 +1,116 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPIMocks "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newDatasetContentItem() *models.ContentItem {
+	return &models.ContentItem{
+		ID:          "content-item-1",
+		BundleID:    "bundle-1",
+		ContentType: models.ContentTypeDataset,
+		Metadata: models.Metadata{
+			DatasetID: "dataset-1",
+			EditionID: "edition-1",
+			VersionID: 1,
+		},
+	}
+}
+
+func TestDatasetProvider_Validate(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a DatasetProvider whose version has a web page link", t, func() {
+		mockDatasetAPI := &datasetAPIMocks.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				return datasetAPIModels.Version{
+					Links: &datasetAPIModels.VersionLinks{
+						WebPage: &datasetAPIModels.LinkObject{HRef: "https://ons.gov.uk/datasets/dataset-1/editions/edition-1/versions/1"},
+					},
+				}, nil
+			},
+		}
+		provider := NewDatasetProvider(mockDatasetAPI)
+		contentItem := newDatasetContentItem()
+
+		Convey("When Validate is called", func() {
+			err := provider.Validate(ctx, datasetAPISDK.Headers{}, contentItem)
+
+			Convey("Then the content item is linked to its version", func() {
+				So(err, ShouldBeNil)
+				So(contentItem.Links.Edit, ShouldEqual, "/data-admin/series/dataset-1/editions/edition-1/versions/1")
+				So(contentItem.Links.Preview, ShouldEqual, "/datasets/dataset-1/editions/edition-1/versions/1")
+			})
+		})
+	})
+
+	Convey("Given a DatasetProvider whose edition does not exist", t, func() {
+		mockDatasetAPI := &datasetAPIMocks.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				return datasetAPIModels.Version{}, errors.New("edition not found")
+			},
+		}
+		provider := NewDatasetProvider(mockDatasetAPI)
+
+		Convey("When Validate is called", func() {
+			err := provider.Validate(ctx, datasetAPISDK.Headers{}, newDatasetContentItem())
+
+			Convey("Then a NotFoundError is returned against the edition ID", func() {
+				var notFoundErr *NotFoundError
+				So(errors.As(err, &notFoundErr), ShouldBeTrue)
+				So(notFoundErr.Field, ShouldEqual, "/metadata/edition_id")
+			})
+		})
+	})
+}
+
+func TestDatasetProvider_GetState(t *testing.T) {
+	Convey("Given a DatasetProvider whose version is approved", t, func() {
+		mockDatasetAPI := &datasetAPIMocks.ClienterMock{
+			GetVersionFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (datasetAPIModels.Version, error) {
+				return datasetAPIModels.Version{State: datasetAPIModels.ApprovedState}, nil
+			},
+		}
+		provider := NewDatasetProvider(mockDatasetAPI)
+
+		Convey("When GetState is called", func() {
+			state, err := provider.GetState(context.Background(), datasetAPISDK.Headers{}, newDatasetContentItem())
+
+			Convey("Then APPROVED is returned", func() {
+				So(err, ShouldBeNil)
+				So(state, ShouldEqual, models.StateApproved)
+			})
+		})
+	})
+}
+
+func TestDatasetProvider_Publish(t *testing.T) {
+	Convey("Given a DatasetProvider", t, func() {
+		mockDatasetAPI := &datasetAPIMocks.ClienterMock{
+			PutVersionStateFunc: func(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
+				return nil
+			},
+		}
+		provider := NewDatasetProvider(mockDatasetAPI)
+
+		Convey("When Publish is called", func() {
+			err := provider.Publish(context.Background(), datasetAPISDK.Headers{}, newDatasetContentItem(), models.StatePublished)
+
+			Convey("Then the version state is updated in dataset API", func() {
+				So(err, ShouldBeNil)
+				So(mockDatasetAPI.PutVersionStateCalls(), ShouldHaveLength, 1)
+				So(mockDatasetAPI.PutVersionStateCalls()[0].DatasetID, ShouldEqual, "dataset-1")
+				So(mockDatasetAPI.PutVersionStateCalls()[0].VersionID, ShouldEqual, "1")
+				So(mockDatasetAPI.PutVersionStateCalls()[0].State, ShouldEqual, "published")
+			})
+		})
+	})
+}
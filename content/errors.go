@@ -0,0 +1,18 @@
+package content
+
+import "fmt"
+
+// NotFoundError is returned if the content a content item refers to does not exist. Field is the content item field
+// that refers to the missing content.
+type NotFoundError struct {
+	Field string
+	Err   error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found: %v", e.Field, e.Err)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
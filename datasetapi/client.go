@@ -0,0 +1,53 @@
+package datasetapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	dphttp "github.com/ONSdigital/dp-net/v3/http"
+)
+
+const serviceName = "dp-dataset-api"
+
+// responseStatusKey is the context key for the responseStatus of a request
+type responseStatusKey struct{}
+
+// responseStatus holds the status of the last response received for a request
+type responseStatus struct {
+	code int
+}
+
+// withResponseStatus returns a context that records the status of the responses to requests made with it
+func withResponseStatus(ctx context.Context) (context.Context, *responseStatus) {
+	status := &responseStatus{}
+	return context.WithValue(ctx, responseStatusKey{}, status), status
+}
+
+// statusRecordingTransport records the status of each response in the responseStatus of the request context, as the
+// dataset API SDK does not include the status in all the errors it returns
+type statusRecordingTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip makes the request using the wrapped transport and records the status of the response
+func (t statusRecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		if status, ok := req.Context().Value(responseStatusKey{}).(*responseStatus); ok {
+			status.code = resp.StatusCode
+		}
+	}
+	return resp, err
+}
+
+// NewClient returns a dataset API client that records the status of each response, so that RetryClient can tell
+// which failed requests to retry. The client does not retry requests itself, so that only requests that are safe to
+// repeat are retried, by RetryClient.
+func NewClient(datasetAPIURL string) *datasetAPISDK.Client {
+	clienter := dphttp.NewClientWithTransport(statusRecordingTransport{next: dphttp.DefaultTransport})
+	clienter.SetMaxRetries(0)
+
+	return datasetAPISDK.NewWithHealthClient(health.NewClientWithClienter(serviceName, datasetAPIURL, clienter))
+}
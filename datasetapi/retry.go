@@ -0,0 +1,156 @@
+package datasetapi
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	"github.com/ONSdigital/log.go/v2/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const retriesMetricName = "dataset_api.client.retries"
+
+// retryableStatusCodes are the statuses, from the gateway in front of dataset API, that mean a request did not reach it
+var retryableStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetryConfig defines how failed dataset API requests are retried
+type RetryConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// RetryClient wraps a dataset API client, retrying the requests made when publishing and approving bundles if they
+// fail with a connection error or a 502, 503 or 504. Only requests that are safe to repeat are retried: getting
+// datasets and versions, and setting the state of a version, which leaves the version in the same state however many
+// times it is made. Retries use exponential backoff with jitter. All other requests are passed straight through to the
+// wrapped client. The status of a failed request is only known if the wrapped client was created with NewClient.
+type RetryClient struct {
+	datasetAPISDK.Clienter
+	config  RetryConfig
+	retries metric.Int64Counter
+	jitter  func(d time.Duration) time.Duration
+}
+
+// NewRetryClient returns a RetryClient that wraps client, recording retries using meter
+func NewRetryClient(client datasetAPISDK.Clienter, config RetryConfig, meter metric.Meter) (*RetryClient, error) {
+	retries, err := meter.Int64Counter(retriesMetricName,
+		metric.WithDescription("The number of times a failed dataset API request has been retried"),
+		metric.WithUnit("{retry}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RetryClient{
+		Clienter: client,
+		config:   config,
+		retries:  retries,
+		jitter:   equalJitter,
+	}, nil
+}
+
+// GetDataset gets a dataset, retrying transient failures
+func (c *RetryClient) GetDataset(ctx context.Context, headers datasetAPISDK.Headers, datasetID string) (dataset datasetAPIModels.Dataset, err error) {
+	err = c.do(ctx, "GetDataset", func(ctx context.Context) error {
+		var callErr error
+		dataset, callErr = c.Clienter.GetDataset(ctx, headers, datasetID)
+		return callErr
+	})
+	return dataset, err
+}
+
+// GetVersion gets a version, retrying transient failures
+func (c *RetryClient) GetVersion(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID string) (version datasetAPIModels.Version, err error) {
+	err = c.do(ctx, "GetVersion", func(ctx context.Context) error {
+		var callErr error
+		version, callErr = c.Clienter.GetVersion(ctx, headers, datasetID, editionID, versionID)
+		return callErr
+	})
+	return version, err
+}
+
+// PutVersionState updates the state of a version, retrying transient failures
+func (c *RetryClient) PutVersionState(ctx context.Context, headers datasetAPISDK.Headers, datasetID, editionID, versionID, state string) error {
+	return c.do(ctx, "PutVersionState", func(ctx context.Context) error {
+		return c.Clienter.PutVersionState(ctx, headers, datasetID, editionID, versionID, state)
+	})
+}
+
+// do calls call until it succeeds, fails with an error that is not retryable, or the maximum number of attempts is
+// reached, and returns the error from the last attempt. Each attempt is made with a context that records the status of
+// the response.
+func (c *RetryClient) do(ctx context.Context, method string, call func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		attemptCtx, status := withResponseStatus(ctx)
+		err := call(attemptCtx)
+		if err == nil || attempt >= c.config.MaxAttempts || !c.isRetryable(ctx, err, status.code) {
+			return err
+		}
+
+		backoff := c.backoff(attempt)
+		log.Warn(ctx, "dataset API request failed, retrying", log.Data{
+			"method":       method,
+			"attempt":      attempt,
+			"max_attempts": c.config.MaxAttempts,
+			"backoff":      backoff.String(),
+			"status_code":  status.code,
+			"error":        err.Error(),
+		})
+		c.retries.Add(ctx, 1, metric.WithAttributes(attribute.String("method", method)))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// backoff returns how long to wait before retrying after the given attempt. The wait doubles after each attempt, up to
+// the configured maximum, and is jittered so that concurrent requests do not all retry at once.
+func (c *RetryClient) backoff(attempt int) time.Duration {
+	backoff := c.config.InitialBackoff
+	for i := 1; i < attempt && backoff < c.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if c.config.MaxBackoff > 0 && backoff > c.config.MaxBackoff {
+		backoff = c.config.MaxBackoff
+	}
+
+	return c.jitter(backoff)
+}
+
+// equalJitter returns a random duration between half of d and d
+func equalJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// isRetryable returns whether a failed request, which received a response with statusCode or none if it is zero, may
+// succeed if it is retried, which is when it failed to reach dataset API rather than being rejected by it
+func (c *RetryClient) isRetryable(ctx context.Context, err error, statusCode int) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return slices.Contains(retryableStatusCodes, statusCode)
+}
@@ -0,0 +1,290 @@
+package datasetapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	datasetAPIModels "github.com/ONSdigital/dp-dataset-api/models"
+	datasetAPISDK "github.com/ONSdigital/dp-dataset-api/sdk"
+	datasetAPIMocks "github.com/ONSdigital/dp-dataset-api/sdk/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+var testRetryConfig = RetryConfig{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     4 * time.Millisecond,
+}
+
+// countingCounter is an Int64Counter that records the total it has been incremented by
+type countingCounter struct {
+	embedded.Int64Counter
+	mu    sync.Mutex
+	total int64
+}
+
+func (c *countingCounter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.total += incr
+}
+
+func (c *countingCounter) Enabled(ctx context.Context) bool {
+	return true
+}
+
+func newTestRetryClient(client datasetAPISDK.Clienter) (*RetryClient, *countingCounter) {
+	retryClient, err := NewRetryClient(client, testRetryConfig, noop.NewMeterProvider().Meter("test"))
+	So(err, ShouldBeNil)
+
+	counter := &countingCounter{}
+	retryClient.retries = counter
+	return retryClient, counter
+}
+
+// fakeDatasetAPI is a dataset API that responds to the first failures requests with failureStatus and failureBody, and
+// to the rest with successBody
+type fakeDatasetAPI struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func newFakeDatasetAPI(failures, failureStatus int, failureBody, successBody string) *fakeDatasetAPI {
+	api := &fakeDatasetAPI{}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		api.requests = append(api.requests, r)
+		count := len(api.requests)
+		api.mu.Unlock()
+
+		if count <= failures {
+			w.WriteHeader(failureStatus)
+			_, _ = w.Write([]byte(failureBody))
+			return
+		}
+		_, _ = w.Write([]byte(successBody))
+	}))
+	return api
+}
+
+func (api *fakeDatasetAPI) requestCount() int {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return len(api.requests)
+}
+
+func TestRetryClient_PutVersionState(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given dataset API fails once with a retryable status before succeeding", t, func() {
+		datasetAPI := newFakeDatasetAPI(1, http.StatusServiceUnavailable, "service unavailable", "")
+		defer datasetAPI.Close()
+		retryClient, retries := newTestRetryClient(NewClient(datasetAPI.URL))
+
+		Convey("When PutVersionState is called", func() {
+			err := retryClient.PutVersionState(ctx, datasetAPISDK.Headers{}, "dataset1", "edition1", "1", "published")
+
+			Convey("Then the request is retried and succeeds", func() {
+				So(err, ShouldBeNil)
+				So(datasetAPI.requestCount(), ShouldEqual, 2)
+				So(datasetAPI.requests[1].URL.Path, ShouldEqual, "/datasets/dataset1/editions/edition1/versions/1/state")
+			})
+
+			Convey("And the retry is counted", func() {
+				So(retries.total, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given dataset API keeps failing with a retryable status", t, func() {
+		datasetAPI := newFakeDatasetAPI(10, http.StatusGatewayTimeout, "gateway timeout", "")
+		defer datasetAPI.Close()
+		retryClient, retries := newTestRetryClient(NewClient(datasetAPI.URL))
+
+		Convey("When PutVersionState is called", func() {
+			err := retryClient.PutVersionState(ctx, datasetAPISDK.Headers{}, "dataset1", "edition1", "1", "published")
+
+			Convey("Then the error is returned once the maximum attempts have been made", func() {
+				So(err, ShouldNotBeNil)
+				So(datasetAPI.requestCount(), ShouldEqual, 3)
+				So(retries.total, ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given dataset API fails with a status that is not retryable", t, func() {
+		datasetAPI := newFakeDatasetAPI(1, http.StatusBadRequest, "invalid state", "")
+		defer datasetAPI.Close()
+		retryClient, retries := newTestRetryClient(NewClient(datasetAPI.URL))
+
+		Convey("When PutVersionState is called", func() {
+			err := retryClient.PutVersionState(ctx, datasetAPISDK.Headers{}, "dataset1", "edition1", "1", "published")
+
+			Convey("Then the error is returned without retrying", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "invalid state")
+				So(datasetAPI.requestCount(), ShouldEqual, 1)
+				So(retries.total, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestRetryClient_GetVersion(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the gateway in front of dataset API fails once before dataset API returns the version", t, func() {
+		datasetAPI := newFakeDatasetAPI(1, http.StatusBadGateway, "bad gateway", `{"id":"version1","state":"approved"}`)
+		defer datasetAPI.Close()
+		retryClient, retries := newTestRetryClient(NewClient(datasetAPI.URL))
+
+		Convey("When GetVersion is called", func() {
+			version, err := retryClient.GetVersion(ctx, datasetAPISDK.Headers{}, "dataset1", "edition1", "1")
+
+			Convey("Then the version from the successful attempt is returned", func() {
+				So(err, ShouldBeNil)
+				So(version.ID, ShouldEqual, "version1")
+				So(version.State, ShouldEqual, datasetAPIModels.ApprovedState)
+				So(datasetAPI.requestCount(), ShouldEqual, 2)
+				So(retries.total, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given dataset API returns an error of its own", t, func() {
+		datasetAPI := newFakeDatasetAPI(1, http.StatusNotFound, `{"errors":[{"code":"VersionNotFound","description":"version not found"}]}`, "")
+		defer datasetAPI.Close()
+		retryClient, retries := newTestRetryClient(NewClient(datasetAPI.URL))
+
+		Convey("When GetVersion is called", func() {
+			_, err := retryClient.GetVersion(ctx, datasetAPISDK.Headers{}, "dataset1", "edition1", "1")
+
+			Convey("Then the error is returned without retrying", func() {
+				So(err, ShouldNotBeNil)
+				So(datasetAPI.requestCount(), ShouldEqual, 1)
+				So(retries.total, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestRetryClient_GetDataset(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given dataset API is unavailable once before returning the dataset", t, func() {
+		datasetAPI := newFakeDatasetAPI(1, http.StatusServiceUnavailable, "service unavailable", `{"id":"dataset1","state":"associated"}`)
+		defer datasetAPI.Close()
+		retryClient, retries := newTestRetryClient(NewClient(datasetAPI.URL))
+
+		Convey("When GetDataset is called", func() {
+			dataset, err := retryClient.GetDataset(ctx, datasetAPISDK.Headers{}, "dataset1")
+
+			Convey("Then the dataset from the successful attempt is returned", func() {
+				So(err, ShouldBeNil)
+				So(dataset.ID, ShouldEqual, "dataset1")
+				So(datasetAPI.requestCount(), ShouldEqual, 2)
+				So(retries.total, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given dataset API cannot be reached", t, func() {
+		datasetAPI := newFakeDatasetAPI(0, 0, "", "")
+		datasetAPI.Close()
+		retryClient, retries := newTestRetryClient(NewClient(datasetAPI.URL))
+
+		Convey("When GetDataset is called", func() {
+			_, err := retryClient.GetDataset(ctx, datasetAPISDK.Headers{}, "dataset1")
+
+			Convey("Then the request is retried until the maximum attempts have been made", func() {
+				So(err, ShouldNotBeNil)
+				So(retries.total, ShouldEqual, 2)
+			})
+		})
+	})
+}
+
+func TestRetryClient_PutVersion(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given dataset API is unavailable", t, func() {
+		datasetAPI := newFakeDatasetAPI(10, http.StatusServiceUnavailable, "service unavailable", "")
+		defer datasetAPI.Close()
+		retryClient, retries := newTestRetryClient(NewClient(datasetAPI.URL))
+
+		Convey("When PutVersion is called", func() {
+			_, err := retryClient.PutVersion(ctx, datasetAPISDK.Headers{}, "dataset1", "edition1", "1", datasetAPIModels.Version{})
+
+			Convey("Then the error is returned without retrying, as the update may not be safe to repeat", func() {
+				So(err, ShouldNotBeNil)
+				So(datasetAPI.requestCount(), ShouldEqual, 1)
+				So(retries.total, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestRetryClient_IsRetryable(t *testing.T) {
+	Convey("Given a RetryClient", t, func() {
+		retryClient, _ := newTestRetryClient(&datasetAPIMocks.ClienterMock{})
+		ctx := context.Background()
+		errFailed := errors.New("Client failed to read DatasetAPI body")
+
+		Convey("Then network errors are retryable", func() {
+			So(retryClient.isRetryable(ctx, &net.OpError{Op: "dial", Err: errors.New("connection refused")}, 0), ShouldBeTrue)
+		})
+
+		Convey("Then 502, 503 and 504 statuses are retryable and others are not", func() {
+			for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+				So(retryClient.isRetryable(ctx, errFailed, status), ShouldBeTrue)
+			}
+			for _, status := range []int{http.StatusNotFound, http.StatusTooManyRequests, http.StatusInternalServerError} {
+				So(retryClient.isRetryable(ctx, errFailed, status), ShouldBeFalse)
+			}
+		})
+
+		Convey("Then errors without a response are not retryable unless they are network errors", func() {
+			So(retryClient.isRetryable(ctx, errFailed, 0), ShouldBeFalse)
+		})
+
+		Convey("Then nothing is retryable once the context is done", func() {
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+			So(retryClient.isRetryable(cancelledCtx, &net.OpError{Op: "dial", Err: errors.New("connection refused")}, 0), ShouldBeFalse)
+			So(retryClient.isRetryable(cancelledCtx, errFailed, http.StatusServiceUnavailable), ShouldBeFalse)
+		})
+	})
+}
+
+func TestRetryClient_Backoff(t *testing.T) {
+	Convey("Given a RetryClient without jitter", t, func() {
+		retryClient, _ := newTestRetryClient(&datasetAPIMocks.ClienterMock{})
+		retryClient.jitter = func(d time.Duration) time.Duration { return d }
+
+		Convey("Then the backoff doubles after each attempt up to the maximum", func() {
+			So(retryClient.backoff(1), ShouldEqual, time.Millisecond)
+			So(retryClient.backoff(2), ShouldEqual, 2*time.Millisecond)
+			So(retryClient.backoff(3), ShouldEqual, 4*time.Millisecond)
+			So(retryClient.backoff(10), ShouldEqual, 4*time.Millisecond)
+		})
+	})
+
+	Convey("When equalJitter is applied to a backoff", t, func() {
+		for i := 0; i < 100; i++ {
+			jittered := equalJitter(10 * time.Millisecond)
+
+			So(jittered, ShouldBeGreaterThanOrEqualTo, 5*time.Millisecond)
+			So(jittered, ShouldBeLessThanOrEqualTo, 10*time.Millisecond)
+		}
+	})
+}
@@ -198,6 +198,14 @@ Feature: Update a bundle - PUT /bundles/{id}
                 "managed_by": "DATA-ADMIN",
                 "state": "IN_REVIEW",
                 "title": "Bundle Moving to Review",
+                "last_transition": {
+                    "from_state": "DRAFT",
+                    "to_state": "IN_REVIEW",
+                    "transitioned_by": {
+                        "email": "janedoe@example.com"
+                    },
+                    "transitioned_at": "{{DYNAMIC_TIMESTAMP}}"
+                },
                 "updated_at": "{{DYNAMIC_TIMESTAMP}}"
             }
             """
@@ -232,6 +240,14 @@ Feature: Update a bundle - PUT /bundles/{id}
                 "managed_by": "DATA-ADMIN",
                 "state": "PUBLISHED",
                 "title": "Published Bundle",
+                "last_transition": {
+                    "from_state": "APPROVED",
+                    "to_state": "PUBLISHED",
+                    "transitioned_by": {
+                        "email": "janedoe@example.com"
+                    },
+                    "transitioned_at": "{{DYNAMIC_TIMESTAMP}}"
+                },
                 "updated_at": "{{DYNAMIC_TIMESTAMP}}"
             }
             """
@@ -482,6 +482,14 @@ Feature: Update Bundles functionality - PUT /bundles/{id}/state
                     ],
                     "state": "PUBLISHED",
                     "title": "bundle-4",
+                    "last_transition": {
+                        "from_state": "APPROVED",
+                        "to_state": "PUBLISHED",
+                        "transitioned_by": {
+                            "email": "janedoe@example.com"
+                        },
+                        "transitioned_at": "{{DYNAMIC_TIMESTAMP}}"
+                    },
                     "updated_at": "{{DYNAMIC_TIMESTAMP}}",
                     "managed_by": "WAGTAIL"
                 }
@@ -541,6 +549,14 @@ Feature: Update Bundles functionality - PUT /bundles/{id}/state
                     ],
                     "state": "PUBLISHED",
                     "title": "bundle-5",
+                    "last_transition": {
+                        "from_state": "APPROVED",
+                        "to_state": "PUBLISHED",
+                        "transitioned_by": {
+                            "email": "janedoe@example.com"
+                        },
+                        "transitioned_at": "{{DYNAMIC_TIMESTAMP}}"
+                    },
                     "updated_at": "{{DYNAMIC_TIMESTAMP}}",
                     "managed_by": "WAGTAIL"
                 }
@@ -654,6 +670,30 @@ Feature: Update Bundles functionality - PUT /bundles/{id}/state
                 }
             """
 
+    Scenario: PUT /bundles/{id}/state for 'IN_REVIEW' -> 'DRAFT' without a reason
+        Given I am an admin user
+        And I set the "If-Match" header to "etag-bundle-3"
+        When I PUT "/bundles/bundle-3/state"
+            """
+                {
+                    "state": "DRAFT"
+                }
+            """
+        Then the HTTP status code should be "400"
+        And I should receive the following JSON response:
+            """
+                {
+                    "errors":[
+                        {
+                            "code": "MissingParameters",
+                            "description": "A reason is required when sending a bundle in review or approved back to DRAFT."
+                        }
+                    ]
+                }
+            """
+        And bundle "bundle-3" should have state "IN_REVIEW"
+        And bundle "bundle-3" should have this etag "etag-bundle-3"
+
     Scenario: PUT /bundles/{id}/state with missing bundle
         Given I am an admin user
         And I set the "If-Match" header to "etag-bundle-4"
@@ -713,7 +753,7 @@ Feature: Update Bundles functionality - PUT /bundles/{id}/state
         And bundle "bundle-10" should have state "IN_REVIEW"
         And bundle "bundle-10" should have this etag "etag-bundle-10"
 
-    Scenario: PUT /bundles/{id}/state where one content item fails but the remaining content item is still processed
+    Scenario: PUT /bundles/{id}/state where one content item fails but the remaining content item is still processed and the bundle is marked as failed
         Given I am an admin user
         And I set the "If-Match" header to "etag-bundle-11"
         When I PUT "/bundles/bundle-11/state"
@@ -723,7 +763,7 @@ Feature: Update Bundles functionality - PUT /bundles/{id}/state
                 }
             """
         Then the HTTP status code should be "200"
-        And bundle "bundle-11" should have state "PUBLISHED"
+        And bundle "bundle-11" should have state "PUBLISH_FAILED"
         And bundle "bundle-11" should not have this etag "etag-bundle-11"
         And these content item states should match:
             """
@@ -771,6 +811,14 @@ Feature: Update Bundles functionality - PUT /bundles/{id}/state
                     ],
                     "state": "APPROVED",
                     "title": "bundle-3",
+                    "last_transition": {
+                        "from_state": "IN_REVIEW",
+                        "to_state": "APPROVED",
+                        "transitioned_by": {
+                            "email": "janedoe@example.com"
+                        },
+                        "transitioned_at": "{{DYNAMIC_TIMESTAMP}}"
+                    },
                     "updated_at": "{{DYNAMIC_TIMESTAMP}}",
                     "managed_by": "WAGTAIL"
                 }
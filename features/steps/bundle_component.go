@@ -149,6 +149,13 @@ func getPermissionsBundle() *permissionsAPISDK.Bundle {
 				},
 			},
 		},
+		"bundles:unpublish": {
+			"groups/role-admin": {
+				{
+					ID: "1",
+				},
+			},
+		},
 		"bundles:delete": {
 			"groups/role-admin": {
 				{
@@ -436,31 +436,50 @@ func (c *BundleComponent) theResponseShouldContainTheFollowingJSONResponseWithAD
 		return fmt.Errorf("invalid expected JSON: %w", err)
 	}
 
-	if expectedTimestamp, ok := expected["updated_at"].(string); ok && expectedTimestamp == "{{DYNAMIC_TIMESTAMP}}" {
-		actualTimestampStr, ok := actual["updated_at"].(string)
+	if err := matchDynamicTimestamp(actual, expected, "updated_at"); err != nil {
+		return err
+	}
+
+	actualTransition, _ := actual["last_transition"].(map[string]interface{})
+	expectedTransition, _ := expected["last_transition"].(map[string]interface{})
+	if actualTransition != nil && expectedTransition != nil {
+		if err := matchDynamicTimestamp(actualTransition, expectedTransition, "transitioned_at"); err != nil {
+			return err
+		}
+	}
+
+	got, _ := json.Marshal(actual)
+	want, _ := json.Marshal(expected)
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("response mismatch:\nExpected: %s\nActual:   %s", want, got)
+	}
+	return nil
+}
+
+// matchDynamicTimestamp checks that the actual value of key is a recent timestamp if the expected value is
+// {{DYNAMIC_TIMESTAMP}}, and if so removes it from both so the rest of the JSON can be compared
+func matchDynamicTimestamp(actual, expected map[string]interface{}, key string) error {
+	if expectedTimestamp, ok := expected[key].(string); ok && expectedTimestamp == "{{DYNAMIC_TIMESTAMP}}" {
+		actualTimestampStr, ok := actual[key].(string)
 		if !ok {
-			return fmt.Errorf("missing or non-string updated_at in actual")
+			return fmt.Errorf("missing or non-string %s in actual", key)
 		}
 		parsedTimestamp, err := time.Parse(time.RFC3339, actualTimestampStr)
 		if err != nil {
-			return fmt.Errorf("updated_at is not a valid RFC3339 timestamp: %w", err)
+			return fmt.Errorf("%s is not a valid RFC3339 timestamp: %w", key, err)
 		}
 		timestampAge := time.Since(parsedTimestamp)
 		if timestampAge < 0 || timestampAge > 10*time.Second {
-			return fmt.Errorf("updated_at %v is not within 10s of now", parsedTimestamp)
+			return fmt.Errorf("%s %v is not within 10s of now", key, parsedTimestamp)
 		}
 
-		delete(actual, "updated_at")
-		delete(expected, "updated_at")
+		delete(actual, key)
+		delete(expected, key)
 	}
 
-	got, _ := json.Marshal(actual)
-	want, _ := json.Marshal(expected)
-	if !bytes.Equal(got, want) {
-		return fmt.Errorf("response mismatch:\nExpected: %s\nActual:   %s", want, got)
-	}
 	return nil
 }
+
 func (c *BundleComponent) iHaveTheseDatasetVersions(contentItemsJSON *godog.DocString) error {
 	versions := []*datasetAPIModels.Version{}
 
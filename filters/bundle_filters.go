@@ -1,27 +1,246 @@
 package filters
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
 )
 
 const (
-	PublishDate = "publish_date"
+	PublishDate     = "publish_date"
+	BundleType      = "bundle_type"
+	ManagedBy       = "managed_by"
+	CreatedBy       = "created_by"
+	PreviewTeam     = "preview_team"
+	Title           = "title"
+	CreatedAfter    = "created_after"
+	CreatedBefore   = "created_before"
+	UpdatedAfter    = "updated_after"
+	UpdatedBefore   = "updated_before"
+	ScheduledAfter  = "scheduled_after"
+	ScheduledBefore = "scheduled_before"
+	Limit           = "limit"
+	Offset          = "offset"
 )
 
+// allowedBundleParams are the query parameters that can be supplied when listing bundles
+var allowedBundleParams = map[string]bool{
+	PublishDate:     true,
+	State:           true,
+	BundleType:      true,
+	ManagedBy:       true,
+	CreatedBy:       true,
+	PreviewTeam:     true,
+	Title:           true,
+	CreatedAfter:    true,
+	CreatedBefore:   true,
+	UpdatedAfter:    true,
+	UpdatedBefore:   true,
+	ScheduledAfter:  true,
+	ScheduledBefore: true,
+	Sort:            true,
+	Cursor:          true,
+	Fields:          true,
+	Embed:           true,
+	Limit:           true,
+	Offset:          true,
+}
+
+// TimeRange is a range of times, either end of which may be open
+type TimeRange struct {
+	After  *time.Time
+	Before *time.Time
+}
+
 // Bundle filter option
 type BundleFilters struct {
 	PublishDate *time.Time
+	States      []models.BundleState
+	BundleType  *models.BundleType
+	ManagedBy   *models.ManagedBy
+	CreatedBy   *string
+	PreviewTeam *string
+	Title       *string
+	CreatedAt   *TimeRange
+	UpdatedAt   *TimeRange
+	ScheduledAt *TimeRange
+	Sort        []SortField
+	Cursor      *string
+	View        *BundleView
 }
 
 // Creates BundleFilters from the query parameters in the request
 func CreateBundlefilters(r *http.Request) (*BundleFilters, *QueryParamParseError) {
+	if err := checkAllowedParams(r, allowedBundleParams); err != nil {
+		return nil, err
+	}
+
 	publishDate, err := parseQueryParam(r, PublishDate, parseTimeRFC3339)
 	if err != nil {
 		return nil, err
 	}
 
+	states, err := parseBundleStates(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleType, err := parseQueryParam(r, BundleType, parseBundleType)
+	if err != nil {
+		return nil, err
+	}
+
+	managedBy, err := parseQueryParam(r, ManagedBy, parseManagedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy, err := parseQueryParam(r, CreatedBy, parseString)
+	if err != nil {
+		return nil, err
+	}
+
+	previewTeam, err := parseQueryParam(r, PreviewTeam, parseString)
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := parseQueryParam(r, Title, parseString)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, err := parseTimeRange(r, CreatedAfter, CreatedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt, err := parseTimeRange(r, UpdatedAfter, UpdatedBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledAt, err := parseTimeRange(r, ScheduledAfter, ScheduledBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := CreateSort(r, BundleSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := CreateCursor(r)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := CreateBundleView(r)
+	if err != nil {
+		return nil, err
+	}
+
 	return &BundleFilters{
 		PublishDate: publishDate,
+		States:      states,
+		BundleType:  bundleType,
+		ManagedBy:   managedBy,
+		CreatedBy:   createdBy,
+		PreviewTeam: previewTeam,
+		Title:       title,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		ScheduledAt: scheduledAt,
+		Sort:        sort,
+		Cursor:      cursor,
+		View:        view,
 	}, nil
 }
+
+// checkAllowedParams returns an error for the first query parameter, in alphabetical order, that is not allowed
+func checkAllowedParams(r *http.Request, allowedParams map[string]bool) *QueryParamParseError {
+	query := r.URL.Query()
+
+	params := make([]string, 0, len(query))
+	for param := range query {
+		params = append(params, param)
+	}
+	slices.Sort(params)
+
+	for _, param := range params {
+		if !allowedParams[param] {
+			return CreateQueryParamParseError(fmt.Errorf("unknown query parameter: %s", param), param)
+		}
+	}
+
+	return nil
+}
+
+// parseBundleStates parses the state parameter, which may be repeated or hold a comma separated list of states
+func parseBundleStates(r *http.Request) ([]models.BundleState, *QueryParamParseError) {
+	values, ok := r.URL.Query()[State]
+	if !ok {
+		return nil, nil
+	}
+
+	var states []models.BundleState
+	for _, value := range values {
+		for _, stateValue := range strings.Split(value, ",") {
+			state := models.BundleState(strings.TrimSpace(stateValue))
+			if !state.IsValid() {
+				return nil, CreateQueryParamParseError(fmt.Errorf("malformed %s parameter. Value: %s, Error: not a valid bundle state", State, value), State)
+			}
+			if !slices.Contains(states, state) {
+				states = append(states, state)
+			}
+		}
+	}
+
+	return states, nil
+}
+
+// parseTimeRange parses a pair of parameters bounding a range of times, returning nil if neither is supplied
+func parseTimeRange(r *http.Request, afterParam, beforeParam string) (*TimeRange, *QueryParamParseError) {
+	after, err := parseQueryParam(r, afterParam, parseTimeRFC3339)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := parseQueryParam(r, beforeParam, parseTimeRFC3339)
+	if err != nil {
+		return nil, err
+	}
+
+	if after == nil && before == nil {
+		return nil, nil
+	}
+
+	if after != nil && before != nil && after.After(*before) {
+		return nil, CreateQueryParamParseError(fmt.Errorf("%s must not be later than %s", afterParam, beforeParam), afterParam)
+	}
+
+	return &TimeRange{After: after, Before: before}, nil
+}
+
+func parseBundleType(value string) (*models.BundleType, error) {
+	bundleType := models.BundleType(value)
+	if !bundleType.IsValid() {
+		return nil, errors.New("not a valid bundle type")
+	}
+
+	return &bundleType, nil
+}
+
+func parseManagedBy(value string) (*models.ManagedBy, error) {
+	managedBy := models.ManagedBy(value)
+	if !managedBy.IsValid() {
+		return nil, errors.New("not a valid managed_by value")
+	}
+
+	return &managedBy, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ONSdigital/dis-bundle-api/models"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -41,5 +42,91 @@ func TestBundleFilters(t *testing.T) {
 			So(err, ShouldNotBeNil)
 			So(result, ShouldBeNil)
 		})
+
+		Convey("Then it creates bundle filters for each of the supported fields", func() {
+			queryParams := url.Values{
+				State:           []string{"DRAFT,IN_REVIEW", "APPROVED"},
+				BundleType:      []string{"SCHEDULED"},
+				ManagedBy:       []string{"WAGTAIL"},
+				CreatedBy:       []string{"publisher@ons.gov.uk"},
+				PreviewTeam:     []string{"team1"},
+				Title:           []string{"CPI"},
+				CreatedAfter:    []string{"2025-01-01T00:00:00Z"},
+				UpdatedBefore:   []string{"2025-02-01T00:00:00Z"},
+				ScheduledAfter:  []string{"2025-01-01T00:00:00Z"},
+				ScheduledBefore: []string{"2025-02-01T00:00:00Z"},
+				Sort:            []string{"-scheduled_at,title"},
+				Fields:          []string{"title,state"},
+				Embed:           []string{"events"},
+				Limit:           []string{"10"},
+				Offset:          []string{"0"},
+			}
+			req := &http.Request{
+				URL: &url.URL{RawQuery: queryParams.Encode()},
+			}
+
+			after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			before := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+			bundleType := models.BundleTypeScheduled
+			managedBy := models.ManagedByWagtail
+			createdBy := "publisher@ons.gov.uk"
+			previewTeam := "team1"
+			title := "CPI"
+
+			expectedResult := BundleFilters{
+				States:      []models.BundleState{models.BundleStateDraft, models.BundleStateInReview, models.BundleStateApproved},
+				BundleType:  &bundleType,
+				ManagedBy:   &managedBy,
+				CreatedBy:   &createdBy,
+				PreviewTeam: &previewTeam,
+				Title:       &title,
+				CreatedAt:   &TimeRange{After: &after},
+				UpdatedAt:   &TimeRange{Before: &before},
+				ScheduledAt: &TimeRange{After: &after, Before: &before},
+				Sort:        []SortField{{Field: "scheduled_at", Descending: true}, {Field: "title"}},
+				View:        &BundleView{Fields: []string{"title", "state"}, Embed: []string{EmbedEvents}},
+			}
+			result, err := CreateBundlefilters(req)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, &expectedResult)
+		})
+
+		Convey("Then it returns an error against the parameter if a value is not valid", func() {
+			invalidParams := map[string]string{
+				State:          "DRAFT,NOT_A_STATE",
+				BundleType:     "NOT_A_TYPE",
+				ManagedBy:      "NOT_A_SYSTEM",
+				Title:          "",
+				CreatedAfter:   "not-an-actual-date",
+				ScheduledAfter: "2025-02-01T00:00:00Z",
+			}
+
+			for param, value := range invalidParams {
+				queryParams := url.Values{param: []string{value}}
+				if param == ScheduledAfter {
+					queryParams.Set(ScheduledBefore, "2025-01-01T00:00:00Z")
+				}
+				req := &http.Request{
+					URL: &url.URL{RawQuery: queryParams.Encode()},
+				}
+
+				result, err := CreateBundlefilters(req)
+				So(result, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Source.Parameter, ShouldEqual, param)
+			}
+		})
+
+		Convey("Then it returns an error against an unknown parameter", func() {
+			queryParams := url.Values{"bundle": []string{"bundle-1"}, Title: []string{"CPI"}}
+			req := &http.Request{
+				URL: &url.URL{RawQuery: queryParams.Encode()},
+			}
+
+			result, err := CreateBundlefilters(req)
+			So(result, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+			So(err.Source.Parameter, ShouldEqual, "bundle")
+		})
 	})
 }
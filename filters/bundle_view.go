@@ -0,0 +1,107 @@
+package filters
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	Fields = "fields"
+	Embed  = "embed"
+
+	EmbedContents    = "contents"
+	EmbedEvents      = "events"
+	EmbedTransitions = "transitions"
+)
+
+// BundleFields maps the fields of a bundle that can be asked for to the stored fields
+var BundleFields = map[string]string{
+	"id":              "id",
+	"bundle_type":     "bundle_type",
+	"created_by":      "created_by",
+	"created_at":      "created_at",
+	"last_updated_by": "last_updated_by",
+	"preview_teams":   "preview_teams",
+	"scheduled_at":    "scheduled_at",
+	"state":           "state",
+	"title":           "title",
+	"updated_at":      "updated_at",
+	"managed_by":      "managed_by",
+	"last_transition": "last_transition",
+}
+
+// bundleEmbeds are the resources that can be embedded in a bundle
+var bundleEmbeds = []string{EmbedContents, EmbedEvents, EmbedTransitions}
+
+// BundleView is the representation of bundles asked for. Fields holds the fields to include, besides the ID, and is
+// empty for all of them. Embed holds the resources to embed in each bundle.
+type BundleView struct {
+	Fields []string
+	Embed  []string
+}
+
+// IsFull returns whether the view is the full representation of bundles, with every field and nothing embedded
+func (v *BundleView) IsFull() bool {
+	return v == nil || (len(v.Fields) == 0 && len(v.Embed) == 0)
+}
+
+// Embeds returns whether the view embeds the resource in bundles
+func (v *BundleView) Embeds(resource string) bool {
+	return v != nil && slices.Contains(v.Embed, resource)
+}
+
+// CreateBundleView creates the BundleView from the fields and embed query parameters in the request, each of which is a
+// comma separated list. Nil is returned if neither parameter is supplied.
+func CreateBundleView(r *http.Request) (*BundleView, *QueryParamParseError) {
+	fields, err := parseQueryParam(r, Fields, func(value string) (*[]string, error) {
+		return parseList(value, func(name string) bool {
+			_, ok := BundleFields[name]
+			return ok
+		}, "cannot select field %q")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embed, err := parseQueryParam(r, Embed, func(value string) (*[]string, error) {
+		return parseList(value, func(name string) bool {
+			return slices.Contains(bundleEmbeds, name)
+		}, "cannot embed %q")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if fields == nil && embed == nil {
+		return nil, nil
+	}
+
+	view := &BundleView{}
+	if fields != nil {
+		view.Fields = *fields
+	}
+	if embed != nil {
+		view.Embed = *embed
+	}
+
+	return view, nil
+}
+
+// parseList parses a comma separated list of names, each of which must be allowed, dropping any repeats
+func parseList(value string, isAllowed func(name string) bool, notAllowedFormat string) (*[]string, error) {
+	var names []string
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if !isAllowed(name) {
+			return nil, fmt.Errorf(notAllowedFormat, name)
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return &names, nil
+}
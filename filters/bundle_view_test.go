@@ -0,0 +1,75 @@
+package filters
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateBundleView(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call CreateBundleView", t, func() {
+		Convey("Then it creates the view from the fields and embed parameters, dropping repeats", func() {
+			queryParams := url.Values{
+				Fields: []string{"title, state,title"},
+				Embed:  []string{"transitions,contents"},
+			}
+			req := &http.Request{
+				URL: &url.URL{RawQuery: queryParams.Encode()},
+			}
+
+			result, err := CreateBundleView(req)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, &BundleView{
+				Fields: []string{"title", "state"},
+				Embed:  []string{EmbedTransitions, EmbedContents},
+			})
+			So(result.IsFull(), ShouldBeFalse)
+			So(result.Embeds(EmbedContents), ShouldBeTrue)
+			So(result.Embeds(EmbedEvents), ShouldBeFalse)
+		})
+
+		Convey("Then it returns nil, the full view, if neither parameter is supplied", func() {
+			req := &http.Request{
+				URL: &url.URL{},
+			}
+
+			result, err := CreateBundleView(req)
+			So(err, ShouldBeNil)
+			So(result, ShouldBeNil)
+			So(result.IsFull(), ShouldBeTrue)
+			So(result.Embeds(EmbedEvents), ShouldBeFalse)
+		})
+
+		Convey("Then it returns an error against the fields parameter if a field cannot be selected", func() {
+			for _, value := range []string{"", "title,colour", "title,", "e_tag"} {
+				queryParams := url.Values{Fields: []string{value}}
+				req := &http.Request{
+					URL: &url.URL{RawQuery: queryParams.Encode()},
+				}
+
+				result, err := CreateBundleView(req)
+				So(result, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Source.Parameter, ShouldEqual, Fields)
+			}
+		})
+
+		Convey("Then it returns an error against the embed parameter if a resource cannot be embedded", func() {
+			for _, value := range []string{"", "contents,comments"} {
+				queryParams := url.Values{Embed: []string{value}}
+				req := &http.Request{
+					URL: &url.URL{RawQuery: queryParams.Encode()},
+				}
+
+				result, err := CreateBundleView(req)
+				So(result, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Source.Parameter, ShouldEqual, Embed)
+			}
+		})
+	})
+}
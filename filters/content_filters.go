@@ -0,0 +1,94 @@
+package filters
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+)
+
+const (
+	DatasetID = "dataset_id"
+	EditionID = "edition_id"
+	VersionID = "version_id"
+	State     = "state"
+)
+
+// Content item filter options
+type ContentFilters struct {
+	DatasetID *string
+	EditionID *string
+	VersionID *int
+	State     *models.State
+	Sort      []SortField
+	Cursor    *string
+}
+
+// Creates ContentFilters from the query parameters in the request
+func CreateContentFilters(r *http.Request) (*ContentFilters, *QueryParamParseError) {
+	datasetID, err := parseQueryParam(r, DatasetID, parseString)
+	if err != nil {
+		return nil, err
+	}
+
+	editionID, err := parseQueryParam(r, EditionID, parseString)
+	if err != nil {
+		return nil, err
+	}
+
+	versionID, err := parseQueryParam(r, VersionID, parseVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := parseQueryParam(r, State, parseContentItemState)
+	if err != nil {
+		return nil, err
+	}
+
+	sort, err := CreateSort(r, ContentSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := CreateCursor(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContentFilters{
+		DatasetID: datasetID,
+		EditionID: editionID,
+		VersionID: versionID,
+		State:     state,
+		Sort:      sort,
+		Cursor:    cursor,
+	}, nil
+}
+
+func parseString(value string) (*string, error) {
+	return &value, nil
+}
+
+func parseVersionID(value string) (*int, error) {
+	versionID, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if versionID < 1 {
+		return nil, errors.New("version_id must be a positive integer")
+	}
+
+	return &versionID, nil
+}
+
+func parseContentItemState(value string) (*models.State, error) {
+	state := models.State(value)
+	if !state.IsValid() {
+		return nil, errors.New("not a valid content item state")
+	}
+
+	return &state, nil
+}
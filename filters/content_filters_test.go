@@ -0,0 +1,61 @@
+package filters
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestContentFilters(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call CreateContentFilters", t, func() {
+		Convey("Then it creates valid content filters from the request", func() {
+			queryParams := url.Values{DatasetID: []string{"cpih"}, EditionID: []string{"time-series"}, VersionID: []string{"2"}, State: []string{"APPROVED"}}
+			req := &http.Request{
+				URL: &url.URL{RawQuery: queryParams.Encode()},
+			}
+
+			datasetID, editionID, versionID, state := "cpih", "time-series", 2, models.StateApproved
+
+			result, err := CreateContentFilters(req)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, &ContentFilters{DatasetID: &datasetID, EditionID: &editionID, VersionID: &versionID, State: &state})
+		})
+
+		Convey("Then it creates empty content filters if there are no query parameters", func() {
+			req := &http.Request{URL: &url.URL{}}
+
+			result, err := CreateContentFilters(req)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, &ContentFilters{})
+		})
+
+		Convey("Then it returns an error for the parameter if version_id is not a positive integer", func() {
+			for _, versionID := range []string{"latest", "0"} {
+				queryParams := url.Values{VersionID: []string{versionID}}
+				req := &http.Request{
+					URL: &url.URL{RawQuery: queryParams.Encode()},
+				}
+
+				result, err := CreateContentFilters(req)
+				So(result, ShouldBeNil)
+				So(err.Source.Parameter, ShouldEqual, VersionID)
+			}
+		})
+
+		Convey("Then it returns an error for the parameter if state is not a content item state", func() {
+			queryParams := url.Values{State: []string{"DRAFT"}}
+			req := &http.Request{
+				URL: &url.URL{RawQuery: queryParams.Encode()},
+			}
+
+			result, err := CreateContentFilters(req)
+			So(result, ShouldBeNil)
+			So(err.Source.Parameter, ShouldEqual, State)
+		})
+	})
+}
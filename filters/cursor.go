@@ -0,0 +1,13 @@
+package filters
+
+import "net/http"
+
+const (
+	Cursor = "cursor"
+)
+
+// CreateCursor gets the cursor query parameter from the request, which marks where a page of results starts. The cursor
+// is opaque here and is only checked by the datastore. Nil is returned if there is no cursor parameter.
+func CreateCursor(r *http.Request) (*string, *QueryParamParseError) {
+	return parseQueryParam(r, Cursor, parseString)
+}
@@ -0,0 +1,58 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	Query = "q"
+
+	// MaxQueryLength is the most characters that can be searched for
+	MaxQueryLength = 200
+)
+
+// allowedSearchParams are the query parameters that can be supplied when searching
+var allowedSearchParams = map[string]bool{
+	Query:  true,
+	Limit:  true,
+	Offset: true,
+}
+
+// SearchFilters holds the text to search for
+type SearchFilters struct {
+	Query string
+}
+
+// Creates SearchFilters from the query parameters in the request. The q parameter must be given.
+func CreateSearchFilters(r *http.Request) (*SearchFilters, *QueryParamParseError) {
+	if err := checkAllowedParams(r, allowedSearchParams); err != nil {
+		return nil, err
+	}
+
+	query, err := parseQueryParam(r, Query, parseSearchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	if query == nil {
+		return nil, CreateQueryParamParseError(fmt.Errorf("missing %s parameter", Query), Query)
+	}
+
+	return &SearchFilters{Query: *query}, nil
+}
+
+func parseSearchQuery(value string) (*string, error) {
+	query := strings.TrimSpace(value)
+	if query == "" {
+		return nil, errors.New("must contain text to search for")
+	}
+
+	if len(query) > MaxQueryLength {
+		return nil, fmt.Errorf("must not be longer than %d characters", MaxQueryLength)
+	}
+
+	return &query, nil
+}
@@ -0,0 +1,54 @@
+package filters
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSearchFilters(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call CreateSearchFilters", t, func() {
+		Convey("Then it creates search filters with the text trimmed", func() {
+			queryParams := url.Values{Query: []string{"  consumer prices "}, Limit: []string{"10"}}
+			req := &http.Request{
+				URL: &url.URL{RawQuery: queryParams.Encode()},
+			}
+
+			result, err := CreateSearchFilters(req)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, &SearchFilters{Query: "consumer prices"})
+		})
+
+		Convey("Then it returns an error for q if it is missing, blank or too long", func() {
+			for _, queryParams := range []url.Values{
+				{},
+				{Query: []string{"   "}},
+				{Query: []string{strings.Repeat("a", MaxQueryLength+1)}},
+			} {
+				req := &http.Request{
+					URL: &url.URL{RawQuery: queryParams.Encode()},
+				}
+
+				result, err := CreateSearchFilters(req)
+				So(result, ShouldBeNil)
+				So(err.Source.Parameter, ShouldEqual, Query)
+			}
+		})
+
+		Convey("Then it returns an error for an unknown parameter", func() {
+			queryParams := url.Values{Query: []string{"cpi"}, State: []string{"DRAFT"}}
+			req := &http.Request{
+				URL: &url.URL{RawQuery: queryParams.Encode()},
+			}
+
+			result, err := CreateSearchFilters(req)
+			So(result, ShouldBeNil)
+			So(err.Source.Parameter, ShouldEqual, State)
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package filters
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	Sort = "sort"
+)
+
+// BundleSortFields maps the fields that bundles can be sorted by to the stored fields
+var BundleSortFields = map[string]string{
+	"id":           "id",
+	"title":        "title",
+	"state":        "state",
+	"bundle_type":  "bundle_type",
+	"managed_by":   "managed_by",
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"scheduled_at": "scheduled_at",
+}
+
+// ContentSortFields maps the fields that content items can be sorted by to the stored fields
+var ContentSortFields = map[string]string{
+	"id":           "id",
+	"bundle_id":    "bundle_id",
+	"content_type": "content_type",
+	"state":        "state",
+	"dataset_id":   "metadata.dataset_id",
+	"edition_id":   "metadata.edition_id",
+	"version_id":   "metadata.version_id",
+	"title":        "metadata.title",
+}
+
+// EventSortFields maps the fields that bundle events can be sorted by to the stored fields
+var EventSortFields = map[string]string{
+	"created_at": "created_at",
+	"action":     "action",
+	"resource":   "resource",
+}
+
+// SortField is a stored field to sort by, in ascending order unless Descending is set
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// CreateSort creates the fields to sort by from the sort query parameter in the request, which is a comma separated
+// list of the sortable fields, each prefixed with - to sort in descending order. Nil is returned if there is no sort
+// parameter.
+func CreateSort(r *http.Request, sortableFields map[string]string) ([]SortField, *QueryParamParseError) {
+	sort, err := parseQueryParam(r, Sort, func(value string) (*[]SortField, error) {
+		return parseSort(value, sortableFields)
+	})
+	if err != nil || sort == nil {
+		return nil, err
+	}
+
+	return *sort, nil
+}
+
+func parseSort(value string, sortableFields map[string]string) (*[]SortField, error) {
+	var sort []SortField
+	seen := make(map[string]bool)
+
+	for _, name := range strings.Split(value, ",") {
+		descending := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+
+		field, ok := sortableFields[name]
+		if !ok {
+			return nil, fmt.Errorf("cannot sort by %q", name)
+		}
+
+		if seen[field] {
+			return nil, fmt.Errorf("cannot sort by %q more than once", name)
+		}
+		seen[field] = true
+
+		sort = append(sort, SortField{Field: field, Descending: descending})
+	}
+
+	return &sort, nil
+}
@@ -0,0 +1,53 @@
+package filters
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateSort(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call CreateSort", t, func() {
+		Convey("Then it creates the sort fields in order, translated to the stored fields", func() {
+			queryParams := url.Values{Sort: []string{"-version_id,dataset_id"}}
+			req := &http.Request{
+				URL: &url.URL{RawQuery: queryParams.Encode()},
+			}
+
+			result, err := CreateSort(req, ContentSortFields)
+			So(err, ShouldBeNil)
+			So(result, ShouldResemble, []SortField{
+				{Field: "metadata.version_id", Descending: true},
+				{Field: "metadata.dataset_id"},
+			})
+		})
+
+		Convey("Then it returns nil if there is no sort parameter", func() {
+			req := &http.Request{
+				URL: &url.URL{},
+			}
+
+			result, err := CreateSort(req, BundleSortFields)
+			So(err, ShouldBeNil)
+			So(result, ShouldBeNil)
+		})
+
+		Convey("Then it returns an error against the sort parameter if a field cannot be sorted by", func() {
+			for _, value := range []string{"", "title,colour", "-", "title,-title"} {
+				queryParams := url.Values{Sort: []string{value}}
+				req := &http.Request{
+					URL: &url.URL{RawQuery: queryParams.Encode()},
+				}
+
+				result, err := CreateSort(req, BundleSortFields)
+				So(result, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Source.Parameter, ShouldEqual, Sort)
+			}
+		})
+	})
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// Approval records that a user has signed off a bundle that is in review
+type Approval struct {
+	ID         string     `bson:"id"                    json:"id"`
+	BundleID   string     `bson:"bundle_id"             json:"bundle_id"`
+	ApprovedBy *User      `bson:"approved_by,omitempty" json:"approved_by,omitempty"`
+	ApprovedAt *time.Time `bson:"approved_at,omitempty" json:"approved_at,omitempty"`
+}
+
+// BundleApprovals is the response for listing the approvals recorded against a bundle, along with the number of
+// approvals the bundle needs before it can be approved
+type BundleApprovals struct {
+	BundleID string      `json:"bundle_id"`
+	Quorum   int         `json:"quorum"`
+	Items    []*Approval `json:"items"`
+}
+
+// NewApproval creates an Approval of the given bundle by approvedBy
+func NewApproval(bundleID, approvedBy string) (*Approval, error) {
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return &Approval{
+		ID:         id.String(),
+		BundleID:   bundleID,
+		ApprovedBy: &User{Email: approvedBy},
+		ApprovedAt: &now,
+	}, nil
+}
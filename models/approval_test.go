@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewApproval(t *testing.T) {
+	Convey("When NewApproval is called", t, func() {
+		approval, err := NewApproval("bundle1", "approver@ons.gov.uk")
+
+		Convey("Then an approval of the bundle by the user is created", func() {
+			So(err, ShouldBeNil)
+			So(approval.ID, ShouldNotBeEmpty)
+			So(approval.BundleID, ShouldEqual, "bundle1")
+			So(approval.ApprovedBy.Email, ShouldEqual, "approver@ons.gov.uk")
+			So(approval.ApprovedAt, ShouldNotBeNil)
+		})
+	})
+}
@@ -30,3 +30,11 @@ func (a *AuthEntityData) GetUserID() string {
 func (a *AuthEntityData) GetUserEmail() string {
 	return a.EntityData.UserID
 }
+
+// ServiceIdentity is the identity recorded for actions the service performs on its own behalf, such as scheduled publishing
+const ServiceIdentity = "dis-bundle-api"
+
+// CreateServiceAuthEntityData creates the AuthEntityData used when the service acts on its own behalf
+func CreateServiceAuthEntityData(serviceToken string) *AuthEntityData {
+	return CreateAuthEntityData(&permissionsAPISDK.EntityData{UserID: ServiceIdentity}, serviceToken, true)
+}
@@ -12,18 +12,66 @@ import (
 
 // Bundle represents the response body when retrieving a bundle
 type Bundle struct {
-	ID            string         `bson:"id"                        json:"id"`
-	BundleType    BundleType     `bson:"bundle_type"               json:"bundle_type"`
-	CreatedBy     *User          `bson:"created_by,omitempty"      json:"created_by,omitempty"`
-	CreatedAt     *time.Time     `bson:"created_at,omitempty"      json:"created_at,omitempty"`
-	LastUpdatedBy *User          `bson:"last_updated_by,omitempty" json:"last_updated_by,omitempty"`
-	PreviewTeams  *[]PreviewTeam `bson:"preview_teams,omitempty"   json:"preview_teams,omitempty"`
-	ScheduledAt   *time.Time     `bson:"scheduled_at,omitempty"    json:"scheduled_at,omitempty"`
-	State         BundleState    `bson:"state"                     json:"state"`
-	Title         string         `bson:"title"                     json:"title"`
-	UpdatedAt     *time.Time     `bson:"updated_at,omitempty"      json:"updated_at,omitempty"`
-	ManagedBy     ManagedBy      `bson:"managed_by"                json:"managed_by"`
-	ETag          string         `bson:"e_tag"                     json:"-"`
+	ID             string           `bson:"id"                        json:"id"`
+	BundleType     BundleType       `bson:"bundle_type"               json:"bundle_type"`
+	CreatedBy      *User            `bson:"created_by,omitempty"      json:"created_by,omitempty"`
+	CreatedAt      *time.Time       `bson:"created_at,omitempty"      json:"created_at,omitempty"`
+	LastUpdatedBy  *User            `bson:"last_updated_by,omitempty" json:"last_updated_by,omitempty"`
+	PreviewTeams   *[]PreviewTeam   `bson:"preview_teams,omitempty"   json:"preview_teams,omitempty"`
+	ScheduledAt    *time.Time       `bson:"scheduled_at,omitempty"    json:"scheduled_at,omitempty"`
+	State          BundleState      `bson:"state"                     json:"state"`
+	Title          string           `bson:"title"                     json:"title"`
+	UpdatedAt      *time.Time       `bson:"updated_at,omitempty"      json:"updated_at,omitempty"`
+	ManagedBy      ManagedBy        `bson:"managed_by"                json:"managed_by"`
+	LastTransition *StateTransition `bson:"last_transition,omitempty" json:"last_transition,omitempty"`
+	ETag           string           `bson:"e_tag"                     json:"-"`
+
+	// Reason explains a change of state made by updating the bundle, and is recorded in its last transition. It is only
+	// read from the request to update the bundle and is never stored.
+	Reason string `bson:"-" json:"reason,omitempty"`
+
+	// ReleaseDateSyncFailures lists the content items whose release date could not be updated when the bundle was
+	// updated. It is only set in the response to the update and is never stored.
+	ReleaseDateSyncFailures []*ReleaseDateSyncFailure `bson:"-" json:"release_date_sync_failures,omitempty"`
+
+	// Embedded holds the resources embedded in the bundle when they are asked for. It is never stored.
+	Embedded *BundleEmbedded `bson:"-" json:"embedded,omitempty"`
+
+	// Fields limits the JSON representation of the bundle to these fields, along with its ID and embedded resources. All
+	// fields are included if it is empty. It is never stored.
+	Fields []string `bson:"-" json:"-"`
+}
+
+// BundleEmbedded holds the resources that can be embedded in a bundle. Each is nil unless it was asked for.
+type BundleEmbedded struct {
+	Contents    *[]*ContentItem        `json:"contents,omitempty"`
+	LatestEvent *Event                 `json:"latest_event,omitempty"`
+	Transitions *[]AvailableTransition `json:"transitions,omitempty"`
+}
+
+// bundleJSON has the fields of Bundle but not its methods, so that it is marshalled as a plain struct
+type bundleJSON Bundle
+
+// MarshalJSON marshals the bundle, keeping only its ID, embedded resources and the fields in Fields if any are set
+func (b Bundle) MarshalJSON() ([]byte, error) {
+	full, err := json.Marshal(bundleJSON(b))
+	if err != nil || len(b.Fields) == 0 {
+		return full, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(full, &all); err != nil {
+		return nil, err
+	}
+
+	limited := make(map[string]json.RawMessage, len(b.Fields)+2)
+	for _, key := range append([]string{"id", "embedded"}, b.Fields...) {
+		if value, ok := all[key]; ok {
+			limited[key] = value
+		}
+	}
+
+	return json.Marshal(limited)
 }
 
 // Bundles represents a list of bundles
@@ -66,6 +114,8 @@ func CreateBundle(reader io.Reader, email string) (*Bundle, error) {
 		Email: email,
 	}
 
+	bundle.LastTransition = nil
+
 	CleanBundle(&bundle)
 
 	etag := dpresponse.GenerateETag(b, false)
@@ -316,12 +366,15 @@ const (
 	BundleStateInReview  BundleState = "IN_REVIEW"
 	BundleStateApproved  BundleState = "APPROVED"
 	BundleStatePublished BundleState = "PUBLISHED"
+
+	BundleStatePublishFailed BundleState = "PUBLISH_FAILED"
+	BundleStateWithdrawn     BundleState = "WITHDRAWN"
 )
 
 // IsValid validates that the BundleState is a valid enum value
 func (bs BundleState) IsValid() bool {
 	switch bs {
-	case BundleStateDraft, BundleStateInReview, BundleStateApproved, BundleStatePublished:
+	case BundleStateDraft, BundleStateInReview, BundleStateApproved, BundleStatePublished, BundleStatePublishFailed, BundleStateWithdrawn:
 		return true
 	default:
 		return false
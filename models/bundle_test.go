@@ -152,6 +152,37 @@ func TestCleanBundle_Success(t *testing.T) {
 	})
 }
 
+func TestBundle_MarshalJSON(t *testing.T) {
+	Convey("Given a bundle with embedded resources", t, func() {
+		bundle := fullyPopulatedBundle
+		bundle.Embedded = &BundleEmbedded{Contents: &[]*ContentItem{}}
+
+		Convey("When it is marshalled without any fields set", func() {
+			bundleJSON, err := json.Marshal(bundle)
+			So(err, ShouldBeNil)
+
+			Convey("Then every field is included", func() {
+				var result map[string]any
+				So(json.Unmarshal(bundleJSON, &result), ShouldBeNil)
+				So(result, ShouldContainKey, "bundle_type")
+				So(result, ShouldContainKey, "preview_teams")
+				So(result, ShouldContainKey, "embedded")
+				So(result, ShouldNotContainKey, "e_tag")
+			})
+		})
+
+		Convey("When it is marshalled with fields set", func() {
+			bundle.Fields = []string{"state", "title"}
+			bundleJSON, err := json.Marshal(&bundle)
+			So(err, ShouldBeNil)
+
+			Convey("Then only the ID, embedded resources and those fields are included, in a stable order", func() {
+				So(string(bundleJSON), ShouldEqual, `{"embedded":{"contents":[]},"id":"123","state":"DRAFT","title":"Fully Populated Bundle"}`)
+			})
+		})
+	})
+}
+
 func TestValidateBundle_Success(t *testing.T) {
 	Convey("Given a minimally populated bundle", t, func() {
 		Convey("When ValidateBundle is called", func() {
@@ -241,6 +272,34 @@ func TestBundleState_IsValid_Success(t *testing.T) {
 	})
 }
 
+func TestBundleState_IsValid_PublishFailed(t *testing.T) {
+	Convey("Given the PUBLISH_FAILED bundle state", t, func() {
+		state := BundleStatePublishFailed
+
+		Convey("When IsValid is called", func() {
+			valid := state.IsValid()
+
+			Convey("Then it should return true", func() {
+				So(valid, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestBundleState_IsValid_Withdrawn(t *testing.T) {
+	Convey("Given the WITHDRAWN bundle state", t, func() {
+		state := BundleStateWithdrawn
+
+		Convey("When IsValid is called", func() {
+			valid := state.IsValid()
+
+			Convey("Then it should return true", func() {
+				So(valid, ShouldBeTrue)
+			})
+		})
+	})
+}
+
 func TestBundleState_IsValid_Failure(t *testing.T) {
 	Convey("Given an invalid bundle state", t, func() {
 		state := BundleState("invalid-state")
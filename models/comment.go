@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+)
+
+// Comment is a review note left on a bundle or one of its content items. A comment with a ParentID is a reply in the
+// thread started by its parent.
+type Comment struct {
+	ID            string     `bson:"id"                        json:"id"`
+	BundleID      string     `bson:"bundle_id"                 json:"bundle_id"`
+	ContentItemID string     `bson:"content_item_id,omitempty" json:"content_item_id,omitempty"`
+	ParentID      string     `bson:"parent_id,omitempty"       json:"parent_id,omitempty"`
+	Body          string     `bson:"body"                      json:"body"`
+	Author        *User      `bson:"author,omitempty"          json:"author,omitempty"`
+	CreatedAt     *time.Time `bson:"created_at,omitempty"      json:"created_at,omitempty"`
+	Resolved      bool       `bson:"resolved"                  json:"resolved"`
+	ResolvedBy    *User      `bson:"resolved_by,omitempty"     json:"resolved_by,omitempty"`
+	ResolvedAt    *time.Time `bson:"resolved_at,omitempty"     json:"resolved_at,omitempty"`
+}
+
+// CreateComment creates a Comment from the body and parent_id in the request body. The remaining fields are set by the
+// API rather than the caller.
+func CreateComment(reader io.Reader) (*Comment, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errs.ErrUnableToReadMessage
+	}
+
+	var request Comment
+
+	err = json.Unmarshal(b, &request)
+	if err != nil {
+		return nil, errs.ErrUnableToParseJSON
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Comment{
+		ID:       id.String(),
+		ParentID: strings.TrimSpace(request.ParentID),
+		Body:     strings.TrimSpace(request.Body),
+	}, nil
+}
+
+// ValidateComment checks that a comment has a body
+func ValidateComment(comment *Comment) []*Error {
+	codeMissingParameters := CodeMissingParameters
+
+	if comment.Body == "" {
+		return []*Error{{Code: &codeMissingParameters, Description: errs.ErrorDescriptionMissingParameters, Source: &Source{Field: "/body"}}}
+	}
+
+	return nil
+}
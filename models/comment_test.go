@@ -0,0 +1,53 @@
+package models
+
+import (
+	"bytes"
+	"testing"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateComment(t *testing.T) {
+	Convey("When CreateComment is called with a reply and fields that are set by the API", t, func() {
+		body := `{"id": "comment-1", "body": "  Please check the title  ", "parent_id": " comment-0 ", "resolved": true, "author": {"email": "someone@ons.gov.uk"}}`
+		comment, err := CreateComment(bytes.NewBufferString(body))
+
+		Convey("Then only the body and parent are taken from the request", func() {
+			So(err, ShouldBeNil)
+			So(comment.ID, ShouldNotBeEmpty)
+			So(comment.ID, ShouldNotEqual, "comment-1")
+			So(comment.Body, ShouldEqual, "Please check the title")
+			So(comment.ParentID, ShouldEqual, "comment-0")
+			So(comment.Resolved, ShouldBeFalse)
+			So(comment.Author, ShouldBeNil)
+		})
+	})
+
+	Convey("When CreateComment is called with invalid JSON", t, func() {
+		comment, err := CreateComment(bytes.NewBufferString(`{"body":`))
+
+		Convey("Then an error is returned", func() {
+			So(comment, ShouldBeNil)
+			So(err, ShouldEqual, errs.ErrUnableToParseJSON)
+		})
+	})
+}
+
+func TestValidateComment(t *testing.T) {
+	Convey("When a comment without a body is validated", t, func() {
+		validationErrs := ValidateComment(&Comment{ID: "comment-1"})
+
+		Convey("Then the missing body is reported", func() {
+			So(validationErrs, ShouldHaveLength, 1)
+			So(*validationErrs[0].Code, ShouldEqual, CodeMissingParameters)
+			So(validationErrs[0].Source.Field, ShouldEqual, "/body")
+		})
+	})
+
+	Convey("When a comment with a body is validated", t, func() {
+		Convey("Then it is valid", func() {
+			So(ValidateComment(&Comment{ID: "comment-1", Body: "Looks good"}), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// ConflictType is the way in which a content item conflicts with a content item in another bundle
+type ConflictType string
+
+// Define the possible values for the ConflictType enum
+const (
+	// ConflictTypeEdition is a content item in another bundle for the same dataset edition
+	ConflictTypeEdition ConflictType = "EDITION"
+	// ConflictTypeDataset is a content item in another bundle for a different edition of the same dataset
+	ConflictTypeDataset ConflictType = "DATASET"
+)
+
+// BundleConflicts lists the content items in a bundle whose datasets are also in other bundles that have not been
+// published
+type BundleConflicts struct {
+	BundleID     string            `json:"bundle_id"`
+	HasConflicts bool              `json:"has_conflicts"`
+	Conflicts    []*BundleConflict `json:"conflicts"`
+}
+
+// BundleConflict is a content item in a bundle whose dataset is also in another bundle
+type BundleConflict struct {
+	Type                     ConflictType       `json:"type"`
+	ContentItemID            string             `json:"content_item_id"`
+	Metadata                 Metadata           `json:"metadata"`
+	ConflictingContentItemID string             `json:"conflicting_content_item_id"`
+	ConflictingMetadata      Metadata           `json:"conflicting_metadata"`
+	ConflictingBundle        *ConflictingBundle `json:"conflicting_bundle"`
+}
+
+// ConflictingBundle is the other bundle in a conflict, with the state and schedule it will be published with
+type ConflictingBundle struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	State       BundleState `json:"state"`
+	BundleType  BundleType  `json:"bundle_type"`
+	ScheduledAt *time.Time  `json:"scheduled_at,omitempty"`
+}
+
+// NewBundleConflict returns the conflict between a content item and a content item in another bundle, or nil if they
+// are not for the same dataset
+func NewBundleConflict(contentItem, conflictingContentItem *ContentItem, conflictingBundle *Bundle) *BundleConflict {
+	if contentItem.Metadata.DatasetID != conflictingContentItem.Metadata.DatasetID {
+		return nil
+	}
+
+	conflictType := ConflictTypeDataset
+	if contentItem.Metadata.EditionID == conflictingContentItem.Metadata.EditionID {
+		conflictType = ConflictTypeEdition
+	}
+
+	return &BundleConflict{
+		Type:                     conflictType,
+		ContentItemID:            contentItem.ID,
+		Metadata:                 contentItem.Metadata,
+		ConflictingContentItemID: conflictingContentItem.ID,
+		ConflictingMetadata:      conflictingContentItem.Metadata,
+		ConflictingBundle: &ConflictingBundle{
+			ID:          conflictingBundle.ID,
+			Title:       conflictingBundle.Title,
+			State:       conflictingBundle.State,
+			BundleType:  conflictingBundle.BundleType,
+			ScheduledAt: conflictingBundle.ScheduledAt,
+		},
+	}
+}
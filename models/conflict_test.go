@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewBundleConflict(t *testing.T) {
+	Convey("Given a content item and a bundle that contains another content item", t, func() {
+		contentItem := &ContentItem{ID: "content-item-1", Metadata: Metadata{DatasetID: "cpih", EditionID: "time-series", VersionID: 2}}
+		bundle := &Bundle{ID: "bundle-2", Title: "Other bundle", State: BundleStateInReview, BundleType: BundleTypeManual}
+
+		Convey("When the other content item is a different version of the same edition", func() {
+			other := &ContentItem{ID: "content-item-2", Metadata: Metadata{DatasetID: "cpih", EditionID: "time-series", VersionID: 3}}
+			conflict := NewBundleConflict(contentItem, other, bundle)
+
+			Convey("Then it is an edition conflict with the other bundle", func() {
+				So(conflict.Type, ShouldEqual, ConflictTypeEdition)
+				So(conflict.ContentItemID, ShouldEqual, "content-item-1")
+				So(conflict.ConflictingContentItemID, ShouldEqual, "content-item-2")
+				So(conflict.ConflictingBundle.ID, ShouldEqual, "bundle-2")
+				So(conflict.ConflictingBundle.State, ShouldEqual, BundleStateInReview)
+			})
+		})
+
+		Convey("When the other content item is a different edition of the same dataset", func() {
+			other := &ContentItem{ID: "content-item-2", Metadata: Metadata{DatasetID: "cpih", EditionID: "2025", VersionID: 1}}
+
+			Convey("Then it is a dataset conflict", func() {
+				So(NewBundleConflict(contentItem, other, bundle).Type, ShouldEqual, ConflictTypeDataset)
+			})
+		})
+
+		Convey("When the other content item is for a different dataset", func() {
+			other := &ContentItem{ID: "content-item-2", Metadata: Metadata{DatasetID: "gdp", EditionID: "time-series", VersionID: 2}}
+
+			Convey("Then there is no conflict", func() {
+				So(NewBundleConflict(contentItem, other, bundle), ShouldBeNil)
+			})
+		})
+	})
+}
@@ -11,12 +11,28 @@ import (
 
 // ContentItem represents information about the datasets to be published as part of the bundle
 type ContentItem struct {
-	ID          string      `bson:"id"              json:"id"`
-	BundleID    string      `bson:"bundle_id"       json:"bundle_id"`
-	ContentType ContentType `bson:"content_type"    json:"content_type"`
-	Metadata    Metadata    `bson:"metadata"        json:"metadata"`
-	State       *State      `bson:"state,omitempty" json:"state,omitempty"`
-	Links       Links       `bson:"links"           json:"links"`
+	ID          string      `bson:"id"                   json:"id"`
+	BundleID    string      `bson:"bundle_id"            json:"bundle_id"`
+	ContentType ContentType `bson:"content_type"         json:"content_type"`
+	Dependency  bool        `bson:"dependency,omitempty" json:"dependency,omitempty"`
+	Metadata    Metadata    `bson:"metadata"             json:"metadata"`
+	State       *State      `bson:"state,omitempty"      json:"state,omitempty"`
+	Links       Links       `bson:"links"                json:"links"`
+
+	// ReleaseDateSyncFailure is set if the content item was added to a scheduled bundle but its dataset version could not
+	// be given the bundle's release date. It is only set in the response to adding the content item and is never stored.
+	ReleaseDateSyncFailure *ReleaseDateSyncFailure `bson:"-" json:"release_date_sync_failure,omitempty"`
+
+	// Bundle summarises the bundle the content item is in. It is only set when content items are listed across bundles
+	// and is never stored.
+	Bundle *ContentItemBundle `bson:"-" json:"bundle,omitempty"`
+}
+
+// ContentItemBundle summarises the bundle a content item is in
+type ContentItemBundle struct {
+	ID    string      `json:"id"`
+	Title string      `json:"title"`
+	State BundleState `json:"state"`
 }
 
 // Metadata represents the metadata for the content item
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+)
+
+// MaxContentItemsBatchSize is the most content items that can be added to or removed from a bundle in one request
+const MaxContentItemsBatchSize = 100
+
+// ContentItemsBatch represents content items that are added to a bundle in one request
+type ContentItemsBatch struct {
+	Items []*ContentItem `json:"items"`
+}
+
+// ContentItemIDsBatch represents the IDs of content items that are removed from a bundle in one request
+type ContentItemIDsBatch struct {
+	IDs []string `json:"ids"`
+}
+
+// CreateContentItemsBatch manages the creation of a ContentItemsBatch from a reader, giving each content item an ID
+func CreateContentItemsBatch(reader io.Reader) (*ContentItemsBatch, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errs.ErrUnableToReadMessage
+	}
+
+	var batch ContentItemsBatch
+
+	err = json.Unmarshal(b, &batch)
+	if err != nil {
+		return nil, errs.ErrUnableToParseJSON
+	}
+
+	for _, contentItem := range batch.Items {
+		if contentItem == nil {
+			continue
+		}
+
+		id, err := newUUID()
+		if err != nil {
+			return nil, err
+		}
+		contentItem.ID = id.String()
+	}
+
+	return &batch, nil
+}
+
+// ValidateContentItemsBatch checks that a batch has between one and MaxContentItemsBatchSize content items and that
+// each is valid. The source of each error is the field within the items array.
+func ValidateContentItemsBatch(batch *ContentItemsBatch) []*Error {
+	codeInvalidParameters := CodeInvalidParameters
+
+	if len(batch.Items) == 0 || len(batch.Items) > MaxContentItemsBatchSize {
+		return []*Error{{Code: &codeInvalidParameters, Description: errs.ErrorDescriptionMalformedRequest, Source: &Source{Field: "/items"}}}
+	}
+
+	var validationErrs []*Error
+	for i, contentItem := range batch.Items {
+		if contentItem == nil {
+			validationErrs = append(validationErrs, &Error{Code: &codeInvalidParameters, Description: errs.ErrorDescriptionMalformedRequest, Source: &Source{Field: fmt.Sprintf("/items/%d", i)}})
+			continue
+		}
+
+		CleanContentItem(contentItem)
+		for _, validationErr := range ValidateContentItem(contentItem) {
+			validationErrs = append(validationErrs, BatchItemError(i, validationErr))
+		}
+	}
+
+	return validationErrs
+}
+
+// CreateContentItemIDsBatch manages the creation of a ContentItemIDsBatch from a reader
+func CreateContentItemIDsBatch(reader io.Reader) (*ContentItemIDsBatch, error) {
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errs.ErrUnableToReadMessage
+	}
+
+	var batch ContentItemIDsBatch
+
+	err = json.Unmarshal(b, &batch)
+	if err != nil {
+		return nil, errs.ErrUnableToParseJSON
+	}
+
+	return &batch, nil
+}
+
+// ValidateContentItemIDsBatch checks that a batch has between one and MaxContentItemsBatchSize IDs, none of which are
+// empty or repeated
+func ValidateContentItemIDsBatch(batch *ContentItemIDsBatch) []*Error {
+	codeInvalidParameters := CodeInvalidParameters
+
+	if len(batch.IDs) == 0 || len(batch.IDs) > MaxContentItemsBatchSize {
+		return []*Error{{Code: &codeInvalidParameters, Description: errs.ErrorDescriptionMalformedRequest, Source: &Source{Field: "/ids"}}}
+	}
+
+	var validationErrs []*Error
+	seen := make(map[string]bool, len(batch.IDs))
+	for i, id := range batch.IDs {
+		if id == "" || seen[id] {
+			validationErrs = append(validationErrs, &Error{Code: &codeInvalidParameters, Description: errs.ErrorDescriptionMalformedRequest, Source: &Source{Field: fmt.Sprintf("/ids/%d", i)}})
+		}
+		seen[id] = true
+	}
+
+	return validationErrs
+}
+
+// BatchItemError returns a copy of err for the content item at index in a batch, so that its source is the field
+// within the items array
+func BatchItemError(index int, err *Error) *Error {
+	field := fmt.Sprintf("/items/%d", index)
+	if err.Source != nil {
+		field += err.Source.Field
+	}
+
+	return &Error{Code: err.Code, Description: err.Description, Source: &Source{Field: field}}
+}
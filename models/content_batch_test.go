@@ -0,0 +1,119 @@
+package models
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateContentItemsBatch(t *testing.T) {
+	Convey("Given a batch of two content items as JSON", t, func() {
+		reader := bytes.NewBufferString(`{"items": [
+			{"content_type": "DATASET", "metadata": {"dataset_id": "cpih", "edition_id": "time-series", "version_id": 1}},
+			{"content_type": "DATASET", "metadata": {"dataset_id": "cpih", "edition_id": "time-series", "version_id": 2}}
+		]}`)
+
+		Convey("When CreateContentItemsBatch is called", func() {
+			batch, err := CreateContentItemsBatch(reader)
+
+			Convey("Then each content item is read and given its own ID", func() {
+				So(err, ShouldBeNil)
+				So(batch.Items, ShouldHaveLength, 2)
+				So(batch.Items[0].ID, ShouldNotBeEmpty)
+				So(batch.Items[1].ID, ShouldNotBeEmpty)
+				So(batch.Items[0].ID, ShouldNotEqual, batch.Items[1].ID)
+				So(batch.Items[1].Metadata.VersionID, ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given a batch that is not valid JSON", t, func() {
+		reader := bytes.NewBufferString(`{"items": [`)
+
+		Convey("When CreateContentItemsBatch is called", func() {
+			_, err := CreateContentItemsBatch(reader)
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldEqual, errs.ErrUnableToParseJSON)
+			})
+		})
+	})
+}
+
+func TestValidateContentItemsBatch(t *testing.T) {
+	Convey("Given a batch with a valid and an invalid content item", t, func() {
+		batch := &ContentItemsBatch{Items: []*ContentItem{
+			{BundleID: "bundle-1", ContentType: ContentTypeDataset, Metadata: Metadata{DatasetID: "cpih", EditionID: "time-series", VersionID: 1}},
+			{BundleID: "bundle-1", ContentType: ContentTypeDataset, Metadata: Metadata{DatasetID: "cpih", VersionID: 2}},
+		}}
+
+		Convey("When ValidateContentItemsBatch is called", func() {
+			validationErrs := ValidateContentItemsBatch(batch)
+
+			Convey("Then the invalid field is reported within the items array", func() {
+				So(validationErrs, ShouldHaveLength, 1)
+				So(validationErrs[0].Source.Field, ShouldEqual, "/items/1/metadata/edition_id")
+				So(validationErrs[0].Description, ShouldEqual, errs.ErrorDescriptionMissingParameters)
+			})
+		})
+	})
+
+	Convey("Given a batch with no content items", t, func() {
+		batch := &ContentItemsBatch{}
+
+		Convey("When ValidateContentItemsBatch is called", func() {
+			validationErrs := ValidateContentItemsBatch(batch)
+
+			Convey("Then the items array is reported as invalid", func() {
+				So(validationErrs, ShouldHaveLength, 1)
+				So(validationErrs[0].Source.Field, ShouldEqual, "/items")
+			})
+		})
+	})
+
+	Convey("Given a batch with more than the maximum number of content items", t, func() {
+		batch := &ContentItemsBatch{Items: make([]*ContentItem, MaxContentItemsBatchSize+1)}
+
+		Convey("When ValidateContentItemsBatch is called", func() {
+			validationErrs := ValidateContentItemsBatch(batch)
+
+			Convey("Then the items array is reported as invalid", func() {
+				So(validationErrs, ShouldHaveLength, 1)
+				So(validationErrs[0].Source.Field, ShouldEqual, "/items")
+			})
+		})
+	})
+}
+
+func TestValidateContentItemIDsBatch(t *testing.T) {
+	Convey("Given a batch of content item IDs read from JSON with an empty and a repeated ID", t, func() {
+		batch, err := CreateContentItemIDsBatch(strings.NewReader(`{"ids": ["content-1", "", "content-1"]}`))
+		So(err, ShouldBeNil)
+
+		Convey("When ValidateContentItemIDsBatch is called", func() {
+			validationErrs := ValidateContentItemIDsBatch(batch)
+
+			Convey("Then the empty and repeated IDs are reported within the ids array", func() {
+				So(validationErrs, ShouldHaveLength, 2)
+				So(validationErrs[0].Source.Field, ShouldEqual, "/ids/1")
+				So(validationErrs[1].Source.Field, ShouldEqual, "/ids/2")
+			})
+		})
+	})
+
+	Convey("Given a batch with no content item IDs", t, func() {
+		batch := &ContentItemIDsBatch{}
+
+		Convey("When ValidateContentItemIDsBatch is called", func() {
+			validationErrs := ValidateContentItemIDsBatch(batch)
+
+			Convey("Then the ids array is reported as invalid", func() {
+				So(validationErrs, ShouldHaveLength, 1)
+				So(validationErrs[0].Source.Field, ShouldEqual, "/ids")
+			})
+		})
+	})
+}
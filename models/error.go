@@ -118,6 +118,20 @@ var (
 	malformedRequestError  = CreateModelError(CodeBadRequest, errs.ErrorDescriptionMalformedRequest)
 )
 
+// scheduledAtError returns an invalid parameters error for the scheduled_at field of a bundle
+func scheduledAtError(description string) *Error {
+	modelError := CreateModelError(CodeInvalidParameters, description)
+	modelError.Source = &Source{Field: "/scheduled_at"}
+	return modelError
+}
+
+// invalidCursorError returns an invalid parameters error for the cursor query parameter
+func invalidCursorError() *Error {
+	modelError := CreateModelError(CodeInvalidParameters, errs.ErrorDescriptionInvalidCursor)
+	modelError.Source = &Source{Parameter: "cursor"}
+	return modelError
+}
+
 // API Errors -> Error map
 var ErrorToModelErrorMap = map[error]*Error{
 	// Not found
@@ -125,6 +139,9 @@ var ErrorToModelErrorMap = map[error]*Error{
 	errs.ErrNotFound:                notFoundError,
 	errs.ErrBundleHasNoContentItems: notFoundError,
 	errs.ErrContentItemNotFound:     notFoundError,
+	errs.ErrPublishRunNotFound:      notFoundError,
+	errs.ErrApprovalNotFound:        notFoundError,
+	errs.ErrCommentNotFound:         notFoundError,
 
 	// Validation - Headers
 	errs.ErrMissingIfMatchHeader: CreateModelError(CodeBadRequest, errs.ErrorDescriptionMissingIfMatchHeader),
@@ -137,11 +154,42 @@ var ErrorToModelErrorMap = map[error]*Error{
 	errs.ErrInvalidBundleState: invalidTransitionError,
 	errs.ErrInvalidTransition:  invalidTransitionError,
 
+	// Validation - Transition reason
+	errs.ErrTransitionReasonRequired: CreateModelError(CodeMissingParameters, errs.ErrorDescriptionTransitionReasonRequired),
+
+	// Validation - Pagination
+	errs.ErrInvalidCursor: invalidCursorError(),
+
+	// Validation - Comments
+	errs.ErrCommentParentNotFound: CreateModelError(CodeInvalidParameters, errs.ErrorDescriptionCommentParentNotFound),
+
+	// Validation - Release calendar
+	errs.ErrScheduledAtNotReleaseSlot: scheduledAtError(errs.ErrorDescriptionScheduledAtNotReleaseSlot),
+	errs.ErrScheduledAtInBlackout:     scheduledAtError(errs.ErrorDescriptionScheduledAtInBlackout),
+
+	// Conflict - State
+	errs.ErrContentItemsNotApproved: CreateModelError(CodeConflict, errs.ErrorDescriptionContentItemsNotApproved),
+
+	// Forbidden - Approval policy
+	errs.ErrApproverCreatedBundle: CreateModelError(CodeForbidden, errs.ErrorDescriptionApproverCreatedBundle),
+	errs.ErrApproverEditedBundle:  CreateModelError(CodeForbidden, errs.ErrorDescriptionApproverEditedBundle),
+
+	// Conflict - Approvals
+	errs.ErrApprovalQuorumNotMet:  CreateModelError(CodeConflict, errs.ErrorDescriptionApprovalQuorumNotMet),
+	errs.ErrApprovalAlreadyExists: CreateModelError(CodeConflict, errs.ErrorDescriptionApprovalAlreadyExists),
+	errs.ErrApprovalNotInReview:   CreateModelError(CodeConflict, errs.ErrorDescriptionApprovalNotInReview),
+	errs.ErrBundleHasConflicts:    CreateModelError(CodeConflict, errs.ErrorDescriptionBundleHasConflicts),
+
+	// Conflict - Scheduling
+	errs.ErrBundleNotScheduled:    CreateModelError(CodeConflict, errs.ErrorDescriptionBundleNotScheduled),
+	errs.ErrScheduleNotChangeable: CreateModelError(CodeConflict, errs.ErrorDescriptionScheduleNotChangeable),
+
 	// Validation - Body and/or params
 	errs.ErrInvalidBody: malformedRequestError,
 
 	// Internal error
-	errs.ErrInternalServer: internalError,
+	errs.ErrInternalServer:             internalError,
+	errs.ErrWithdrawContentItemsFailed: CreateModelError(CodeInternalError, errs.ErrorDescriptionWithdrawContentItemsFailed),
 
 	// Auth
 	errs.ErrUnauthorised: CreateModelError(CodeUnauthorised, errs.ErrorDescriptionAccessDenied),
@@ -13,6 +13,8 @@ type Event struct {
 	Resource    string       `bson:"resource"               json:"resource"`
 	ContentItem *ContentItem `bson:"content_item,omitempty" json:"content_item,omitempty"`
 	Bundle      *Bundle      `bson:"bundle,omitempty"       json:"bundle,omitempty"`
+	Comment     *Comment     `bson:"comment,omitempty"      json:"comment,omitempty"`
+	Reason      string       `bson:"reason,omitempty"       json:"reason,omitempty"`
 }
 
 // RequestedBy represents the user who made the request
@@ -36,6 +38,9 @@ const (
 	ActionRead   Action = "READ"
 	ActionUpdate Action = "UPDATE"
 	ActionDelete Action = "DELETE"
+
+	// ActionWithdraw records the result of reverting a content item when its bundle is withdrawn
+	ActionWithdraw Action = "WITHDRAW"
 )
 
 // CreateEventModel creates an Event model for either a Bundle or a ContentItem
@@ -63,3 +68,26 @@ func CreateEventModel(id, email string, action Action, bundle *Bundle, contentIt
 
 	return event, nil
 }
+
+// CreateCommentEventModel creates an Event model for a comment on a bundle or one of its content items
+func CreateCommentEventModel(id, email string, action Action, comment *Comment) (*Event, error) {
+	if comment == nil {
+		return nil, errors.New("comment must be provided")
+	}
+
+	resource := "/bundles/" + comment.BundleID
+	if comment.ContentItemID != "" {
+		resource += "/contents/" + comment.ContentItemID
+	}
+
+	// CreatedAt will be set within Mongo.CreateEvent
+	return &Event{
+		RequestedBy: &RequestedBy{
+			ID:    id,
+			Email: email,
+		},
+		Action:   action,
+		Resource: resource + "/comments/" + comment.ID,
+		Comment:  comment,
+	}, nil
+}
@@ -63,3 +63,42 @@ func TestCreateEventModel(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateCommentEventModel(t *testing.T) {
+	Convey("Given a comment on a bundle", t, func() {
+		comment := &Comment{ID: "comment-1", BundleID: "bundle-1", Body: "Please check the title"}
+
+		Convey("When an event is created for it", func() {
+			event, err := CreateCommentEventModel("user-id", "user@example.com", ActionCreate, comment)
+
+			Convey("Then the event refers to the comment on the bundle", func() {
+				So(err, ShouldBeNil)
+				So(event.RequestedBy, ShouldResemble, &RequestedBy{ID: "user-id", Email: "user@example.com"})
+				So(event.Action, ShouldEqual, ActionCreate)
+				So(event.Resource, ShouldEqual, "/bundles/bundle-1/comments/comment-1")
+				So(event.Comment, ShouldEqual, comment)
+				So(event.Bundle, ShouldBeNil)
+				So(event.ContentItem, ShouldBeNil)
+			})
+		})
+
+		Convey("When an event is created for a comment on one of its content items", func() {
+			comment.ContentItemID = "content-item-1"
+			event, err := CreateCommentEventModel("user-id", "user@example.com", ActionUpdate, comment)
+
+			Convey("Then the event refers to the comment on the content item", func() {
+				So(err, ShouldBeNil)
+				So(event.Resource, ShouldEqual, "/bundles/bundle-1/contents/content-item-1/comments/comment-1")
+			})
+		})
+	})
+
+	Convey("When an event is created without a comment", t, func() {
+		event, err := CreateCommentEventModel("user-id", "user@example.com", ActionCreate, nil)
+
+		Convey("Then an error is returned", func() {
+			So(event, ShouldBeNil)
+			So(err.Error(), ShouldEqual, "comment must be provided")
+		})
+	})
+}
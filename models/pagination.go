@@ -2,15 +2,17 @@ package models
 
 // PaginationFields represents the fields used for pagination in an API response
 type PaginationFields struct {
-	Count      int `json:"count"`
-	Limit      int `json:"limit"`
-	Offset     int `json:"offset"`
-	TotalCount int `json:"total_count"`
+	Count      int    `json:"count"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	TotalCount int    `json:"total_count"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type PaginationResult[TItem any] struct {
 	Items      []*TItem
 	TotalCount int
+	NextCursor string
 }
 
 type PaginationSuccessResult[TItem any] = SuccessResult[PaginationResult[TItem]]
@@ -23,3 +25,15 @@ func CreatePaginationSuccessResult[TItem any](items []*TItem, totalCount int) *S
 
 	return CreateOkResult(paginationResult)
 }
+
+// CreateCursorPaginationSuccessResult creates a pagination result with the cursor for the next page, which is empty if
+// there are no more items
+func CreateCursorPaginationSuccessResult[TItem any](items []*TItem, totalCount int, nextCursor string) *SuccessResult[PaginationResult[TItem]] {
+	paginationResult := &PaginationResult[TItem]{
+		Items:      items,
+		TotalCount: totalCount,
+		NextCursor: nextCursor,
+	}
+
+	return CreateOkResult(paginationResult)
+}
@@ -0,0 +1,55 @@
+package models
+
+// PublishPreflightReport is the result of checking whether a bundle can be published, without publishing it
+type PublishPreflightReport struct {
+	BundleID     string                        `json:"bundle_id"`
+	State        BundleState                   `json:"state"`
+	Ready        bool                          `json:"ready"`
+	Errors       []*Error                      `json:"errors,omitempty"`
+	ContentItems []PublishPreflightContentItem `json:"contents"`
+}
+
+// PublishPreflightContentItem is the result of checking whether a single content item can be published
+type PublishPreflightContentItem struct {
+	ID       string   `json:"id"`
+	Metadata Metadata `json:"metadata"`
+	Ready    bool     `json:"ready"`
+	Errors   []*Error `json:"errors,omitempty"`
+}
+
+// NewPublishPreflightContentItem returns a preflight result for a content item with no problems found
+func NewPublishPreflightContentItem(contentItem *ContentItem) PublishPreflightContentItem {
+	return PublishPreflightContentItem{
+		ID:       contentItem.ID,
+		Metadata: contentItem.Metadata,
+		Ready:    true,
+	}
+}
+
+// AddError records a problem that would stop the content item being published
+func (p *PublishPreflightContentItem) AddError(code Code, description, field string) {
+	p.Ready = false
+	p.Errors = append(p.Errors, newPreflightError(code, description, field))
+}
+
+// AddError records a problem that would stop the bundle being published
+func (r *PublishPreflightReport) AddError(code Code, description, field string) {
+	r.Ready = false
+	r.Errors = append(r.Errors, newPreflightError(code, description, field))
+}
+
+// AddContentItem adds the result for a content item to the report. The bundle is not ready if the content item is not.
+func (r *PublishPreflightReport) AddContentItem(contentItem PublishPreflightContentItem) {
+	if !contentItem.Ready {
+		r.Ready = false
+	}
+	r.ContentItems = append(r.ContentItems, contentItem)
+}
+
+func newPreflightError(code Code, description, field string) *Error {
+	preflightError := CreateModelError(code, description)
+	if field != "" {
+		preflightError.Source = &Source{Field: field}
+	}
+	return preflightError
+}
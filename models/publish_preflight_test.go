@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPublishPreflightReport(t *testing.T) {
+	Convey("Given a report for a bundle that is ready to publish", t, func() {
+		report := &PublishPreflightReport{BundleID: "bundle1", Ready: true}
+		contentItem := &ContentItem{ID: "item1", Metadata: Metadata{DatasetID: "dataset1", EditionID: "edition1", VersionID: 1}}
+
+		Convey("When a content item with no problems is added", func() {
+			report.AddContentItem(NewPublishPreflightContentItem(contentItem))
+
+			Convey("Then the report is still ready", func() {
+				So(report.Ready, ShouldBeTrue)
+				So(report.ContentItems, ShouldHaveLength, 1)
+				So(report.ContentItems[0].ID, ShouldEqual, "item1")
+				So(report.ContentItems[0].Metadata, ShouldResemble, contentItem.Metadata)
+			})
+		})
+
+		Convey("When a content item with a problem is added", func() {
+			result := NewPublishPreflightContentItem(contentItem)
+			result.AddError(CodeConflict, "The version is not approved.", "/state")
+			report.AddContentItem(result)
+
+			Convey("Then neither the content item nor the report is ready", func() {
+				So(report.Ready, ShouldBeFalse)
+				So(report.ContentItems[0].Ready, ShouldBeFalse)
+				So(*report.ContentItems[0].Errors[0].Code, ShouldEqual, CodeConflict)
+				So(report.ContentItems[0].Errors[0].Source, ShouldResemble, &Source{Field: "/state"})
+			})
+		})
+
+		Convey("When a problem with the bundle is added without a field", func() {
+			report.AddError(CodeInternalError, "Failed to get the policy for a preview team from permissions API.", "")
+
+			Convey("Then the report is not ready and the error has no source", func() {
+				So(report.Ready, ShouldBeFalse)
+				So(report.Errors, ShouldHaveLength, 1)
+				So(report.Errors[0].Source, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+)
+
+// PublishRun records the progress of a single attempt to publish a bundle, so that an interrupted publish can be
+// detected and resumed
+type PublishRun struct {
+	ID          string           `bson:"id"                     json:"id"`
+	BundleID    string           `bson:"bundle_id"              json:"bundle_id"`
+	State       PublishRunState  `bson:"state"                  json:"state"`
+	StartedBy   *User            `bson:"started_by,omitempty"   json:"started_by,omitempty"`
+	StartedAt   *time.Time       `bson:"started_at,omitempty"   json:"started_at,omitempty"`
+	UpdatedAt   *time.Time       `bson:"updated_at,omitempty"   json:"updated_at,omitempty"`
+	CompletedAt *time.Time       `bson:"completed_at,omitempty" json:"completed_at,omitempty"`
+	Items       []PublishRunItem `bson:"items"                  json:"items"`
+}
+
+// PublishRunItem records the publish status of a single content item within a PublishRun
+type PublishRunItem struct {
+	ContentItemID string              `bson:"content_item_id"      json:"content_item_id"`
+	DatasetID     string              `bson:"dataset_id"           json:"dataset_id"`
+	EditionID     string              `bson:"edition_id"           json:"edition_id"`
+	VersionID     int                 `bson:"version_id"           json:"version_id"`
+	State         PublishRunItemState `bson:"state"                json:"state"`
+	Attempts      int                 `bson:"attempts"             json:"attempts"`
+	LastError     string              `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	UpdatedAt     *time.Time          `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// PublishRunState enum type representing the state of a publish run
+type PublishRunState string
+
+// Define the possible values for the PublishRunState enum
+const (
+	PublishRunStateInProgress PublishRunState = "IN_PROGRESS"
+	PublishRunStateCompleted  PublishRunState = "COMPLETED"
+	PublishRunStateFailed     PublishRunState = "FAILED"
+)
+
+// PublishRunItemState enum type representing the publish state of a content item within a publish run
+type PublishRunItemState string
+
+// Define the possible values for the PublishRunItemState enum
+const (
+	PublishRunItemStatePending   PublishRunItemState = "PENDING"
+	PublishRunItemStatePublished PublishRunItemState = "PUBLISHED"
+	PublishRunItemStateFailed    PublishRunItemState = "FAILED"
+)
+
+// String returns the string representation of the PublishRunState
+func (s PublishRunState) String() string {
+	return string(s)
+}
+
+// String returns the string representation of the PublishRunItemState
+func (s PublishRunItemState) String() string {
+	return string(s)
+}
+
+// NewPublishRun creates an in progress PublishRun for the given bundle, with a pending item for each content item
+func NewPublishRun(bundleID string, contentItems []ContentItem, startedBy string) (*PublishRun, error) {
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	items := make([]PublishRunItem, 0, len(contentItems))
+	for i := range contentItems {
+		items = append(items, PublishRunItem{
+			ContentItemID: contentItems[i].ID,
+			DatasetID:     contentItems[i].Metadata.DatasetID,
+			EditionID:     contentItems[i].Metadata.EditionID,
+			VersionID:     contentItems[i].Metadata.VersionID,
+			State:         PublishRunItemStatePending,
+		})
+	}
+
+	return &PublishRun{
+		ID:        id.String(),
+		BundleID:  bundleID,
+		State:     PublishRunStateInProgress,
+		StartedBy: &User{Email: startedBy},
+		StartedAt: &now,
+		UpdatedAt: &now,
+		Items:     items,
+	}, nil
+}
+
+// GetItem returns the PublishRunItem for the given content item, or nil if the content item is not part of the run
+func (r *PublishRun) GetItem(contentItemID string) *PublishRunItem {
+	for i := range r.Items {
+		if r.Items[i].ContentItemID == contentItemID {
+			return &r.Items[i]
+		}
+	}
+	return nil
+}
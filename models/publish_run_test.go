@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewPublishRun(t *testing.T) {
+	Convey("Given a bundle with two content items", t, func() {
+		contentItems := []ContentItem{
+			{ID: "item1", Metadata: Metadata{DatasetID: "dataset1", EditionID: "edition1", VersionID: 1}},
+			{ID: "item2", Metadata: Metadata{DatasetID: "dataset2", EditionID: "edition2", VersionID: 2}},
+		}
+
+		Convey("When NewPublishRun is called", func() {
+			publishRun, err := NewPublishRun("bundle1", contentItems, "publisher@ons.gov.uk")
+
+			Convey("Then an in progress run is created with a pending item for each content item", func() {
+				So(err, ShouldBeNil)
+				So(publishRun.ID, ShouldNotBeEmpty)
+				So(publishRun.BundleID, ShouldEqual, "bundle1")
+				So(publishRun.State, ShouldEqual, PublishRunStateInProgress)
+				So(publishRun.StartedBy.Email, ShouldEqual, "publisher@ons.gov.uk")
+				So(publishRun.StartedAt, ShouldNotBeNil)
+				So(publishRun.UpdatedAt, ShouldEqual, publishRun.StartedAt)
+				So(publishRun.Items, ShouldResemble, []PublishRunItem{
+					{ContentItemID: "item1", DatasetID: "dataset1", EditionID: "edition1", VersionID: 1, State: PublishRunItemStatePending},
+					{ContentItemID: "item2", DatasetID: "dataset2", EditionID: "edition2", VersionID: 2, State: PublishRunItemStatePending},
+				})
+			})
+
+			Convey("And its items can be looked up by content item ID", func() {
+				So(publishRun.GetItem("item2").DatasetID, ShouldEqual, "dataset2")
+				So(publishRun.GetItem("unknown"), ShouldBeNil)
+			})
+		})
+	})
+}
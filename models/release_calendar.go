@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// ReleaseCalendar lists the release slots between two times, along with the bundles scheduled to publish in each of them
+type ReleaseCalendar struct {
+	From             time.Time                `json:"from"`
+	To               time.Time                `json:"to"`
+	TimeZone         string                   `json:"time_zone"`
+	BlackoutDates    []string                 `json:"blackout_dates"`
+	Slots            []*ReleaseSlot           `json:"slots"`
+	UnslottedBundles []*ReleaseCalendarBundle `json:"unslotted_bundles"`
+}
+
+// ReleaseSlot is a time that bundles can be scheduled to publish at, and the bundles that are scheduled for it
+type ReleaseSlot struct {
+	ScheduledAt time.Time                `json:"scheduled_at"`
+	Bundles     []*ReleaseCalendarBundle `json:"bundles"`
+}
+
+// ReleaseCalendarBundle is a bundle scheduled to publish at a time in the release calendar
+type ReleaseCalendarBundle struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	State       BundleState `json:"state"`
+	ScheduledAt *time.Time  `json:"scheduled_at"`
+}
+
+// NewReleaseCalendar returns a release calendar from from to to with the given slots, none of which have any bundles
+// scheduled for them yet
+func NewReleaseCalendar(from, to time.Time, timeZone string, blackoutDates []string, slots []time.Time) *ReleaseCalendar {
+	releaseCalendar := &ReleaseCalendar{
+		From:             from,
+		To:               to,
+		TimeZone:         timeZone,
+		BlackoutDates:    blackoutDates,
+		Slots:            []*ReleaseSlot{},
+		UnslottedBundles: []*ReleaseCalendarBundle{},
+	}
+
+	for _, slot := range slots {
+		releaseCalendar.Slots = append(releaseCalendar.Slots, &ReleaseSlot{ScheduledAt: slot, Bundles: []*ReleaseCalendarBundle{}})
+	}
+
+	return releaseCalendar
+}
+
+// AddBundle adds a scheduled bundle to the slot it is scheduled for, or to the unslotted bundles if it is not scheduled
+// for any of the slots
+func (c *ReleaseCalendar) AddBundle(bundle *Bundle) {
+	calendarBundle := &ReleaseCalendarBundle{
+		ID:          bundle.ID,
+		Title:       bundle.Title,
+		State:       bundle.State,
+		ScheduledAt: bundle.ScheduledAt,
+	}
+
+	if bundle.ScheduledAt != nil {
+		for _, slot := range c.Slots {
+			if slot.ScheduledAt.Equal(*bundle.ScheduledAt) {
+				slot.Bundles = append(slot.Bundles, calendarBundle)
+				return
+			}
+		}
+	}
+
+	c.UnslottedBundles = append(c.UnslottedBundles, calendarBundle)
+}
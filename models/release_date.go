@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// ReleaseDateFormat is the format of the release date given to the dataset version of a content item in a scheduled bundle
+const ReleaseDateFormat = "2006-01-02T15:04:05.000Z"
+
+// ReleaseDateSyncFailure describes a content item whose dataset version could not be given the scheduled_at of its bundle
+// as its release date
+type ReleaseDateSyncFailure struct {
+	ContentItemID string   `json:"content_item_id"`
+	Metadata      Metadata `json:"metadata"`
+	Error         string   `json:"error"`
+}
+
+// ReleaseDateCheck is the result of comparing the scheduled_at of a bundle with the release date of the dataset version of
+// each of its content items
+type ReleaseDateCheck struct {
+	BundleID    string                `json:"bundle_id"`
+	ScheduledAt *time.Time            `json:"scheduled_at,omitempty"`
+	Consistent  bool                  `json:"consistent"`
+	Mismatches  []ReleaseDateMismatch `json:"mismatches"`
+}
+
+// ReleaseDateMismatch describes a content item whose dataset version does not have the scheduled_at of its bundle as its
+// release date, or whose dataset version could not be read
+type ReleaseDateMismatch struct {
+	ContentItemID string   `json:"content_item_id"`
+	Metadata      Metadata `json:"metadata"`
+	ReleaseDate   string   `json:"release_date,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// NewReleaseDateSyncFailure returns a failure to give the dataset version of a content item its release date
+func NewReleaseDateSyncFailure(contentItem *ContentItem, err error) *ReleaseDateSyncFailure {
+	return &ReleaseDateSyncFailure{
+		ContentItemID: contentItem.ID,
+		Metadata:      contentItem.Metadata,
+		Error:         err.Error(),
+	}
+}
+
+// NewReleaseDateCheck returns a check of a bundle's release dates with no mismatches found
+func NewReleaseDateCheck(bundle *Bundle) *ReleaseDateCheck {
+	return &ReleaseDateCheck{
+		BundleID:    bundle.ID,
+		ScheduledAt: bundle.ScheduledAt,
+		Consistent:  true,
+		Mismatches:  []ReleaseDateMismatch{},
+	}
+}
+
+// AddMismatch records a content item whose dataset version has the wrong release date
+func (c *ReleaseDateCheck) AddMismatch(contentItem *ContentItem, releaseDate string, err error) {
+	mismatch := ReleaseDateMismatch{
+		ContentItemID: contentItem.ID,
+		Metadata:      contentItem.Metadata,
+		ReleaseDate:   releaseDate,
+	}
+	if err != nil {
+		mismatch.Error = err.Error()
+	}
+
+	c.Consistent = false
+	c.Mismatches = append(c.Mismatches, mismatch)
+}
+
+// ReleaseDateMatches reports whether the release date of a dataset version is the same instant as scheduledAt, to the
+// precision of ReleaseDateFormat
+func ReleaseDateMatches(releaseDate string, scheduledAt time.Time) bool {
+	parsed, err := time.Parse(time.RFC3339Nano, releaseDate)
+	if err != nil {
+		return false
+	}
+
+	return parsed.Truncate(time.Millisecond).Equal(scheduledAt.Truncate(time.Millisecond))
+}
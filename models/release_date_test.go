@@ -0,0 +1,65 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReleaseDateMatches(t *testing.T) {
+	Convey("Given a scheduled_at", t, func() {
+		scheduledAt := time.Date(2025, 7, 1, 9, 30, 0, 123456789, time.UTC)
+
+		Convey("When the release date is the same instant formatted with ReleaseDateFormat", func() {
+			Convey("Then it matches", func() {
+				So(ReleaseDateMatches(scheduledAt.Format(ReleaseDateFormat), scheduledAt), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the release date is the same instant in another time zone", func() {
+			Convey("Then it matches", func() {
+				So(ReleaseDateMatches("2025-07-01T10:30:00.123+01:00", scheduledAt), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the release date is a different instant", func() {
+			Convey("Then it does not match", func() {
+				So(ReleaseDateMatches("2025-07-02T09:30:00.123Z", scheduledAt), ShouldBeFalse)
+			})
+		})
+
+		Convey("When the release date is missing or cannot be parsed", func() {
+			Convey("Then it does not match", func() {
+				So(ReleaseDateMatches("", scheduledAt), ShouldBeFalse)
+				So(ReleaseDateMatches("1 July 2025", scheduledAt), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestReleaseDateCheck(t *testing.T) {
+	Convey("Given a release date check for a bundle", t, func() {
+		scheduledAt := time.Now()
+		check := NewReleaseDateCheck(&Bundle{ID: "bundle1", ScheduledAt: &scheduledAt})
+
+		Convey("Then it is consistent with no mismatches", func() {
+			So(check.BundleID, ShouldEqual, "bundle1")
+			So(check.Consistent, ShouldBeTrue)
+			So(check.Mismatches, ShouldBeEmpty)
+		})
+
+		Convey("When a mismatch is added", func() {
+			contentItem := &ContentItem{ID: "content1", Metadata: Metadata{DatasetID: "dataset1", EditionID: "edition1", VersionID: 1}}
+			check.AddMismatch(contentItem, "", errors.New("version not found"))
+
+			Convey("Then the check is not consistent and the mismatch is recorded", func() {
+				So(check.Consistent, ShouldBeFalse)
+				So(check.Mismatches, ShouldResemble, []ReleaseDateMismatch{
+					{ContentItemID: "content1", Metadata: contentItem.Metadata, Error: "version not found"},
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+)
+
+// RescheduleRequest is the request body for moving a scheduled bundle to a new release date
+type RescheduleRequest struct {
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+// ValidateRescheduleRequest checks that the new release date is set and is in the future
+func ValidateRescheduleRequest(request *RescheduleRequest) []*Error {
+	codeInvalidParameters := CodeInvalidParameters
+
+	if request.ScheduledAt == nil {
+		return []*Error{{Code: &codeInvalidParameters, Description: errs.ErrorDescriptionScheduledAtIsRequired, Source: &Source{Field: "/scheduled_at"}}}
+	}
+
+	if request.ScheduledAt.Before(time.Now()) {
+		return []*Error{{Code: &codeInvalidParameters, Description: errs.ErrorDescriptionScheduledAtIsInPast, Source: &Source{Field: "/scheduled_at"}}}
+	}
+
+	return nil
+}
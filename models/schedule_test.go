@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	errs "github.com/ONSdigital/dis-bundle-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestValidateRescheduleRequest(t *testing.T) {
+	Convey("When a request to reschedule a bundle to a future date is validated", t, func() {
+		scheduledAt := time.Now().Add(time.Hour)
+
+		Convey("Then it is valid", func() {
+			So(ValidateRescheduleRequest(&RescheduleRequest{ScheduledAt: &scheduledAt}), ShouldBeNil)
+		})
+	})
+
+	Convey("When a request to reschedule a bundle without a date is validated", t, func() {
+		validationErrs := ValidateRescheduleRequest(&RescheduleRequest{})
+
+		Convey("Then the missing date is reported", func() {
+			So(validationErrs, ShouldHaveLength, 1)
+			So(validationErrs[0].Description, ShouldEqual, errs.ErrorDescriptionScheduledAtIsRequired)
+			So(validationErrs[0].Source.Field, ShouldEqual, "/scheduled_at")
+		})
+	})
+
+	Convey("When a request to reschedule a bundle to a date in the past is validated", t, func() {
+		scheduledAt := time.Now().Add(-time.Hour)
+		validationErrs := ValidateRescheduleRequest(&RescheduleRequest{ScheduledAt: &scheduledAt})
+
+		Convey("Then the date in the past is reported", func() {
+			So(validationErrs, ShouldHaveLength, 1)
+			So(validationErrs[0].Description, ShouldEqual, errs.ErrorDescriptionScheduledAtIsInPast)
+		})
+	})
+}
@@ -0,0 +1,12 @@
+package models
+
+// BundleSearchResult is a bundle that matches a search, either by its title or by the titles of content items in it
+type BundleSearchResult struct {
+	Bundle *Bundle `json:"bundle"`
+	// Score is how well the bundle matches. Results are ranked by it, best first.
+	Score float64 `json:"score"`
+	// TitleMatched is whether the title of the bundle itself matches
+	TitleMatched bool `json:"title_matched"`
+	// MatchedContents are the content items in the bundle whose titles match, best first
+	MatchedContents []*ContentItem `json:"matched_contents"`
+}
@@ -1,5 +1,8 @@
 package models
 
+// UpdateStateRequest is the request body for changing the state of a bundle. Reason explains the change, and is
+// required when a bundle in review or approved is sent back to DRAFT.
 type UpdateStateRequest struct {
-	State BundleState
+	State  BundleState `json:"state"`
+	Reason string      `json:"reason,omitempty"`
 }
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// StateTransition records a change to the state of a bundle, who made it and why
+type StateTransition struct {
+	FromState      BundleState `bson:"from_state"                json:"from_state"`
+	ToState        BundleState `bson:"to_state"                  json:"to_state"`
+	Reason         string      `bson:"reason,omitempty"          json:"reason,omitempty"`
+	TransitionedBy *User       `bson:"transitioned_by,omitempty" json:"transitioned_by,omitempty"`
+	TransitionedAt *time.Time  `bson:"transitioned_at,omitempty" json:"transitioned_at,omitempty"`
+}
+
+// BundleTransitions lists the states a bundle can move to from its current state
+type BundleTransitions struct {
+	BundleID    string                `json:"bundle_id"`
+	State       BundleState           `json:"state"`
+	Transitions []AvailableTransition `json:"transitions"`
+}
+
+// AvailableTransition is a state a bundle can move to, and whether the move is currently permitted
+type AvailableTransition struct {
+	State              BundleState `json:"state"`
+	Permitted          bool        `json:"permitted"`
+	UnmetPreconditions []string    `json:"unmet_preconditions,omitempty"`
+}
+
+// AddUnmetPrecondition records a precondition that stops the transition being made
+func (t *AvailableTransition) AddUnmetPrecondition(description string) {
+	t.Permitted = false
+	t.UnmetPreconditions = append(t.UnmetPreconditions, description)
+}
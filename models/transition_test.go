@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAvailableTransition(t *testing.T) {
+	Convey("Given a permitted transition", t, func() {
+		transition := &AvailableTransition{State: BundleStatePublished, Permitted: true}
+
+		Convey("When unmet preconditions are added", func() {
+			transition.AddUnmetPrecondition("2 content items not approved")
+			transition.AddUnmetPrecondition("bundle has no content items")
+
+			Convey("Then the transition is no longer permitted and each precondition is listed", func() {
+				So(transition.Permitted, ShouldBeFalse)
+				So(transition.UnmetPreconditions, ShouldResemble, []string{"2 content items not approved", "bundle has no content items"})
+			})
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CreateApproval inserts a new approval
+func (m *Mongo) CreateApproval(ctx context.Context, approval *models.Approval) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.ApprovalsCollection)).
+		InsertOne(ctx, approval)
+
+	return err
+}
+
+// ListApprovals retrieves the approvals recorded against a bundle, oldest first
+func (m *Mongo) ListApprovals(ctx context.Context, bundleID string) ([]*models.Approval, error) {
+	approvals := []*models.Approval{}
+
+	filter, sort := buildListApprovalsQuery(bundleID)
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.ApprovalsCollection)).
+		Find(ctx, filter, &approvals, mongodriver.Sort(sort))
+	if err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+func buildListApprovalsQuery(bundleID string) (filter, sort bson.M) {
+	filter = bson.M{"bundle_id": bundleID}
+	sort = bson.M{"approved_at": 1}
+	return filter, sort
+}
+
+// DeleteApproval removes the approval of a bundle recorded by approvedBy
+func (m *Mongo) DeleteApproval(ctx context.Context, bundleID, approvedBy string) error {
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.ApprovalsCollection)).
+		DeleteMany(ctx, buildDeleteApprovalQuery(bundleID, approvedBy))
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return apierrors.ErrApprovalNotFound
+	}
+
+	return nil
+}
+
+func buildDeleteApprovalQuery(bundleID, approvedBy string) bson.M {
+	return bson.M{
+		"bundle_id":         bundleID,
+		"approved_by.email": approvedBy,
+	}
+}
+
+// DeleteApprovals removes every approval recorded against a bundle, returning how many were removed
+func (m *Mongo) DeleteApprovals(ctx context.Context, bundleID string) (int, error) {
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.ApprovalsCollection)).
+		DeleteMany(ctx, bson.M{"bundle_id": bundleID})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,121 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func newTestApproval(id, bundleID, approvedBy string, approvedAt time.Time) *models.Approval {
+	return &models.Approval{
+		ID:         id,
+		BundleID:   bundleID,
+		ApprovedBy: &models.User{Email: approvedBy},
+		ApprovedAt: &approvedAt,
+	}
+}
+
+func setupTestDataForApprovals(ctx context.Context, mongo *Mongo, approvals ...*models.Approval) error {
+	if err := mongo.Connection.DropDatabase(ctx); err != nil {
+		return err
+	}
+
+	for _, approval := range approvals {
+		if _, err := mongo.Connection.Collection(mongo.ActualCollectionName(config.ApprovalsCollection)).InsertOne(ctx, approval); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestApprovals(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly and there are approvals for two bundles", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		err = setupTestDataForApprovals(ctx, mongodb,
+			newTestApproval("approval2", "bundle1", "second@ons.gov.uk", now),
+			newTestApproval("approval1", "bundle1", "first@ons.gov.uk", now.Add(-time.Hour)),
+			newTestApproval("approval3", "bundle2", "first@ons.gov.uk", now),
+		)
+		So(err, ShouldBeNil)
+
+		Convey("When ListApprovals is called for a bundle", func() {
+			approvals, err := mongodb.ListApprovals(ctx, "bundle1")
+
+			Convey("Then only the approvals for that bundle are returned, oldest first", func() {
+				So(err, ShouldBeNil)
+				So(approvals, ShouldHaveLength, 2)
+				So(approvals[0].ID, ShouldEqual, "approval1")
+				So(approvals[1].ID, ShouldEqual, "approval2")
+			})
+		})
+
+		Convey("When DeleteApproval is called for a user who approved the bundle", func() {
+			err := mongodb.DeleteApproval(ctx, "bundle1", "first@ons.gov.uk")
+
+			Convey("Then only their approval of that bundle is removed", func() {
+				So(err, ShouldBeNil)
+
+				approvals, err := mongodb.ListApprovals(ctx, "bundle1")
+				So(err, ShouldBeNil)
+				So(approvals, ShouldHaveLength, 1)
+				So(approvals[0].ID, ShouldEqual, "approval2")
+
+				approvals, err = mongodb.ListApprovals(ctx, "bundle2")
+				So(err, ShouldBeNil)
+				So(approvals, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When DeleteApproval is called for a user who has not approved the bundle", func() {
+			err := mongodb.DeleteApproval(ctx, "bundle2", "second@ons.gov.uk")
+
+			Convey("Then ErrApprovalNotFound is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrApprovalNotFound)
+			})
+		})
+
+		Convey("When DeleteApprovals is called for a bundle", func() {
+			deleted, err := mongodb.DeleteApprovals(ctx, "bundle1")
+
+			Convey("Then every approval of that bundle is removed", func() {
+				So(err, ShouldBeNil)
+				So(deleted, ShouldEqual, 2)
+
+				approvals, err := mongodb.ListApprovals(ctx, "bundle1")
+				So(err, ShouldBeNil)
+				So(approvals, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestBuildApprovalQueries(t *testing.T) {
+	Convey("When buildListApprovalsQuery is called", t, func() {
+		filter, sort := buildListApprovalsQuery("bundle1")
+
+		Convey("Then it filters by bundle and sorts oldest first", func() {
+			So(filter, ShouldResemble, bson.M{"bundle_id": "bundle1"})
+			So(sort, ShouldResemble, bson.M{"approved_at": 1})
+		})
+	})
+
+	Convey("When buildDeleteApprovalQuery is called", t, func() {
+		filter := buildDeleteApprovalQuery("bundle1", "approver@ons.gov.uk")
+
+		Convey("Then it matches the user's approval of the bundle", func() {
+			So(filter, ShouldResemble, bson.M{"bundle_id": "bundle1", "approved_by.email": "approver@ons.gov.uk"})
+		})
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ONSdigital/dis-bundle-api/apierrors"
 	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"go.mongodb.org/mongo-driver/bson"
@@ -37,6 +38,27 @@ func (m *Mongo) CreateContentItem(ctx context.Context, contentItem *models.Conte
 	return err
 }
 
+// CreateContentItems inserts all of contentItems, or none of them. If the insert fails part of the way through then the
+// content items that were inserted are removed again.
+func (m *Mongo) CreateContentItems(ctx context.Context, contentItems []*models.ContentItem) error {
+	documents := make([]interface{}, len(contentItems))
+	contentItemIDs := make([]string, len(contentItems))
+	for i, contentItem := range contentItems {
+		documents[i] = contentItem
+		contentItemIDs[i] = contentItem.ID
+	}
+
+	collection := m.Connection.Collection(m.ActualCollectionName(config.BundleContentsCollection))
+	if _, err := collection.InsertMany(ctx, documents); err != nil {
+		if _, deleteErr := collection.DeleteMany(ctx, bson.M{"id": bson.M{"$in": contentItemIDs}}); deleteErr != nil {
+			return errors.Join(err, deleteErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
 // CheckAllBundleContentsAreApproved checks if all contents of a bundle are in the approved state
 func (m *Mongo) CheckAllBundleContentsAreApproved(ctx context.Context, bundleID string) (bool, error) {
 	filter := bson.M{
@@ -71,6 +93,78 @@ func (m *Mongo) CheckContentItemExistsByDatasetEditionVersion(ctx context.Contex
 	return count > 0, nil
 }
 
+// ListContents returns a page of the content items in all bundles that match the filters, ordered by dataset, edition and
+// then latest version first, along with the cursor for the next page if there is one
+func (m *Mongo) ListContents(ctx context.Context, offset, limit int, contentFilters *filters.ContentFilters) (contents []*models.ContentItem, totalCount int, nextCursor string, err error) {
+	filter, sort := buildListContentsQuery(contentFilters)
+
+	var cursor *string
+	if contentFilters != nil {
+		cursor = contentFilters.Cursor
+	}
+
+	return findPage[models.ContentItem](ctx, m.Connection.Collection(m.ActualCollectionName(config.BundleContentsCollection)), filter, sort, offset, limit, cursor)
+}
+
+// buildListContentsQuery builds the MongoDB filter and sort for the content items that match the supplied
+// ContentFilters value
+func buildListContentsQuery(contentFilters *filters.ContentFilters) (filter bson.M, sort bson.D) {
+	filter = bson.M{}
+	defaultSort := []filters.SortField{
+		{Field: "metadata.dataset_id"},
+		{Field: "metadata.edition_id"},
+		{Field: "metadata.version_id", Descending: true},
+	}
+
+	if contentFilters == nil {
+		return filter, buildSort(nil, defaultSort, "id")
+	}
+
+	sort = buildSort(contentFilters.Sort, defaultSort, "id")
+
+	if contentFilters.DatasetID != nil {
+		filter["metadata.dataset_id"] = *contentFilters.DatasetID
+	}
+	if contentFilters.EditionID != nil {
+		filter["metadata.edition_id"] = *contentFilters.EditionID
+	}
+	if contentFilters.VersionID != nil {
+		filter["metadata.version_id"] = *contentFilters.VersionID
+	}
+	if contentFilters.State != nil {
+		filter["state"] = *contentFilters.State
+	}
+
+	return filter, sort
+}
+
+// ListContentItemsByDatasetIDs returns the content items for any of the given datasets that are not in the excluded bundle
+func (m *Mongo) ListContentItemsByDatasetIDs(ctx context.Context, datasetIDs []string, excludeBundleID string) ([]*models.ContentItem, error) {
+	results := []*models.ContentItem{}
+
+	filter, sort := buildListContentItemsByDatasetIDsQuery(datasetIDs, excludeBundleID)
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.BundleContentsCollection)).
+		Find(ctx, filter, &results, mongodriver.Sort(sort))
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// buildListContentItemsByDatasetIDsQuery builds the MongoDB filter and sort for the content items for a set of datasets
+// in bundles other than the excluded bundle
+func buildListContentItemsByDatasetIDsQuery(datasetIDs []string, excludeBundleID string) (filter, sort bson.M) {
+	filter = bson.M{
+		"metadata.dataset_id": bson.M{"$in": datasetIDs},
+		"bundle_id":           bson.M{"$ne": excludeBundleID},
+	}
+	sort = bson.M{"bundle_id": 1}
+
+	return filter, sort
+}
+
 // GetContentItemsByBundleID retrieves all content items for a specific bundle
 func (m *Mongo) GetContentItemsByBundleID(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
 	var results []*models.ContentItem
@@ -135,6 +229,17 @@ func (m *Mongo) DeleteContentItem(ctx context.Context, contentItemID string) err
 	return nil
 }
 
+// DeleteContentItems removes the content items in a bundle with the given IDs, returning how many were removed
+func (m *Mongo) DeleteContentItems(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.BundleContentsCollection)).
+		DeleteMany(ctx, bson.M{"bundle_id": bundleID, "id": bson.M{"$in": contentItemIDs}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
 // UpdateContentItemState updates the state for the content item matching the contentItemID
 func (m *Mongo) UpdateContentItemState(ctx context.Context, contentItemID, state string) error {
 	var currentItem models.ContentItem
@@ -173,19 +278,10 @@ func (m *Mongo) UpdateContentItemState(ctx context.Context, contentItemID, state
 	return nil
 }
 
-func (m *Mongo) ListBundleContents(ctx context.Context, bundleID string, offset, limit int) (contents []*models.ContentItem, totalCount int, err error) {
-	var results []*models.ContentItem
-
-	filter, sort := buildListBundleContentsQuery(bundleID)
-
-	totalCount, err = m.Connection.Collection(m.ActualCollectionName(config.BundleContentsCollection)).
-		Find(ctx, filter, &results, mongodriver.Sort(sort), mongodriver.Offset(offset), mongodriver.Limit(limit))
-
-	if err != nil {
-		return nil, 0, err
-	}
+func (m *Mongo) ListBundleContents(ctx context.Context, bundleID string, offset, limit int, sortFields []filters.SortField, cursor *string) (contents []*models.ContentItem, totalCount int, nextCursor string, err error) {
+	filter, sort := buildListBundleContentsQuery(bundleID, sortFields)
 
-	return results, totalCount, nil
+	return findPage[models.ContentItem](ctx, m.Connection.Collection(m.ActualCollectionName(config.BundleContentsCollection)), filter, sort, offset, limit, cursor)
 }
 
 func (m *Mongo) ListBundleContentIDsWithoutLimit(ctx context.Context, bundleID string) (contents []*models.ContentItem, err error) {
@@ -223,14 +319,14 @@ func (m *Mongo) CountBundleContents(ctx context.Context, bundleID string) (int,
 	return count, nil
 }
 
-func buildListBundleContentsQuery(bundleID string) (filter, sort bson.M) {
+func buildListBundleContentsQuery(bundleID string, sortFields []filters.SortField) (filter bson.M, sort bson.D) {
 	filter = bson.M{}
 
 	if bundleID != "" {
 		filter["bundle_id"] = bundleID
 	}
 
-	sort = bson.M{"id": -1}
+	sort = buildSort(sortFields, []filters.SortField{{Field: "id", Descending: true}}, "id")
 	return
 }
 
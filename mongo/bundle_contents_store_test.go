@@ -6,8 +6,10 @@ import (
 
 	"github.com/ONSdigital/dis-bundle-api/apierrors"
 	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 const (
@@ -393,6 +395,63 @@ func TestDeleteContentItem_Failure(t *testing.T) {
 	})
 }
 
+func TestCreateContentItems_Success(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		err = setupBundleContentsTestData(ctx, mongodb)
+		So(err, ShouldBeNil)
+
+		Convey("When CreateContentItems is called with new content items", func() {
+			newContentItems := []*models.ContentItem{
+				{ID: "new-content-item-1", BundleID: "bundle3", ContentType: models.ContentTypeDataset, Metadata: models.Metadata{DatasetID: "dataset4", EditionID: "2025", VersionID: 1}},
+				{ID: "new-content-item-2", BundleID: "bundle3", ContentType: models.ContentTypeDataset, Metadata: models.Metadata{DatasetID: "dataset5", EditionID: "2025", VersionID: 1}},
+			}
+			err := mongodb.CreateContentItems(ctx, newContentItems)
+
+			Convey("Then all of them are inserted", func() {
+				So(err, ShouldBeNil)
+
+				contentItems, err := mongodb.GetContentItemsByBundleID(ctx, "bundle3")
+				So(err, ShouldBeNil)
+				So(contentItems, ShouldHaveLength, 2)
+			})
+		})
+	})
+}
+
+func TestDeleteContentItems_Success(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		err = setupBundleContentsTestData(ctx, mongodb)
+		So(err, ShouldBeNil)
+
+		Convey("When DeleteContentItems is called with content items in the bundle and in another bundle", func() {
+			deleted, err := mongodb.DeleteContentItems(ctx, Bundle1ID, []string{contentsTestData[0].ID, contentsTestData[2].ID})
+
+			Convey("Then only the content item in the bundle is deleted", func() {
+				So(err, ShouldBeNil)
+				So(deleted, ShouldEqual, 1)
+
+				contentItems, err := mongodb.GetContentItemsByBundleID(ctx, Bundle1ID)
+				So(err, ShouldBeNil)
+				So(contentItems, ShouldHaveLength, 1)
+				So(contentItems[0].ID, ShouldEqual, contentsTestData[1].ID)
+
+				_, err = mongodb.GetContentItemByBundleIDAndContentItemID(ctx, "bundle2", contentsTestData[2].ID)
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestUpdateContentItemState_Success(t *testing.T) {
 	ctx := context.Background()
 
@@ -664,3 +723,120 @@ func TestUpdateContentItemMetadataAndLinks_Failure(t *testing.T) {
 		})
 	})
 }
+
+func TestListContents(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly and its indexes exist", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		err = setupBundleContentsTestData(ctx, mongodb)
+		So(err, ShouldBeNil)
+
+		So(mongodb.ensureIndexes(ctx), ShouldBeNil)
+
+		Convey("When ListContents is called for a dataset edition version", func() {
+			datasetID, editionID, versionID := "dataset2", "2025", 1
+			contents, totalCount, _, err := mongodb.ListContents(ctx, 0, 10, &filters.ContentFilters{DatasetID: &datasetID, EditionID: &editionID, VersionID: &versionID})
+
+			Convey("Then the content item for it is returned with its bundle ID", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 1)
+				So(contents[0].ID, ShouldEqual, "af8b48b0-d085-4ea7-8f12-524fa8e6b0a0")
+				So(contents[0].BundleID, ShouldEqual, Bundle1ID)
+			})
+		})
+
+		Convey("When ListContents is called for approved content items", func() {
+			state := models.StateApproved
+			contents, totalCount, _, err := mongodb.ListContents(ctx, 0, 1, &filters.ContentFilters{State: &state})
+
+			Convey("Then a page of the approved content items in every bundle is returned in dataset order", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 2)
+				So(contents, ShouldHaveLength, 1)
+				So(contents[0].Metadata.DatasetID, ShouldEqual, "dataset1")
+			})
+		})
+
+		Convey("When the indexes are ensured again", func() {
+			err := mongodb.ensureIndexes(ctx)
+
+			Convey("Then nothing is changed and no error is returned", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestBuildListContentsQuery(t *testing.T) {
+	Convey("When buildListContentsQuery is called with every filter", t, func() {
+		datasetID, editionID, versionID, state := "cpih", "time-series", 2, models.StatePublished
+		filter, sort := buildListContentsQuery(&filters.ContentFilters{DatasetID: &datasetID, EditionID: &editionID, VersionID: &versionID, State: &state})
+
+		Convey("Then it filters on each of them and sorts by dataset, edition and latest version", func() {
+			So(filter, ShouldResemble, bson.M{
+				"metadata.dataset_id": "cpih",
+				"metadata.edition_id": "time-series",
+				"metadata.version_id": 2,
+				"state":               models.StatePublished,
+			})
+			So(sort, ShouldResemble, bson.D{
+				{Key: "metadata.dataset_id", Value: 1},
+				{Key: "metadata.edition_id", Value: 1},
+				{Key: "metadata.version_id", Value: -1},
+				{Key: "id", Value: 1},
+			})
+		})
+	})
+
+	Convey("When buildListContentsQuery is called without filters", t, func() {
+		filter, _ := buildListContentsQuery(nil)
+
+		Convey("Then it matches every content item", func() {
+			So(filter, ShouldResemble, bson.M{})
+		})
+	})
+
+	Convey("When buildListContentsQuery is called with sort fields", t, func() {
+		_, sort := buildListContentsQuery(&filters.ContentFilters{Sort: []filters.SortField{{Field: "state", Descending: true}}})
+
+		Convey("Then it sorts by them and then by ID", func() {
+			So(sort, ShouldResemble, bson.D{{Key: "state", Value: -1}, {Key: "id", Value: 1}})
+		})
+	})
+}
+
+func TestBuildListBundleContentsQuery(t *testing.T) {
+	Convey("When buildListBundleContentsQuery is called without sort fields", t, func() {
+		filter, sort := buildListBundleContentsQuery("bundle1", nil)
+
+		Convey("Then it filters on the bundle and sorts by ID, latest first", func() {
+			So(filter, ShouldResemble, bson.M{"bundle_id": "bundle1"})
+			So(sort, ShouldResemble, bson.D{{Key: "id", Value: -1}})
+		})
+	})
+
+	Convey("When buildListBundleContentsQuery is called with sort fields", t, func() {
+		_, sort := buildListBundleContentsQuery("bundle1", []filters.SortField{{Field: "metadata.title"}})
+
+		Convey("Then it sorts by them and then by ID", func() {
+			So(sort, ShouldResemble, bson.D{{Key: "metadata.title", Value: 1}, {Key: "id", Value: 1}})
+		})
+	})
+}
+
+func TestBuildListContentItemsByDatasetIDsQuery(t *testing.T) {
+	Convey("When buildListContentItemsByDatasetIDsQuery is called", t, func() {
+		filter, sort := buildListContentItemsByDatasetIDsQuery([]string{"cpih", "gdp"}, "bundle1")
+
+		Convey("Then it filters on the datasets in other bundles and sorts by bundle", func() {
+			So(filter, ShouldResemble, bson.M{
+				"metadata.dataset_id": bson.M{"$in": []string{"cpih", "gdp"}},
+				"bundle_id":           bson.M{"$ne": "bundle1"},
+			})
+			So(sort, ShouldResemble, bson.M{"bundle_id": 1})
+		})
+	})
+}
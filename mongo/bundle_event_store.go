@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"go.mongodb.org/mongo-driver/bson"
@@ -22,29 +23,22 @@ func (m *Mongo) CreateEvent(ctx context.Context, event *models.Event) error {
 	return err
 }
 
-// ListBundleEvents retrieves all bundle events with optional filtering and pagination
-func (m *Mongo) ListBundleEvents(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time) (events []*models.Event, totalCount int, err error) {
-	var results []*models.Event
+// ListBundleEvents retrieves all bundle events with optional filtering and pagination, along with the cursor for the next
+// page if there is one
+func (m *Mongo) ListBundleEvents(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) (events []*models.Event, totalCount int, nextCursor string, err error) {
+	filter, sort := buildListBundleEventsQuery(bundleID, after, before, sortFields)
 
-	filter, sort := buildListBundleEventsQuery(bundleID, after, before)
-
-	totalCount, err = m.Connection.Collection(m.ActualCollectionName(config.BundleEventsCollection)).
-		Find(ctx, filter, &results, mongodriver.Sort(sort), mongodriver.Offset(offset), mongodriver.Limit(limit))
-
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return results, totalCount, nil
+	return findPage[models.Event](ctx, m.Connection.Collection(m.ActualCollectionName(config.BundleEventsCollection)), filter, sort, offset, limit, cursor)
 }
 
-func buildListBundleEventsQuery(bundleID string, after, before *time.Time) (filter, sort bson.M) {
+func buildListBundleEventsQuery(bundleID string, after, before *time.Time, sortFields []filters.SortField) (filter bson.M, sort bson.D) {
 	filter = bson.M{}
 
 	if bundleID != "" {
 		filter["$or"] = []bson.M{
 			{"bundle.id": bundleID},
 			{"content_item.bundle_id": bundleID},
+			{"comment.bundle_id": bundleID},
 		}
 	}
 
@@ -59,10 +53,37 @@ func buildListBundleEventsQuery(bundleID string, after, before *time.Time) (filt
 		filter["created_at"] = dateFilter
 	}
 
-	sort = bson.M{"created_at": -1}
+	// events have no ID of their own, so the document ID is used to break ties
+	sort = buildSort(sortFields, []filters.SortField{{Field: "created_at", Descending: true}}, "_id")
 	return
 }
 
+// ListBundleEditors returns the IDs of the users who have created, updated or deleted a bundle or any of its content
+// items. Commenting on a bundle does not make someone an editor of it.
+func (m *Mongo) ListBundleEditors(ctx context.Context, bundleID string) ([]string, error) {
+	values, err := m.Connection.Collection(m.ActualCollectionName(config.BundleEventsCollection)).
+		Distinct(ctx, "requested_by.id", buildListBundleEditorsQuery(bundleID))
+	if err != nil {
+		return nil, err
+	}
+
+	editors := make([]string, 0, len(values))
+	for _, value := range values {
+		if editor, ok := value.(string); ok && editor != "" {
+			editors = append(editors, editor)
+		}
+	}
+
+	return editors, nil
+}
+
+func buildListBundleEditorsQuery(bundleID string) bson.M {
+	filter, _ := buildListBundleEventsQuery(bundleID, nil, nil, nil)
+	filter["comment"] = bson.M{"$exists": false}
+	filter["action"] = bson.M{"$in": []models.Action{models.ActionCreate, models.ActionUpdate, models.ActionDelete}}
+	return filter
+}
+
 // GetBundleEvent retrieves an event by Bundle ID
 func (m *Mongo) GetBundleEvent(ctx context.Context, bundleID string) (*models.Event, error) {
 	filter := buildGetBundleEventQuery(bundleID)
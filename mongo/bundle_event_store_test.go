@@ -5,8 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 var (
@@ -75,6 +77,46 @@ func TestCreateEvent_Failure(t *testing.T) {
 	})
 }
 
+func TestBuildListBundleEditorsQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildListBundleEditorsQuery", t, func() {
+		filter := buildListBundleEditorsQuery("bundle1")
+
+		Convey("Then it should filter on events that changed the bundle or its content items, ignoring comments", func() {
+			So(filter, ShouldResemble, bson.M{
+				"$or": []bson.M{
+					{"bundle.id": "bundle1"},
+					{"content_item.bundle_id": "bundle1"},
+					{"comment.bundle_id": "bundle1"},
+				},
+				"comment": bson.M{"$exists": false},
+				"action":  bson.M{"$in": []models.Action{models.ActionCreate, models.ActionUpdate, models.ActionDelete}},
+			})
+		})
+	})
+}
+
+func TestBuildListBundleEventsQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildListBundleEventsQuery without sort fields", t, func() {
+		_, sort := buildListBundleEventsQuery("bundle1", nil, nil, nil)
+
+		Convey("Then it should sort by latest first and then by document ID", func() {
+			So(sort, ShouldResemble, bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}})
+		})
+	})
+
+	Convey("When we call buildListBundleEventsQuery with sort fields", t, func() {
+		_, sort := buildListBundleEventsQuery("bundle1", nil, nil, []filters.SortField{{Field: "action"}, {Field: "created_at"}})
+
+		Convey("Then it should sort by them and then by document ID", func() {
+			So(sort, ShouldResemble, bson.D{{Key: "action", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+		})
+	})
+}
+
 func setupTestDataForEvents(ctx context.Context, mongo *Mongo) error {
 	if err := mongo.Connection.DropDatabase(ctx); err != nil {
 		return err
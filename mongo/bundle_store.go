@@ -13,37 +13,95 @@ import (
 	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"github.com/ONSdigital/log.go/v2/log"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// ListBundles retrieves all bundles based on the provided offset, limit, and BundleFilters
-func (m *Mongo) ListBundles(ctx context.Context, offset, limit int, bundleFilters *filters.BundleFilters) (bundles []*models.Bundle, totalCount int, err error) {
-	bundles = []*models.Bundle{}
-
+// ListBundles retrieves all bundles based on the provided offset, limit, and BundleFilters, along with the cursor for the
+// next page if there is one
+func (m *Mongo) ListBundles(ctx context.Context, offset, limit int, bundleFilters *filters.BundleFilters) (bundles []*models.Bundle, totalCount int, nextCursor string, err error) {
 	filter, sort := buildListBundlesQuery(bundleFilters)
 
-	totalCount, err = m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).
-		Find(ctx, filter, &bundles, mongodriver.Sort(sort), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	var cursor *string
+	var view *filters.BundleView
+	if bundleFilters != nil {
+		cursor = bundleFilters.Cursor
+		view = bundleFilters.View
+	}
+
+	var opts []mongodriver.FindOption
+	if projection := buildBundleProjection(view, sort); projection != nil {
+		opts = append(opts, mongodriver.Projection(projection))
+	}
 
+	bundles, totalCount, nextCursor, err = findPage[models.Bundle](ctx, m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)), filter, sort, offset, limit, cursor, opts...)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 
-	return bundles, totalCount, nil
+	if err := m.embedBundleResources(ctx, bundles, view); err != nil {
+		return nil, 0, "", err
+	}
+
+	return bundles, totalCount, nextCursor, nil
 }
 
-// buildListBundlesQuery Builds the MongoDB filter query based on the supplied BundleFilters value
-func buildListBundlesQuery(bundleFilters *filters.BundleFilters) (filter, sort bson.M) {
+// buildListBundlesQuery Builds the MongoDB filter query and sort based on the supplied BundleFilters value
+func buildListBundlesQuery(bundleFilters *filters.BundleFilters) (filter bson.M, sort bson.D) {
 	filter = bson.M{}
-	sort = bson.M{"updated_at": -1}
+	defaultSort := []filters.SortField{{Field: "updated_at", Descending: true}}
 
 	if bundleFilters == nil {
-		return filter, sort
+		return filter, buildSort(nil, defaultSort, "id")
 	}
 
+	sort = buildSort(bundleFilters.Sort, defaultSort, "id")
+
 	if bundleFilters.PublishDate != nil {
 		filter["scheduled_at"] = buildDateTimeFilter(*bundleFilters.PublishDate)
 	}
 
+	if bundleFilters.ScheduledAt != nil {
+		scheduledAtFilter := buildTimeRangeFilter(bundleFilters.ScheduledAt)
+		if existing, ok := filter["scheduled_at"]; ok {
+			delete(filter, "scheduled_at")
+			filter["$and"] = []bson.M{{"scheduled_at": existing}, {"scheduled_at": scheduledAtFilter}}
+		} else {
+			filter["scheduled_at"] = scheduledAtFilter
+		}
+	}
+
+	if bundleFilters.CreatedAt != nil {
+		filter["created_at"] = buildTimeRangeFilter(bundleFilters.CreatedAt)
+	}
+
+	if bundleFilters.UpdatedAt != nil {
+		filter["updated_at"] = buildTimeRangeFilter(bundleFilters.UpdatedAt)
+	}
+
+	if len(bundleFilters.States) > 0 {
+		filter["state"] = bson.M{"$in": bundleFilters.States}
+	}
+
+	if bundleFilters.BundleType != nil {
+		filter["bundle_type"] = *bundleFilters.BundleType
+	}
+
+	if bundleFilters.ManagedBy != nil {
+		filter["managed_by"] = *bundleFilters.ManagedBy
+	}
+
+	if bundleFilters.CreatedBy != nil {
+		filter["created_by.email"] = *bundleFilters.CreatedBy
+	}
+
+	if bundleFilters.PreviewTeam != nil {
+		filter["preview_teams.id"] = *bundleFilters.PreviewTeam
+	}
+
+	if bundleFilters.Title != nil {
+		filter["title"] = buildSubstringFilter(*bundleFilters.Title)
+	}
+
 	return filter, sort
 }
 
@@ -68,6 +126,178 @@ func buildGetBundleQuery(bundleID string) bson.M {
 	return bson.M{"id": bundleID}
 }
 
+// GetBundleView retrieves a single bundle by ID with only the fields in the view, along with the resources it embeds
+func (m *Mongo) GetBundleView(ctx context.Context, bundleID string, view *filters.BundleView) (*models.Bundle, error) {
+	var opts []mongodriver.FindOption
+	if projection := buildBundleProjection(view, nil); projection != nil {
+		opts = append(opts, mongodriver.Projection(projection))
+	}
+
+	var result models.Bundle
+	err := m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).
+		FindOne(ctx, buildGetBundleQuery(bundleID), &result, opts...)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, apierrors.ErrBundleNotFound
+		}
+		return nil, err
+	}
+
+	if err := m.embedBundleResources(ctx, []*models.Bundle{&result}, view); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// buildBundleProjection builds the projection of the stored fields of bundles in the view, along with the ID, the ETag
+// so that a weak ETag can be made from it whichever fields are in the view, and the keys of the sort so that a cursor
+// can be made for the page. Nil is returned, for whole bundles, if the view has no fields or embeds transitions, as
+// the guards of each transition are checked against the whole bundle.
+func buildBundleProjection(view *filters.BundleView, sort bson.D) bson.M {
+	if view == nil || len(view.Fields) == 0 || view.Embeds(filters.EmbedTransitions) {
+		return nil
+	}
+
+	projection := bson.M{"_id": 0, "id": 1, "e_tag": 1}
+	for _, field := range view.Fields {
+		projection[filters.BundleFields[field]] = 1
+	}
+	for _, elem := range sort {
+		projection[elem.Key] = 1
+	}
+
+	return projection
+}
+
+// bundleEmbeds holds the resources embedded in a bundle, as found by the pipeline built by buildEmbedBundleResourcesPipeline
+type bundleEmbeds struct {
+	ID                     string                `bson:"id"`
+	Contents               []*models.ContentItem `bson:"contents"`
+	LatestBundleEvent      []*models.Event       `bson:"latest_bundle_event"`
+	LatestContentItemEvent []*models.Event       `bson:"latest_content_item_event"`
+	LatestCommentEvent     []*models.Event       `bson:"latest_comment_event"`
+}
+
+// latestEvent returns the latest of the events looked up for the bundle, which is nil if there are none
+func (e *bundleEmbeds) latestEvent() *models.Event {
+	var latest *models.Event
+	for _, events := range [][]*models.Event{e.LatestBundleEvent, e.LatestContentItemEvent, e.LatestCommentEvent} {
+		for _, event := range events {
+			if latest == nil || (event.CreatedAt != nil && (latest.CreatedAt == nil || event.CreatedAt.After(*latest.CreatedAt))) {
+				latest = event
+			}
+		}
+	}
+
+	return latest
+}
+
+// embedBundleResources embeds the content items and latest event in each of the bundles, if the view asks for them,
+// finding them for all the bundles with one aggregation. Transitions are left for the caller to embed.
+func (m *Mongo) embedBundleResources(ctx context.Context, bundles []*models.Bundle, view *filters.BundleView) error {
+	if len(bundles) == 0 || view == nil || len(view.Embed) == 0 {
+		return nil
+	}
+
+	for _, bundle := range bundles {
+		bundle.Embedded = &models.BundleEmbedded{}
+	}
+
+	embedContents := view.Embeds(filters.EmbedContents)
+	embedEvents := view.Embeds(filters.EmbedEvents)
+	if !embedContents && !embedEvents {
+		return nil
+	}
+
+	bundleIDs := make([]string, len(bundles))
+	for i, bundle := range bundles {
+		bundleIDs[i] = bundle.ID
+	}
+
+	pipeline := buildEmbedBundleResourcesPipeline(bundleIDs, embedContents, embedEvents,
+		m.ActualCollectionName(config.BundleContentsCollection), m.ActualCollectionName(config.BundleEventsCollection))
+
+	var results []*bundleEmbeds
+	if err := m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).Aggregate(ctx, pipeline, &results); err != nil {
+		return err
+	}
+
+	embedsByBundleID := make(map[string]*bundleEmbeds, len(results))
+	for _, result := range results {
+		embedsByBundleID[result.ID] = result
+	}
+
+	for _, bundle := range bundles {
+		embeds, ok := embedsByBundleID[bundle.ID]
+		if !ok {
+			embeds = &bundleEmbeds{}
+		}
+
+		if embedContents {
+			contents := embeds.Contents
+			if contents == nil {
+				contents = []*models.ContentItem{}
+			}
+			bundle.Embedded.Contents = &contents
+		}
+
+		if embedEvents {
+			bundle.Embedded.LatestEvent = embeds.latestEvent()
+		}
+	}
+
+	return nil
+}
+
+// buildEmbedBundleResourcesPipeline builds the aggregation pipeline that looks up the content items of each of the
+// bundles, in the same order as they are listed, and the most recent events about each bundle or anything in it, of
+// which the latest is embedded
+func buildEmbedBundleResourcesPipeline(bundleIDs []string, embedContents, embedEvents bool, contentsCollection, eventsCollection string) []bson.M {
+	pipeline := []bson.M{
+		{"$match": bson.M{"id": bson.M{"$in": bundleIDs}}},
+		{"$project": bson.M{"_id": 0, "id": 1}},
+	}
+
+	if embedContents {
+		pipeline = append(pipeline, bson.M{"$lookup": bson.M{
+			"from": contentsCollection,
+			"let":  bson.M{"bundle_id": "$id"},
+			"pipeline": []bson.M{
+				{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$bundle_id", "$$bundle_id"}}}},
+				{"$sort": bson.D{{Key: "id", Value: -1}}},
+			},
+			"as": "contents",
+		}})
+	}
+
+	if embedEvents {
+		pipeline = append(pipeline,
+			buildLatestEventLookup(eventsCollection, "bundle.id", "latest_bundle_event"),
+			buildLatestEventLookup(eventsCollection, "content_item.bundle_id", "latest_content_item_event"),
+			buildLatestEventLookup(eventsCollection, "comment.bundle_id", "latest_comment_event"),
+		)
+	}
+
+	return pipeline
+}
+
+// buildLatestEventLookup builds the lookup of the latest event whose field holds the ID of the bundle. An event is about
+// a bundle, one of its content items or a comment on it, so there is a lookup for each of the fields that can hold the
+// bundle's ID. Each matches on one indexed field, so that it uses the index rather than scanning every event.
+func buildLatestEventLookup(eventsCollection, field, as string) bson.M {
+	return bson.M{"$lookup": bson.M{
+		"from": eventsCollection,
+		"let":  bson.M{"bundle_id": "$id"},
+		"pipeline": []bson.M{
+			{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$" + field, "$$bundle_id"}}}},
+			{"$sort": bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+			{"$limit": 1},
+		},
+		"as": as,
+	}}
+}
+
 // CreateBundle inserts a new bundle
 func (m *Mongo) CreateBundle(ctx context.Context, bundle *models.Bundle) error {
 	now := time.Now()
@@ -96,22 +326,27 @@ func (m *Mongo) UpdateBundle(ctx context.Context, id string, update *models.Bund
 
 	update.ETag = update.GenerateETag(&bytes)
 
-	updateData := bson.M{
-		"$set": bson.M{
-			"bundle_type":     update.BundleType,
-			"created_by":      update.CreatedBy,
-			"created_at":      update.CreatedAt,
-			"last_updated_by": update.LastUpdatedBy,
-			"preview_teams":   update.PreviewTeams,
-			"scheduled_at":    update.ScheduledAt,
-			"state":           update.State,
-			"title":           update.Title,
-			"updated_at":      update.UpdatedAt,
-			"managed_by":      update.ManagedBy,
-			"e_tag":           update.ETag,
-		},
+	set := bson.M{
+		"bundle_type":     update.BundleType,
+		"created_by":      update.CreatedBy,
+		"created_at":      update.CreatedAt,
+		"last_updated_by": update.LastUpdatedBy,
+		"preview_teams":   update.PreviewTeams,
+		"scheduled_at":    update.ScheduledAt,
+		"state":           update.State,
+		"title":           update.Title,
+		"updated_at":      update.UpdatedAt,
+		"managed_by":      update.ManagedBy,
+		"e_tag":           update.ETag,
 	}
 
+	// The last transition is kept unless the update records a new one
+	if update.LastTransition != nil {
+		set["last_transition"] = update.LastTransition
+	}
+
+	updateData := bson.M{"$set": set}
+
 	_, err = m.Connection.Collection(collectionName).UpdateOne(ctx, filter, updateData)
 	if err != nil {
 		return nil, err
@@ -222,3 +457,153 @@ func (m *Mongo) GetBundlesByPreviewTeamID(ctx context.Context, teamID string) ([
 
 	return results, nil
 }
+
+// ListScheduledBundles retrieves the approved scheduled bundles that are waiting to be published, ordered by scheduled_at
+func (m *Mongo) ListScheduledBundles(ctx context.Context, offset, limit int) (bundles []*models.Bundle, totalCount int, err error) {
+	bundles = []*models.Bundle{}
+
+	filter, sort := buildListScheduledBundlesQuery()
+
+	totalCount, err = m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).
+		Find(ctx, filter, &bundles, mongodriver.Sort(sort), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return bundles, totalCount, nil
+}
+
+// buildListScheduledBundlesQuery builds the MongoDB filter and sort for approved scheduled bundles
+func buildListScheduledBundlesQuery() (filter bson.M, sort bson.D) {
+	filter = bson.M{
+		"state":        models.BundleStateApproved,
+		"bundle_type":  models.BundleTypeScheduled,
+		"scheduled_at": bson.M{"$exists": true},
+	}
+	sort = bson.D{{Key: "scheduled_at", Value: 1}, {Key: "id", Value: 1}}
+
+	return filter, sort
+}
+
+// ListBundlesByIDs returns the bundles with the given IDs. Bundles that do not exist are left out.
+func (m *Mongo) ListBundlesByIDs(ctx context.Context, bundleIDs []string) ([]*models.Bundle, error) {
+	bundles := []*models.Bundle{}
+
+	filter := bson.M{"id": bson.M{"$in": bundleIDs}}
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).Find(ctx, filter, &bundles)
+	if err != nil {
+		return nil, err
+	}
+
+	return bundles, nil
+}
+
+// ListBundlesScheduledBetween returns the scheduled bundles, in any state, that are scheduled to publish from from,
+// inclusive, to to, exclusive, ordered by scheduled_at
+func (m *Mongo) ListBundlesScheduledBetween(ctx context.Context, from, to time.Time) ([]*models.Bundle, error) {
+	bundles := []*models.Bundle{}
+
+	filter, sort := buildListBundlesScheduledBetweenQuery(from, to)
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).
+		Find(ctx, filter, &bundles, mongodriver.Sort(sort))
+	if err != nil {
+		return nil, err
+	}
+
+	return bundles, nil
+}
+
+// buildListBundlesScheduledBetweenQuery builds the MongoDB filter and sort for bundles scheduled to publish between two
+// times
+func buildListBundlesScheduledBetweenQuery(from, to time.Time) (filter bson.M, sort bson.D) {
+	filter = bson.M{
+		"bundle_type":  models.BundleTypeScheduled,
+		"scheduled_at": bson.M{"$gte": from, "$lt": to},
+	}
+	sort = bson.D{{Key: "scheduled_at", Value: 1}, {Key: "id", Value: 1}}
+
+	return filter, sort
+}
+
+// ClaimDueScheduledBundle atomically claims the earliest approved scheduled bundle whose scheduled_at has passed and
+// which is not currently claimed by another instance. The claim expires after lockDuration so that a bundle claimed by
+// an instance that dies is picked up again. Returns nil if there is no bundle to claim.
+func (m *Mongo) ClaimDueScheduledBundle(ctx context.Context, now time.Time, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+	filter, update, sort := buildClaimDueScheduledBundleQuery(now, owner, lockDuration)
+
+	var result models.Bundle
+	err := m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).
+		FindOneAndUpdate(ctx, filter, update, &result, mongodriver.Sort(sort), mongodriver.ReturnDocument(options.After))
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// RenewScheduledBundleClaim extends owner's claim on a scheduled bundle so that it expires at expiresAt. It returns false,
+// leaving the bundle unchanged, if owner no longer holds the claim.
+func (m *Mongo) RenewScheduledBundleClaim(ctx context.Context, bundleID, owner string, expiresAt time.Time) (bool, error) {
+	filter, update := buildRenewScheduledBundleClaimQuery(bundleID, owner, expiresAt)
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+func buildRenewScheduledBundleClaimQuery(bundleID, owner string, expiresAt time.Time) (filter, update bson.M) {
+	filter = bson.M{"id": bundleID, "publish_lock.owner": owner}
+	update = bson.M{"$set": bson.M{"publish_lock.expires_at": expiresAt}}
+	return filter, update
+}
+
+// ReleaseScheduledBundleClaim removes owner's claim on a scheduled bundle, if it still holds it
+func (m *Mongo) ReleaseScheduledBundleClaim(ctx context.Context, bundleID, owner string) error {
+	filter, update := buildReleaseScheduledBundleClaimQuery(bundleID, owner)
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).
+		UpdateOne(ctx, filter, update)
+
+	return err
+}
+
+func buildReleaseScheduledBundleClaimQuery(bundleID, owner string) (filter, update bson.M) {
+	filter = bson.M{"id": bundleID, "publish_lock.owner": owner}
+	update = bson.M{"$unset": bson.M{"publish_lock": ""}}
+	return filter, update
+}
+
+// buildClaimDueScheduledBundleQuery builds the MongoDB filter, update and sort used to claim a due scheduled bundle
+func buildClaimDueScheduledBundleQuery(now time.Time, owner string, lockDuration time.Duration) (filter, update bson.M, sort bson.D) {
+	filter = bson.M{
+		"state":        models.BundleStateApproved,
+		"bundle_type":  models.BundleTypeScheduled,
+		"scheduled_at": bson.M{"$lte": now},
+		"$or": bson.A{
+			bson.M{"publish_lock": bson.M{"$exists": false}},
+			bson.M{"publish_lock.expires_at": bson.M{"$lte": now}},
+		},
+	}
+
+	update = bson.M{
+		"$set": bson.M{
+			"publish_lock": bson.M{
+				"owner":      owner,
+				"expires_at": now.Add(lockDuration),
+			},
+		},
+	}
+
+	sort = bson.D{{Key: "scheduled_at", Value: 1}, {Key: "id", Value: 1}}
+
+	return filter, update, sort
+}
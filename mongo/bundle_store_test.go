@@ -6,10 +6,12 @@ import (
 	"time"
 
 	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/config"
 	"github.com/ONSdigital/dis-bundle-api/filters"
 	"github.com/ONSdigital/dis-bundle-api/models"
 	. "github.com/smartystreets/goconvey/convey"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func setupBundleTestData(ctx context.Context, mongo *Mongo) ([]*models.Bundle, error) {
@@ -82,7 +84,7 @@ func TestListBundles_Success(t *testing.T) {
 		So(err, ShouldBeNil)
 
 		Convey("When ListBundles is called with nil filters", func() {
-			bundles, totalCount, err := mongodb.ListBundles(ctx, 0, 10, nil)
+			bundles, totalCount, _, err := mongodb.ListBundles(ctx, 0, 10, nil)
 
 			Convey("Then it should return the correct bundles and total count", func() {
 				So(err, ShouldBeNil)
@@ -98,6 +100,51 @@ func TestListBundles_Success(t *testing.T) {
 			})
 		})
 
+		Convey("When ListBundles is called with the cursor from each page", func() {
+			var ids []string
+			var totalCounts []int
+			var cursor *string
+
+			for range mockBundles {
+				bundles, totalCount, nextCursor, err := mongodb.ListBundles(ctx, 0, 1, &filters.BundleFilters{Cursor: cursor})
+				So(err, ShouldBeNil)
+				So(bundles, ShouldHaveLength, 1)
+
+				ids = append(ids, bundles[0].ID)
+				totalCounts = append(totalCounts, totalCount)
+				cursor = &nextCursor
+			}
+
+			Convey("Then each page should start after the last bundle on the previous page", func() {
+				So(ids, ShouldResemble, []string{"bundle1", "bundle2", "bundle3"})
+			})
+
+			Convey("And the total count of each page should be the number of all the bundles", func() {
+				So(totalCounts, ShouldResemble, []int{3, 3, 3})
+			})
+
+			Convey("And there should be no cursor after the last page", func() {
+				So(*cursor, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When ListBundles is called with a cursor for a different sort", func() {
+			_, _, nextCursor, err := mongodb.ListBundles(ctx, 0, 1, nil)
+			So(err, ShouldBeNil)
+
+			bundleFilters := filters.BundleFilters{
+				Sort:   []filters.SortField{{Field: "title"}},
+				Cursor: &nextCursor,
+			}
+			bundles, totalCount, _, err := mongodb.ListBundles(ctx, 0, 1, &bundleFilters)
+
+			Convey("Then it should return an invalid cursor error", func() {
+				So(err, ShouldEqual, apierrors.ErrInvalidCursor)
+				So(bundles, ShouldBeEmpty)
+				So(totalCount, ShouldEqual, 0)
+			})
+		})
+
 		Convey("When ListBundles is called with valid filters", func() {
 			Convey("Then it should return the matching correct bundles and total count when matching bundles", func() {
 				expectedBundle := mockBundles[0]
@@ -107,7 +154,7 @@ func TestListBundles_Success(t *testing.T) {
 					PublishDate: scheduledAtDate,
 				}
 
-				bundles, totalCount, err := mongodb.ListBundles(ctx, 0, 10, &bundleFilters)
+				bundles, totalCount, _, err := mongodb.ListBundles(ctx, 0, 10, &bundleFilters)
 
 				So(err, ShouldBeNil)
 				So(totalCount, ShouldEqual, 1)
@@ -124,7 +171,7 @@ func TestListBundles_Success(t *testing.T) {
 					PublishDate: &scheduledAtDate,
 				}
 
-				bundles, totalCount, err := mongodb.ListBundles(ctx, 0, 10, &bundleFilters)
+				bundles, totalCount, _, err := mongodb.ListBundles(ctx, 0, 10, &bundleFilters)
 
 				So(err, ShouldBeNil)
 				So(totalCount, ShouldEqual, 0)
@@ -146,7 +193,7 @@ func TestListBundles_Failure(t *testing.T) {
 
 		Convey("When ListBundles is called and the connection fails", func() {
 			mongodb.Connection.Close(ctx)
-			bundles, totalCount, err := mongodb.ListBundles(ctx, 0, 10, nil)
+			bundles, totalCount, _, err := mongodb.ListBundles(ctx, 0, 10, nil)
 
 			Convey("Then it should return an error and no bundles", func() {
 				So(err, ShouldNotBeNil)
@@ -165,7 +212,7 @@ func TestBuildListBundlesQuery(t *testing.T) {
 
 		Convey("Then it should return an empty filter and sort by updated_at descending", func() {
 			expectedFilter := bson.M{}
-			expectedSort := bson.M{"updated_at": -1}
+			expectedSort := bson.D{{Key: "updated_at", Value: -1}, {Key: "id", Value: 1}}
 
 			So(filter, ShouldResemble, expectedFilter)
 			So(sort, ShouldResemble, expectedSort)
@@ -180,7 +227,7 @@ func TestBuildListBundlesQuery(t *testing.T) {
 			filter, sort := buildListBundlesQuery(&bundleFilters)
 
 			expectedFilter := bson.M{}
-			expectedSort := bson.M{"updated_at": -1}
+			expectedSort := bson.D{{Key: "updated_at", Value: -1}, {Key: "id", Value: 1}}
 
 			So(filter, ShouldResemble, expectedFilter)
 			So(sort, ShouldResemble, expectedSort)
@@ -202,11 +249,82 @@ func TestBuildListBundlesQuery(t *testing.T) {
 			expectedFilter := bson.M{
 				"scheduled_at": scheduledAtFilter,
 			}
-			expectedSort := bson.M{"updated_at": -1}
+			expectedSort := bson.D{{Key: "updated_at", Value: -1}, {Key: "id", Value: 1}}
+
+			So(filter, ShouldResemble, expectedFilter)
+			So(sort, ShouldResemble, expectedSort)
+		})
+
+		Convey("Then it should return a filter on each supplied field", func() {
+			after := time.Date(2025, 01, 01, 0, 0, 0, 0, time.UTC)
+			before := time.Date(2025, 02, 01, 0, 0, 0, 0, time.UTC)
+			bundleType := models.BundleTypeScheduled
+			managedBy := models.ManagedByWagtail
+			createdBy := "publisher@ons.gov.uk"
+			previewTeam := "team1"
+			title := "CPI"
+
+			bundleFilters := filters.BundleFilters{
+				States:      []models.BundleState{models.BundleStateDraft, models.BundleStateInReview},
+				BundleType:  &bundleType,
+				ManagedBy:   &managedBy,
+				CreatedBy:   &createdBy,
+				PreviewTeam: &previewTeam,
+				Title:       &title,
+				CreatedAt:   &filters.TimeRange{After: &after},
+				UpdatedAt:   &filters.TimeRange{Before: &before},
+				ScheduledAt: &filters.TimeRange{After: &after, Before: &before},
+			}
+
+			filter, sort := buildListBundlesQuery(&bundleFilters)
+
+			expectedFilter := bson.M{
+				"state":            bson.M{"$in": []models.BundleState{models.BundleStateDraft, models.BundleStateInReview}},
+				"bundle_type":      models.BundleTypeScheduled,
+				"managed_by":       models.ManagedByWagtail,
+				"created_by.email": "publisher@ons.gov.uk",
+				"preview_teams.id": "team1",
+				"title":            primitive.Regex{Pattern: "CPI", Options: "i"},
+				"created_at":       bson.M{"$gte": after},
+				"updated_at":       bson.M{"$lte": before},
+				"scheduled_at":     bson.M{"$gte": after, "$lte": before},
+			}
+			expectedSort := bson.D{{Key: "updated_at", Value: -1}, {Key: "id", Value: 1}}
 
 			So(filter, ShouldResemble, expectedFilter)
 			So(sort, ShouldResemble, expectedSort)
 		})
+
+		Convey("Then it should sort by the supplied sort fields and then by ID", func() {
+			bundleFilters := filters.BundleFilters{
+				Sort: []filters.SortField{{Field: "scheduled_at", Descending: true}, {Field: "title"}},
+			}
+
+			_, sort := buildListBundlesQuery(&bundleFilters)
+
+			So(sort, ShouldResemble, bson.D{{Key: "scheduled_at", Value: -1}, {Key: "title", Value: 1}, {Key: "id", Value: 1}})
+		})
+
+		Convey("Then it should combine the publishDate and scheduled_at range filters if both are supplied", func() {
+			publishDate := time.Date(2025, 01, 15, 9, 30, 0, 0, time.UTC)
+			after := time.Date(2025, 01, 01, 0, 0, 0, 0, time.UTC)
+
+			bundleFilters := filters.BundleFilters{
+				PublishDate: &publishDate,
+				ScheduledAt: &filters.TimeRange{After: &after},
+			}
+
+			filter, _ := buildListBundlesQuery(&bundleFilters)
+
+			expectedFilter := bson.M{
+				"$and": []bson.M{
+					{"scheduled_at": buildDateTimeFilter(publishDate)},
+					{"scheduled_at": bson.M{"$gte": after}},
+				},
+			}
+
+			So(filter, ShouldResemble, expectedFilter)
+		})
 	})
 }
 
@@ -278,6 +396,189 @@ func TestBuildGetBundleQuery(t *testing.T) {
 	})
 }
 
+func TestGetBundleView_Success(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		_, err = setupBundleTestData(ctx, mongodb)
+		So(err, ShouldBeNil)
+
+		for _, id := range []string{"content1", "content2"} {
+			So(mongodb.CreateContentItem(ctx, &models.ContentItem{ID: id, BundleID: "bundle1", ContentType: models.ContentTypeDataset}), ShouldBeNil)
+		}
+		So(mongodb.CreateEvent(ctx, &models.Event{Action: models.ActionCreate, Resource: "/bundles/bundle1", Bundle: &models.Bundle{ID: "bundle1"}}), ShouldBeNil)
+		So(mongodb.CreateEvent(ctx, &models.Event{Action: models.ActionCreate, Resource: "content2", ContentItem: &models.ContentItem{ID: "content2", BundleID: "bundle1"}}), ShouldBeNil)
+
+		Convey("When GetBundleView is called with fields and embeds", func() {
+			storedBundle, err := mongodb.UpdateBundleETag(ctx, "bundle1", "publisher@ons.gov.uk")
+			So(err, ShouldBeNil)
+
+			view := &filters.BundleView{Fields: []string{"title"}, Embed: []string{filters.EmbedContents, filters.EmbedEvents}}
+			bundle, err := mongodb.GetBundleView(ctx, "bundle1", view)
+
+			Convey("Then it returns only those fields and the stored ETag, with the content items and latest event embedded", func() {
+				So(err, ShouldBeNil)
+				So(bundle.ID, ShouldEqual, "bundle1")
+				So(bundle.Title, ShouldEqual, "Scheduled Bundle 1")
+				So(bundle.State, ShouldBeEmpty)
+				So(bundle.ETag, ShouldEqual, storedBundle.ETag)
+				So(bundle.Embedded, ShouldNotBeNil)
+				So(*bundle.Embedded.Contents, ShouldHaveLength, 2)
+				So((*bundle.Embedded.Contents)[0].ID, ShouldEqual, "content2")
+				So(bundle.Embedded.LatestEvent.Resource, ShouldEqual, "content2")
+			})
+		})
+
+		Convey("When GetBundleView is called for a bundle with nothing to embed", func() {
+			view := &filters.BundleView{Embed: []string{filters.EmbedContents, filters.EmbedEvents}}
+			bundle, err := mongodb.GetBundleView(ctx, "bundle2", view)
+
+			Convey("Then it returns the whole bundle with no content items or latest event", func() {
+				So(err, ShouldBeNil)
+				So(bundle.State, ShouldEqual, models.BundleStateDraft)
+				So(*bundle.Embedded.Contents, ShouldBeEmpty)
+				So(bundle.Embedded.LatestEvent, ShouldBeNil)
+			})
+		})
+
+		Convey("When ListBundles is called with fields and a cursor", func() {
+			view := &filters.BundleView{Fields: []string{"state"}}
+			bundles, _, nextCursor, err := mongodb.ListBundles(ctx, 0, 1, &filters.BundleFilters{View: view})
+			So(err, ShouldBeNil)
+			So(bundles, ShouldHaveLength, 1)
+			So(bundles[0].Title, ShouldBeEmpty)
+			So(bundles[0].State, ShouldNotBeEmpty)
+
+			nextBundles, _, _, err := mongodb.ListBundles(ctx, 0, 1, &filters.BundleFilters{View: view, Cursor: &nextCursor})
+
+			Convey("Then the cursor still marks where the next page starts", func() {
+				So(err, ShouldBeNil)
+				So(nextBundles, ShouldHaveLength, 1)
+				So(nextBundles[0].ID, ShouldNotEqual, bundles[0].ID)
+			})
+		})
+	})
+}
+
+func TestGetBundleView_Failure(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		_, err = setupBundleTestData(ctx, mongodb)
+		So(err, ShouldBeNil)
+
+		Convey("When GetBundleView is called with a non-existent bundle ID", func() {
+			_, err := mongodb.GetBundleView(ctx, "non-existent-id", &filters.BundleView{Fields: []string{"title"}})
+
+			Convey("Then it should return a bundle not found error", func() {
+				So(err, ShouldEqual, apierrors.ErrBundleNotFound)
+			})
+		})
+	})
+}
+
+func TestBuildBundleProjection(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildBundleProjection", t, func() {
+		sort := bson.D{{Key: "updated_at", Value: -1}, {Key: "id", Value: 1}}
+
+		Convey("Then it projects the fields in the view, the ID, the ETag and the sort keys", func() {
+			projection := buildBundleProjection(&filters.BundleView{Fields: []string{"title", "state"}}, sort)
+			So(projection, ShouldResemble, bson.M{"_id": 0, "id": 1, "e_tag": 1, "title": 1, "state": 1, "updated_at": 1})
+		})
+
+		Convey("Then it returns nil for whole bundles if there is no view or it has no fields", func() {
+			So(buildBundleProjection(nil, sort), ShouldBeNil)
+			So(buildBundleProjection(&filters.BundleView{Embed: []string{filters.EmbedContents}}, sort), ShouldBeNil)
+		})
+
+		Convey("Then it returns nil for whole bundles if the view embeds transitions", func() {
+			view := &filters.BundleView{Fields: []string{"title"}, Embed: []string{filters.EmbedTransitions}}
+			So(buildBundleProjection(view, sort), ShouldBeNil)
+		})
+	})
+}
+
+func TestBuildEmbedBundleResourcesPipeline(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildEmbedBundleResourcesPipeline", t, func() {
+		bundleIDs := []string{"bundle1", "bundle2"}
+
+		Convey("Then it matches the bundles and looks up the content items and latest event of each", func() {
+			pipeline := buildEmbedBundleResourcesPipeline(bundleIDs, true, true, "bundle_contents", "bundle_events")
+			So(pipeline, ShouldHaveLength, 6)
+			So(pipeline[0], ShouldResemble, bson.M{"$match": bson.M{"id": bson.M{"$in": bundleIDs}}})
+
+			contentsLookup := pipeline[2]["$lookup"].(bson.M)
+			So(contentsLookup["from"], ShouldEqual, "bundle_contents")
+			So(contentsLookup["as"], ShouldEqual, "contents")
+
+			for i, as := range []string{"latest_bundle_event", "latest_content_item_event", "latest_comment_event"} {
+				eventsLookup := pipeline[3+i]["$lookup"].(bson.M)
+				So(eventsLookup["from"], ShouldEqual, "bundle_events")
+				So(eventsLookup["as"], ShouldEqual, as)
+				So(eventsLookup["pipeline"].([]bson.M)[2], ShouldResemble, bson.M{"$limit": 1})
+			}
+		})
+
+		Convey("Then each events lookup matches on one indexed field", func() {
+			pipeline := buildEmbedBundleResourcesPipeline(bundleIDs, false, true, "bundle_contents", "bundle_events")
+
+			indexedFields := map[string]bool{}
+			for _, idx := range indexes {
+				if idx.collection == config.BundleEventsCollection {
+					indexedFields[idx.keys[0].Key] = true
+				}
+			}
+
+			for _, field := range []string{"bundle.id", "content_item.bundle_id", "comment.bundle_id"} {
+				So(indexedFields, ShouldContainKey, field)
+			}
+			for _, stage := range pipeline[2:] {
+				match := stage["$lookup"].(bson.M)["pipeline"].([]bson.M)[0]
+				eq := match["$match"].(bson.M)["$expr"].(bson.M)["$eq"].(bson.A)
+				So(indexedFields, ShouldContainKey, eq[0].(string)[1:])
+			}
+		})
+
+		Convey("Then it only looks up the resources asked for", func() {
+			pipeline := buildEmbedBundleResourcesPipeline(bundleIDs, false, true, "bundle_contents", "bundle_events")
+			So(pipeline, ShouldHaveLength, 5)
+			So(pipeline[2]["$lookup"].(bson.M)["as"], ShouldEqual, "latest_bundle_event")
+		})
+	})
+}
+
+func TestBundleEmbedsLatestEvent(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the latest events of each kind about a bundle", t, func() {
+		earlier := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		later := earlier.Add(time.Hour)
+
+		embeds := &bundleEmbeds{
+			LatestBundleEvent:  []*models.Event{{Resource: "bundle1", CreatedAt: &earlier}},
+			LatestCommentEvent: []*models.Event{{Resource: "comment1", CreatedAt: &later}},
+		}
+
+		Convey("Then latestEvent returns the latest of them", func() {
+			So(embeds.latestEvent().Resource, ShouldEqual, "comment1")
+		})
+
+		Convey("Then latestEvent returns nil if there are none", func() {
+			So((&bundleEmbeds{}).latestEvent(), ShouldBeNil)
+		})
+	})
+}
+
 func TestCreateBundle_Success(t *testing.T) {
 	ctx := context.Background()
 
@@ -688,3 +989,216 @@ func TestGetBundlesByPreviewTeamID_Failure(t *testing.T) {
 		})
 	})
 }
+
+func setupScheduledBundleTestData(ctx context.Context, mongo *Mongo, now time.Time) error {
+	if err := mongo.Connection.DropDatabase(ctx); err != nil {
+		return err
+	}
+
+	oneHourAgo := now.Add(-time.Hour)
+	twoHoursAgo := now.Add(-2 * time.Hour)
+	oneHourFromNow := now.Add(time.Hour)
+	bundles := []*models.Bundle{
+		{ID: "due-later", BundleType: models.BundleTypeScheduled, ScheduledAt: &oneHourAgo, State: models.BundleStateApproved, Title: "Due later"},
+		{ID: "due-first", BundleType: models.BundleTypeScheduled, ScheduledAt: &twoHoursAgo, State: models.BundleStateApproved, Title: "Due first"},
+		{ID: "not-due", BundleType: models.BundleTypeScheduled, ScheduledAt: &oneHourFromNow, State: models.BundleStateApproved, Title: "Not due"},
+		{ID: "not-approved", BundleType: models.BundleTypeScheduled, ScheduledAt: &twoHoursAgo, State: models.BundleStateInReview, Title: "Not approved"},
+		{ID: "manual", BundleType: models.BundleTypeManual, ScheduledAt: &twoHoursAgo, State: models.BundleStateApproved, Title: "Manual"},
+	}
+
+	for _, b := range bundles {
+		if err := mongo.CreateBundle(ctx, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestListScheduledBundles(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		err = setupScheduledBundleTestData(ctx, mongodb, time.Now())
+		So(err, ShouldBeNil)
+
+		Convey("When ListScheduledBundles is called", func() {
+			bundles, totalCount, err := mongodb.ListScheduledBundles(ctx, 0, 10)
+
+			Convey("Then only approved scheduled bundles are returned, earliest first", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 3)
+				So(bundles, ShouldHaveLength, 3)
+				So(bundles[0].ID, ShouldEqual, "due-first")
+				So(bundles[1].ID, ShouldEqual, "due-later")
+				So(bundles[2].ID, ShouldEqual, "not-due")
+			})
+		})
+	})
+}
+
+func TestClaimDueScheduledBundle(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		now := time.Now()
+		err = setupScheduledBundleTestData(ctx, mongodb, now)
+		So(err, ShouldBeNil)
+
+		Convey("When ClaimDueScheduledBundle is called repeatedly", func() {
+			first, err1 := mongodb.ClaimDueScheduledBundle(ctx, now, "owner-1", time.Minute)
+			second, err2 := mongodb.ClaimDueScheduledBundle(ctx, now, "owner-2", time.Minute)
+			third, err3 := mongodb.ClaimDueScheduledBundle(ctx, now, "owner-1", time.Minute)
+
+			Convey("Then each due bundle is claimed once, earliest first, and then there is nothing left to claim", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(err3, ShouldBeNil)
+				So(first.ID, ShouldEqual, "due-first")
+				So(second.ID, ShouldEqual, "due-later")
+				So(third, ShouldBeNil)
+			})
+
+			Convey("And a claim can be taken over once it has expired", func() {
+				later := now.Add(2 * time.Minute)
+				reclaimed, err := mongodb.ClaimDueScheduledBundle(ctx, later, "owner-2", time.Minute)
+				So(err, ShouldBeNil)
+				So(reclaimed.ID, ShouldEqual, "due-first")
+			})
+
+			Convey("And a claim that is renewed before it expires cannot be taken over", func() {
+				held, err := mongodb.RenewScheduledBundleClaim(ctx, "due-first", "owner-1", now.Add(3*time.Minute))
+				So(err, ShouldBeNil)
+				So(held, ShouldBeTrue)
+
+				later := now.Add(2 * time.Minute)
+				reclaimed, err := mongodb.ClaimDueScheduledBundle(ctx, later, "owner-2", time.Minute)
+				So(err, ShouldBeNil)
+				So(reclaimed, ShouldBeNil)
+			})
+
+			Convey("And a claim that has been taken over cannot be renewed or released by its previous owner", func() {
+				later := now.Add(2 * time.Minute)
+				reclaimed, err := mongodb.ClaimDueScheduledBundle(ctx, later, "owner-2", time.Minute)
+				So(err, ShouldBeNil)
+				So(reclaimed.ID, ShouldEqual, "due-first")
+
+				held, err := mongodb.RenewScheduledBundleClaim(ctx, "due-first", "owner-1", later.Add(time.Minute))
+				So(err, ShouldBeNil)
+				So(held, ShouldBeFalse)
+
+				So(mongodb.ReleaseScheduledBundleClaim(ctx, "due-first", "owner-1"), ShouldBeNil)
+				other, err := mongodb.ClaimDueScheduledBundle(ctx, later, "owner-1", time.Minute)
+				So(err, ShouldBeNil)
+				So(other, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestBuildRenewScheduledBundleClaimQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildRenewScheduledBundleClaimQuery", t, func() {
+		expiresAt := time.Date(2025, 01, 01, 9, 35, 0, 0, time.UTC)
+		filter, update := buildRenewScheduledBundleClaimQuery("bundle-1", "owner-1", expiresAt)
+
+		Convey("Then it should only match the bundle while the owner holds its claim", func() {
+			So(filter, ShouldResemble, bson.M{"id": "bundle-1", "publish_lock.owner": "owner-1"})
+		})
+
+		Convey("And it should move the expiry of the claim", func() {
+			So(update, ShouldResemble, bson.M{"$set": bson.M{"publish_lock.expires_at": expiresAt}})
+		})
+	})
+}
+
+func TestBuildReleaseScheduledBundleClaimQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildReleaseScheduledBundleClaimQuery", t, func() {
+		filter, update := buildReleaseScheduledBundleClaimQuery("bundle-1", "owner-1")
+
+		Convey("Then it should remove the claim only if the owner still holds it", func() {
+			So(filter, ShouldResemble, bson.M{"id": "bundle-1", "publish_lock.owner": "owner-1"})
+			So(update, ShouldResemble, bson.M{"$unset": bson.M{"publish_lock": ""}})
+		})
+	})
+}
+
+func TestBuildListScheduledBundlesQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildListScheduledBundlesQuery", t, func() {
+		filter, sort := buildListScheduledBundlesQuery()
+
+		Convey("Then it should filter on approved scheduled bundles and sort by scheduled_at ascending", func() {
+			So(filter, ShouldResemble, bson.M{
+				"state":        models.BundleStateApproved,
+				"bundle_type":  models.BundleTypeScheduled,
+				"scheduled_at": bson.M{"$exists": true},
+			})
+			So(sort, ShouldResemble, bson.D{{Key: "scheduled_at", Value: 1}, {Key: "id", Value: 1}})
+		})
+	})
+}
+
+func TestBuildListBundlesScheduledBetweenQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildListBundlesScheduledBetweenQuery", t, func() {
+		from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 0, 7)
+		filter, sort := buildListBundlesScheduledBetweenQuery(from, to)
+
+		Convey("Then it should filter on scheduled bundles in the range and sort by scheduled_at ascending", func() {
+			So(filter, ShouldResemble, bson.M{
+				"bundle_type":  models.BundleTypeScheduled,
+				"scheduled_at": bson.M{"$gte": from, "$lt": to},
+			})
+			So(sort, ShouldResemble, bson.D{{Key: "scheduled_at", Value: 1}, {Key: "id", Value: 1}})
+		})
+	})
+}
+
+func TestBuildClaimDueScheduledBundleQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildClaimDueScheduledBundleQuery", t, func() {
+		now := time.Date(2025, 01, 01, 9, 30, 0, 0, time.UTC)
+		filter, update, sort := buildClaimDueScheduledBundleQuery(now, "owner-1", 5*time.Minute)
+
+		Convey("Then it should filter on due, unclaimed or expired, approved scheduled bundles", func() {
+			So(filter, ShouldResemble, bson.M{
+				"state":        models.BundleStateApproved,
+				"bundle_type":  models.BundleTypeScheduled,
+				"scheduled_at": bson.M{"$lte": now},
+				"$or": bson.A{
+					bson.M{"publish_lock": bson.M{"$exists": false}},
+					bson.M{"publish_lock.expires_at": bson.M{"$lte": now}},
+				},
+			})
+		})
+
+		Convey("And it should set a publish lock for the owner that expires after the lock duration", func() {
+			So(update, ShouldResemble, bson.M{
+				"$set": bson.M{
+					"publish_lock": bson.M{
+						"owner":      "owner-1",
+						"expires_at": now.Add(5 * time.Minute),
+					},
+				},
+			})
+		})
+
+		Convey("And it should claim the earliest scheduled bundle first", func() {
+			So(sort, ShouldResemble, bson.D{{Key: "scheduled_at", Value: 1}, {Key: "id", Value: 1}})
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CreateComment inserts a new comment
+func (m *Mongo) CreateComment(ctx context.Context, comment *models.Comment) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.CommentsCollection)).
+		InsertOne(ctx, comment)
+
+	return err
+}
+
+// GetComment retrieves a comment on a bundle or any of its content items
+func (m *Mongo) GetComment(ctx context.Context, bundleID, commentID string) (*models.Comment, error) {
+	var comment models.Comment
+
+	err := m.Connection.Collection(m.ActualCollectionName(config.CommentsCollection)).
+		FindOne(ctx, bson.M{"id": commentID, "bundle_id": bundleID}, &comment)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, apierrors.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// ListComments retrieves the comments on a bundle, or on one of its content items if contentItemID is set, oldest first
+func (m *Mongo) ListComments(ctx context.Context, bundleID, contentItemID string, offset, limit int) (comments []*models.Comment, totalCount int, err error) {
+	comments = []*models.Comment{}
+
+	filter, sort := buildListCommentsQuery(bundleID, contentItemID)
+
+	totalCount, err = m.Connection.Collection(m.ActualCollectionName(config.CommentsCollection)).
+		Find(ctx, filter, &comments, mongodriver.Sort(sort), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, totalCount, nil
+}
+
+func buildListCommentsQuery(bundleID, contentItemID string) (filter, sort bson.M) {
+	filter = bson.M{"bundle_id": bundleID}
+
+	if contentItemID != "" {
+		filter["content_item_id"] = contentItemID
+	} else {
+		filter["content_item_id"] = bson.M{"$exists": false}
+	}
+
+	sort = bson.M{"created_at": 1}
+	return filter, sort
+}
+
+// UpdateCommentResolution marks a comment as resolved by resolvedBy, or as unresolved if resolvedBy is nil
+func (m *Mongo) UpdateCommentResolution(ctx context.Context, commentID string, resolvedBy *models.User, resolvedAt *time.Time) error {
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.CommentsCollection)).
+		UpdateOne(ctx, bson.M{"id": commentID}, buildUpdateCommentResolutionQuery(resolvedBy, resolvedAt))
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return apierrors.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+func buildUpdateCommentResolutionQuery(resolvedBy *models.User, resolvedAt *time.Time) bson.M {
+	if resolvedBy == nil {
+		return bson.M{
+			"$set":   bson.M{"resolved": false},
+			"$unset": bson.M{"resolved_by": "", "resolved_at": ""},
+		}
+	}
+
+	return bson.M{
+		"$set": bson.M{
+			"resolved":    true,
+			"resolved_by": resolvedBy,
+			"resolved_at": resolvedAt,
+		},
+	}
+}
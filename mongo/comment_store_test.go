@@ -0,0 +1,147 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func newTestComment(id, bundleID, contentItemID string, createdAt time.Time) *models.Comment {
+	return &models.Comment{
+		ID:            id,
+		BundleID:      bundleID,
+		ContentItemID: contentItemID,
+		Body:          "comment " + id,
+		Author:        &models.User{Email: "reviewer@ons.gov.uk"},
+		CreatedAt:     &createdAt,
+	}
+}
+
+func setupTestDataForComments(ctx context.Context, mongo *Mongo, comments ...*models.Comment) error {
+	if err := mongo.Connection.DropDatabase(ctx); err != nil {
+		return err
+	}
+
+	for _, comment := range comments {
+		if _, err := mongo.Connection.Collection(mongo.ActualCollectionName(config.CommentsCollection)).InsertOne(ctx, comment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestComments(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly and there are comments on a bundle and its content item", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		err = setupTestDataForComments(ctx, mongodb,
+			newTestComment("comment2", "bundle1", "", now),
+			newTestComment("comment1", "bundle1", "", now.Add(-time.Hour)),
+			newTestComment("comment3", "bundle1", "content-item-1", now),
+			newTestComment("comment4", "bundle2", "", now),
+		)
+		So(err, ShouldBeNil)
+
+		Convey("When ListComments is called for the bundle", func() {
+			comments, totalCount, err := mongodb.ListComments(ctx, "bundle1", "", 0, 10)
+
+			Convey("Then only the comments on the bundle itself are returned, oldest first", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 2)
+				So(comments, ShouldHaveLength, 2)
+				So(comments[0].ID, ShouldEqual, "comment1")
+				So(comments[1].ID, ShouldEqual, "comment2")
+			})
+		})
+
+		Convey("When ListComments is called for the content item", func() {
+			comments, totalCount, err := mongodb.ListComments(ctx, "bundle1", "content-item-1", 0, 10)
+
+			Convey("Then only the comments on the content item are returned", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 1)
+				So(comments[0].ID, ShouldEqual, "comment3")
+			})
+		})
+
+		Convey("When GetComment is called for a comment on a different bundle", func() {
+			comment, err := mongodb.GetComment(ctx, "bundle1", "comment4")
+
+			Convey("Then ErrCommentNotFound is returned", func() {
+				So(comment, ShouldBeNil)
+				So(err, ShouldEqual, apierrors.ErrCommentNotFound)
+			})
+		})
+
+		Convey("When a comment is resolved and then unresolved", func() {
+			So(mongodb.UpdateCommentResolution(ctx, "comment1", &models.User{Email: "publisher@ons.gov.uk"}, &now), ShouldBeNil)
+
+			resolved, err := mongodb.GetComment(ctx, "bundle1", "comment1")
+			So(err, ShouldBeNil)
+
+			So(mongodb.UpdateCommentResolution(ctx, "comment1", nil, nil), ShouldBeNil)
+
+			unresolved, err := mongodb.GetComment(ctx, "bundle1", "comment1")
+			So(err, ShouldBeNil)
+
+			Convey("Then who resolved it is recorded and then cleared", func() {
+				So(resolved.Resolved, ShouldBeTrue)
+				So(resolved.ResolvedBy.Email, ShouldEqual, "publisher@ons.gov.uk")
+				So(resolved.ResolvedAt.Equal(now), ShouldBeTrue)
+
+				So(unresolved.Resolved, ShouldBeFalse)
+				So(unresolved.ResolvedBy, ShouldBeNil)
+				So(unresolved.ResolvedAt, ShouldBeNil)
+			})
+		})
+
+		Convey("When UpdateCommentResolution is called for a comment that does not exist", func() {
+			err := mongodb.UpdateCommentResolution(ctx, "missing", nil, nil)
+
+			Convey("Then ErrCommentNotFound is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrCommentNotFound)
+			})
+		})
+	})
+}
+
+func TestBuildCommentQueries(t *testing.T) {
+	Convey("When buildListCommentsQuery is called for a bundle", t, func() {
+		filter, sort := buildListCommentsQuery("bundle1", "")
+
+		Convey("Then it excludes comments on the bundle's content items and sorts oldest first", func() {
+			So(filter, ShouldResemble, bson.M{"bundle_id": "bundle1", "content_item_id": bson.M{"$exists": false}})
+			So(sort, ShouldResemble, bson.M{"created_at": 1})
+		})
+	})
+
+	Convey("When buildListCommentsQuery is called for a content item", t, func() {
+		filter, _ := buildListCommentsQuery("bundle1", "content-item-1")
+
+		Convey("Then it filters by the content item", func() {
+			So(filter, ShouldResemble, bson.M{"bundle_id": "bundle1", "content_item_id": "content-item-1"})
+		})
+	})
+
+	Convey("When buildUpdateCommentResolutionQuery is called without a user", t, func() {
+		update := buildUpdateCommentResolutionQuery(nil, nil)
+
+		Convey("Then it clears the resolution", func() {
+			So(update, ShouldResemble, bson.M{
+				"$set":   bson.M{"resolved": false},
+				"$unset": bson.M{"resolved_by": "", "resolved_at": ""},
+			})
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package mongo
+
+import (
+	"context"
+	"encoding/base64"
+	"slices"
+	"strings"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// pageCursor is the decoded form of a cursor. It holds the sort the page was in, with - before the keys sorted in
+// descending order, and the values of those keys in the last document on the page.
+type pageCursor struct {
+	Sort   []string        `bson:"s"`
+	Values []bson.RawValue `bson:"v"`
+}
+
+// findPage finds a page of the documents in a collection that match the filter, in the sort order. If a cursor is given
+// the page starts after the document it marks and the offset is ignored, as the API does not accept both. The total
+// count is always the number of documents that match the filter, whether or not a cursor is given. The cursor for the
+// next page is returned if there are more matching documents after this page. Any other find options, such as a
+// projection, are passed on to the find and must keep the sort keys.
+func findPage[T any](ctx context.Context, collection *mongodriver.Collection, filter bson.M, sort bson.D, offset, limit int, cursor *string, opts ...mongodriver.FindOption) (results []*T, totalCount int, nextCursor string, err error) {
+	pageFilter := filter
+	if cursor != nil {
+		decoded, err := decodeCursor(*cursor, sort)
+		if err != nil {
+			return nil, 0, "", err
+		}
+
+		pageFilter = bson.M{"$and": []bson.M{filter, buildCursorFilter(sort, decoded.Values)}}
+		offset = 0
+	}
+
+	var documents []bson.Raw
+	opts = append([]mongodriver.FindOption{mongodriver.Sort(sort), mongodriver.Offset(offset), mongodriver.Limit(limit)}, opts...)
+	pageCount, err := collection.Find(ctx, pageFilter, &documents, opts...)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	results = make([]*T, 0, len(documents))
+	for _, document := range documents {
+		result := new(T)
+		if err := bson.Unmarshal(document, result); err != nil {
+			return nil, 0, "", err
+		}
+		results = append(results, result)
+	}
+
+	// the count of the find is of the documents from the start of the page onwards when a cursor is given
+	if len(documents) > 0 && offset+len(documents) < pageCount {
+		nextCursor, err = encodeCursor(sort, documents[len(documents)-1])
+		if err != nil {
+			return nil, 0, "", err
+		}
+	}
+
+	totalCount = pageCount
+	if cursor != nil {
+		totalCount, err = collection.Count(ctx, filter)
+		if err != nil {
+			return nil, 0, "", err
+		}
+	}
+
+	return results, totalCount, nextCursor, nil
+}
+
+// encodeCursor encodes a cursor marking the document as the last on a page in the sort order
+func encodeCursor(sort bson.D, document bson.Raw) (string, error) {
+	decoded := pageCursor{
+		Sort:   cursorSortKeys(sort),
+		Values: make([]bson.RawValue, len(sort)),
+	}
+
+	for i, elem := range sort {
+		value, err := document.LookupErr(strings.Split(elem.Key, ".")...)
+		if err != nil {
+			// a document without the key sorts as if it were null
+			value = bson.RawValue{Type: bson.TypeNull}
+		}
+		decoded.Values[i] = value
+	}
+
+	encoded, err := bson.Marshal(decoded)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// decodeCursor decodes a cursor, returning apierrors.ErrInvalidCursor if it cannot be decoded or was not made for the
+// sort order
+func decodeCursor(cursor string, sort bson.D) (*pageCursor, error) {
+	encoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, apierrors.ErrInvalidCursor
+	}
+
+	var decoded pageCursor
+	if err := bson.Unmarshal(encoded, &decoded); err != nil {
+		return nil, apierrors.ErrInvalidCursor
+	}
+
+	if !slices.Equal(decoded.Sort, cursorSortKeys(sort)) || len(decoded.Values) != len(sort) {
+		return nil, apierrors.ErrInvalidCursor
+	}
+
+	return &decoded, nil
+}
+
+func cursorSortKeys(sort bson.D) []string {
+	keys := make([]string, len(sort))
+	for i, elem := range sort {
+		keys[i] = elem.Key
+		if elem.Value == -1 {
+			keys[i] = "-" + elem.Key
+		}
+	}
+	return keys
+}
+
+// buildCursorFilter builds a bson filter for the documents that come after the values in the sort order. A document
+// comes after if it has the same values for the first keys and then a value that comes after for the next key. Null
+// and missing values sort before all others.
+func buildCursorFilter(sort bson.D, values []bson.RawValue) bson.M {
+	conditions := make([]bson.M, 0, len(sort))
+
+	for i, elem := range sort {
+		after := buildAfterValueFilter(elem.Key, elem.Value == -1, values[i])
+		if after == nil {
+			continue
+		}
+
+		condition := bson.M{}
+		for j := 0; j < i; j++ {
+			condition[sort[j].Key] = values[j]
+		}
+		for key, value := range after {
+			condition[key] = value
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	if len(conditions) == 0 {
+		// nothing can come after the last document
+		return bson.M{"_id": bson.M{"$exists": false}}
+	}
+
+	return bson.M{"$or": conditions}
+}
+
+// buildAfterValueFilter builds a bson filter for the values of a key that come after the value in the sort order, or
+// nil if none can
+func buildAfterValueFilter(key string, descending bool, value bson.RawValue) bson.M {
+	isNull := value.Type == bson.TypeNull || value.Type == bson.TypeUndefined
+
+	switch {
+	case isNull && descending:
+		return nil
+	case isNull:
+		return bson.M{key: bson.M{"$ne": nil}}
+	case descending:
+		return bson.M{"$or": []bson.M{{key: bson.M{"$lt": value}}, {key: nil}}}
+	default:
+		return bson.M{key: bson.M{"$gt": value}}
+	}
+}
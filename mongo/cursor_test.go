@@ -0,0 +1,118 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestEncodeDecodeCursor(t *testing.T) {
+	t.Parallel()
+
+	sort := bson.D{{Key: "metadata.title", Value: -1}, {Key: "id", Value: 1}}
+
+	Convey("Given a cursor encoded from a document", t, func() {
+		document, err := bson.Marshal(bson.M{"id": "content1", "metadata": bson.M{"title": "Title 1"}})
+		So(err, ShouldBeNil)
+
+		cursor, err := encodeCursor(sort, document)
+		So(err, ShouldBeNil)
+
+		Convey("When it is decoded for the same sort", func() {
+			decoded, err := decodeCursor(cursor, sort)
+
+			Convey("Then it should hold the values of the sort keys in the document", func() {
+				So(err, ShouldBeNil)
+				So(decoded.Sort, ShouldResemble, []string{"-metadata.title", "id"})
+				So(decoded.Values, ShouldHaveLength, 2)
+				So(decoded.Values[0].StringValue(), ShouldEqual, "Title 1")
+				So(decoded.Values[1].StringValue(), ShouldEqual, "content1")
+			})
+		})
+
+		Convey("When it is decoded for a different sort", func() {
+			decoded, err := decodeCursor(cursor, bson.D{{Key: "metadata.title", Value: 1}, {Key: "id", Value: 1}})
+
+			Convey("Then it should return an invalid cursor error", func() {
+				So(err, ShouldEqual, apierrors.ErrInvalidCursor)
+				So(decoded, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a document without one of the sort keys", t, func() {
+		document, err := bson.Marshal(bson.M{"id": "content1"})
+		So(err, ShouldBeNil)
+
+		Convey("When a cursor is encoded from it and decoded", func() {
+			cursor, err := encodeCursor(sort, document)
+			So(err, ShouldBeNil)
+
+			decoded, err := decodeCursor(cursor, sort)
+
+			Convey("Then the missing key should have a null value", func() {
+				So(err, ShouldBeNil)
+				So(decoded.Values[0].Type, ShouldEqual, bson.TypeNull)
+			})
+		})
+	})
+
+	Convey("When a cursor that was not encoded by the API is decoded", t, func() {
+		for _, cursor := range []string{"not a cursor", "bm90IGEgY3Vyc29y"} {
+			decoded, err := decodeCursor(cursor, sort)
+
+			Convey("Then it should return an invalid cursor error for "+cursor, func() {
+				So(err, ShouldEqual, apierrors.ErrInvalidCursor)
+				So(decoded, ShouldBeNil)
+			})
+		}
+	})
+}
+
+func TestBuildCursorFilter(t *testing.T) {
+	t.Parallel()
+
+	title := bson.RawValue{Type: bson.TypeString, Value: rawString("Title 1")}
+	id := bson.RawValue{Type: bson.TypeString, Value: rawString("content1")}
+	null := bson.RawValue{Type: bson.TypeNull}
+
+	Convey("When buildCursorFilter is called for an ascending and a descending key", t, func() {
+		filter := buildCursorFilter(bson.D{{Key: "metadata.title", Value: -1}, {Key: "id", Value: 1}}, []bson.RawValue{title, id})
+
+		Convey("Then it should match a lower or null title, or the same title and a greater id", func() {
+			So(filter, ShouldResemble, bson.M{"$or": []bson.M{
+				{"$or": []bson.M{{"metadata.title": bson.M{"$lt": title}}, {"metadata.title": nil}}},
+				{"metadata.title": title, "id": bson.M{"$gt": id}},
+			}})
+		})
+	})
+
+	Convey("When buildCursorFilter is called with a null value for an ascending key", t, func() {
+		filter := buildCursorFilter(bson.D{{Key: "scheduled_at", Value: 1}, {Key: "id", Value: 1}}, []bson.RawValue{null, id})
+
+		Convey("Then it should match any value that is not null, or null and a greater id", func() {
+			So(filter, ShouldResemble, bson.M{"$or": []bson.M{
+				{"scheduled_at": bson.M{"$ne": nil}},
+				{"scheduled_at": null, "id": bson.M{"$gt": id}},
+			}})
+		})
+	})
+
+	Convey("When buildCursorFilter is called with a null value for a descending key", t, func() {
+		filter := buildCursorFilter(bson.D{{Key: "scheduled_at", Value: -1}}, []bson.RawValue{null})
+
+		Convey("Then it should match nothing", func() {
+			So(filter, ShouldResemble, bson.M{"_id": bson.M{"$exists": false}})
+		})
+	})
+}
+
+func rawString(value string) []byte {
+	_, raw, err := bson.MarshalValue(value)
+	if err != nil {
+		panic(err)
+	}
+	return raw
+}
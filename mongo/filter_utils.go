@@ -1,9 +1,12 @@
 package mongo
 
 import (
+	"regexp"
 	"time"
 
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // buildDateTimeFilter builds a bson filter for the datetime with a window of duration around the datetime
@@ -18,3 +21,48 @@ func buildDateTimeFilter(date time.Time) bson.M {
 		"$lte": endTime,
 	}
 }
+
+// buildTimeRangeFilter builds a bson filter for the datetimes within the range, including either end
+func buildTimeRangeFilter(timeRange *filters.TimeRange) bson.M {
+	filter := bson.M{}
+
+	if timeRange.After != nil {
+		filter["$gte"] = *timeRange.After
+	}
+
+	if timeRange.Before != nil {
+		filter["$lte"] = *timeRange.Before
+	}
+
+	return filter
+}
+
+// buildSubstringFilter builds a bson filter matching strings that contain the value, ignoring case
+func buildSubstringFilter(value string) primitive.Regex {
+	return primitive.Regex{Pattern: regexp.QuoteMeta(value), Options: "i"}
+}
+
+// buildSort builds a bson sort from the sort fields, or from the default sort fields if there are none. The tiebreaker
+// field is sorted on last, in ascending order, unless it is already sorted on, so that pages of results are stable.
+func buildSort(sortFields, defaultSortFields []filters.SortField, tiebreaker string) bson.D {
+	if len(sortFields) == 0 {
+		sortFields = defaultSortFields
+	}
+
+	sort := make(bson.D, 0, len(sortFields)+1)
+	hasTiebreaker := false
+	for _, sortField := range sortFields {
+		direction := 1
+		if sortField.Descending {
+			direction = -1
+		}
+		sort = append(sort, bson.E{Key: sortField.Field, Value: direction})
+		hasTiebreaker = hasTiebreaker || sortField.Field == tiebreaker
+	}
+
+	if !hasTiebreaker {
+		sort = append(sort, bson.E{Key: tiebreaker, Value: 1})
+	}
+
+	return sort
+}
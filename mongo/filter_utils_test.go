@@ -4,8 +4,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ONSdigital/dis-bundle-api/filters"
 	. "github.com/smartystreets/goconvey/convey"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestBuildDateTimeFilter(t *testing.T) {
@@ -26,3 +28,68 @@ func TestBuildDateTimeFilter(t *testing.T) {
 		})
 	})
 }
+
+func TestBuildTimeRangeFilter(t *testing.T) {
+	t.Parallel()
+
+	after := time.Date(2025, 01, 01, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2025, 02, 01, 0, 0, 0, 0, time.UTC)
+
+	Convey("When we call buildTimeRangeFilter with both ends of the range", t, func() {
+		filter := buildTimeRangeFilter(&filters.TimeRange{After: &after, Before: &before})
+
+		Convey("Then it should return a bson.M object with a gte and lte of those times", func() {
+			So(filter, ShouldResemble, bson.M{"$gte": after, "$lte": before})
+		})
+	})
+
+	Convey("When we call buildTimeRangeFilter with only the start of the range", t, func() {
+		filter := buildTimeRangeFilter(&filters.TimeRange{After: &after})
+
+		Convey("Then it should return a bson.M object with only a gte", func() {
+			So(filter, ShouldResemble, bson.M{"$gte": after})
+		})
+	})
+}
+
+func TestBuildSubstringFilter(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildSubstringFilter with a value containing regex characters", t, func() {
+		filter := buildSubstringFilter("CPI (2025)")
+
+		Convey("Then it should return a case-insensitive regex matching the value literally", func() {
+			So(filter, ShouldResemble, primitive.Regex{Pattern: `CPI \(2025\)`, Options: "i"})
+		})
+	})
+}
+
+func TestBuildSort(t *testing.T) {
+	t.Parallel()
+
+	defaultSort := []filters.SortField{{Field: "updated_at", Descending: true}}
+
+	Convey("When we call buildSort with no sort fields", t, func() {
+		sort := buildSort(nil, defaultSort, "id")
+
+		Convey("Then it should sort by the default sort fields and then the tiebreaker", func() {
+			So(sort, ShouldResemble, bson.D{{Key: "updated_at", Value: -1}, {Key: "id", Value: 1}})
+		})
+	})
+
+	Convey("When we call buildSort with sort fields", t, func() {
+		sort := buildSort([]filters.SortField{{Field: "scheduled_at", Descending: true}, {Field: "title"}}, defaultSort, "id")
+
+		Convey("Then it should sort by them in order and then the tiebreaker", func() {
+			So(sort, ShouldResemble, bson.D{{Key: "scheduled_at", Value: -1}, {Key: "title", Value: 1}, {Key: "id", Value: 1}})
+		})
+	})
+
+	Convey("When we call buildSort with sort fields that include the tiebreaker", t, func() {
+		sort := buildSort([]filters.SortField{{Field: "id", Descending: true}, {Field: "title"}}, defaultSort, "id")
+
+		Convey("Then it should not sort by the tiebreaker again", func() {
+			So(sort, ShouldResemble, bson.D{{Key: "id", Value: -1}, {Key: "title", Value: 1}})
+		})
+	})
+}
@@ -0,0 +1,121 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ONSdigital/dis-bundle-api/config"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// index is a MongoDB index on a collection
+type index struct {
+	collection string
+	name       string
+	keys       bson.D
+}
+
+// indexes are the indexes that support the queries made by the datastore
+var indexes = []index{
+	{
+		// Supports looking up content items by dataset, edition and version across all bundles
+		collection: config.BundleContentsCollection,
+		name:       "metadata_dataset_id_edition_id_version_id",
+		keys:       bson.D{{Key: "metadata.dataset_id", Value: 1}, {Key: "metadata.edition_id", Value: 1}, {Key: "metadata.version_id", Value: -1}},
+	},
+	{
+		collection: config.BundleContentsCollection,
+		name:       "state",
+		keys:       bson.D{{Key: "state", Value: 1}},
+	},
+	{
+		// Supports the default order of the content items in a bundle, so that pages can start after a cursor
+		collection: config.BundleContentsCollection,
+		name:       "bundle_id_id",
+		keys:       bson.D{{Key: "bundle_id", Value: 1}, {Key: "id", Value: -1}},
+	},
+	{
+		// Supports the default order of bundles, so that pages can start after a cursor
+		collection: config.BundlesCollection,
+		name:       "updated_at_id",
+		keys:       bson.D{{Key: "updated_at", Value: -1}, {Key: "id", Value: 1}},
+	},
+	{
+		// Supports the default order of bundle events, so that pages can start after a cursor
+		collection: config.BundleEventsCollection,
+		name:       "created_at_id",
+		keys:       bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: 1}},
+	},
+	{
+		// Supports listing the events about a bundle, and looking up its latest event. An event holds the ID of the
+		// bundle in a different field depending on whether it is about the bundle, a content item or a comment, so
+		// there is an index for each field.
+		collection: config.BundleEventsCollection,
+		name:       "bundle_id_created_at",
+		keys:       bson.D{{Key: "bundle.id", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+	{
+		collection: config.BundleEventsCollection,
+		name:       "content_item_bundle_id_created_at",
+		keys:       bson.D{{Key: "content_item.bundle_id", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+	{
+		collection: config.BundleEventsCollection,
+		name:       "comment_bundle_id_created_at",
+		keys:       bson.D{{Key: "comment.bundle_id", Value: 1}, {Key: "created_at", Value: -1}},
+	},
+	{
+		// Supports searching for bundles by the words in their titles
+		collection: config.BundlesCollection,
+		name:       "title_text",
+		keys:       bson.D{{Key: "title", Value: "text"}},
+	},
+	{
+		// Supports searching for bundles by the words in the titles of their content items. MongoDB keeps the index up
+		// to date as titles are written, including when they are filled in from the dataset API.
+		collection: config.BundleContentsCollection,
+		name:       "metadata_title_text",
+		keys:       bson.D{{Key: "metadata.title", Value: "text"}},
+	},
+	{
+		// Supports listing the publish runs of a bundle, newest first. Creating the index also creates the collection,
+		// which must exist before it is health checked.
+		collection: config.PublishRunsCollection,
+		name:       "bundle_id_started_at",
+		keys:       bson.D{{Key: "bundle_id", Value: 1}, {Key: "started_at", Value: -1}},
+	},
+	{
+		// Supports listing the approvals of a bundle. Creating the index also creates the collection, which must exist
+		// before it is health checked.
+		collection: config.ApprovalsCollection,
+		name:       "bundle_id_approved_at",
+		keys:       bson.D{{Key: "bundle_id", Value: 1}, {Key: "approved_at", Value: 1}},
+	},
+	{
+		// Supports listing the comments on a bundle and its content items. Creating the index also creates the
+		// collection, which must exist before it is health checked.
+		collection: config.CommentsCollection,
+		name:       "bundle_id_created_at",
+		keys:       bson.D{{Key: "bundle_id", Value: 1}, {Key: "created_at", Value: 1}},
+	},
+}
+
+// ensureIndexes creates any of the indexes that do not already exist. Creating an index that already exists with the
+// same keys and name does nothing.
+func (m *Mongo) ensureIndexes(ctx context.Context) error {
+	for _, idx := range indexes {
+		if err := m.Connection.RunCommand(ctx, buildCreateIndexCommand(m.ActualCollectionName(idx.collection), idx)); err != nil {
+			return fmt.Errorf("failed to create index %q: %w", idx.name, err)
+		}
+	}
+
+	return nil
+}
+
+// buildCreateIndexCommand builds the MongoDB command that creates an index on a collection
+func buildCreateIndexCommand(collectionName string, idx index) bson.D {
+	return bson.D{
+		{Key: "createIndexes", Value: collectionName},
+		{Key: "indexes", Value: bson.A{bson.M{"key": idx.keys, "name": idx.name}}},
+	}
+}
@@ -0,0 +1,41 @@
+package mongo
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildCreateIndexCommand(t *testing.T) {
+	t.Parallel()
+
+	Convey("When buildCreateIndexCommand is called for an index", t, func() {
+		idx := index{name: "state", keys: bson.D{{Key: "state", Value: 1}}}
+		command := buildCreateIndexCommand("bundle_contents", idx)
+
+		Convey("Then it creates the index with its keys and name on the collection", func() {
+			So(command, ShouldResemble, bson.D{
+				{Key: "createIndexes", Value: "bundle_contents"},
+				{Key: "indexes", Value: bson.A{bson.M{"key": bson.D{{Key: "state", Value: 1}}, "name": "state"}}},
+			})
+		})
+	})
+}
+
+func TestIndexesCreateHealthCheckedCollections(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the collections that are health checked", t, func() {
+		Convey("Then each has an index, so that it is created before it is health checked", func() {
+			indexedCollections := make(map[string]bool)
+			for _, idx := range indexes {
+				indexedCollections[idx.collection] = true
+			}
+
+			for _, collection := range healthCheckedCollections {
+				So(indexedCollections, ShouldContainKey, collection)
+			}
+		})
+	})
+}
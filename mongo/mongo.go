@@ -10,6 +10,17 @@ import (
 	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
 )
 
+// healthCheckedCollections are the collections the health check reports as missing if they do not exist. Each must have
+// an index in indexes, so that it is created when the service starts.
+var healthCheckedCollections = []string{
+	config.BundlesCollection,
+	config.BundleEventsCollection,
+	config.BundleContentsCollection,
+	config.PublishRunsCollection,
+	config.ApprovalsCollection,
+	config.CommentsCollection,
+}
+
 type Mongo struct {
 	config.MongoConfig
 
@@ -18,23 +29,24 @@ type Mongo struct {
 }
 
 // Init returns an initialised Mongo object encapsulating a connection to the mongo server/cluster with the given configuration,
-// a health client to check the health of the mongo server/cluster, and a lock client
+// a health client to check the health of the mongo server/cluster, and a lock client. The indexes needed by the datastore
+// are created if they do not already exist.
 func (m *Mongo) Init(ctx context.Context) (err error) {
 	m.Connection, err = mongodriver.Open(&m.MongoDriverConfig)
 	if err != nil {
 		return err
 	}
 
+	collections := make([]mongohealth.Collection, len(healthCheckedCollections))
+	for i, collection := range healthCheckedCollections {
+		collections[i] = mongohealth.Collection(m.ActualCollectionName(collection))
+	}
 	databaseCollectionBuilder := map[mongohealth.Database][]mongohealth.Collection{
-		mongohealth.Database(m.Database): {
-			mongohealth.Collection(m.ActualCollectionName(config.BundlesCollection)),
-			mongohealth.Collection(m.ActualCollectionName(config.BundleEventsCollection)),
-			mongohealth.Collection(m.ActualCollectionName(config.BundleContentsCollection)),
-		},
+		mongohealth.Database(m.Database): collections,
 	}
 	m.healthClient = mongohealth.NewClientWithCollections(m.Connection, databaseCollectionBuilder)
 
-	return nil
+	return m.ensureIndexes(ctx)
 }
 
 // Close represents mongo session closing within the context deadline
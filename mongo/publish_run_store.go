@@ -0,0 +1,169 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CreatePublishRun inserts a new publish run
+func (m *Mongo) CreatePublishRun(ctx context.Context, publishRun *models.PublishRun) error {
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.PublishRunsCollection)).
+		InsertOne(ctx, publishRun)
+
+	return err
+}
+
+// ListPublishRuns retrieves the publish runs for a bundle, most recent first
+func (m *Mongo) ListPublishRuns(ctx context.Context, bundleID string, offset, limit int) (publishRuns []*models.PublishRun, totalCount int, err error) {
+	publishRuns = []*models.PublishRun{}
+
+	filter, sort := buildListPublishRunsQuery(bundleID)
+
+	totalCount, err = m.Connection.Collection(m.ActualCollectionName(config.PublishRunsCollection)).
+		Find(ctx, filter, &publishRuns, mongodriver.Sort(sort), mongodriver.Offset(offset), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return publishRuns, totalCount, nil
+}
+
+func buildListPublishRunsQuery(bundleID string) (filter, sort bson.M) {
+	filter = bson.M{"bundle_id": bundleID}
+	sort = bson.M{"started_at": -1}
+	return filter, sort
+}
+
+// UpdatePublishRunItem records the outcome of an attempt to publish a content item within a publish run.
+// Each call counts as an attempt, and also marks the run as still active.
+func (m *Mongo) UpdatePublishRunItem(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error {
+	filter, update := buildUpdatePublishRunItemQuery(publishRunID, contentItemID, state, lastError, time.Now())
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.PublishRunsCollection)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return apierrors.ErrPublishRunNotFound
+	}
+
+	return nil
+}
+
+func buildUpdatePublishRunItemQuery(publishRunID, contentItemID string, state models.PublishRunItemState, lastError string, now time.Time) (filter, update bson.M) {
+	filter = bson.M{
+		"id":                    publishRunID,
+		"items.content_item_id": contentItemID,
+	}
+
+	update = bson.M{
+		"$set": bson.M{
+			"items.$.state":      state,
+			"items.$.last_error": lastError,
+			"items.$.updated_at": now,
+			"updated_at":         now,
+		},
+		"$inc": bson.M{
+			"items.$.attempts": 1,
+		},
+	}
+
+	return filter, update
+}
+
+// CompletePublishRun sets the final state of a publish run
+func (m *Mongo) CompletePublishRun(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+	now := time.Now()
+	filter := bson.M{"id": publishRunID}
+	update := bson.M{
+		"$set": bson.M{
+			"state":        state,
+			"completed_at": now,
+			"updated_at":   now,
+		},
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.PublishRunsCollection)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return apierrors.ErrPublishRunNotFound
+	}
+
+	return nil
+}
+
+// RenewPublishRun marks an in progress publish run as still active, so that it is not treated as stale and resumed by
+// another instance while it is being published
+func (m *Mongo) RenewPublishRun(ctx context.Context, publishRunID string) error {
+	filter, update := buildRenewPublishRunQuery(publishRunID, time.Now())
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.PublishRunsCollection)).
+		UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return apierrors.ErrPublishRunNotFound
+	}
+
+	return nil
+}
+
+func buildRenewPublishRunQuery(publishRunID string, now time.Time) (filter, update bson.M) {
+	filter = bson.M{
+		"id":    publishRunID,
+		"state": models.PublishRunStateInProgress,
+	}
+	update = bson.M{
+		"$set": bson.M{"updated_at": now},
+	}
+
+	return filter, update
+}
+
+// ClaimStalePublishRun atomically claims the oldest in progress publish run that has not been updated since
+// staleBefore, i.e. one whose publishing instance has most likely died. Claiming the run marks it as updated at now,
+// so no other instance will claim it while it is being resumed. Returns nil if there is no stale run.
+func (m *Mongo) ClaimStalePublishRun(ctx context.Context, staleBefore, now time.Time) (*models.PublishRun, error) {
+	filter, update, sort := buildClaimStalePublishRunQuery(staleBefore, now)
+
+	var result models.PublishRun
+	err := m.Connection.Collection(m.ActualCollectionName(config.PublishRunsCollection)).
+		FindOneAndUpdate(ctx, filter, update, &result, mongodriver.Sort(sort), mongodriver.ReturnDocument(options.After))
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func buildClaimStalePublishRunQuery(staleBefore, now time.Time) (filter, update, sort bson.M) {
+	filter = bson.M{
+		"state":      models.PublishRunStateInProgress,
+		"updated_at": bson.M{"$lt": staleBefore},
+	}
+	update = bson.M{
+		"$set": bson.M{"updated_at": now},
+	}
+	sort = bson.M{"updated_at": 1}
+
+	return filter, update, sort
+}
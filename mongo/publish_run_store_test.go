@@ -0,0 +1,211 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/apierrors"
+	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func newTestPublishRun(id, bundleID string, state models.PublishRunState, updatedAt time.Time) *models.PublishRun {
+	return &models.PublishRun{
+		ID:        id,
+		BundleID:  bundleID,
+		State:     state,
+		StartedAt: &updatedAt,
+		UpdatedAt: &updatedAt,
+		Items: []models.PublishRunItem{
+			{ContentItemID: "item1", DatasetID: "dataset1", EditionID: "edition1", VersionID: 1, State: models.PublishRunItemStatePending},
+		},
+	}
+}
+
+func setupTestDataForPublishRuns(ctx context.Context, mongo *Mongo, publishRuns ...*models.PublishRun) error {
+	if err := mongo.Connection.DropDatabase(ctx); err != nil {
+		return err
+	}
+
+	for _, publishRun := range publishRuns {
+		if _, err := mongo.Connection.Collection(mongo.ActualCollectionName(config.PublishRunsCollection)).InsertOne(ctx, publishRun); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestListPublishRuns(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly and there are publish runs for two bundles", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		now := time.Now().UTC().Truncate(time.Millisecond)
+		err = setupTestDataForPublishRuns(ctx, mongodb,
+			newTestPublishRun("run1", "bundle1", models.PublishRunStateFailed, now.Add(-time.Hour)),
+			newTestPublishRun("run2", "bundle1", models.PublishRunStateCompleted, now),
+			newTestPublishRun("run3", "bundle2", models.PublishRunStateCompleted, now),
+		)
+		So(err, ShouldBeNil)
+
+		Convey("When ListPublishRuns is called for a bundle", func() {
+			publishRuns, totalCount, err := mongodb.ListPublishRuns(ctx, "bundle1", 0, 10)
+
+			Convey("Then only the runs for that bundle are returned, most recent first", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 2)
+				So(publishRuns, ShouldHaveLength, 2)
+				So(publishRuns[0].ID, ShouldEqual, "run2")
+				So(publishRuns[1].ID, ShouldEqual, "run1")
+			})
+		})
+	})
+}
+
+func TestUpdatePublishRunItem(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly and there is an in progress publish run", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		err = setupTestDataForPublishRuns(ctx, mongodb, newTestPublishRun("run1", "bundle1", models.PublishRunStateInProgress, time.Now().UTC()))
+		So(err, ShouldBeNil)
+
+		Convey("When UpdatePublishRunItem is called for an item in the run", func() {
+			err := mongodb.UpdatePublishRunItem(ctx, "run1", "item1", models.PublishRunItemStateFailed, "dataset API unavailable")
+			So(err, ShouldBeNil)
+
+			Convey("Then the item state, error and attempts are recorded", func() {
+				publishRuns, _, err := mongodb.ListPublishRuns(ctx, "bundle1", 0, 10)
+				So(err, ShouldBeNil)
+				So(publishRuns[0].Items[0].State, ShouldEqual, models.PublishRunItemStateFailed)
+				So(publishRuns[0].Items[0].LastError, ShouldEqual, "dataset API unavailable")
+				So(publishRuns[0].Items[0].Attempts, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When UpdatePublishRunItem is called for an item that is not in the run", func() {
+			err := mongodb.UpdatePublishRunItem(ctx, "run1", "unknown-item", models.PublishRunItemStatePublished, "")
+
+			Convey("Then a publish run not found error is returned", func() {
+				So(err, ShouldEqual, apierrors.ErrPublishRunNotFound)
+			})
+		})
+	})
+}
+
+func TestClaimStalePublishRun(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly and there is a stale in progress publish run", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		now := time.Now().UTC()
+		err = setupTestDataForPublishRuns(ctx, mongodb,
+			newTestPublishRun("stale-run", "bundle1", models.PublishRunStateInProgress, now.Add(-time.Hour)),
+			newTestPublishRun("active-run", "bundle2", models.PublishRunStateInProgress, now),
+			newTestPublishRun("completed-run", "bundle3", models.PublishRunStateCompleted, now.Add(-time.Hour)),
+		)
+		So(err, ShouldBeNil)
+
+		Convey("When ClaimStalePublishRun is called twice", func() {
+			first, err := mongodb.ClaimStalePublishRun(ctx, now.Add(-time.Minute), now)
+			So(err, ShouldBeNil)
+			second, err := mongodb.ClaimStalePublishRun(ctx, now.Add(-time.Minute), now)
+			So(err, ShouldBeNil)
+
+			Convey("Then only the stale run is claimed, and only once", func() {
+				So(first.ID, ShouldEqual, "stale-run")
+				So(second, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestBuildListPublishRunsQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildListPublishRunsQuery", t, func() {
+		filter, sort := buildListPublishRunsQuery("bundle1")
+
+		Convey("Then it should filter on the bundle and sort the most recent run first", func() {
+			So(filter, ShouldResemble, bson.M{"bundle_id": "bundle1"})
+			So(sort, ShouldResemble, bson.M{"started_at": -1})
+		})
+	})
+}
+
+func TestBuildUpdatePublishRunItemQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildUpdatePublishRunItemQuery", t, func() {
+		now := time.Date(2025, 01, 01, 9, 30, 0, 0, time.UTC)
+		filter, update := buildUpdatePublishRunItemQuery("run1", "item1", models.PublishRunItemStateFailed, "failed", now)
+
+		Convey("Then it should filter on the run and the content item within it", func() {
+			So(filter, ShouldResemble, bson.M{"id": "run1", "items.content_item_id": "item1"})
+		})
+
+		Convey("And it should record the outcome, count the attempt and mark the run as updated", func() {
+			So(update, ShouldResemble, bson.M{
+				"$set": bson.M{
+					"items.$.state":      models.PublishRunItemStateFailed,
+					"items.$.last_error": "failed",
+					"items.$.updated_at": now,
+					"updated_at":         now,
+				},
+				"$inc": bson.M{"items.$.attempts": 1},
+			})
+		})
+	})
+}
+
+func TestBuildRenewPublishRunQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildRenewPublishRunQuery", t, func() {
+		now := time.Date(2025, 01, 01, 9, 30, 0, 0, time.UTC)
+		filter, update := buildRenewPublishRunQuery("run1", now)
+
+		Convey("Then it should filter on the run while it is in progress", func() {
+			So(filter, ShouldResemble, bson.M{"id": "run1", "state": models.PublishRunStateInProgress})
+		})
+
+		Convey("And it should mark the run as updated now", func() {
+			So(update, ShouldResemble, bson.M{"$set": bson.M{"updated_at": now}})
+		})
+	})
+}
+
+func TestBuildClaimStalePublishRunQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When we call buildClaimStalePublishRunQuery", t, func() {
+		now := time.Date(2025, 01, 01, 9, 30, 0, 0, time.UTC)
+		staleBefore := now.Add(-5 * time.Minute)
+		filter, update, sort := buildClaimStalePublishRunQuery(staleBefore, now)
+
+		Convey("Then it should filter on in progress runs that have not been updated since staleBefore", func() {
+			So(filter, ShouldResemble, bson.M{
+				"state":      models.PublishRunStateInProgress,
+				"updated_at": bson.M{"$lt": staleBefore},
+			})
+		})
+
+		Convey("And it should mark the claimed run as updated now", func() {
+			So(update, ShouldResemble, bson.M{"$set": bson.M{"updated_at": now}})
+		})
+
+		Convey("And it should claim the longest idle run first", func() {
+			So(sort, ShouldResemble, bson.M{"updated_at": 1})
+		})
+	})
+}
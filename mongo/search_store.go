@@ -0,0 +1,149 @@
+package mongo
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// scoredBundle is a bundle with how well its title matches a text search
+type scoredBundle struct {
+	models.Bundle `bson:",inline"`
+	Score         float64 `bson:"score"`
+}
+
+// scoredBundleContents is the content items in a bundle that match a text search, best match first, with how well the
+// best of them matches
+type scoredBundleContents struct {
+	BundleID string                `bson:"_id"`
+	Score    float64               `bson:"score"`
+	Contents []*models.ContentItem `bson:"contents"`
+}
+
+// maxSearchMatches is the most bundles that are found by a text search of their titles, and the most bundles that are
+// found by a text search of the titles of their content items. The best matches are found, so a search that matches
+// more than this leaves out only the worst of them.
+const maxSearchMatches = 1000
+
+// SearchBundles returns a page of the bundles whose titles, or the titles of whose content items, match the words in
+// the text, using the text indexes on those titles. A bundle is scored by how well its title matches plus how well its
+// best matching content item does, and the bundles are ranked best first, then by ID. The best maxSearchMatches bundles
+// are found by each title, ranked and grouped by bundle in MongoDB, so only they are ranked together here.
+func (m *Mongo) SearchBundles(ctx context.Context, text string, offset, limit int) (results []*models.BundleSearchResult, totalCount int, err error) {
+	filter, projection, sort := buildTextSearchQuery(text)
+
+	var bundles []*scoredBundle
+	_, err = m.Connection.Collection(m.ActualCollectionName(config.BundlesCollection)).
+		Find(ctx, filter, &bundles, mongodriver.Projection(projection), mongodriver.Sort(sort), mongodriver.Limit(maxSearchMatches))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var bundleContents []*scoredBundleContents
+	err = m.Connection.Collection(m.ActualCollectionName(config.BundleContentsCollection)).
+		Aggregate(ctx, buildContentsTextSearchPipeline(text), &bundleContents)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resultsByBundleID := make(map[string]*models.BundleSearchResult, len(bundles))
+	for _, bundle := range bundles {
+		resultsByBundleID[bundle.ID] = &models.BundleSearchResult{
+			Bundle:          &bundle.Bundle,
+			Score:           bundle.Score,
+			TitleMatched:    true,
+			MatchedContents: []*models.ContentItem{},
+		}
+	}
+
+	var unmatchedBundleIDs []string
+	for _, contents := range bundleContents {
+		result, ok := resultsByBundleID[contents.BundleID]
+		if !ok {
+			result = &models.BundleSearchResult{}
+			resultsByBundleID[contents.BundleID] = result
+			unmatchedBundleIDs = append(unmatchedBundleIDs, contents.BundleID)
+		}
+
+		result.Score += contents.Score
+		result.MatchedContents = contents.Contents
+	}
+
+	if len(unmatchedBundleIDs) > 0 {
+		otherBundles, err := m.ListBundlesByIDs(ctx, unmatchedBundleIDs)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, bundle := range otherBundles {
+			resultsByBundleID[bundle.ID].Bundle = bundle
+		}
+	}
+
+	results = make([]*models.BundleSearchResult, 0, len(resultsByBundleID))
+	for _, result := range resultsByBundleID {
+		// content items whose bundle no longer exists are left out
+		if result.Bundle != nil {
+			results = append(results, result)
+		}
+	}
+	rankBundleSearchResults(results)
+
+	return pageOf(results, offset, limit), len(results), nil
+}
+
+// buildTextSearchQuery builds the MongoDB filter for a text search, and the projection and sort that add the score
+// for how well each document matches and put the best matches first
+func buildTextSearchQuery(text string) (filter, projection bson.M, sort bson.D) {
+	textScore := bson.M{"$meta": "textScore"}
+
+	filter = bson.M{"$text": bson.M{"$search": text}}
+	projection = bson.M{"score": textScore}
+	sort = bson.D{{Key: "score", Value: textScore}}
+
+	return filter, projection, sort
+}
+
+// buildContentsTextSearchPipeline builds the MongoDB aggregation that finds the content items whose titles match a text
+// search, grouped by bundle with the best match first. The bundles are ranked by their best matching content item, and
+// only the best maxSearchMatches of them are kept.
+func buildContentsTextSearchPipeline(text string) bson.A {
+	return bson.A{
+		bson.M{"$match": bson.M{"$text": bson.M{"$search": text}}},
+		bson.M{"$addFields": bson.M{"score": bson.M{"$meta": "textScore"}}},
+		bson.M{"$sort": bson.D{{Key: "score", Value: -1}}},
+		bson.M{"$group": bson.M{
+			"_id":      "$bundle_id",
+			"score":    bson.M{"$first": "$score"},
+			"contents": bson.M{"$push": "$$ROOT"},
+		}},
+		bson.M{"$sort": bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}},
+		bson.M{"$limit": maxSearchMatches},
+	}
+}
+
+// rankBundleSearchResults sorts search results by score, best first, and then by bundle ID
+func rankBundleSearchResults(results []*models.BundleSearchResult) {
+	slices.SortFunc(results, func(a, b *models.BundleSearchResult) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Bundle.ID, b.Bundle.ID)
+	})
+}
+
+// pageOf returns the page of items from offset, holding at most limit items
+func pageOf[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+
+	end := min(offset+limit, len(items))
+	return items[offset:end]
+}
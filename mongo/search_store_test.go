@@ -0,0 +1,173 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func setupSearchTestData(ctx context.Context, mongodb *Mongo) error {
+	if err := mongodb.Connection.DropDatabase(ctx); err != nil {
+		return err
+	}
+
+	if err := mongodb.ensureIndexes(ctx); err != nil {
+		return err
+	}
+
+	bundles := []*models.Bundle{
+		{ID: "bundle1", BundleType: models.BundleTypeManual, State: models.BundleStateDraft, Title: "Consumer prices"},
+		{ID: "bundle2", BundleType: models.BundleTypeManual, State: models.BundleStateDraft, Title: "Labour market"},
+		{ID: "bundle3", BundleType: models.BundleTypeManual, State: models.BundleStateDraft, Title: "Housing"},
+	}
+	for _, bundle := range bundles {
+		if err := mongodb.CreateBundle(ctx, bundle); err != nil {
+			return err
+		}
+	}
+
+	contentItems := []*models.ContentItem{
+		{ID: "content1", BundleID: "bundle2", ContentType: models.ContentTypeDataset, Metadata: models.Metadata{DatasetID: "cpi", Title: "Consumer price inflation"}},
+		{ID: "content2", BundleID: "bundle3", ContentType: models.ContentTypeDataset, Metadata: models.Metadata{DatasetID: "hpi"}},
+		{ID: "content3", BundleID: "deleted-bundle", ContentType: models.ContentTypeDataset, Metadata: models.Metadata{DatasetID: "spending", Title: "Consumer spending"}},
+	}
+	for _, contentItem := range contentItems {
+		if _, err := mongodb.Connection.Collection(mongodb.ActualCollectionName(config.BundleContentsCollection)).InsertOne(ctx, contentItem); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestSearchBundles(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given the db connection is initialized correctly and its indexes exist", t, func() {
+		mongodb, err := getTestMongoDB(ctx, t)
+		So(err, ShouldBeNil)
+
+		So(setupSearchTestData(ctx, mongodb), ShouldBeNil)
+
+		Convey("When SearchBundles is called with a word in bundle and content item titles", func() {
+			results, totalCount, err := mongodb.SearchBundles(ctx, "consumer", 0, 10)
+
+			Convey("Then the bundles that match by their title or their content items are returned", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 2)
+
+				resultsByID := map[string]*models.BundleSearchResult{}
+				for _, result := range results {
+					resultsByID[result.Bundle.ID] = result
+				}
+
+				So(resultsByID["bundle1"].TitleMatched, ShouldBeTrue)
+				So(resultsByID["bundle1"].MatchedContents, ShouldBeEmpty)
+				So(resultsByID["bundle2"].TitleMatched, ShouldBeFalse)
+				So(resultsByID["bundle2"].Bundle.Title, ShouldEqual, "Labour market")
+				So(resultsByID["bundle2"].MatchedContents, ShouldHaveLength, 1)
+				So(resultsByID["bundle2"].MatchedContents[0].ID, ShouldEqual, "content1")
+			})
+		})
+
+		Convey("When the title of a content item is filled in and SearchBundles is called with a word in it", func() {
+			So(mongodb.UpdateContentItemDatasetInfo(ctx, "content2", "House price index", "APPROVED"), ShouldBeNil)
+
+			results, totalCount, err := mongodb.SearchBundles(ctx, "house", 0, 10)
+
+			Convey("Then the bundle the content item is in is returned", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 1)
+				So(results[0].Bundle.ID, ShouldEqual, "bundle3")
+				So(results[0].MatchedContents[0].Metadata.Title, ShouldEqual, "House price index")
+			})
+		})
+
+		Convey("When SearchBundles is called with a word that nothing matches", func() {
+			results, totalCount, err := mongodb.SearchBundles(ctx, "fishing", 0, 10)
+
+			Convey("Then no bundles are returned", func() {
+				So(err, ShouldBeNil)
+				So(totalCount, ShouldEqual, 0)
+				So(results, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestBuildTextSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	Convey("When buildTextSearchQuery is called", t, func() {
+		filter, projection, sort := buildTextSearchQuery("consumer prices")
+
+		Convey("Then it searches the text index and sorts by the score, which is added to each document", func() {
+			So(filter, ShouldResemble, bson.M{"$text": bson.M{"$search": "consumer prices"}})
+			So(projection, ShouldResemble, bson.M{"score": bson.M{"$meta": "textScore"}})
+			So(sort, ShouldResemble, bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}})
+		})
+	})
+}
+
+func TestBuildContentsTextSearchPipeline(t *testing.T) {
+	t.Parallel()
+
+	Convey("When buildContentsTextSearchPipeline is called", t, func() {
+		pipeline := buildContentsTextSearchPipeline("consumer prices")
+
+		Convey("Then it searches the text index first, so that the index is used", func() {
+			So(pipeline[0], ShouldResemble, bson.M{"$match": bson.M{"$text": bson.M{"$search": "consumer prices"}}})
+		})
+
+		Convey("Then it groups the matches by bundle, scored by the best of them", func() {
+			So(pipeline[3], ShouldResemble, bson.M{"$group": bson.M{
+				"_id":      "$bundle_id",
+				"score":    bson.M{"$first": "$score"},
+				"contents": bson.M{"$push": "$$ROOT"},
+			}})
+		})
+
+		Convey("Then it keeps only the best matching bundles", func() {
+			So(pipeline[4], ShouldResemble, bson.M{"$sort": bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}})
+			So(pipeline[5], ShouldResemble, bson.M{"$limit": maxSearchMatches})
+		})
+	})
+}
+
+func TestRankBundleSearchResults(t *testing.T) {
+	t.Parallel()
+
+	Convey("When rankBundleSearchResults is called", t, func() {
+		results := []*models.BundleSearchResult{
+			{Bundle: &models.Bundle{ID: "bundle3"}, Score: 1},
+			{Bundle: &models.Bundle{ID: "bundle1"}, Score: 2},
+			{Bundle: &models.Bundle{ID: "bundle2"}, Score: 1},
+		}
+		rankBundleSearchResults(results)
+
+		Convey("Then the results are in score order, best first, and then in bundle ID order", func() {
+			So(results[0].Bundle.ID, ShouldEqual, "bundle1")
+			So(results[1].Bundle.ID, ShouldEqual, "bundle2")
+			So(results[2].Bundle.ID, ShouldEqual, "bundle3")
+		})
+	})
+}
+
+func TestPageOf(t *testing.T) {
+	t.Parallel()
+
+	Convey("When pageOf is called", t, func() {
+		items := []int{1, 2, 3}
+
+		Convey("Then it returns at most limit items from the offset", func() {
+			So(pageOf(items, 0, 2), ShouldResemble, []int{1, 2})
+			So(pageOf(items, 2, 2), ShouldResemble, []int{3})
+			So(pageOf(items, 3, 2), ShouldResemble, []int{})
+			So(pageOf(items, 1, 0), ShouldResemble, []int{})
+		})
+	})
+}
@@ -18,7 +18,7 @@ import (
 
 // TPaginatedHandler is a func type for an endpoint that returns a list of values that we want to paginate
 type TPaginatedHandler[TItem any] func(w http.ResponseWriter, r *http.Request, limit int, offset int) (successResult *models.PaginationSuccessResult[TItem], errorResult *models.ErrorResult[models.Error])
-type PaginatedHandler func(w http.ResponseWriter, r *http.Request, limit int, offset int) (items any, totalCount int, eventErrors *models.Error)
+type PaginatedHandler func(w http.ResponseWriter, r *http.Request, limit int, offset int) (items any, totalCount int, nextCursor string, eventErrors *models.Error)
 
 type PaginatedResponse struct {
 	Items                   interface{} `json:"items"`
@@ -43,10 +43,19 @@ func (p *Paginator) getPaginationParameters(r *http.Request) (offset, limit int,
 	logData := log.Data{}
 	offsetParameter := r.URL.Query().Get("offset")
 	limitParameter := r.URL.Query().Get("limit")
+	cursorParameter := r.URL.Query().Get("cursor")
 
 	offset = p.DefaultOffset
 	limit = p.DefaultLimit
 
+	if offsetParameter != "" && cursorParameter != "" {
+		// a cursor marks where the page starts, so cannot be combined with an offset
+		logData["offset"] = offsetParameter
+		err = errors.New("invalid query parameter: offset")
+		log.Error(r.Context(), "offset cannot be given with a cursor", err, logData)
+		return 0, 0, err
+	}
+
 	if offsetParameter != "" {
 		logData["offset"] = offsetParameter
 		offset, err = strconv.Atoi(offsetParameter)
@@ -76,7 +85,7 @@ func (p *Paginator) getPaginationParameters(r *http.Request) (offset, limit int,
 	return offset, limit, err
 }
 
-func renderPage(list interface{}, offset, limit, totalCount int) PaginatedResponse {
+func renderPage(list interface{}, offset, limit, totalCount int, nextCursor string) PaginatedResponse {
 	return PaginatedResponse{
 		Items: list,
 		PaginationFields: models.PaginationFields{
@@ -84,6 +93,7 @@ func renderPage(list interface{}, offset, limit, totalCount int) PaginatedRespon
 			Offset:     offset,
 			Limit:      limit,
 			TotalCount: totalCount,
+			NextCursor: nextCursor,
 		},
 	}
 }
@@ -108,7 +118,7 @@ func Paginate[TItem any](p *Paginator, paginatedHandler TPaginatedHandler[TItem]
 			return
 		}
 
-		renderedPage := renderPage(successResult.Result.Items, offset, limit, successResult.Result.TotalCount)
+		renderedPage := renderPage(successResult.Result.Items, offset, limit, successResult.Result.TotalCount, successResult.Result.NextCursor)
 
 		returnPaginatedResults(w, r, renderedPage)
 	}
@@ -122,14 +132,14 @@ func (p *Paginator) Paginate(paginatedHandler PaginatedHandler) func(w http.Resp
 			return
 		}
 
-		items, totalCount, requestError := paginatedHandler(w, r, limit, offset)
+		items, totalCount, nextCursor, requestError := paginatedHandler(w, r, limit, offset)
 		if requestError != nil {
 			status := mapErrorCodeToStatus(requestError.Code)
 			utils.HandleBundleAPIErr(w, r, status, requestError)
 			return
 		}
 
-		renderedPage := renderPage(items, offset, limit, totalCount)
+		renderedPage := renderPage(items, offset, limit, totalCount, nextCursor)
 		returnPaginatedResults(w, r, renderedPage)
 	}
 }
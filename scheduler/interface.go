@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+)
+
+//go:generate moq -skip-ensure -out mocks/publisher.go -pkg mocks . BundlePublisher PublishRunResumer
+
+// BundlePublisher represents the operations the Scheduler needs to find and publish scheduled bundles
+type BundlePublisher interface {
+	ListScheduledBundles(ctx context.Context, offset, limit int) ([]*models.Bundle, int, error)
+	ClaimDueScheduledBundle(ctx context.Context, owner string, lockDuration time.Duration) (*models.Bundle, error)
+	RenewScheduledBundleClaim(ctx context.Context, bundleID, owner string, lockDuration time.Duration) (bool, error)
+	ReleaseScheduledBundleClaim(ctx context.Context, bundleID, owner string) error
+	PublishScheduledBundle(ctx context.Context, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error)
+}
+
+// PublishRunResumer represents the operations the Resumer needs to find and resume interrupted publish runs
+type PublishRunResumer interface {
+	ClaimStalePublishRun(ctx context.Context, staleTimeout time.Duration) (*models.PublishRun, error)
+	ResumePublishRun(ctx context.Context, publishRun *models.PublishRun, authEntityData *models.AuthEntityData) (*models.Bundle, error)
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Resumer resumes publish runs that were interrupted, e.g. by an instance of the service being stopped mid-publish.
+// Bundles published on request are published in runs as well as scheduled bundles, so the Resumer runs whether or not
+// the Scheduler does.
+type Resumer struct {
+	publisher      PublishRunResumer
+	authEntityData *models.AuthEntityData
+	staleTimeout   time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewResumer returns a Resumer that resumes publish runs using the identity in authEntityData.
+// A publish run that has not been updated for staleTimeout is treated as interrupted. The Resumer checks for
+// interrupted runs when it starts, and then every half of staleTimeout.
+func NewResumer(publisher PublishRunResumer, authEntityData *models.AuthEntityData, staleTimeout time.Duration) *Resumer {
+	return &Resumer{
+		publisher:      publisher,
+		authEntityData: authEntityData,
+		staleTimeout:   staleTimeout,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// Start runs the resumer in a new go-routine until Stop is called
+func (r *Resumer) Start(ctx context.Context) {
+	log.Info(ctx, "starting publish run resumer", log.Data{"stale_timeout": r.staleTimeout.String()})
+	go r.run(ctx)
+}
+
+// Stop signals the resumer to stop and waits for any in-flight resume to complete, or for ctx to be done.
+// Stop must only be called after Start.
+func (r *Resumer) Stop(ctx context.Context) error {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+
+	select {
+	case <-r.done:
+		log.Info(ctx, "publish run resumer stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed once the resumer has stopped
+func (r *Resumer) Done() <-chan struct{} {
+	return r.done
+}
+
+func (r *Resumer) run(ctx context.Context) {
+	defer close(r.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-timer.C:
+			r.resumeStalePublishRuns(ctx)
+			timer.Reset(max(r.staleTimeout/2, time.Millisecond))
+		}
+	}
+}
+
+// resumeStalePublishRuns claims and resumes interrupted publish runs until there are none left to claim
+func (r *Resumer) resumeStalePublishRuns(ctx context.Context) {
+	for {
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+
+		publishRun, err := r.publisher.ClaimStalePublishRun(ctx, r.staleTimeout)
+		if err != nil {
+			log.Error(ctx, "failed to claim stale publish run", err)
+			return
+		}
+
+		if publishRun == nil {
+			return
+		}
+
+		logData := log.Data{"bundle_id": publishRun.BundleID, "publish_run_id": publishRun.ID}
+		log.Info(ctx, "resuming interrupted publish run", logData)
+
+		// a run that fails to resume is left in progress, so it is claimed and retried once it is stale again
+		if _, err := r.publisher.ResumePublishRun(ctx, publishRun, r.authEntityData); err != nil {
+			log.Error(ctx, "failed to resume publish run", err, logData)
+			continue
+		}
+
+		log.Info(ctx, "publish run resumed", logData)
+	}
+}
@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/scheduler/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testStaleTimeout = 10 * time.Minute
+
+var errResume = errors.New("resume failed")
+
+func newTestResumer(publisher PublishRunResumer) *Resumer {
+	return NewResumer(publisher, models.CreateServiceAuthEntityData("test-service-token"), testStaleTimeout)
+}
+
+// staleRunQueue returns a ClaimStalePublishRunFunc that hands out the given publish runs in order, followed by nil
+func staleRunQueue(publishRuns ...*models.PublishRun) func(ctx context.Context, staleTimeout time.Duration) (*models.PublishRun, error) {
+	return func(ctx context.Context, staleTimeout time.Duration) (*models.PublishRun, error) {
+		if len(publishRuns) == 0 {
+			return nil, nil
+		}
+		next := publishRuns[0]
+		publishRuns = publishRuns[1:]
+		return next, nil
+	}
+}
+
+func TestResumeStalePublishRuns(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given two publish runs have been interrupted", t, func() {
+		publishRun1 := &models.PublishRun{ID: "run-1", BundleID: "bundle-1"}
+		publishRun2 := &models.PublishRun{ID: "run-2", BundleID: "bundle-2"}
+
+		Convey("When resumeStalePublishRuns is called and the first run fails to resume", func() {
+			publisher := &mocks.PublishRunResumerMock{
+				ClaimStalePublishRunFunc: staleRunQueue(publishRun1, publishRun2),
+				ResumePublishRunFunc: func(ctx context.Context, publishRun *models.PublishRun, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+					if publishRun.ID == "run-1" {
+						return nil, errResume
+					}
+					return &models.Bundle{ID: publishRun.BundleID}, nil
+				},
+			}
+			r := newTestResumer(publisher)
+			r.resumeStalePublishRuns(ctx)
+
+			Convey("Then runs are claimed using the resumer's stale timeout", func() {
+				So(publisher.ClaimStalePublishRunCalls(), ShouldHaveLength, 3)
+				So(publisher.ClaimStalePublishRunCalls()[0].StaleTimeout, ShouldEqual, testStaleTimeout)
+			})
+
+			Convey("And both runs are resumed with the service identity", func() {
+				So(publisher.ResumePublishRunCalls(), ShouldHaveLength, 2)
+				So(publisher.ResumePublishRunCalls()[0].PublishRun.ID, ShouldEqual, "run-1")
+				So(publisher.ResumePublishRunCalls()[1].PublishRun.ID, ShouldEqual, "run-2")
+				So(publisher.ResumePublishRunCalls()[0].AuthEntityData.IsServiceAuth, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given claiming a stale publish run fails", t, func() {
+		publisher := &mocks.PublishRunResumerMock{
+			ClaimStalePublishRunFunc: func(ctx context.Context, staleTimeout time.Duration) (*models.PublishRun, error) {
+				return nil, errClaim
+			},
+		}
+		r := newTestResumer(publisher)
+
+		Convey("When resumeStalePublishRuns is called", func() {
+			r.resumeStalePublishRuns(ctx)
+
+			Convey("Then no runs are resumed and no further claims are attempted", func() {
+				So(publisher.ClaimStalePublishRunCalls(), ShouldHaveLength, 1)
+				So(publisher.ResumePublishRunCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestResumerStartAndStop(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a publish run was interrupted before the resumer started", t, func() {
+		resuming := make(chan struct{})
+		release := make(chan struct{})
+
+		publisher := &mocks.PublishRunResumerMock{
+			ClaimStalePublishRunFunc: staleRunQueue(&models.PublishRun{ID: "run-1", BundleID: "bundle-1"}),
+			ResumePublishRunFunc: func(ctx context.Context, publishRun *models.PublishRun, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+				close(resuming)
+				<-release
+				return &models.Bundle{ID: publishRun.BundleID}, nil
+			},
+		}
+		r := newTestResumer(publisher)
+
+		Convey("When the resumer is started", func() {
+			r.Start(ctx)
+
+			Convey("Then the run is resumed straight away, without waiting for the stale timeout", func() {
+				select {
+				case <-resuming:
+				case <-time.After(time.Second):
+					t.Fatal("publish run was not resumed at start up")
+				}
+
+				Convey("And Stop waits for the in-flight resume to complete", func() {
+					stopCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+					defer cancel()
+					So(r.Stop(stopCtx), ShouldEqual, context.DeadlineExceeded)
+
+					close(release)
+					So(r.Stop(ctx), ShouldBeNil)
+					So(publisher.ResumePublishRunCalls(), ShouldHaveLength, 1)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,224 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gofrs/uuid"
+)
+
+// Scheduler publishes approved scheduled bundles once their scheduled_at time has passed.
+// Each bundle is claimed before it is published so that only one replica of the service publishes it.
+type Scheduler struct {
+	publisher      BundlePublisher
+	authEntityData *models.AuthEntityData
+	owner          string
+	pollInterval   time.Duration
+	lockDuration   time.Duration
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// New returns a Scheduler that publishes bundles using the identity in authEntityData.
+// The scheduler checks for due bundles at least every pollInterval. Its claim on a bundle lasts for lockDuration and is
+// renewed for as long as the bundle is being published.
+func New(publisher BundlePublisher, authEntityData *models.AuthEntityData, pollInterval, lockDuration time.Duration) (*Scheduler, error) {
+	owner, err := newOwnerID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scheduler{
+		publisher:      publisher,
+		authEntityData: authEntityData,
+		owner:          owner,
+		pollInterval:   pollInterval,
+		lockDuration:   lockDuration,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}, nil
+}
+
+// newOwnerID returns an identifier, unique to this instance of the service, used to claim bundles
+func newOwnerID() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%s", hostname, id.String()), nil
+}
+
+// Owner returns the identifier this scheduler uses when claiming bundles
+func (s *Scheduler) Owner() string {
+	return s.owner
+}
+
+// Start runs the scheduler in a new go-routine until Stop is called
+func (s *Scheduler) Start(ctx context.Context) {
+	log.Info(ctx, "starting bundle publish scheduler", log.Data{"owner": s.owner, "poll_interval": s.pollInterval.String(), "lock_duration": s.lockDuration.String()})
+	go s.run(ctx)
+}
+
+// Stop signals the scheduler to stop and waits for any in-flight publish to complete, or for ctx to be done.
+// Stop must only be called after Start.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	select {
+	case <-s.done:
+		log.Info(ctx, "bundle publish scheduler stopped", log.Data{"owner": s.owner})
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed once the scheduler has stopped
+func (s *Scheduler) Done() <-chan struct{} {
+	return s.done
+}
+
+// Next returns the next bundle the scheduler will publish, or nil if no approved scheduled bundles are waiting
+func (s *Scheduler) Next(ctx context.Context) (*models.Bundle, error) {
+	bundles, _, err := s.publisher.ListScheduledBundles(ctx, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bundles) == 0 {
+		return nil, nil
+	}
+
+	return bundles[0], nil
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer close(s.done)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-timer.C:
+			s.publishDueBundles(ctx)
+			timer.Reset(s.nextWait(ctx))
+		}
+	}
+}
+
+// publishDueBundles claims and publishes due bundles until there are none left to claim
+func (s *Scheduler) publishDueBundles(ctx context.Context) {
+	for {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		bundle, err := s.publisher.ClaimDueScheduledBundle(ctx, s.owner, s.lockDuration)
+		if err != nil {
+			log.Error(ctx, "failed to claim scheduled bundle for publishing", err, log.Data{"owner": s.owner})
+			return
+		}
+
+		if bundle == nil {
+			return
+		}
+
+		logData := log.Data{"bundle_id": bundle.ID, "scheduled_at": bundle.ScheduledAt, "owner": s.owner}
+		log.Info(ctx, "publishing scheduled bundle", logData)
+
+		stopRenewing := s.holdClaim(ctx, bundle.ID, logData)
+		_, err = s.publisher.PublishScheduledBundle(ctx, bundle, s.authEntityData)
+		stopRenewing()
+
+		// a bundle that fails to publish stays claimed until its lock expires, after which it is retried
+		if err != nil {
+			log.Error(ctx, "failed to publish scheduled bundle", err, logData)
+			continue
+		}
+
+		if err := s.publisher.ReleaseScheduledBundleClaim(ctx, bundle.ID, s.owner); err != nil {
+			log.Error(ctx, "failed to release claim on published bundle", err, logData)
+		}
+
+		log.Info(ctx, "scheduled bundle published", logData)
+	}
+}
+
+// holdClaim renews the scheduler's claim on a bundle every third of the lock duration while it is being published, so
+// that the claim cannot expire and let another replica publish the bundle again. The returned function stops renewing
+// the claim and waits for any renewal in progress to finish.
+func (s *Scheduler) holdClaim(ctx context.Context, bundleID string, logData log.Data) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(max(s.lockDuration/3, time.Millisecond))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				held, err := s.publisher.RenewScheduledBundleClaim(ctx, bundleID, s.owner, s.lockDuration)
+				if err != nil {
+					log.Error(ctx, "failed to renew claim on scheduled bundle being published", err, logData)
+					continue
+				}
+				if !held {
+					log.Warn(ctx, "claim on scheduled bundle being published was lost", logData)
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// nextWait returns how long to wait before next checking for due bundles.
+// This is the poll interval, unless the next scheduled bundle is due sooner.
+func (s *Scheduler) nextWait(ctx context.Context) time.Duration {
+	next, err := s.Next(ctx)
+	if err != nil {
+		log.Error(ctx, "failed to get next scheduled bundle", err)
+		return s.pollInterval
+	}
+
+	if next == nil || next.ScheduledAt == nil {
+		return s.pollInterval
+	}
+
+	wait := time.Until(*next.ScheduledAt)
+	if wait <= 0 || wait > s.pollInterval {
+		return s.pollInterval
+	}
+
+	log.Info(ctx, "next scheduled bundle due before next poll", log.Data{"bundle_id": next.ID, "scheduled_at": next.ScheduledAt})
+	return wait
+}
@@ -0,0 +1,308 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/scheduler/mocks"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testPollInterval = 30 * time.Second
+	testLockDuration = 5 * time.Minute
+)
+
+var (
+	errClaim   = errors.New("claim failed")
+	errPublish = errors.New("publish failed")
+)
+
+func newTestScheduler(publisher BundlePublisher) *Scheduler {
+	s, err := New(publisher, models.CreateServiceAuthEntityData("test-service-token"), testPollInterval, testLockDuration)
+	So(err, ShouldBeNil)
+	return s
+}
+
+// claimQueue returns a ClaimDueScheduledBundleFunc that hands out the given bundles in order, followed by nil
+func claimQueue(bundles ...*models.Bundle) func(ctx context.Context, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+	return func(ctx context.Context, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+		if len(bundles) == 0 {
+			return nil, nil
+		}
+		next := bundles[0]
+		bundles = bundles[1:]
+		return next, nil
+	}
+}
+
+// releaseClaim is a ReleaseScheduledBundleClaimFunc that always succeeds
+func releaseClaim(ctx context.Context, bundleID, owner string) error {
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	Convey("When a new Scheduler is created", t, func() {
+		s := newTestScheduler(&mocks.BundlePublisherMock{})
+
+		Convey("Then it has a unique owner identifier", func() {
+			other := newTestScheduler(&mocks.BundlePublisherMock{})
+			So(s.Owner(), ShouldNotBeEmpty)
+			So(s.Owner(), ShouldNotEqual, other.Owner())
+		})
+
+		Convey("And it publishes as the service identity", func() {
+			So(s.authEntityData.IsServiceAuth, ShouldBeTrue)
+			So(s.authEntityData.GetUserID(), ShouldEqual, models.ServiceIdentity)
+			So(s.authEntityData.Headers.AccessToken, ShouldEqual, "test-service-token")
+		})
+	})
+}
+
+func TestPublishDueBundles(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given two bundles are due to be published", t, func() {
+		bundle1 := &models.Bundle{ID: "bundle-1"}
+		bundle2 := &models.Bundle{ID: "bundle-2"}
+
+		Convey("When publishDueBundles is called and both publish successfully", func() {
+			publisher := &mocks.BundlePublisherMock{
+				ClaimDueScheduledBundleFunc:     claimQueue(bundle1, bundle2),
+				ReleaseScheduledBundleClaimFunc: releaseClaim,
+				PublishScheduledBundleFunc: func(ctx context.Context, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+					return bundle, nil
+				},
+			}
+			s := newTestScheduler(publisher)
+			s.publishDueBundles(ctx)
+
+			Convey("Then each bundle is claimed with the scheduler's owner and lock duration", func() {
+				So(publisher.ClaimDueScheduledBundleCalls(), ShouldHaveLength, 3)
+				So(publisher.ClaimDueScheduledBundleCalls()[0].Owner, ShouldEqual, s.Owner())
+				So(publisher.ClaimDueScheduledBundleCalls()[0].LockDuration, ShouldEqual, testLockDuration)
+			})
+
+			Convey("And each bundle is published with the service identity", func() {
+				So(publisher.PublishScheduledBundleCalls(), ShouldHaveLength, 2)
+				So(publisher.PublishScheduledBundleCalls()[0].Bundle.ID, ShouldEqual, "bundle-1")
+				So(publisher.PublishScheduledBundleCalls()[1].Bundle.ID, ShouldEqual, "bundle-2")
+				So(publisher.PublishScheduledBundleCalls()[0].AuthEntityData.IsServiceAuth, ShouldBeTrue)
+			})
+
+			Convey("And the claim on each bundle is released once it is published", func() {
+				So(publisher.ReleaseScheduledBundleClaimCalls(), ShouldHaveLength, 2)
+				So(publisher.ReleaseScheduledBundleClaimCalls()[0].BundleID, ShouldEqual, "bundle-1")
+				So(publisher.ReleaseScheduledBundleClaimCalls()[0].Owner, ShouldEqual, s.Owner())
+			})
+		})
+
+		Convey("When publishDueBundles is called and the first bundle fails to publish", func() {
+			publisher := &mocks.BundlePublisherMock{
+				ClaimDueScheduledBundleFunc:     claimQueue(bundle1, bundle2),
+				ReleaseScheduledBundleClaimFunc: releaseClaim,
+				PublishScheduledBundleFunc: func(ctx context.Context, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+					if bundle.ID == "bundle-1" {
+						return nil, errPublish
+					}
+					return bundle, nil
+				},
+			}
+			s := newTestScheduler(publisher)
+			s.publishDueBundles(ctx)
+
+			Convey("Then the remaining bundle is still published", func() {
+				So(publisher.PublishScheduledBundleCalls(), ShouldHaveLength, 2)
+				So(publisher.PublishScheduledBundleCalls()[1].Bundle.ID, ShouldEqual, "bundle-2")
+			})
+
+			Convey("And the failed bundle stays claimed until its lock expires", func() {
+				So(publisher.ReleaseScheduledBundleClaimCalls(), ShouldHaveLength, 1)
+				So(publisher.ReleaseScheduledBundleClaimCalls()[0].BundleID, ShouldEqual, "bundle-2")
+			})
+		})
+	})
+
+	Convey("Given a bundle takes longer to publish than the lock is held for", t, func() {
+		bundle := &models.Bundle{ID: "bundle-1"}
+		lockDuration := 30 * time.Millisecond
+
+		Convey("When publishDueBundles is called", func() {
+			publisher := &mocks.BundlePublisherMock{
+				ClaimDueScheduledBundleFunc: claimQueue(bundle),
+				RenewScheduledBundleClaimFunc: func(ctx context.Context, bundleID, owner string, lockDuration time.Duration) (bool, error) {
+					return true, nil
+				},
+				ReleaseScheduledBundleClaimFunc: releaseClaim,
+				PublishScheduledBundleFunc: func(ctx context.Context, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+					time.Sleep(4 * lockDuration)
+					return bundle, nil
+				},
+			}
+			s, err := New(publisher, models.CreateServiceAuthEntityData("test-service-token"), testPollInterval, lockDuration)
+			So(err, ShouldBeNil)
+			s.publishDueBundles(ctx)
+
+			Convey("Then the claim is renewed before it expires for as long as the bundle is being published", func() {
+				renewals := publisher.RenewScheduledBundleClaimCalls()
+				So(len(renewals), ShouldBeGreaterThanOrEqualTo, 4)
+				So(renewals[0].BundleID, ShouldEqual, "bundle-1")
+				So(renewals[0].Owner, ShouldEqual, s.Owner())
+				So(renewals[0].LockDuration, ShouldEqual, lockDuration)
+			})
+
+			Convey("And no renewals are made once the bundle is published and the claim released", func() {
+				renewals := len(publisher.RenewScheduledBundleClaimCalls())
+				time.Sleep(2 * lockDuration)
+				So(publisher.RenewScheduledBundleClaimCalls(), ShouldHaveLength, renewals)
+				So(publisher.ReleaseScheduledBundleClaimCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the claim is lost while the bundle is being published", func() {
+			publisher := &mocks.BundlePublisherMock{
+				ClaimDueScheduledBundleFunc: claimQueue(bundle),
+				RenewScheduledBundleClaimFunc: func(ctx context.Context, bundleID, owner string, lockDuration time.Duration) (bool, error) {
+					return false, nil
+				},
+				ReleaseScheduledBundleClaimFunc: releaseClaim,
+				PublishScheduledBundleFunc: func(ctx context.Context, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+					time.Sleep(4 * lockDuration)
+					return bundle, nil
+				},
+			}
+			s, err := New(publisher, models.CreateServiceAuthEntityData("test-service-token"), testPollInterval, lockDuration)
+			So(err, ShouldBeNil)
+			s.publishDueBundles(ctx)
+
+			Convey("Then it stops trying to renew the claim", func() {
+				So(publisher.RenewScheduledBundleClaimCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given claiming a bundle fails", t, func() {
+		publisher := &mocks.BundlePublisherMock{
+			ClaimDueScheduledBundleFunc: func(ctx context.Context, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+				return nil, errClaim
+			},
+		}
+		s := newTestScheduler(publisher)
+
+		Convey("When publishDueBundles is called", func() {
+			s.publishDueBundles(ctx)
+
+			Convey("Then no bundles are published and no further claims are attempted", func() {
+				So(publisher.ClaimDueScheduledBundleCalls(), ShouldHaveLength, 1)
+				So(publisher.PublishScheduledBundleCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestNextWait(t *testing.T) {
+	ctx := context.Background()
+
+	listReturning := func(bundles []*models.Bundle, err error) *mocks.BundlePublisherMock {
+		return &mocks.BundlePublisherMock{
+			ListScheduledBundlesFunc: func(ctx context.Context, offset, limit int) ([]*models.Bundle, int, error) {
+				return bundles, len(bundles), err
+			},
+		}
+	}
+
+	Convey("Given there are no scheduled bundles", t, func() {
+		s := newTestScheduler(listReturning([]*models.Bundle{}, nil))
+
+		Convey("Then Next returns nil and the scheduler waits for the poll interval", func() {
+			next, err := s.Next(ctx)
+			So(err, ShouldBeNil)
+			So(next, ShouldBeNil)
+			So(s.nextWait(ctx), ShouldEqual, testPollInterval)
+		})
+	})
+
+	Convey("Given the next scheduled bundle is due before the next poll", t, func() {
+		scheduledAt := time.Now().Add(10 * time.Second)
+		publisher := listReturning([]*models.Bundle{{ID: "bundle-1", ScheduledAt: &scheduledAt}}, nil)
+		s := newTestScheduler(publisher)
+
+		Convey("Then Next returns the bundle and the scheduler waits until it is due", func() {
+			next, err := s.Next(ctx)
+			So(err, ShouldBeNil)
+			So(next.ID, ShouldEqual, "bundle-1")
+
+			wait := s.nextWait(ctx)
+			So(wait, ShouldBeGreaterThan, 0)
+			So(wait, ShouldBeLessThanOrEqualTo, 10*time.Second)
+			So(publisher.ListScheduledBundlesCalls()[0].Limit, ShouldEqual, 1)
+		})
+	})
+
+	Convey("Given the next scheduled bundle is due after the next poll", t, func() {
+		scheduledAt := time.Now().Add(time.Hour)
+		s := newTestScheduler(listReturning([]*models.Bundle{{ID: "bundle-1", ScheduledAt: &scheduledAt}}, nil))
+
+		Convey("Then the scheduler waits for the poll interval", func() {
+			So(s.nextWait(ctx), ShouldEqual, testPollInterval)
+		})
+	})
+
+	Convey("Given the next scheduled bundle is overdue, e.g. because it is claimed by another instance", t, func() {
+		scheduledAt := time.Now().Add(-time.Minute)
+		s := newTestScheduler(listReturning([]*models.Bundle{{ID: "bundle-1", ScheduledAt: &scheduledAt}}, nil))
+
+		Convey("Then the scheduler waits for the poll interval", func() {
+			So(s.nextWait(ctx), ShouldEqual, testPollInterval)
+		})
+	})
+
+	Convey("Given listing scheduled bundles fails", t, func() {
+		s := newTestScheduler(listReturning(nil, errors.New("list failed")))
+
+		Convey("Then the scheduler waits for the poll interval", func() {
+			So(s.nextWait(ctx), ShouldEqual, testPollInterval)
+		})
+	})
+}
+
+func TestStartAndStop(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a scheduler with a bundle due to be published", t, func() {
+		publishing := make(chan struct{})
+		release := make(chan struct{})
+
+		publisher := &mocks.BundlePublisherMock{
+			ClaimDueScheduledBundleFunc:     claimQueue(&models.Bundle{ID: "bundle-1"}),
+			ReleaseScheduledBundleClaimFunc: releaseClaim,
+			PublishScheduledBundleFunc: func(ctx context.Context, bundle *models.Bundle, authEntityData *models.AuthEntityData) (*models.Bundle, error) {
+				close(publishing)
+				<-release
+				return bundle, nil
+			},
+			ListScheduledBundlesFunc: func(ctx context.Context, offset, limit int) ([]*models.Bundle, int, error) {
+				return []*models.Bundle{}, 0, nil
+			},
+		}
+		s := newTestScheduler(publisher)
+
+		Convey("When the scheduler is started", func() {
+			s.Start(ctx)
+			<-publishing
+
+			Convey("Then Stop waits for the in-flight publish to complete", func() {
+				stopCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+				defer cancel()
+				So(s.Stop(stopCtx), ShouldEqual, context.DeadlineExceeded)
+
+				close(release)
+				So(s.Stop(ctx), ShouldBeNil)
+				So(publisher.PublishScheduledBundleCalls(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
@@ -22,12 +22,30 @@ type BundlesList struct {
 	Offset     int             `json:"offset"`
 	Limit      int             `json:"limit"`
 	TotalCount int             `json:"total_count"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 }
 
 // QueryParams represents the possible query parameters that a caller can provide
 type QueryParams struct {
 	Limit  int
 	Offset int
+
+	// Cursor is the NextCursor from a previous page, to get the page after it. It cannot be used with Offset.
+	Cursor string
+
+	// Filters. Bundles are only returned if they match every filter that is set.
+	States          []models.BundleState
+	BundleType      models.BundleType
+	ManagedBy       models.ManagedBy
+	CreatedBy       string
+	PreviewTeam     string
+	Title           string
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	UpdatedAfter    *time.Time
+	UpdatedBefore   *time.Time
+	ScheduledAfter  *time.Time
+	ScheduledBefore *time.Time
 }
 
 // Validate validates tht no negative values are provided for limit or offset, and that the length of
@@ -36,15 +54,50 @@ func (q *QueryParams) Validate() error {
 	if q.Limit < 0 || q.Offset < 0 {
 		return errors.New("negative offsets or limits are not allowed")
 	}
+	if q.Cursor != "" && q.Offset != 0 {
+		return errors.New("an offset cannot be used with a cursor")
+	}
 	return nil
 }
 
+// addFilters adds the filters that are set to the query
+func (q *QueryParams) addFilters(query url.Values) {
+	for _, state := range q.States {
+		query.Add("state", state.String())
+	}
+
+	addIfSet := func(name, value string) {
+		if value != "" {
+			query.Add(name, value)
+		}
+	}
+	addIfSet("bundle_type", q.BundleType.String())
+	addIfSet("managed_by", q.ManagedBy.String())
+	addIfSet("created_by", q.CreatedBy)
+	addIfSet("preview_team", q.PreviewTeam)
+	addIfSet("title", q.Title)
+
+	addTimeIfSet := func(name string, value *time.Time) {
+		if value != nil {
+			query.Add(name, value.Format(time.RFC3339))
+		}
+	}
+	addTimeIfSet("created_after", q.CreatedAfter)
+	addTimeIfSet("created_before", q.CreatedBefore)
+	addTimeIfSet("updated_after", q.UpdatedAfter)
+	addTimeIfSet("updated_before", q.UpdatedBefore)
+	addTimeIfSet("scheduled_after", q.ScheduledAfter)
+	addTimeIfSet("scheduled_before", q.ScheduledBefore)
+}
+
 // GetBundles gets a list of bundles
 func (cli *Client) GetBundles(ctx context.Context, headers Headers, scheduledAt *time.Time, queryParams *QueryParams) (*BundlesList, apiError.Error) {
 	var bundlesList BundlesList
 	path := fmt.Sprintf("%s/bundles", cli.hcCli.URL)
-	if !scheduledAt.IsZero() {
-		path += "?publish_date=" + scheduledAt.Format(time.RFC3339)
+
+	query := url.Values{}
+	if scheduledAt != nil && !scheduledAt.IsZero() {
+		query.Add("publish_date", scheduledAt.Format(time.RFC3339))
 	}
 
 	// Add query parameters to request if valid
@@ -57,11 +110,17 @@ func (cli *Client) GetBundles(ctx context.Context, headers Headers, scheduledAt
 		}
 
 		// Add query parameters
-		query := url.Values{}
 		query.Add("limit", strconv.Itoa(queryParams.Limit))
-		query.Add("offset", strconv.Itoa(queryParams.Offset))
+		if queryParams.Cursor != "" {
+			query.Add("cursor", queryParams.Cursor)
+		} else {
+			query.Add("offset", strconv.Itoa(queryParams.Offset))
+		}
+		queryParams.addFilters(query)
+	}
 
-		path += query.Encode()
+	if len(query) > 0 {
+		path += "?" + query.Encode()
 	}
 
 	respInfo, apiErr := cli.callBundleAPI(ctx, path, http.MethodGet, headers, nil)
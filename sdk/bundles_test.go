@@ -141,6 +141,63 @@ func TestGetBundles(t *testing.T) {
 		})
 	})
 
+	Convey("Given bundle API returns successfully for a filtered request", t, func() {
+		body, err := json.Marshal(testPaginationBundles)
+		if err != nil {
+			t.Errorf("failed to setup test data, error: %v", err)
+		}
+
+		httpClient := newMockHTTPClient(
+			&http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			},
+			nil)
+
+		bundleAPIClient := newBundleAPIClient(t, httpClient)
+
+		Convey("When GetBundles is called with filters", func() {
+			createdAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			queryParams := &QueryParams{
+				Limit:        10,
+				States:       []models.BundleState{models.BundleStateDraft, models.BundleStateInReview},
+				BundleType:   models.BundleTypeScheduled,
+				Title:        "CPI",
+				CreatedAfter: &createdAfter,
+			}
+			_, err := bundleAPIClient.GetBundles(ctx, Headers{}, nil, queryParams)
+
+			Convey("Then the filters are sent as query parameters", func() {
+				So(err, ShouldBeNil)
+
+				doCalls := httpClient.DoCalls()
+				So(doCalls, ShouldHaveLength, 1)
+				So(doCalls[0].Req.URL.Path, ShouldEqual, "/bundles")
+
+				query := doCalls[0].Req.URL.Query()
+				So(query["state"], ShouldResemble, []string{"DRAFT", "IN_REVIEW"})
+				So(query.Get("bundle_type"), ShouldEqual, "SCHEDULED")
+				So(query.Get("title"), ShouldEqual, "CPI")
+				So(query.Get("created_after"), ShouldEqual, "2025-01-01T00:00:00Z")
+				So(query.Get("limit"), ShouldEqual, "10")
+				So(query.Has("managed_by"), ShouldBeFalse)
+				So(query.Has("publish_date"), ShouldBeFalse)
+			})
+		})
+
+		Convey("When GetBundles is called with a cursor", func() {
+			_, err := bundleAPIClient.GetBundles(ctx, Headers{}, nil, &QueryParams{Limit: 10, Cursor: "abc"})
+
+			Convey("Then the cursor is sent instead of an offset", func() {
+				So(err, ShouldBeNil)
+
+				query := httpClient.DoCalls()[0].Req.URL.Query()
+				So(query.Get("cursor"), ShouldEqual, "abc")
+				So(query.Has("offset"), ShouldBeFalse)
+			})
+		})
+	})
+
 	Convey("When GetBundles is called with no response body returned", t, func() {
 		httpClient := newMockHTTPClient(
 			&http.Response{
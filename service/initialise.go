@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/datasetapi"
 	"github.com/ONSdigital/dis-bundle-api/mongo"
 	"github.com/ONSdigital/dis-bundle-api/slack"
 	"github.com/ONSdigital/dis-bundle-api/store"
@@ -68,7 +69,7 @@ func (e *ExternalServiceList) GetDatasetAPIClient(datasetAPIURL string) datasetA
 
 // DoGetDatasetAPIClient returns a new Dataset API client with the provided datasetAPIURL
 func (e *Init) DoGetDatasetAPIClient(datasetAPIURL string) datasetAPISDK.Clienter {
-	client := datasetAPISDK.New(datasetAPIURL)
+	client := datasetapi.NewClient(datasetAPIURL)
 	return client
 }
 
@@ -3,11 +3,14 @@ package service
 import (
 	"context"
 	"net/http"
-	"sync"
 
 	"github.com/ONSdigital/dis-bundle-api/api"
 	"github.com/ONSdigital/dis-bundle-api/application"
+	"github.com/ONSdigital/dis-bundle-api/calendar"
 	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/datasetapi"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/scheduler"
 	"github.com/ONSdigital/dis-bundle-api/slack"
 	"github.com/ONSdigital/dis-bundle-api/store"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
@@ -19,6 +22,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/justinas/alice"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
 )
 
 // Service contains all the configs, server and clients to run the API
@@ -36,51 +40,23 @@ type Service struct {
 	stateMachineBundleAPI *application.StateMachineBundleAPI
 	AuthMiddleware        auth.Middleware
 	ZebedeeClient         *health.Client
+	PublishScheduler      *scheduler.Scheduler
+	PublishRunResumer     *scheduler.Resumer
 }
 
 type BundleAPIStore struct {
 	store.MongoDB
 }
 
-var stateMachine *application.StateMachine
-var stateMachineInit sync.Once
-
-func GetListTransitions() []application.Transition {
-	draftTransition := application.Transition{
-		Label:               "DRAFT",
-		TargetState:         application.Draft,
-		AllowedSourceStates: []string{"DRAFT", "IN_REVIEW", "APPROVED"},
-	}
-
-	inReviewTransition := application.Transition{
-		Label:               "IN_REVIEW",
-		TargetState:         application.InReview,
-		AllowedSourceStates: []string{"DRAFT", "APPROVED", "IN_REVIEW"},
-	}
-
-	approvedTransition := application.Transition{
-		Label:               "APPROVED",
-		TargetState:         application.Approved,
-		AllowedSourceStates: []string{"IN_REVIEW"},
-	}
-
-	publishedTransition := application.Transition{
-		Label:               "PUBLISHED",
-		TargetState:         application.Published,
-		AllowedSourceStates: []string{"APPROVED"},
+// GetStateMachine loads the workflow definition from path, or the default workflow definition if path is empty, and
+// returns a state machine for it
+func GetStateMachine(ctx context.Context, path string, datastore store.Datastore, datasetAPIClienter datasetAPISDK.Clienter) (*application.StateMachine, error) {
+	definition, err := application.LoadWorkflowDefinition(path)
+	if err != nil {
+		return nil, err
 	}
 
-	return []application.Transition{draftTransition, inReviewTransition, approvedTransition, publishedTransition}
-}
-
-func GetStateMachine(ctx context.Context, datastore store.Datastore, datasetAPIClienter datasetAPISDK.Clienter) *application.StateMachine {
-	stateMachineInit.Do(func() {
-		states := []application.State{application.Draft, application.InReview, application.Approved, application.Published}
-		transitions := GetListTransitions()
-		stateMachine = application.NewStateMachine(ctx, states, transitions, datastore, datasetAPIClienter)
-	})
-
-	return stateMachine
+	return application.NewStateMachineFromDefinition(ctx, definition, application.DefaultRegistry(), datastore, datasetAPIClienter)
 }
 
 // New creates a new service
@@ -164,15 +140,66 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 	// Get Datastore
 	datastore := store.Datastore{Backend: BundleAPIStore{svc.mongoDB}}
 
+	// Retry transient dataset API failures when publishing and approving bundles
+	datasetAPIRetryClient, err := datasetapi.NewRetryClient(svc.datasetAPIClient, datasetapi.RetryConfig{
+		MaxAttempts:    cfg.DatasetAPIRetryMaxAttempts,
+		InitialBackoff: cfg.DatasetAPIRetryInitialBackoff,
+		MaxBackoff:     cfg.DatasetAPIRetryMaxBackoff,
+	}, otel.Meter("github.com/ONSdigital/dis-bundle-api/datasetapi"))
+	if err != nil {
+		log.Fatal(ctx, "could not instantiate dataset API retry client", err)
+		return err
+	}
+
 	// Setup state machine
-	sm := GetStateMachine(ctx, datastore, svc.datasetAPIClient)
-	svc.stateMachineBundleAPI = application.Setup(datastore, sm, svc.datasetAPIClient, svc.permissionsAPIClient, svc.dataBundleSlackClient, cfg.PreviewServiceURL)
+	sm, err := GetStateMachine(ctx, cfg.StateMachineDefinitionPath, datastore, svc.datasetAPIClient)
+	if err != nil {
+		log.Fatal(ctx, "could not load state machine definition", err, log.Data{"path": cfg.StateMachineDefinitionPath})
+		return err
+	}
+	approvalPolicy := application.ApprovalPolicy{
+		RequireDifferentCreator: cfg.ApprovalRequireDifferentCreator,
+		RequireDifferentEditors: cfg.ApprovalRequireDifferentEditors,
+		ExemptServiceIdentities: cfg.ApprovalExemptServiceIdentities,
+		Quorum:                  cfg.ApprovalQuorum,
+		QuorumByBundleType:      cfg.ApprovalQuorumByBundleType,
+		QuorumByManagedBy:       cfg.ApprovalQuorumByManagedBy,
+		BlockOnConflicts:        cfg.ApprovalBlockOnConflicts,
+	}
+	releaseCalendar, err := calendar.New(calendar.Config{
+		Enforced:      cfg.ReleaseCalendarEnforced,
+		TimeZone:      cfg.ReleaseCalendarTimeZone,
+		Slots:         cfg.ReleaseCalendarSlots,
+		Weekdays:      cfg.ReleaseCalendarWeekdays,
+		BlackoutDates: cfg.ReleaseCalendarBlackoutDates,
+	})
+	if err != nil {
+		log.Fatal(ctx, "could not load release calendar", err)
+		return err
+	}
+	svc.stateMachineBundleAPI = application.Setup(datastore, sm, svc.datasetAPIClient, svc.permissionsAPIClient, svc.dataBundleSlackClient, cfg.PreviewServiceURL, cfg.PublishMaxConcurrency, approvalPolicy, releaseCalendar)
+	svc.stateMachineBundleAPI.PublishDatasetAPIClient = datasetAPIRetryClient
+	svc.stateMachineBundleAPI.PublishRunHeartbeatInterval = cfg.PublishRunStaleTimeout / 3
 
 	// Setup API
 	svc.API = api.Setup(ctx, svc.Config, r, &datastore, svc.stateMachineBundleAPI, authorisation, svc.ZebedeeClient.Client)
 
 	svc.HealthCheck.Start(ctx)
 
+	// Start resuming interrupted publish runs, which are left by bundles published on request as well as by the scheduler
+	svc.PublishRunResumer = scheduler.NewResumer(svc.stateMachineBundleAPI, models.CreateServiceAuthEntityData(cfg.ServiceAuthToken), cfg.PublishRunStaleTimeout)
+	svc.PublishRunResumer.Start(ctx)
+
+	// Start the scheduler that publishes approved scheduled bundles
+	if cfg.SchedulerEnabled {
+		svc.PublishScheduler, err = scheduler.New(svc.stateMachineBundleAPI, models.CreateServiceAuthEntityData(cfg.ServiceAuthToken), cfg.SchedulerPollInterval, cfg.SchedulerLockDuration)
+		if err != nil {
+			log.Fatal(ctx, "could not instantiate publish scheduler", err)
+			return err
+		}
+		svc.PublishScheduler.Start(ctx)
+	}
+
 	// Run the http server in a new go-routine
 	go func() {
 		if err := svc.Server.ListenAndServe(); err != nil {
@@ -227,6 +254,22 @@ func (svc *Service) Close(ctx context.Context) error {
 			hasShutdownError = true
 		}
 
+		// stop the publish scheduler, allowing any in-flight publish to complete
+		if svc.PublishScheduler != nil {
+			if err := svc.PublishScheduler.Stop(shutdownContext); err != nil {
+				log.Error(shutdownContext, "failed to stop publish scheduler", err)
+				hasShutdownError = true
+			}
+		}
+
+		// stop resuming publish runs, allowing any in-flight resume to complete
+		if svc.PublishRunResumer != nil {
+			if err := svc.PublishRunResumer.Stop(shutdownContext); err != nil {
+				log.Error(shutdownContext, "failed to stop publish run resumer", err)
+				hasShutdownError = true
+			}
+		}
+
 		// Close MongoDB (if it exists)
 		if svc.ServiceList.MongoDB {
 			if err := svc.mongoDB.Close(shutdownContext); err != nil {
@@ -6,8 +6,12 @@ import (
 	"net/http"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ONSdigital/dis-bundle-api/config"
+	"github.com/ONSdigital/dis-bundle-api/models"
+	"github.com/ONSdigital/dis-bundle-api/scheduler"
+	schedulerMock "github.com/ONSdigital/dis-bundle-api/scheduler/mocks"
 	"github.com/ONSdigital/dis-bundle-api/service"
 	serviceMock "github.com/ONSdigital/dis-bundle-api/service/mock"
 	"github.com/ONSdigital/dis-bundle-api/slack"
@@ -97,7 +101,11 @@ func TestRun(t *testing.T) {
 		}
 
 		funcDoGetMongoDBOk := func(context.Context, config.MongoConfig) (store.MongoDB, error) {
-			return &storeMock.MongoDBMock{}, nil
+			return &storeMock.MongoDBMock{
+				ClaimStalePublishRunFunc: func(ctx context.Context, staleBefore, now time.Time) (*models.PublishRun, error) {
+					return nil, nil
+				},
+			}, nil
 		}
 
 		funcDoGetDatasetAPIClientOk := func(datasetAPIURL string) datasetAPISDK.Clienter {
@@ -260,6 +268,92 @@ func TestRun(t *testing.T) {
 			})
 		})
 
+		Convey("Given that all dependencies are successfully initialised and the scheduler is disabled", func() {
+			claimed := make(chan struct{})
+			claimOnce := sync.Once{}
+			mongoMock := &storeMock.MongoDBMock{
+				ClaimStalePublishRunFunc: func(ctx context.Context, staleBefore, now time.Time) (*models.PublishRun, error) {
+					claimOnce.Do(func() { close(claimed) })
+					return nil, nil
+				},
+			}
+
+			initMock := &serviceMock.InitialiserMock{
+				DoGetMongoDBFunc: func(context.Context, config.MongoConfig) (store.MongoDB, error) {
+					return mongoMock, nil
+				},
+				DoGetDatasetAPIClientFunc:        funcDoGetDatasetAPIClientOk,
+				DoGetPermissionsAPIClientFunc:    funcDoGetPermissionsAPIClientOk,
+				DoGetDataBundleSlackClientFunc:   funcDoGetDataBundleSlackClientOk,
+				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthMiddlewareOk,
+				DoGetHealthCheckFunc:             funcDoGetHealthcheckOk,
+				DoGetHTTPServerFunc:              funcDoGetHTTPServerOk,
+			}
+
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc := service.New(cfg, svcList)
+			serverWg.Add(1)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run succeeds and interrupted publish runs are resumed without the publish scheduler", func() {
+				So(err, ShouldBeNil)
+				So(svc.PublishScheduler, ShouldBeNil)
+				So(svc.PublishRunResumer, ShouldNotBeNil)
+				<-claimed
+				So(svc.PublishRunResumer.Stop(ctx), ShouldBeNil)
+				So(mongoMock.ClaimStalePublishRunCalls()[0].Now.Sub(mongoMock.ClaimStalePublishRunCalls()[0].StaleBefore), ShouldEqual, cfg.PublishRunStaleTimeout)
+				serverWg.Wait()
+			})
+		})
+
+		Convey("Given that all dependencies are successfully initialised and the scheduler is enabled", func() {
+			claimed := make(chan struct{})
+			claimOnce := sync.Once{}
+			mongoMock := &storeMock.MongoDBMock{
+				ClaimDueScheduledBundleFunc: func(ctx context.Context, now time.Time, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+					claimOnce.Do(func() { close(claimed) })
+					return nil, nil
+				},
+				ListScheduledBundlesFunc: func(ctx context.Context, offset, limit int) ([]*models.Bundle, int, error) {
+					return []*models.Bundle{}, 0, nil
+				},
+				ClaimStalePublishRunFunc: func(ctx context.Context, staleBefore, now time.Time) (*models.PublishRun, error) {
+					return nil, nil
+				},
+			}
+
+			initMock := &serviceMock.InitialiserMock{
+				DoGetMongoDBFunc: func(context.Context, config.MongoConfig) (store.MongoDB, error) {
+					return mongoMock, nil
+				},
+				DoGetDatasetAPIClientFunc:        funcDoGetDatasetAPIClientOk,
+				DoGetPermissionsAPIClientFunc:    funcDoGetPermissionsAPIClientOk,
+				DoGetDataBundleSlackClientFunc:   funcDoGetDataBundleSlackClientOk,
+				DoGetAuthorisationMiddlewareFunc: funcDoGetAuthMiddlewareOk,
+				DoGetHealthCheckFunc:             funcDoGetHealthcheckOk,
+				DoGetHTTPServerFunc:              funcDoGetHTTPServerOk,
+			}
+
+			schedulerCfg := *cfg
+			schedulerCfg.SchedulerEnabled = true
+
+			svcErrors := make(chan error, 1)
+			svcList := service.NewServiceList(initMock)
+			svc := service.New(&schedulerCfg, svcList)
+			serverWg.Add(1)
+			err := svc.Run(ctx, testBuildTime, testGitCommit, testVersion, svcErrors)
+
+			Convey("Then service Run succeeds and the publish scheduler is started", func() {
+				So(err, ShouldBeNil)
+				So(svc.PublishScheduler, ShouldNotBeNil)
+				<-claimed
+				So(svc.PublishScheduler.Stop(ctx), ShouldBeNil)
+				So(mongoMock.ClaimDueScheduledBundleCalls()[0].Owner, ShouldEqual, svc.PublishScheduler.Owner())
+				serverWg.Wait()
+			})
+		})
+
 		Convey("Given that Checkers cannot be registered", func() {
 			errAddCheckFail := errors.New("Error(s) registering checkers for healthcheck")
 			hcMockAddFail := &serviceMock.HealthCheckerMock{
@@ -393,6 +487,57 @@ func TestClose(t *testing.T) {
 			So(len(mongoMock.CloseCalls()), ShouldEqual, 1)
 		})
 
+		Convey("Closing the service stops the publish scheduler and publish run resumer before closing MongoDB", func() {
+			schedulerStopped := false
+			resumerStopped := false
+			publisher := &schedulerMock.BundlePublisherMock{
+				ClaimDueScheduledBundleFunc: func(ctx context.Context, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+					return nil, nil
+				},
+				ListScheduledBundlesFunc: func(ctx context.Context, offset, limit int) ([]*models.Bundle, int, error) {
+					return []*models.Bundle{}, 0, nil
+				},
+			}
+			publishScheduler, err := scheduler.New(publisher, models.CreateServiceAuthEntityData(""), time.Minute, time.Minute)
+			So(err, ShouldBeNil)
+			publishScheduler.Start(ctx)
+
+			publishRunResumer := scheduler.NewResumer(&schedulerMock.PublishRunResumerMock{
+				ClaimStalePublishRunFunc: func(ctx context.Context, staleTimeout time.Duration) (*models.PublishRun, error) {
+					return nil, nil
+				},
+			}, models.CreateServiceAuthEntityData(""), time.Minute)
+			publishRunResumer.Start(ctx)
+
+			orderedMongoMock := &storeMock.MongoDBMock{
+				CloseFunc: func(ctx context.Context) error {
+					select {
+					case <-publishScheduler.Done():
+						schedulerStopped = true
+					default:
+					}
+					select {
+					case <-publishRunResumer.Done():
+						resumerStopped = true
+					default:
+					}
+					return funcClose(ctx)
+				},
+			}
+
+			svc := service.New(cfg, fullSvcList)
+			svc.SetServer(serverMock)
+			svc.SetHealthCheck(hcMock)
+			svc.SetMongoDB(orderedMongoMock)
+			svc.PublishScheduler = publishScheduler
+			svc.PublishRunResumer = publishRunResumer
+			err = svc.Close(context.Background())
+			So(err, ShouldBeNil)
+			So(schedulerStopped, ShouldBeTrue)
+			So(resumerStopped, ShouldBeTrue)
+			So(len(orderedMongoMock.CloseCalls()), ShouldEqual, 1)
+		})
+
 		Convey("If services fail to stop, the Close operation tries to close all dependencies and returns an error", func() {
 			failingserverMock := &serviceMock.HTTPServerMock{
 				ListenAndServeFunc: func() error { return nil },
@@ -20,9 +20,10 @@ type Datastore struct {
 
 type dataMongoDB interface {
 	// Bundles
-	ListBundles(ctx context.Context, offset, limit int, filters *filters.BundleFilters) (bundles []*models.Bundle, totalCount int, err error)
-	ListBundleEvents(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time) ([]*models.Event, int, error)
+	ListBundles(ctx context.Context, offset, limit int, filters *filters.BundleFilters) (bundles []*models.Bundle, totalCount int, nextCursor string, err error)
+	ListBundleEvents(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error)
 	GetBundle(ctx context.Context, bundleID string) (*models.Bundle, error)
+	GetBundleView(ctx context.Context, bundleID string, view *filters.BundleView) (*models.Bundle, error)
 	CreateBundle(ctx context.Context, bundle *models.Bundle) error
 	DeleteBundle(ctx context.Context, id string) (err error)
 	CheckBundleExistsByTitle(ctx context.Context, title string) (bool, error)
@@ -30,26 +31,58 @@ type dataMongoDB interface {
 	CheckBundleExists(ctx context.Context, bundleID string) (bool, error)
 	UpdateBundle(ctx context.Context, id string, update *models.Bundle) (*models.Bundle, error)
 	GetBundlesByPreviewTeamID(ctx context.Context, teamID string) ([]*models.Bundle, error)
+	ListScheduledBundles(ctx context.Context, offset, limit int) (bundles []*models.Bundle, totalCount int, err error)
+	ListBundlesScheduledBetween(ctx context.Context, from, to time.Time) (bundles []*models.Bundle, err error)
+	ListBundlesByIDs(ctx context.Context, bundleIDs []string) (bundles []*models.Bundle, err error)
+	ListContents(ctx context.Context, offset, limit int, contentFilters *filters.ContentFilters) (contents []*models.ContentItem, totalCount int, nextCursor string, err error)
+	ListContentItemsByDatasetIDs(ctx context.Context, datasetIDs []string, excludeBundleID string) (contentItems []*models.ContentItem, err error)
+	SearchBundles(ctx context.Context, text string, offset, limit int) (results []*models.BundleSearchResult, totalCount int, err error)
+	ClaimDueScheduledBundle(ctx context.Context, now time.Time, owner string, lockDuration time.Duration) (*models.Bundle, error)
+	RenewScheduledBundleClaim(ctx context.Context, bundleID, owner string, expiresAt time.Time) (bool, error)
+	ReleaseScheduledBundleClaim(ctx context.Context, bundleID, owner string) error
 
 	// Content items
 	CountBundleContents(ctx context.Context, bundleID string) (int, error)
-	ListBundleContents(ctx context.Context, bundleID string, offset, limit int) ([]*models.ContentItem, int, error)
+	ListBundleContents(ctx context.Context, bundleID string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error)
 	ListBundleContentIDsWithoutLimit(ctx context.Context, bundleID string) (contents []*models.ContentItem, err error)
 	GetContentItemByBundleIDAndContentItemID(ctx context.Context, bundleID, contentItemID string) (*models.ContentItem, error)
 	CreateContentItem(ctx context.Context, contentItem *models.ContentItem) error
+	CreateContentItems(ctx context.Context, contentItems []*models.ContentItem) error
 	CheckAllBundleContentsAreApproved(ctx context.Context, bundleID string) (bool, error)
 	CheckContentItemExistsByDatasetEditionVersion(ctx context.Context, datasetID, editionID string, versionID int) (bool, error)
 	DeleteContentItem(ctx context.Context, contentItemID string) error
+	DeleteContentItems(ctx context.Context, bundleID string, contentItemIDs []string) (int, error)
 	GetBundleContentsForBundle(ctx context.Context, bundleID string) (*[]models.ContentItem, error)
 	UpdateContentItemState(ctx context.Context, contentItemID, state string) error
 
 	// Events
 	CreateEvent(ctx context.Context, event *models.Event) error
+	ListBundleEditors(ctx context.Context, bundleID string) ([]string, error)
 	CheckBundleExistsByTitleUpdate(ctx context.Context, title, excludeID string) (bool, error)
 	GetContentItemsByBundleID(ctx context.Context, bundleID string) ([]*models.ContentItem, error)
 	UpdateContentItemDatasetInfo(ctx context.Context, contentItemID, title, state string) error
 	UpdateContentItemMetadataAndLinks(ctx context.Context, contentItemID, datasetID, editionID, editLink, previewLink string) error
 
+	// Publish runs
+	CreatePublishRun(ctx context.Context, publishRun *models.PublishRun) error
+	ListPublishRuns(ctx context.Context, bundleID string, offset, limit int) (publishRuns []*models.PublishRun, totalCount int, err error)
+	UpdatePublishRunItem(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error
+	CompletePublishRun(ctx context.Context, publishRunID string, state models.PublishRunState) error
+	RenewPublishRun(ctx context.Context, publishRunID string) error
+	ClaimStalePublishRun(ctx context.Context, staleBefore, now time.Time) (*models.PublishRun, error)
+
+	// Approvals
+	CreateApproval(ctx context.Context, approval *models.Approval) error
+	ListApprovals(ctx context.Context, bundleID string) ([]*models.Approval, error)
+	DeleteApproval(ctx context.Context, bundleID, approvedBy string) error
+	DeleteApprovals(ctx context.Context, bundleID string) (int, error)
+
+	// Comments
+	CreateComment(ctx context.Context, comment *models.Comment) error
+	GetComment(ctx context.Context, bundleID, commentID string) (*models.Comment, error)
+	ListComments(ctx context.Context, bundleID, contentItemID string, offset, limit int) (comments []*models.Comment, totalCount int, err error)
+	UpdateCommentResolution(ctx context.Context, commentID string, resolvedBy *models.User, resolvedAt *time.Time) error
+
 	// Other
 	Checker(ctx context.Context, state *healthcheck.CheckState) error
 	Close(ctx context.Context) error
@@ -67,17 +100,21 @@ type Storer interface {
 	dataMongoDB
 }
 
-func (ds *Datastore) ListBundles(ctx context.Context, offset, limit int, bundleFilters *filters.BundleFilters) ([]*models.Bundle, int, error) {
+func (ds *Datastore) ListBundles(ctx context.Context, offset, limit int, bundleFilters *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
 	return ds.Backend.ListBundles(ctx, offset, limit, bundleFilters)
 }
 
-func (ds *Datastore) ListBundleEvents(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time) ([]*models.Event, int, error) {
-	return ds.Backend.ListBundleEvents(ctx, offset, limit, bundleID, after, before)
+func (ds *Datastore) ListBundleEvents(ctx context.Context, offset, limit int, bundleID string, after, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
+	return ds.Backend.ListBundleEvents(ctx, offset, limit, bundleID, after, before, sortFields, cursor)
 }
 func (ds *Datastore) GetBundle(ctx context.Context, bundleID string) (*models.Bundle, error) {
 	return ds.Backend.GetBundle(ctx, bundleID)
 }
 
+func (ds *Datastore) GetBundleView(ctx context.Context, bundleID string, view *filters.BundleView) (*models.Bundle, error) {
+	return ds.Backend.GetBundleView(ctx, bundleID, view)
+}
+
 func (ds *Datastore) CreateBundle(ctx context.Context, bundle *models.Bundle) error {
 	return ds.Backend.CreateBundle(ctx, bundle)
 }
@@ -106,6 +143,10 @@ func (ds *Datastore) CreateContentItem(ctx context.Context, contentItem *models.
 	return ds.Backend.CreateContentItem(ctx, contentItem)
 }
 
+func (ds *Datastore) CreateContentItems(ctx context.Context, contentItems []*models.ContentItem) error {
+	return ds.Backend.CreateContentItems(ctx, contentItems)
+}
+
 func (ds *Datastore) CheckAllBundleContentsAreApproved(ctx context.Context, bundleID string) (bool, error) {
 	return ds.Backend.CheckAllBundleContentsAreApproved(ctx, bundleID)
 }
@@ -118,10 +159,18 @@ func (ds *Datastore) DeleteContentItem(ctx context.Context, contentItemID string
 	return ds.Backend.DeleteContentItem(ctx, contentItemID)
 }
 
+func (ds *Datastore) DeleteContentItems(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+	return ds.Backend.DeleteContentItems(ctx, bundleID, contentItemIDs)
+}
+
 func (ds *Datastore) CreateEvent(ctx context.Context, event *models.Event) error {
 	return ds.Backend.CreateEvent(ctx, event)
 }
 
+func (ds *Datastore) ListBundleEditors(ctx context.Context, bundleID string) ([]string, error) {
+	return ds.Backend.ListBundleEditors(ctx, bundleID)
+}
+
 func (ds *Datastore) CheckBundleExistsByTitleUpdate(ctx context.Context, title, excludeID string) (bool, error) {
 	return ds.Backend.CheckBundleExistsByTitleUpdate(ctx, title, excludeID)
 }
@@ -145,8 +194,8 @@ func (ds *Datastore) CheckBundleExistsByTitle(ctx context.Context, title string)
 	return ds.Backend.CheckBundleExistsByTitle(ctx, title)
 }
 
-func (ds *Datastore) ListBundleContents(ctx context.Context, bundleID string, offset, limit int) ([]*models.ContentItem, int, error) {
-	return ds.Backend.ListBundleContents(ctx, bundleID, offset, limit)
+func (ds *Datastore) ListBundleContents(ctx context.Context, bundleID string, offset, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
+	return ds.Backend.ListBundleContents(ctx, bundleID, offset, limit, sortFields, cursor)
 }
 
 func (ds *Datastore) ListBundleContentIDsWithoutLimit(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
@@ -160,3 +209,95 @@ func (ds *Datastore) GetBundlesByPreviewTeamID(ctx context.Context, teamID strin
 func (ds *Datastore) UpdateContentItemMetadataAndLinks(ctx context.Context, contentItemID, datasetID, editionID, editLink, previewLink string) error {
 	return ds.Backend.UpdateContentItemMetadataAndLinks(ctx, contentItemID, datasetID, editionID, editLink, previewLink)
 }
+
+func (ds *Datastore) ListScheduledBundles(ctx context.Context, offset, limit int) ([]*models.Bundle, int, error) {
+	return ds.Backend.ListScheduledBundles(ctx, offset, limit)
+}
+
+func (ds *Datastore) ListBundlesScheduledBetween(ctx context.Context, from, to time.Time) ([]*models.Bundle, error) {
+	return ds.Backend.ListBundlesScheduledBetween(ctx, from, to)
+}
+
+func (ds *Datastore) ListBundlesByIDs(ctx context.Context, bundleIDs []string) ([]*models.Bundle, error) {
+	return ds.Backend.ListBundlesByIDs(ctx, bundleIDs)
+}
+
+func (ds *Datastore) ListContents(ctx context.Context, offset, limit int, contentFilters *filters.ContentFilters) ([]*models.ContentItem, int, string, error) {
+	return ds.Backend.ListContents(ctx, offset, limit, contentFilters)
+}
+
+func (ds *Datastore) ListContentItemsByDatasetIDs(ctx context.Context, datasetIDs []string, excludeBundleID string) ([]*models.ContentItem, error) {
+	return ds.Backend.ListContentItemsByDatasetIDs(ctx, datasetIDs, excludeBundleID)
+}
+
+func (ds *Datastore) SearchBundles(ctx context.Context, text string, offset, limit int) ([]*models.BundleSearchResult, int, error) {
+	return ds.Backend.SearchBundles(ctx, text, offset, limit)
+}
+
+func (ds *Datastore) ClaimDueScheduledBundle(ctx context.Context, now time.Time, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+	return ds.Backend.ClaimDueScheduledBundle(ctx, now, owner, lockDuration)
+}
+
+func (ds *Datastore) RenewScheduledBundleClaim(ctx context.Context, bundleID, owner string, expiresAt time.Time) (bool, error) {
+	return ds.Backend.RenewScheduledBundleClaim(ctx, bundleID, owner, expiresAt)
+}
+
+func (ds *Datastore) ReleaseScheduledBundleClaim(ctx context.Context, bundleID, owner string) error {
+	return ds.Backend.ReleaseScheduledBundleClaim(ctx, bundleID, owner)
+}
+
+func (ds *Datastore) CreatePublishRun(ctx context.Context, publishRun *models.PublishRun) error {
+	return ds.Backend.CreatePublishRun(ctx, publishRun)
+}
+
+func (ds *Datastore) ListPublishRuns(ctx context.Context, bundleID string, offset, limit int) ([]*models.PublishRun, int, error) {
+	return ds.Backend.ListPublishRuns(ctx, bundleID, offset, limit)
+}
+
+func (ds *Datastore) UpdatePublishRunItem(ctx context.Context, publishRunID, contentItemID string, state models.PublishRunItemState, lastError string) error {
+	return ds.Backend.UpdatePublishRunItem(ctx, publishRunID, contentItemID, state, lastError)
+}
+
+func (ds *Datastore) CompletePublishRun(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+	return ds.Backend.CompletePublishRun(ctx, publishRunID, state)
+}
+
+func (ds *Datastore) RenewPublishRun(ctx context.Context, publishRunID string) error {
+	return ds.Backend.RenewPublishRun(ctx, publishRunID)
+}
+
+func (ds *Datastore) ClaimStalePublishRun(ctx context.Context, staleBefore, now time.Time) (*models.PublishRun, error) {
+	return ds.Backend.ClaimStalePublishRun(ctx, staleBefore, now)
+}
+
+func (ds *Datastore) CreateApproval(ctx context.Context, approval *models.Approval) error {
+	return ds.Backend.CreateApproval(ctx, approval)
+}
+
+func (ds *Datastore) ListApprovals(ctx context.Context, bundleID string) ([]*models.Approval, error) {
+	return ds.Backend.ListApprovals(ctx, bundleID)
+}
+
+func (ds *Datastore) DeleteApproval(ctx context.Context, bundleID, approvedBy string) error {
+	return ds.Backend.DeleteApproval(ctx, bundleID, approvedBy)
+}
+
+func (ds *Datastore) DeleteApprovals(ctx context.Context, bundleID string) (int, error) {
+	return ds.Backend.DeleteApprovals(ctx, bundleID)
+}
+
+func (ds *Datastore) CreateComment(ctx context.Context, comment *models.Comment) error {
+	return ds.Backend.CreateComment(ctx, comment)
+}
+
+func (ds *Datastore) GetComment(ctx context.Context, bundleID, commentID string) (*models.Comment, error) {
+	return ds.Backend.GetComment(ctx, bundleID, commentID)
+}
+
+func (ds *Datastore) ListComments(ctx context.Context, bundleID, contentItemID string, offset, limit int) (comments []*models.Comment, totalCount int, err error) {
+	return ds.Backend.ListComments(ctx, bundleID, contentItemID, offset, limit)
+}
+
+func (ds *Datastore) UpdateCommentResolution(ctx context.Context, commentID string, resolvedBy *models.User, resolvedAt *time.Time) error {
+	return ds.Backend.UpdateCommentResolution(ctx, commentID, resolvedBy, resolvedAt)
+}
@@ -41,60 +41,138 @@ var _ store.Storer = &StorerMock{}
 //			CheckerFunc: func(ctx context.Context, state *healthcheck.CheckState) error {
 //				panic("mock out the Checker method")
 //			},
+//			ClaimDueScheduledBundleFunc: func(ctx context.Context, now time.Time, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+//				panic("mock out the ClaimDueScheduledBundle method")
+//			},
+//			ClaimStalePublishRunFunc: func(ctx context.Context, staleBefore time.Time, now time.Time) (*models.PublishRun, error) {
+//				panic("mock out the ClaimStalePublishRun method")
+//			},
 //			CloseFunc: func(ctx context.Context) error {
 //				panic("mock out the Close method")
 //			},
+//			CompletePublishRunFunc: func(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+//				panic("mock out the CompletePublishRun method")
+//			},
 //			CountBundleContentsFunc: func(ctx context.Context, bundleID string) (int, error) {
 //				panic("mock out the CountBundleContents method")
 //			},
+//			CreateApprovalFunc: func(ctx context.Context, approval *models.Approval) error {
+//				panic("mock out the CreateApproval method")
+//			},
 //			CreateBundleFunc: func(ctx context.Context, bundle *models.Bundle) error {
 //				panic("mock out the CreateBundle method")
 //			},
+//			CreateCommentFunc: func(ctx context.Context, comment *models.Comment) error {
+//				panic("mock out the CreateComment method")
+//			},
 //			CreateContentItemFunc: func(ctx context.Context, contentItem *models.ContentItem) error {
 //				panic("mock out the CreateContentItem method")
 //			},
+//			CreateContentItemsFunc: func(ctx context.Context, contentItems []*models.ContentItem) error {
+//				panic("mock out the CreateContentItems method")
+//			},
 //			CreateEventFunc: func(ctx context.Context, event *models.Event) error {
 //				panic("mock out the CreateEvent method")
 //			},
+//			CreatePublishRunFunc: func(ctx context.Context, publishRun *models.PublishRun) error {
+//				panic("mock out the CreatePublishRun method")
+//			},
+//			DeleteApprovalFunc: func(ctx context.Context, bundleID string, approvedBy string) error {
+//				panic("mock out the DeleteApproval method")
+//			},
+//			DeleteApprovalsFunc: func(ctx context.Context, bundleID string) (int, error) {
+//				panic("mock out the DeleteApprovals method")
+//			},
 //			DeleteBundleFunc: func(ctx context.Context, id string) error {
 //				panic("mock out the DeleteBundle method")
 //			},
 //			DeleteContentItemFunc: func(ctx context.Context, contentItemID string) error {
 //				panic("mock out the DeleteContentItem method")
 //			},
+//			DeleteContentItemsFunc: func(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+//				panic("mock out the DeleteContentItems method")
+//			},
 //			GetBundleFunc: func(ctx context.Context, bundleID string) (*models.Bundle, error) {
 //				panic("mock out the GetBundle method")
 //			},
 //			GetBundleContentsForBundleFunc: func(ctx context.Context, bundleID string) (*[]models.ContentItem, error) {
 //				panic("mock out the GetBundleContentsForBundle method")
 //			},
+//			GetBundleViewFunc: func(ctx context.Context, bundleID string, view *filters.BundleView) (*models.Bundle, error) {
+//				panic("mock out the GetBundleView method")
+//			},
 //			GetBundlesByPreviewTeamIDFunc: func(ctx context.Context, teamID string) ([]*models.Bundle, error) {
 //				panic("mock out the GetBundlesByPreviewTeamID method")
 //			},
+//			GetCommentFunc: func(ctx context.Context, bundleID string, commentID string) (*models.Comment, error) {
+//				panic("mock out the GetComment method")
+//			},
 //			GetContentItemByBundleIDAndContentItemIDFunc: func(ctx context.Context, bundleID string, contentItemID string) (*models.ContentItem, error) {
 //				panic("mock out the GetContentItemByBundleIDAndContentItemID method")
 //			},
 //			GetContentItemsByBundleIDFunc: func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
 //				panic("mock out the GetContentItemsByBundleID method")
 //			},
+//			ListApprovalsFunc: func(ctx context.Context, bundleID string) ([]*models.Approval, error) {
+//				panic("mock out the ListApprovals method")
+//			},
 //			ListBundleContentIDsWithoutLimitFunc: func(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
 //				panic("mock out the ListBundleContentIDsWithoutLimit method")
 //			},
-//			ListBundleContentsFunc: func(ctx context.Context, bundleID string, offset int, limit int) ([]*models.ContentItem, int, error) {
+//			ListBundleContentsFunc: func(ctx context.Context, bundleID string, offset int, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
 //				panic("mock out the ListBundleContents method")
 //			},
-//			ListBundleEventsFunc: func(ctx context.Context, offset int, limit int, bundleID string, after *time.Time, before *time.Time) ([]*models.Event, int, error) {
+//			ListBundleEditorsFunc: func(ctx context.Context, bundleID string) ([]string, error) {
+//				panic("mock out the ListBundleEditors method")
+//			},
+//			ListBundleEventsFunc: func(ctx context.Context, offset int, limit int, bundleID string, after *time.Time, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
 //				panic("mock out the ListBundleEvents method")
 //			},
-//			ListBundlesFunc: func(ctx context.Context, offset int, limit int, filtersMoqParam *filters.BundleFilters) ([]*models.Bundle, int, error) {
+//			ListBundlesFunc: func(ctx context.Context, offset int, limit int, filtersMoqParam *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
 //				panic("mock out the ListBundles method")
 //			},
+//			ListBundlesByIDsFunc: func(ctx context.Context, bundleIDs []string) ([]*models.Bundle, error) {
+//				panic("mock out the ListBundlesByIDs method")
+//			},
+//			ListBundlesScheduledBetweenFunc: func(ctx context.Context, from time.Time, to time.Time) ([]*models.Bundle, error) {
+//				panic("mock out the ListBundlesScheduledBetween method")
+//			},
+//			ListCommentsFunc: func(ctx context.Context, bundleID string, contentItemID string, offset int, limit int) ([]*models.Comment, int, error) {
+//				panic("mock out the ListComments method")
+//			},
+//			ListContentItemsByDatasetIDsFunc: func(ctx context.Context, datasetIDs []string, excludeBundleID string) ([]*models.ContentItem, error) {
+//				panic("mock out the ListContentItemsByDatasetIDs method")
+//			},
+//			ListContentsFunc: func(ctx context.Context, offset int, limit int, contentFilters *filters.ContentFilters) ([]*models.ContentItem, int, string, error) {
+//				panic("mock out the ListContents method")
+//			},
+//			ListPublishRunsFunc: func(ctx context.Context, bundleID string, offset int, limit int) ([]*models.PublishRun, int, error) {
+//				panic("mock out the ListPublishRuns method")
+//			},
+//			ListScheduledBundlesFunc: func(ctx context.Context, offset int, limit int) ([]*models.Bundle, int, error) {
+//				panic("mock out the ListScheduledBundles method")
+//			},
+//			ReleaseScheduledBundleClaimFunc: func(ctx context.Context, bundleID string, owner string) error {
+//				panic("mock out the ReleaseScheduledBundleClaim method")
+//			},
+//			RenewPublishRunFunc: func(ctx context.Context, publishRunID string) error {
+//				panic("mock out the RenewPublishRun method")
+//			},
+//			RenewScheduledBundleClaimFunc: func(ctx context.Context, bundleID string, owner string, expiresAt time.Time) (bool, error) {
+//				panic("mock out the RenewScheduledBundleClaim method")
+//			},
+//			SearchBundlesFunc: func(ctx context.Context, text string, offset int, limit int) ([]*models.BundleSearchResult, int, error) {
+//				panic("mock out the SearchBundles method")
+//			},
 //			UpdateBundleFunc: func(ctx context.Context, id string, update *models.Bundle) (*models.Bundle, error) {
 //				panic("mock out the UpdateBundle method")
 //			},
 //			UpdateBundleETagFunc: func(ctx context.Context, bundleID string, email string) (*models.Bundle, error) {
 //				panic("mock out the UpdateBundleETag method")
 //			},
+//			UpdateCommentResolutionFunc: func(ctx context.Context, commentID string, resolvedBy *models.User, resolvedAt *time.Time) error {
+//				panic("mock out the UpdateCommentResolution method")
+//			},
 //			UpdateContentItemDatasetInfoFunc: func(ctx context.Context, contentItemID string, title string, state string) error {
 //				panic("mock out the UpdateContentItemDatasetInfo method")
 //			},
@@ -104,6 +182,9 @@ var _ store.Storer = &StorerMock{}
 //			UpdateContentItemStateFunc: func(ctx context.Context, contentItemID string, state string) error {
 //				panic("mock out the UpdateContentItemState method")
 //			},
+//			UpdatePublishRunItemFunc: func(ctx context.Context, publishRunID string, contentItemID string, state models.PublishRunItemState, lastError string) error {
+//				panic("mock out the UpdatePublishRunItem method")
+//			},
 //		}
 //
 //		// use mockedStorer in code that requires store.Storer
@@ -129,53 +210,128 @@ type StorerMock struct {
 	// CheckerFunc mocks the Checker method.
 	CheckerFunc func(ctx context.Context, state *healthcheck.CheckState) error
 
+	// ClaimDueScheduledBundleFunc mocks the ClaimDueScheduledBundle method.
+	ClaimDueScheduledBundleFunc func(ctx context.Context, now time.Time, owner string, lockDuration time.Duration) (*models.Bundle, error)
+
+	// ClaimStalePublishRunFunc mocks the ClaimStalePublishRun method.
+	ClaimStalePublishRunFunc func(ctx context.Context, staleBefore time.Time, now time.Time) (*models.PublishRun, error)
+
 	// CloseFunc mocks the Close method.
 	CloseFunc func(ctx context.Context) error
 
+	// CompletePublishRunFunc mocks the CompletePublishRun method.
+	CompletePublishRunFunc func(ctx context.Context, publishRunID string, state models.PublishRunState) error
+
 	// CountBundleContentsFunc mocks the CountBundleContents method.
 	CountBundleContentsFunc func(ctx context.Context, bundleID string) (int, error)
 
+	// CreateApprovalFunc mocks the CreateApproval method.
+	CreateApprovalFunc func(ctx context.Context, approval *models.Approval) error
+
 	// CreateBundleFunc mocks the CreateBundle method.
 	CreateBundleFunc func(ctx context.Context, bundle *models.Bundle) error
 
+	// CreateCommentFunc mocks the CreateComment method.
+	CreateCommentFunc func(ctx context.Context, comment *models.Comment) error
+
 	// CreateContentItemFunc mocks the CreateContentItem method.
 	CreateContentItemFunc func(ctx context.Context, contentItem *models.ContentItem) error
 
+	// CreateContentItemsFunc mocks the CreateContentItems method.
+	CreateContentItemsFunc func(ctx context.Context, contentItems []*models.ContentItem) error
+
 	// CreateEventFunc mocks the CreateEvent method.
 	CreateEventFunc func(ctx context.Context, event *models.Event) error
 
+	// CreatePublishRunFunc mocks the CreatePublishRun method.
+	CreatePublishRunFunc func(ctx context.Context, publishRun *models.PublishRun) error
+
+	// DeleteApprovalFunc mocks the DeleteApproval method.
+	DeleteApprovalFunc func(ctx context.Context, bundleID string, approvedBy string) error
+
+	// DeleteApprovalsFunc mocks the DeleteApprovals method.
+	DeleteApprovalsFunc func(ctx context.Context, bundleID string) (int, error)
+
 	// DeleteBundleFunc mocks the DeleteBundle method.
 	DeleteBundleFunc func(ctx context.Context, id string) error
 
 	// DeleteContentItemFunc mocks the DeleteContentItem method.
 	DeleteContentItemFunc func(ctx context.Context, contentItemID string) error
 
+	// DeleteContentItemsFunc mocks the DeleteContentItems method.
+	DeleteContentItemsFunc func(ctx context.Context, bundleID string, contentItemIDs []string) (int, error)
+
 	// GetBundleFunc mocks the GetBundle method.
 	GetBundleFunc func(ctx context.Context, bundleID string) (*models.Bundle, error)
 
 	// GetBundleContentsForBundleFunc mocks the GetBundleContentsForBundle method.
 	GetBundleContentsForBundleFunc func(ctx context.Context, bundleID string) (*[]models.ContentItem, error)
 
+	// GetBundleViewFunc mocks the GetBundleView method.
+	GetBundleViewFunc func(ctx context.Context, bundleID string, view *filters.BundleView) (*models.Bundle, error)
+
 	// GetBundlesByPreviewTeamIDFunc mocks the GetBundlesByPreviewTeamID method.
 	GetBundlesByPreviewTeamIDFunc func(ctx context.Context, teamID string) ([]*models.Bundle, error)
 
+	// GetCommentFunc mocks the GetComment method.
+	GetCommentFunc func(ctx context.Context, bundleID string, commentID string) (*models.Comment, error)
+
 	// GetContentItemByBundleIDAndContentItemIDFunc mocks the GetContentItemByBundleIDAndContentItemID method.
 	GetContentItemByBundleIDAndContentItemIDFunc func(ctx context.Context, bundleID string, contentItemID string) (*models.ContentItem, error)
 
 	// GetContentItemsByBundleIDFunc mocks the GetContentItemsByBundleID method.
 	GetContentItemsByBundleIDFunc func(ctx context.Context, bundleID string) ([]*models.ContentItem, error)
 
+	// ListApprovalsFunc mocks the ListApprovals method.
+	ListApprovalsFunc func(ctx context.Context, bundleID string) ([]*models.Approval, error)
+
 	// ListBundleContentIDsWithoutLimitFunc mocks the ListBundleContentIDsWithoutLimit method.
 	ListBundleContentIDsWithoutLimitFunc func(ctx context.Context, bundleID string) ([]*models.ContentItem, error)
 
 	// ListBundleContentsFunc mocks the ListBundleContents method.
-	ListBundleContentsFunc func(ctx context.Context, bundleID string, offset int, limit int) ([]*models.ContentItem, int, error)
+	ListBundleContentsFunc func(ctx context.Context, bundleID string, offset int, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error)
+
+	// ListBundleEditorsFunc mocks the ListBundleEditors method.
+	ListBundleEditorsFunc func(ctx context.Context, bundleID string) ([]string, error)
 
 	// ListBundleEventsFunc mocks the ListBundleEvents method.
-	ListBundleEventsFunc func(ctx context.Context, offset int, limit int, bundleID string, after *time.Time, before *time.Time) ([]*models.Event, int, error)
+	ListBundleEventsFunc func(ctx context.Context, offset int, limit int, bundleID string, after *time.Time, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error)
 
 	// ListBundlesFunc mocks the ListBundles method.
-	ListBundlesFunc func(ctx context.Context, offset int, limit int, filtersMoqParam *filters.BundleFilters) ([]*models.Bundle, int, error)
+	ListBundlesFunc func(ctx context.Context, offset int, limit int, filtersMoqParam *filters.BundleFilters) ([]*models.Bundle, int, string, error)
+
+	// ListBundlesByIDsFunc mocks the ListBundlesByIDs method.
+	ListBundlesByIDsFunc func(ctx context.Context, bundleIDs []string) ([]*models.Bundle, error)
+
+	// ListBundlesScheduledBetweenFunc mocks the ListBundlesScheduledBetween method.
+	ListBundlesScheduledBetweenFunc func(ctx context.Context, from time.Time, to time.Time) ([]*models.Bundle, error)
+
+	// ListCommentsFunc mocks the ListComments method.
+	ListCommentsFunc func(ctx context.Context, bundleID string, contentItemID string, offset int, limit int) ([]*models.Comment, int, error)
+
+	// ListContentItemsByDatasetIDsFunc mocks the ListContentItemsByDatasetIDs method.
+	ListContentItemsByDatasetIDsFunc func(ctx context.Context, datasetIDs []string, excludeBundleID string) ([]*models.ContentItem, error)
+
+	// ListContentsFunc mocks the ListContents method.
+	ListContentsFunc func(ctx context.Context, offset int, limit int, contentFilters *filters.ContentFilters) ([]*models.ContentItem, int, string, error)
+
+	// ListPublishRunsFunc mocks the ListPublishRuns method.
+	ListPublishRunsFunc func(ctx context.Context, bundleID string, offset int, limit int) ([]*models.PublishRun, int, error)
+
+	// ListScheduledBundlesFunc mocks the ListScheduledBundles method.
+	ListScheduledBundlesFunc func(ctx context.Context, offset int, limit int) ([]*models.Bundle, int, error)
+
+	// ReleaseScheduledBundleClaimFunc mocks the ReleaseScheduledBundleClaim method.
+	ReleaseScheduledBundleClaimFunc func(ctx context.Context, bundleID string, owner string) error
+
+	// RenewPublishRunFunc mocks the RenewPublishRun method.
+	RenewPublishRunFunc func(ctx context.Context, publishRunID string) error
+
+	// RenewScheduledBundleClaimFunc mocks the RenewScheduledBundleClaim method.
+	RenewScheduledBundleClaimFunc func(ctx context.Context, bundleID string, owner string, expiresAt time.Time) (bool, error)
+
+	// SearchBundlesFunc mocks the SearchBundles method.
+	SearchBundlesFunc func(ctx context.Context, text string, offset int, limit int) ([]*models.BundleSearchResult, int, error)
 
 	// UpdateBundleFunc mocks the UpdateBundle method.
 	UpdateBundleFunc func(ctx context.Context, id string, update *models.Bundle) (*models.Bundle, error)
@@ -183,6 +339,9 @@ type StorerMock struct {
 	// UpdateBundleETagFunc mocks the UpdateBundleETag method.
 	UpdateBundleETagFunc func(ctx context.Context, bundleID string, email string) (*models.Bundle, error)
 
+	// UpdateCommentResolutionFunc mocks the UpdateCommentResolution method.
+	UpdateCommentResolutionFunc func(ctx context.Context, commentID string, resolvedBy *models.User, resolvedAt *time.Time) error
+
 	// UpdateContentItemDatasetInfoFunc mocks the UpdateContentItemDatasetInfo method.
 	UpdateContentItemDatasetInfoFunc func(ctx context.Context, contentItemID string, title string, state string) error
 
@@ -192,6 +351,9 @@ type StorerMock struct {
 	// UpdateContentItemStateFunc mocks the UpdateContentItemState method.
 	UpdateContentItemStateFunc func(ctx context.Context, contentItemID string, state string) error
 
+	// UpdatePublishRunItemFunc mocks the UpdatePublishRunItem method.
+	UpdatePublishRunItemFunc func(ctx context.Context, publishRunID string, contentItemID string, state models.PublishRunItemState, lastError string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CheckAllBundleContentsAreApproved holds details about calls to the CheckAllBundleContentsAreApproved method.
@@ -242,11 +404,40 @@ type StorerMock struct {
 			// State is the state argument value.
 			State *healthcheck.CheckState
 		}
+		// ClaimDueScheduledBundle holds details about calls to the ClaimDueScheduledBundle method.
+		ClaimDueScheduledBundle []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+			// Owner is the owner argument value.
+			Owner string
+			// LockDuration is the lockDuration argument value.
+			LockDuration time.Duration
+		}
+		// ClaimStalePublishRun holds details about calls to the ClaimStalePublishRun method.
+		ClaimStalePublishRun []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// StaleBefore is the staleBefore argument value.
+			StaleBefore time.Time
+			// Now is the now argument value.
+			Now time.Time
+		}
 		// Close holds details about calls to the Close method.
 		Close []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// CompletePublishRun holds details about calls to the CompletePublishRun method.
+		CompletePublishRun []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PublishRunID is the publishRunID argument value.
+			PublishRunID string
+			// State is the state argument value.
+			State models.PublishRunState
+		}
 		// CountBundleContents holds details about calls to the CountBundleContents method.
 		CountBundleContents []struct {
 			// Ctx is the ctx argument value.
@@ -254,6 +445,13 @@ type StorerMock struct {
 			// BundleID is the bundleID argument value.
 			BundleID string
 		}
+		// CreateApproval holds details about calls to the CreateApproval method.
+		CreateApproval []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Approval is the approval argument value.
+			Approval *models.Approval
+		}
 		// CreateBundle holds details about calls to the CreateBundle method.
 		CreateBundle []struct {
 			// Ctx is the ctx argument value.
@@ -261,6 +459,13 @@ type StorerMock struct {
 			// Bundle is the bundle argument value.
 			Bundle *models.Bundle
 		}
+		// CreateComment holds details about calls to the CreateComment method.
+		CreateComment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Comment is the comment argument value.
+			Comment *models.Comment
+		}
 		// CreateContentItem holds details about calls to the CreateContentItem method.
 		CreateContentItem []struct {
 			// Ctx is the ctx argument value.
@@ -268,6 +473,13 @@ type StorerMock struct {
 			// ContentItem is the contentItem argument value.
 			ContentItem *models.ContentItem
 		}
+		// CreateContentItems holds details about calls to the CreateContentItems method.
+		CreateContentItems []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ContentItems is the contentItems argument value.
+			ContentItems []*models.ContentItem
+		}
 		// CreateEvent holds details about calls to the CreateEvent method.
 		CreateEvent []struct {
 			// Ctx is the ctx argument value.
@@ -275,6 +487,29 @@ type StorerMock struct {
 			// Event is the event argument value.
 			Event *models.Event
 		}
+		// CreatePublishRun holds details about calls to the CreatePublishRun method.
+		CreatePublishRun []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PublishRun is the publishRun argument value.
+			PublishRun *models.PublishRun
+		}
+		// DeleteApproval holds details about calls to the DeleteApproval method.
+		DeleteApproval []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+			// ApprovedBy is the approvedBy argument value.
+			ApprovedBy string
+		}
+		// DeleteApprovals holds details about calls to the DeleteApprovals method.
+		DeleteApprovals []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+		}
 		// DeleteBundle holds details about calls to the DeleteBundle method.
 		DeleteBundle []struct {
 			// Ctx is the ctx argument value.
@@ -289,6 +524,15 @@ type StorerMock struct {
 			// ContentItemID is the contentItemID argument value.
 			ContentItemID string
 		}
+		// DeleteContentItems holds details about calls to the DeleteContentItems method.
+		DeleteContentItems []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+			// ContentItemIDs is the contentItemIDs argument value.
+			ContentItemIDs []string
+		}
 		// GetBundle holds details about calls to the GetBundle method.
 		GetBundle []struct {
 			// Ctx is the ctx argument value.
@@ -303,6 +547,15 @@ type StorerMock struct {
 			// BundleID is the bundleID argument value.
 			BundleID string
 		}
+		// GetBundleView holds details about calls to the GetBundleView method.
+		GetBundleView []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+			// View is the view argument value.
+			View *filters.BundleView
+		}
 		// GetBundlesByPreviewTeamID holds details about calls to the GetBundlesByPreviewTeamID method.
 		GetBundlesByPreviewTeamID []struct {
 			// Ctx is the ctx argument value.
@@ -310,6 +563,15 @@ type StorerMock struct {
 			// TeamID is the teamID argument value.
 			TeamID string
 		}
+		// GetComment holds details about calls to the GetComment method.
+		GetComment []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+			// CommentID is the commentID argument value.
+			CommentID string
+		}
 		// GetContentItemByBundleIDAndContentItemID holds details about calls to the GetContentItemByBundleIDAndContentItemID method.
 		GetContentItemByBundleIDAndContentItemID []struct {
 			// Ctx is the ctx argument value.
@@ -326,6 +588,13 @@ type StorerMock struct {
 			// BundleID is the bundleID argument value.
 			BundleID string
 		}
+		// ListApprovals holds details about calls to the ListApprovals method.
+		ListApprovals []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+		}
 		// ListBundleContentIDsWithoutLimit holds details about calls to the ListBundleContentIDsWithoutLimit method.
 		ListBundleContentIDsWithoutLimit []struct {
 			// Ctx is the ctx argument value.
@@ -343,6 +612,17 @@ type StorerMock struct {
 			Offset int
 			// Limit is the limit argument value.
 			Limit int
+			// SortFields is the sortFields argument value.
+			SortFields []filters.SortField
+			// Cursor is the cursor argument value.
+			Cursor *string
+		}
+		// ListBundleEditors holds details about calls to the ListBundleEditors method.
+		ListBundleEditors []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
 		}
 		// ListBundleEvents holds details about calls to the ListBundleEvents method.
 		ListBundleEvents []struct {
@@ -358,6 +638,10 @@ type StorerMock struct {
 			After *time.Time
 			// Before is the before argument value.
 			Before *time.Time
+			// SortFields is the sortFields argument value.
+			SortFields []filters.SortField
+			// Cursor is the cursor argument value.
+			Cursor *string
 		}
 		// ListBundles holds details about calls to the ListBundles method.
 		ListBundles []struct {
@@ -370,6 +654,113 @@ type StorerMock struct {
 			// FiltersMoqParam is the filtersMoqParam argument value.
 			FiltersMoqParam *filters.BundleFilters
 		}
+		// ListBundlesByIDs holds details about calls to the ListBundlesByIDs method.
+		ListBundlesByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleIDs is the bundleIDs argument value.
+			BundleIDs []string
+		}
+		// ListBundlesScheduledBetween holds details about calls to the ListBundlesScheduledBetween method.
+		ListBundlesScheduledBetween []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+		}
+		// ListComments holds details about calls to the ListComments method.
+		ListComments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+			// ContentItemID is the contentItemID argument value.
+			ContentItemID string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// ListContentItemsByDatasetIDs holds details about calls to the ListContentItemsByDatasetIDs method.
+		ListContentItemsByDatasetIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DatasetIDs is the datasetIDs argument value.
+			DatasetIDs []string
+			// ExcludeBundleID is the excludeBundleID argument value.
+			ExcludeBundleID string
+		}
+		// ListContents holds details about calls to the ListContents method.
+		ListContents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+			// ContentFilters is the contentFilters argument value.
+			ContentFilters *filters.ContentFilters
+		}
+		// ListPublishRuns holds details about calls to the ListPublishRuns method.
+		ListPublishRuns []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// ListScheduledBundles holds details about calls to the ListScheduledBundles method.
+		ListScheduledBundles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// ReleaseScheduledBundleClaim holds details about calls to the ReleaseScheduledBundleClaim method.
+		ReleaseScheduledBundleClaim []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+			// Owner is the owner argument value.
+			Owner string
+		}
+		// RenewPublishRun holds details about calls to the RenewPublishRun method.
+		RenewPublishRun []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PublishRunID is the publishRunID argument value.
+			PublishRunID string
+		}
+		// RenewScheduledBundleClaim holds details about calls to the RenewScheduledBundleClaim method.
+		RenewScheduledBundleClaim []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BundleID is the bundleID argument value.
+			BundleID string
+			// Owner is the owner argument value.
+			Owner string
+			// ExpiresAt is the expiresAt argument value.
+			ExpiresAt time.Time
+		}
+		// SearchBundles holds details about calls to the SearchBundles method.
+		SearchBundles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Text is the text argument value.
+			Text string
+			// Offset is the offset argument value.
+			Offset int
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// UpdateBundle holds details about calls to the UpdateBundle method.
 		UpdateBundle []struct {
 			// Ctx is the ctx argument value.
@@ -388,6 +779,17 @@ type StorerMock struct {
 			// Email is the email argument value.
 			Email string
 		}
+		// UpdateCommentResolution holds details about calls to the UpdateCommentResolution method.
+		UpdateCommentResolution []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CommentID is the commentID argument value.
+			CommentID string
+			// ResolvedBy is the resolvedBy argument value.
+			ResolvedBy *models.User
+			// ResolvedAt is the resolvedAt argument value.
+			ResolvedAt *time.Time
+		}
 		// UpdateContentItemDatasetInfo holds details about calls to the UpdateContentItemDatasetInfo method.
 		UpdateContentItemDatasetInfo []struct {
 			// Ctx is the ctx argument value.
@@ -423,6 +825,19 @@ type StorerMock struct {
 			// State is the state argument value.
 			State string
 		}
+		// UpdatePublishRunItem holds details about calls to the UpdatePublishRunItem method.
+		UpdatePublishRunItem []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PublishRunID is the publishRunID argument value.
+			PublishRunID string
+			// ContentItemID is the contentItemID argument value.
+			ContentItemID string
+			// State is the state argument value.
+			State models.PublishRunItemState
+			// LastError is the lastError argument value.
+			LastError string
+		}
 	}
 	lockCheckAllBundleContentsAreApproved             sync.RWMutex
 	lockCheckBundleExists                             sync.RWMutex
@@ -430,27 +845,54 @@ type StorerMock struct {
 	lockCheckBundleExistsByTitleUpdate                sync.RWMutex
 	lockCheckContentItemExistsByDatasetEditionVersion sync.RWMutex
 	lockChecker                                       sync.RWMutex
+	lockClaimDueScheduledBundle                       sync.RWMutex
+	lockClaimStalePublishRun                          sync.RWMutex
 	lockClose                                         sync.RWMutex
+	lockCompletePublishRun                            sync.RWMutex
 	lockCountBundleContents                           sync.RWMutex
+	lockCreateApproval                                sync.RWMutex
 	lockCreateBundle                                  sync.RWMutex
+	lockCreateComment                                 sync.RWMutex
 	lockCreateContentItem                             sync.RWMutex
+	lockCreateContentItems                            sync.RWMutex
 	lockCreateEvent                                   sync.RWMutex
+	lockCreatePublishRun                              sync.RWMutex
+	lockDeleteApproval                                sync.RWMutex
+	lockDeleteApprovals                               sync.RWMutex
 	lockDeleteBundle                                  sync.RWMutex
 	lockDeleteContentItem                             sync.RWMutex
+	lockDeleteContentItems                            sync.RWMutex
 	lockGetBundle                                     sync.RWMutex
 	lockGetBundleContentsForBundle                    sync.RWMutex
+	lockGetBundleView                                 sync.RWMutex
 	lockGetBundlesByPreviewTeamID                     sync.RWMutex
+	lockGetComment                                    sync.RWMutex
 	lockGetContentItemByBundleIDAndContentItemID      sync.RWMutex
 	lockGetContentItemsByBundleID                     sync.RWMutex
+	lockListApprovals                                 sync.RWMutex
 	lockListBundleContentIDsWithoutLimit              sync.RWMutex
 	lockListBundleContents                            sync.RWMutex
+	lockListBundleEditors                             sync.RWMutex
 	lockListBundleEvents                              sync.RWMutex
 	lockListBundles                                   sync.RWMutex
+	lockListBundlesByIDs                              sync.RWMutex
+	lockListBundlesScheduledBetween                   sync.RWMutex
+	lockListComments                                  sync.RWMutex
+	lockListContentItemsByDatasetIDs                  sync.RWMutex
+	lockListContents                                  sync.RWMutex
+	lockListPublishRuns                               sync.RWMutex
+	lockListScheduledBundles                          sync.RWMutex
+	lockReleaseScheduledBundleClaim                   sync.RWMutex
+	lockRenewPublishRun                               sync.RWMutex
+	lockRenewScheduledBundleClaim                     sync.RWMutex
+	lockSearchBundles                                 sync.RWMutex
 	lockUpdateBundle                                  sync.RWMutex
 	lockUpdateBundleETag                              sync.RWMutex
+	lockUpdateCommentResolution                       sync.RWMutex
 	lockUpdateContentItemDatasetInfo                  sync.RWMutex
 	lockUpdateContentItemMetadataAndLinks             sync.RWMutex
 	lockUpdateContentItemState                        sync.RWMutex
+	lockUpdatePublishRunItem                          sync.RWMutex
 }
 
 // CheckAllBundleContentsAreApproved calls CheckAllBundleContentsAreApprovedFunc.
@@ -681,6 +1123,90 @@ func (mock *StorerMock) CheckerCalls() []struct {
 	return calls
 }
 
+// ClaimDueScheduledBundle calls ClaimDueScheduledBundleFunc.
+func (mock *StorerMock) ClaimDueScheduledBundle(ctx context.Context, now time.Time, owner string, lockDuration time.Duration) (*models.Bundle, error) {
+	if mock.ClaimDueScheduledBundleFunc == nil {
+		panic("StorerMock.ClaimDueScheduledBundleFunc: method is nil but Storer.ClaimDueScheduledBundle was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Now          time.Time
+		Owner        string
+		LockDuration time.Duration
+	}{
+		Ctx:          ctx,
+		Now:          now,
+		Owner:        owner,
+		LockDuration: lockDuration,
+	}
+	mock.lockClaimDueScheduledBundle.Lock()
+	mock.calls.ClaimDueScheduledBundle = append(mock.calls.ClaimDueScheduledBundle, callInfo)
+	mock.lockClaimDueScheduledBundle.Unlock()
+	return mock.ClaimDueScheduledBundleFunc(ctx, now, owner, lockDuration)
+}
+
+// ClaimDueScheduledBundleCalls gets all the calls that were made to ClaimDueScheduledBundle.
+// Check the length with:
+//
+//	len(mockedStorer.ClaimDueScheduledBundleCalls())
+func (mock *StorerMock) ClaimDueScheduledBundleCalls() []struct {
+	Ctx          context.Context
+	Now          time.Time
+	Owner        string
+	LockDuration time.Duration
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Now          time.Time
+		Owner        string
+		LockDuration time.Duration
+	}
+	mock.lockClaimDueScheduledBundle.RLock()
+	calls = mock.calls.ClaimDueScheduledBundle
+	mock.lockClaimDueScheduledBundle.RUnlock()
+	return calls
+}
+
+// ClaimStalePublishRun calls ClaimStalePublishRunFunc.
+func (mock *StorerMock) ClaimStalePublishRun(ctx context.Context, staleBefore time.Time, now time.Time) (*models.PublishRun, error) {
+	if mock.ClaimStalePublishRunFunc == nil {
+		panic("StorerMock.ClaimStalePublishRunFunc: method is nil but Storer.ClaimStalePublishRun was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		StaleBefore time.Time
+		Now         time.Time
+	}{
+		Ctx:         ctx,
+		StaleBefore: staleBefore,
+		Now:         now,
+	}
+	mock.lockClaimStalePublishRun.Lock()
+	mock.calls.ClaimStalePublishRun = append(mock.calls.ClaimStalePublishRun, callInfo)
+	mock.lockClaimStalePublishRun.Unlock()
+	return mock.ClaimStalePublishRunFunc(ctx, staleBefore, now)
+}
+
+// ClaimStalePublishRunCalls gets all the calls that were made to ClaimStalePublishRun.
+// Check the length with:
+//
+//	len(mockedStorer.ClaimStalePublishRunCalls())
+func (mock *StorerMock) ClaimStalePublishRunCalls() []struct {
+	Ctx         context.Context
+	StaleBefore time.Time
+	Now         time.Time
+} {
+	var calls []struct {
+		Ctx         context.Context
+		StaleBefore time.Time
+		Now         time.Time
+	}
+	mock.lockClaimStalePublishRun.RLock()
+	calls = mock.calls.ClaimStalePublishRun
+	mock.lockClaimStalePublishRun.RUnlock()
+	return calls
+}
+
 // Close calls CloseFunc.
 func (mock *StorerMock) Close(ctx context.Context) error {
 	if mock.CloseFunc == nil {
@@ -713,6 +1239,46 @@ func (mock *StorerMock) CloseCalls() []struct {
 	return calls
 }
 
+// CompletePublishRun calls CompletePublishRunFunc.
+func (mock *StorerMock) CompletePublishRun(ctx context.Context, publishRunID string, state models.PublishRunState) error {
+	if mock.CompletePublishRunFunc == nil {
+		panic("StorerMock.CompletePublishRunFunc: method is nil but Storer.CompletePublishRun was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		PublishRunID string
+		State        models.PublishRunState
+	}{
+		Ctx:          ctx,
+		PublishRunID: publishRunID,
+		State:        state,
+	}
+	mock.lockCompletePublishRun.Lock()
+	mock.calls.CompletePublishRun = append(mock.calls.CompletePublishRun, callInfo)
+	mock.lockCompletePublishRun.Unlock()
+	return mock.CompletePublishRunFunc(ctx, publishRunID, state)
+}
+
+// CompletePublishRunCalls gets all the calls that were made to CompletePublishRun.
+// Check the length with:
+//
+//	len(mockedStorer.CompletePublishRunCalls())
+func (mock *StorerMock) CompletePublishRunCalls() []struct {
+	Ctx          context.Context
+	PublishRunID string
+	State        models.PublishRunState
+} {
+	var calls []struct {
+		Ctx          context.Context
+		PublishRunID string
+		State        models.PublishRunState
+	}
+	mock.lockCompletePublishRun.RLock()
+	calls = mock.calls.CompletePublishRun
+	mock.lockCompletePublishRun.RUnlock()
+	return calls
+}
+
 // CountBundleContents calls CountBundleContentsFunc.
 func (mock *StorerMock) CountBundleContents(ctx context.Context, bundleID string) (int, error) {
 	if mock.CountBundleContentsFunc == nil {
@@ -749,6 +1315,42 @@ func (mock *StorerMock) CountBundleContentsCalls() []struct {
 	return calls
 }
 
+// CreateApproval calls CreateApprovalFunc.
+func (mock *StorerMock) CreateApproval(ctx context.Context, approval *models.Approval) error {
+	if mock.CreateApprovalFunc == nil {
+		panic("StorerMock.CreateApprovalFunc: method is nil but Storer.CreateApproval was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Approval *models.Approval
+	}{
+		Ctx:      ctx,
+		Approval: approval,
+	}
+	mock.lockCreateApproval.Lock()
+	mock.calls.CreateApproval = append(mock.calls.CreateApproval, callInfo)
+	mock.lockCreateApproval.Unlock()
+	return mock.CreateApprovalFunc(ctx, approval)
+}
+
+// CreateApprovalCalls gets all the calls that were made to CreateApproval.
+// Check the length with:
+//
+//	len(mockedStorer.CreateApprovalCalls())
+func (mock *StorerMock) CreateApprovalCalls() []struct {
+	Ctx      context.Context
+	Approval *models.Approval
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Approval *models.Approval
+	}
+	mock.lockCreateApproval.RLock()
+	calls = mock.calls.CreateApproval
+	mock.lockCreateApproval.RUnlock()
+	return calls
+}
+
 // CreateBundle calls CreateBundleFunc.
 func (mock *StorerMock) CreateBundle(ctx context.Context, bundle *models.Bundle) error {
 	if mock.CreateBundleFunc == nil {
@@ -785,22 +1387,58 @@ func (mock *StorerMock) CreateBundleCalls() []struct {
 	return calls
 }
 
-// CreateContentItem calls CreateContentItemFunc.
-func (mock *StorerMock) CreateContentItem(ctx context.Context, contentItem *models.ContentItem) error {
-	if mock.CreateContentItemFunc == nil {
-		panic("StorerMock.CreateContentItemFunc: method is nil but Storer.CreateContentItem was just called")
+// CreateComment calls CreateCommentFunc.
+func (mock *StorerMock) CreateComment(ctx context.Context, comment *models.Comment) error {
+	if mock.CreateCommentFunc == nil {
+		panic("StorerMock.CreateCommentFunc: method is nil but Storer.CreateComment was just called")
 	}
 	callInfo := struct {
-		Ctx         context.Context
-		ContentItem *models.ContentItem
+		Ctx     context.Context
+		Comment *models.Comment
 	}{
-		Ctx:         ctx,
-		ContentItem: contentItem,
+		Ctx:     ctx,
+		Comment: comment,
 	}
-	mock.lockCreateContentItem.Lock()
-	mock.calls.CreateContentItem = append(mock.calls.CreateContentItem, callInfo)
-	mock.lockCreateContentItem.Unlock()
-	return mock.CreateContentItemFunc(ctx, contentItem)
+	mock.lockCreateComment.Lock()
+	mock.calls.CreateComment = append(mock.calls.CreateComment, callInfo)
+	mock.lockCreateComment.Unlock()
+	return mock.CreateCommentFunc(ctx, comment)
+}
+
+// CreateCommentCalls gets all the calls that were made to CreateComment.
+// Check the length with:
+//
+//	len(mockedStorer.CreateCommentCalls())
+func (mock *StorerMock) CreateCommentCalls() []struct {
+	Ctx     context.Context
+	Comment *models.Comment
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Comment *models.Comment
+	}
+	mock.lockCreateComment.RLock()
+	calls = mock.calls.CreateComment
+	mock.lockCreateComment.RUnlock()
+	return calls
+}
+
+// CreateContentItem calls CreateContentItemFunc.
+func (mock *StorerMock) CreateContentItem(ctx context.Context, contentItem *models.ContentItem) error {
+	if mock.CreateContentItemFunc == nil {
+		panic("StorerMock.CreateContentItemFunc: method is nil but Storer.CreateContentItem was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ContentItem *models.ContentItem
+	}{
+		Ctx:         ctx,
+		ContentItem: contentItem,
+	}
+	mock.lockCreateContentItem.Lock()
+	mock.calls.CreateContentItem = append(mock.calls.CreateContentItem, callInfo)
+	mock.lockCreateContentItem.Unlock()
+	return mock.CreateContentItemFunc(ctx, contentItem)
 }
 
 // CreateContentItemCalls gets all the calls that were made to CreateContentItem.
@@ -821,6 +1459,42 @@ func (mock *StorerMock) CreateContentItemCalls() []struct {
 	return calls
 }
 
+// CreateContentItems calls CreateContentItemsFunc.
+func (mock *StorerMock) CreateContentItems(ctx context.Context, contentItems []*models.ContentItem) error {
+	if mock.CreateContentItemsFunc == nil {
+		panic("StorerMock.CreateContentItemsFunc: method is nil but Storer.CreateContentItems was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		ContentItems []*models.ContentItem
+	}{
+		Ctx:          ctx,
+		ContentItems: contentItems,
+	}
+	mock.lockCreateContentItems.Lock()
+	mock.calls.CreateContentItems = append(mock.calls.CreateContentItems, callInfo)
+	mock.lockCreateContentItems.Unlock()
+	return mock.CreateContentItemsFunc(ctx, contentItems)
+}
+
+// CreateContentItemsCalls gets all the calls that were made to CreateContentItems.
+// Check the length with:
+//
+//	len(mockedStorer.CreateContentItemsCalls())
+func (mock *StorerMock) CreateContentItemsCalls() []struct {
+	Ctx          context.Context
+	ContentItems []*models.ContentItem
+} {
+	var calls []struct {
+		Ctx          context.Context
+		ContentItems []*models.ContentItem
+	}
+	mock.lockCreateContentItems.RLock()
+	calls = mock.calls.CreateContentItems
+	mock.lockCreateContentItems.RUnlock()
+	return calls
+}
+
 // CreateEvent calls CreateEventFunc.
 func (mock *StorerMock) CreateEvent(ctx context.Context, event *models.Event) error {
 	if mock.CreateEventFunc == nil {
@@ -857,6 +1531,118 @@ func (mock *StorerMock) CreateEventCalls() []struct {
 	return calls
 }
 
+// CreatePublishRun calls CreatePublishRunFunc.
+func (mock *StorerMock) CreatePublishRun(ctx context.Context, publishRun *models.PublishRun) error {
+	if mock.CreatePublishRunFunc == nil {
+		panic("StorerMock.CreatePublishRunFunc: method is nil but Storer.CreatePublishRun was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		PublishRun *models.PublishRun
+	}{
+		Ctx:        ctx,
+		PublishRun: publishRun,
+	}
+	mock.lockCreatePublishRun.Lock()
+	mock.calls.CreatePublishRun = append(mock.calls.CreatePublishRun, callInfo)
+	mock.lockCreatePublishRun.Unlock()
+	return mock.CreatePublishRunFunc(ctx, publishRun)
+}
+
+// CreatePublishRunCalls gets all the calls that were made to CreatePublishRun.
+// Check the length with:
+//
+//	len(mockedStorer.CreatePublishRunCalls())
+func (mock *StorerMock) CreatePublishRunCalls() []struct {
+	Ctx        context.Context
+	PublishRun *models.PublishRun
+} {
+	var calls []struct {
+		Ctx        context.Context
+		PublishRun *models.PublishRun
+	}
+	mock.lockCreatePublishRun.RLock()
+	calls = mock.calls.CreatePublishRun
+	mock.lockCreatePublishRun.RUnlock()
+	return calls
+}
+
+// DeleteApproval calls DeleteApprovalFunc.
+func (mock *StorerMock) DeleteApproval(ctx context.Context, bundleID string, approvedBy string) error {
+	if mock.DeleteApprovalFunc == nil {
+		panic("StorerMock.DeleteApprovalFunc: method is nil but Storer.DeleteApproval was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		BundleID   string
+		ApprovedBy string
+	}{
+		Ctx:        ctx,
+		BundleID:   bundleID,
+		ApprovedBy: approvedBy,
+	}
+	mock.lockDeleteApproval.Lock()
+	mock.calls.DeleteApproval = append(mock.calls.DeleteApproval, callInfo)
+	mock.lockDeleteApproval.Unlock()
+	return mock.DeleteApprovalFunc(ctx, bundleID, approvedBy)
+}
+
+// DeleteApprovalCalls gets all the calls that were made to DeleteApproval.
+// Check the length with:
+//
+//	len(mockedStorer.DeleteApprovalCalls())
+func (mock *StorerMock) DeleteApprovalCalls() []struct {
+	Ctx        context.Context
+	BundleID   string
+	ApprovedBy string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		BundleID   string
+		ApprovedBy string
+	}
+	mock.lockDeleteApproval.RLock()
+	calls = mock.calls.DeleteApproval
+	mock.lockDeleteApproval.RUnlock()
+	return calls
+}
+
+// DeleteApprovals calls DeleteApprovalsFunc.
+func (mock *StorerMock) DeleteApprovals(ctx context.Context, bundleID string) (int, error) {
+	if mock.DeleteApprovalsFunc == nil {
+		panic("StorerMock.DeleteApprovalsFunc: method is nil but Storer.DeleteApprovals was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		BundleID string
+	}{
+		Ctx:      ctx,
+		BundleID: bundleID,
+	}
+	mock.lockDeleteApprovals.Lock()
+	mock.calls.DeleteApprovals = append(mock.calls.DeleteApprovals, callInfo)
+	mock.lockDeleteApprovals.Unlock()
+	return mock.DeleteApprovalsFunc(ctx, bundleID)
+}
+
+// DeleteApprovalsCalls gets all the calls that were made to DeleteApprovals.
+// Check the length with:
+//
+//	len(mockedStorer.DeleteApprovalsCalls())
+func (mock *StorerMock) DeleteApprovalsCalls() []struct {
+	Ctx      context.Context
+	BundleID string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		BundleID string
+	}
+	mock.lockDeleteApprovals.RLock()
+	calls = mock.calls.DeleteApprovals
+	mock.lockDeleteApprovals.RUnlock()
+	return calls
+}
+
 // DeleteBundle calls DeleteBundleFunc.
 func (mock *StorerMock) DeleteBundle(ctx context.Context, id string) error {
 	if mock.DeleteBundleFunc == nil {
@@ -929,6 +1715,46 @@ func (mock *StorerMock) DeleteContentItemCalls() []struct {
 	return calls
 }
 
+// DeleteContentItems calls DeleteContentItemsFunc.
+func (mock *StorerMock) DeleteContentItems(ctx context.Context, bundleID string, contentItemIDs []string) (int, error) {
+	if mock.DeleteContentItemsFunc == nil {
+		panic("StorerMock.DeleteContentItemsFunc: method is nil but Storer.DeleteContentItems was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		BundleID       string
+		ContentItemIDs []string
+	}{
+		Ctx:            ctx,
+		BundleID:       bundleID,
+		ContentItemIDs: contentItemIDs,
+	}
+	mock.lockDeleteContentItems.Lock()
+	mock.calls.DeleteContentItems = append(mock.calls.DeleteContentItems, callInfo)
+	mock.lockDeleteContentItems.Unlock()
+	return mock.DeleteContentItemsFunc(ctx, bundleID, contentItemIDs)
+}
+
+// DeleteContentItemsCalls gets all the calls that were made to DeleteContentItems.
+// Check the length with:
+//
+//	len(mockedStorer.DeleteContentItemsCalls())
+func (mock *StorerMock) DeleteContentItemsCalls() []struct {
+	Ctx            context.Context
+	BundleID       string
+	ContentItemIDs []string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		BundleID       string
+		ContentItemIDs []string
+	}
+	mock.lockDeleteContentItems.RLock()
+	calls = mock.calls.DeleteContentItems
+	mock.lockDeleteContentItems.RUnlock()
+	return calls
+}
+
 // GetBundle calls GetBundleFunc.
 func (mock *StorerMock) GetBundle(ctx context.Context, bundleID string) (*models.Bundle, error) {
 	if mock.GetBundleFunc == nil {
@@ -1001,6 +1827,46 @@ func (mock *StorerMock) GetBundleContentsForBundleCalls() []struct {
 	return calls
 }
 
+// GetBundleView calls GetBundleViewFunc.
+func (mock *StorerMock) GetBundleView(ctx context.Context, bundleID string, view *filters.BundleView) (*models.Bundle, error) {
+	if mock.GetBundleViewFunc == nil {
+		panic("StorerMock.GetBundleViewFunc: method is nil but Storer.GetBundleView was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		BundleID string
+		View     *filters.BundleView
+	}{
+		Ctx:      ctx,
+		BundleID: bundleID,
+		View:     view,
+	}
+	mock.lockGetBundleView.Lock()
+	mock.calls.GetBundleView = append(mock.calls.GetBundleView, callInfo)
+	mock.lockGetBundleView.Unlock()
+	return mock.GetBundleViewFunc(ctx, bundleID, view)
+}
+
+// GetBundleViewCalls gets all the calls that were made to GetBundleView.
+// Check the length with:
+//
+//	len(mockedStorer.GetBundleViewCalls())
+func (mock *StorerMock) GetBundleViewCalls() []struct {
+	Ctx      context.Context
+	BundleID string
+	View     *filters.BundleView
+} {
+	var calls []struct {
+		Ctx      context.Context
+		BundleID string
+		View     *filters.BundleView
+	}
+	mock.lockGetBundleView.RLock()
+	calls = mock.calls.GetBundleView
+	mock.lockGetBundleView.RUnlock()
+	return calls
+}
+
 // GetBundlesByPreviewTeamID calls GetBundlesByPreviewTeamIDFunc.
 func (mock *StorerMock) GetBundlesByPreviewTeamID(ctx context.Context, teamID string) ([]*models.Bundle, error) {
 	if mock.GetBundlesByPreviewTeamIDFunc == nil {
@@ -1037,6 +1903,46 @@ func (mock *StorerMock) GetBundlesByPreviewTeamIDCalls() []struct {
 	return calls
 }
 
+// GetComment calls GetCommentFunc.
+func (mock *StorerMock) GetComment(ctx context.Context, bundleID string, commentID string) (*models.Comment, error) {
+	if mock.GetCommentFunc == nil {
+		panic("StorerMock.GetCommentFunc: method is nil but Storer.GetComment was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		BundleID  string
+		CommentID string
+	}{
+		Ctx:       ctx,
+		BundleID:  bundleID,
+		CommentID: commentID,
+	}
+	mock.lockGetComment.Lock()
+	mock.calls.GetComment = append(mock.calls.GetComment, callInfo)
+	mock.lockGetComment.Unlock()
+	return mock.GetCommentFunc(ctx, bundleID, commentID)
+}
+
+// GetCommentCalls gets all the calls that were made to GetComment.
+// Check the length with:
+//
+//	len(mockedStorer.GetCommentCalls())
+func (mock *StorerMock) GetCommentCalls() []struct {
+	Ctx       context.Context
+	BundleID  string
+	CommentID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		BundleID  string
+		CommentID string
+	}
+	mock.lockGetComment.RLock()
+	calls = mock.calls.GetComment
+	mock.lockGetComment.RUnlock()
+	return calls
+}
+
 // GetContentItemByBundleIDAndContentItemID calls GetContentItemByBundleIDAndContentItemIDFunc.
 func (mock *StorerMock) GetContentItemByBundleIDAndContentItemID(ctx context.Context, bundleID string, contentItemID string) (*models.ContentItem, error) {
 	if mock.GetContentItemByBundleIDAndContentItemIDFunc == nil {
@@ -1113,6 +2019,42 @@ func (mock *StorerMock) GetContentItemsByBundleIDCalls() []struct {
 	return calls
 }
 
+// ListApprovals calls ListApprovalsFunc.
+func (mock *StorerMock) ListApprovals(ctx context.Context, bundleID string) ([]*models.Approval, error) {
+	if mock.ListApprovalsFunc == nil {
+		panic("StorerMock.ListApprovalsFunc: method is nil but Storer.ListApprovals was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		BundleID string
+	}{
+		Ctx:      ctx,
+		BundleID: bundleID,
+	}
+	mock.lockListApprovals.Lock()
+	mock.calls.ListApprovals = append(mock.calls.ListApprovals, callInfo)
+	mock.lockListApprovals.Unlock()
+	return mock.ListApprovalsFunc(ctx, bundleID)
+}
+
+// ListApprovalsCalls gets all the calls that were made to ListApprovals.
+// Check the length with:
+//
+//	len(mockedStorer.ListApprovalsCalls())
+func (mock *StorerMock) ListApprovalsCalls() []struct {
+	Ctx      context.Context
+	BundleID string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		BundleID string
+	}
+	mock.lockListApprovals.RLock()
+	calls = mock.calls.ListApprovals
+	mock.lockListApprovals.RUnlock()
+	return calls
+}
+
 // ListBundleContentIDsWithoutLimit calls ListBundleContentIDsWithoutLimitFunc.
 func (mock *StorerMock) ListBundleContentIDsWithoutLimit(ctx context.Context, bundleID string) ([]*models.ContentItem, error) {
 	if mock.ListBundleContentIDsWithoutLimitFunc == nil {
@@ -1150,25 +2092,29 @@ func (mock *StorerMock) ListBundleContentIDsWithoutLimitCalls() []struct {
 }
 
 // ListBundleContents calls ListBundleContentsFunc.
-func (mock *StorerMock) ListBundleContents(ctx context.Context, bundleID string, offset int, limit int) ([]*models.ContentItem, int, error) {
+func (mock *StorerMock) ListBundleContents(ctx context.Context, bundleID string, offset int, limit int, sortFields []filters.SortField, cursor *string) ([]*models.ContentItem, int, string, error) {
 	if mock.ListBundleContentsFunc == nil {
 		panic("StorerMock.ListBundleContentsFunc: method is nil but Storer.ListBundleContents was just called")
 	}
 	callInfo := struct {
-		Ctx      context.Context
-		BundleID string
-		Offset   int
-		Limit    int
+		Ctx        context.Context
+		BundleID   string
+		Offset     int
+		Limit      int
+		SortFields []filters.SortField
+		Cursor     *string
 	}{
-		Ctx:      ctx,
-		BundleID: bundleID,
-		Offset:   offset,
-		Limit:    limit,
+		Ctx:        ctx,
+		BundleID:   bundleID,
+		Offset:     offset,
+		Limit:      limit,
+		SortFields: sortFields,
+		Cursor:     cursor,
 	}
 	mock.lockListBundleContents.Lock()
 	mock.calls.ListBundleContents = append(mock.calls.ListBundleContents, callInfo)
 	mock.lockListBundleContents.Unlock()
-	return mock.ListBundleContentsFunc(ctx, bundleID, offset, limit)
+	return mock.ListBundleContentsFunc(ctx, bundleID, offset, limit, sortFields, cursor)
 }
 
 // ListBundleContentsCalls gets all the calls that were made to ListBundleContents.
@@ -1176,16 +2122,20 @@ func (mock *StorerMock) ListBundleContents(ctx context.Context, bundleID string,
 //
 //	len(mockedStorer.ListBundleContentsCalls())
 func (mock *StorerMock) ListBundleContentsCalls() []struct {
-	Ctx      context.Context
-	BundleID string
-	Offset   int
-	Limit    int
+	Ctx        context.Context
+	BundleID   string
+	Offset     int
+	Limit      int
+	SortFields []filters.SortField
+	Cursor     *string
 } {
 	var calls []struct {
-		Ctx      context.Context
-		BundleID string
-		Offset   int
-		Limit    int
+		Ctx        context.Context
+		BundleID   string
+		Offset     int
+		Limit      int
+		SortFields []filters.SortField
+		Cursor     *string
 	}
 	mock.lockListBundleContents.RLock()
 	calls = mock.calls.ListBundleContents
@@ -1193,30 +2143,70 @@ func (mock *StorerMock) ListBundleContentsCalls() []struct {
 	return calls
 }
 
-// ListBundleEvents calls ListBundleEventsFunc.
-func (mock *StorerMock) ListBundleEvents(ctx context.Context, offset int, limit int, bundleID string, after *time.Time, before *time.Time) ([]*models.Event, int, error) {
-	if mock.ListBundleEventsFunc == nil {
-		panic("StorerMock.ListBundleEventsFunc: method is nil but Storer.ListBundleEvents was just called")
+// ListBundleEditors calls ListBundleEditorsFunc.
+func (mock *StorerMock) ListBundleEditors(ctx context.Context, bundleID string) ([]string, error) {
+	if mock.ListBundleEditorsFunc == nil {
+		panic("StorerMock.ListBundleEditorsFunc: method is nil but Storer.ListBundleEditors was just called")
 	}
 	callInfo := struct {
 		Ctx      context.Context
-		Offset   int
-		Limit    int
 		BundleID string
-		After    *time.Time
-		Before   *time.Time
 	}{
 		Ctx:      ctx,
-		Offset:   offset,
-		Limit:    limit,
 		BundleID: bundleID,
-		After:    after,
-		Before:   before,
+	}
+	mock.lockListBundleEditors.Lock()
+	mock.calls.ListBundleEditors = append(mock.calls.ListBundleEditors, callInfo)
+	mock.lockListBundleEditors.Unlock()
+	return mock.ListBundleEditorsFunc(ctx, bundleID)
+}
+
+// ListBundleEditorsCalls gets all the calls that were made to ListBundleEditors.
+// Check the length with:
+//
+//	len(mockedStorer.ListBundleEditorsCalls())
+func (mock *StorerMock) ListBundleEditorsCalls() []struct {
+	Ctx      context.Context
+	BundleID string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		BundleID string
+	}
+	mock.lockListBundleEditors.RLock()
+	calls = mock.calls.ListBundleEditors
+	mock.lockListBundleEditors.RUnlock()
+	return calls
+}
+
+// ListBundleEvents calls ListBundleEventsFunc.
+func (mock *StorerMock) ListBundleEvents(ctx context.Context, offset int, limit int, bundleID string, after *time.Time, before *time.Time, sortFields []filters.SortField, cursor *string) ([]*models.Event, int, string, error) {
+	if mock.ListBundleEventsFunc == nil {
+		panic("StorerMock.ListBundleEventsFunc: method is nil but Storer.ListBundleEvents was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Offset     int
+		Limit      int
+		BundleID   string
+		After      *time.Time
+		Before     *time.Time
+		SortFields []filters.SortField
+		Cursor     *string
+	}{
+		Ctx:        ctx,
+		Offset:     offset,
+		Limit:      limit,
+		BundleID:   bundleID,
+		After:      after,
+		Before:     before,
+		SortFields: sortFields,
+		Cursor:     cursor,
 	}
 	mock.lockListBundleEvents.Lock()
 	mock.calls.ListBundleEvents = append(mock.calls.ListBundleEvents, callInfo)
 	mock.lockListBundleEvents.Unlock()
-	return mock.ListBundleEventsFunc(ctx, offset, limit, bundleID, after, before)
+	return mock.ListBundleEventsFunc(ctx, offset, limit, bundleID, after, before, sortFields, cursor)
 }
 
 // ListBundleEventsCalls gets all the calls that were made to ListBundleEvents.
@@ -1224,20 +2214,24 @@ func (mock *StorerMock) ListBundleEvents(ctx context.Context, offset int, limit
 //
 //	len(mockedStorer.ListBundleEventsCalls())
 func (mock *StorerMock) ListBundleEventsCalls() []struct {
-	Ctx      context.Context
-	Offset   int
-	Limit    int
-	BundleID string
-	After    *time.Time
-	Before   *time.Time
+	Ctx        context.Context
+	Offset     int
+	Limit      int
+	BundleID   string
+	After      *time.Time
+	Before     *time.Time
+	SortFields []filters.SortField
+	Cursor     *string
 } {
 	var calls []struct {
-		Ctx      context.Context
-		Offset   int
-		Limit    int
-		BundleID string
-		After    *time.Time
-		Before   *time.Time
+		Ctx        context.Context
+		Offset     int
+		Limit      int
+		BundleID   string
+		After      *time.Time
+		Before     *time.Time
+		SortFields []filters.SortField
+		Cursor     *string
 	}
 	mock.lockListBundleEvents.RLock()
 	calls = mock.calls.ListBundleEvents
@@ -1246,7 +2240,7 @@ func (mock *StorerMock) ListBundleEventsCalls() []struct {
 }
 
 // ListBundles calls ListBundlesFunc.
-func (mock *StorerMock) ListBundles(ctx context.Context, offset int, limit int, filtersMoqParam *filters.BundleFilters) ([]*models.Bundle, int, error) {
+func (mock *StorerMock) ListBundles(ctx context.Context, offset int, limit int, filtersMoqParam *filters.BundleFilters) ([]*models.Bundle, int, string, error) {
 	if mock.ListBundlesFunc == nil {
 		panic("StorerMock.ListBundlesFunc: method is nil but Storer.ListBundles was just called")
 	}
@@ -1289,6 +2283,462 @@ func (mock *StorerMock) ListBundlesCalls() []struct {
 	return calls
 }
 
+// ListBundlesByIDs calls ListBundlesByIDsFunc.
+func (mock *StorerMock) ListBundlesByIDs(ctx context.Context, bundleIDs []string) ([]*models.Bundle, error) {
+	if mock.ListBundlesByIDsFunc == nil {
+		panic("StorerMock.ListBundlesByIDsFunc: method is nil but Storer.ListBundlesByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		BundleIDs []string
+	}{
+		Ctx:       ctx,
+		BundleIDs: bundleIDs,
+	}
+	mock.lockListBundlesByIDs.Lock()
+	mock.calls.ListBundlesByIDs = append(mock.calls.ListBundlesByIDs, callInfo)
+	mock.lockListBundlesByIDs.Unlock()
+	return mock.ListBundlesByIDsFunc(ctx, bundleIDs)
+}
+
+// ListBundlesByIDsCalls gets all the calls that were made to ListBundlesByIDs.
+// Check the length with:
+//
+//	len(mockedStorer.ListBundlesByIDsCalls())
+func (mock *StorerMock) ListBundlesByIDsCalls() []struct {
+	Ctx       context.Context
+	BundleIDs []string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		BundleIDs []string
+	}
+	mock.lockListBundlesByIDs.RLock()
+	calls = mock.calls.ListBundlesByIDs
+	mock.lockListBundlesByIDs.RUnlock()
+	return calls
+}
+
+// ListBundlesScheduledBetween calls ListBundlesScheduledBetweenFunc.
+func (mock *StorerMock) ListBundlesScheduledBetween(ctx context.Context, from time.Time, to time.Time) ([]*models.Bundle, error) {
+	if mock.ListBundlesScheduledBetweenFunc == nil {
+		panic("StorerMock.ListBundlesScheduledBetweenFunc: method is nil but Storer.ListBundlesScheduledBetween was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		From time.Time
+		To   time.Time
+	}{
+		Ctx:  ctx,
+		From: from,
+		To:   to,
+	}
+	mock.lockListBundlesScheduledBetween.Lock()
+	mock.calls.ListBundlesScheduledBetween = append(mock.calls.ListBundlesScheduledBetween, callInfo)
+	mock.lockListBundlesScheduledBetween.Unlock()
+	return mock.ListBundlesScheduledBetweenFunc(ctx, from, to)
+}
+
+// ListBundlesScheduledBetweenCalls gets all the calls that were made to ListBundlesScheduledBetween.
+// Check the length with:
+//
+//	len(mockedStorer.ListBundlesScheduledBetweenCalls())
+func (mock *StorerMock) ListBundlesScheduledBetweenCalls() []struct {
+	Ctx  context.Context
+	From time.Time
+	To   time.Time
+} {
+	var calls []struct {
+		Ctx  context.Context
+		From time.Time
+		To   time.Time
+	}
+	mock.lockListBundlesScheduledBetween.RLock()
+	calls = mock.calls.ListBundlesScheduledBetween
+	mock.lockListBundlesScheduledBetween.RUnlock()
+	return calls
+}
+
+// ListComments calls ListCommentsFunc.
+func (mock *StorerMock) ListComments(ctx context.Context, bundleID string, contentItemID string, offset int, limit int) ([]*models.Comment, int, error) {
+	if mock.ListCommentsFunc == nil {
+		panic("StorerMock.ListCommentsFunc: method is nil but Storer.ListComments was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BundleID      string
+		ContentItemID string
+		Offset        int
+		Limit         int
+	}{
+		Ctx:           ctx,
+		BundleID:      bundleID,
+		ContentItemID: contentItemID,
+		Offset:        offset,
+		Limit:         limit,
+	}
+	mock.lockListComments.Lock()
+	mock.calls.ListComments = append(mock.calls.ListComments, callInfo)
+	mock.lockListComments.Unlock()
+	return mock.ListCommentsFunc(ctx, bundleID, contentItemID, offset, limit)
+}
+
+// ListCommentsCalls gets all the calls that were made to ListComments.
+// Check the length with:
+//
+//	len(mockedStorer.ListCommentsCalls())
+func (mock *StorerMock) ListCommentsCalls() []struct {
+	Ctx           context.Context
+	BundleID      string
+	ContentItemID string
+	Offset        int
+	Limit         int
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BundleID      string
+		ContentItemID string
+		Offset        int
+		Limit         int
+	}
+	mock.lockListComments.RLock()
+	calls = mock.calls.ListComments
+	mock.lockListComments.RUnlock()
+	return calls
+}
+
+// ListContentItemsByDatasetIDs calls ListContentItemsByDatasetIDsFunc.
+func (mock *StorerMock) ListContentItemsByDatasetIDs(ctx context.Context, datasetIDs []string, excludeBundleID string) ([]*models.ContentItem, error) {
+	if mock.ListContentItemsByDatasetIDsFunc == nil {
+		panic("StorerMock.ListContentItemsByDatasetIDsFunc: method is nil but Storer.ListContentItemsByDatasetIDs was just called")
+	}
+	callInfo := struct {
+		Ctx             context.Context
+		DatasetIDs      []string
+		ExcludeBundleID string
+	}{
+		Ctx:             ctx,
+		DatasetIDs:      datasetIDs,
+		ExcludeBundleID: excludeBundleID,
+	}
+	mock.lockListContentItemsByDatasetIDs.Lock()
+	mock.calls.ListContentItemsByDatasetIDs = append(mock.calls.ListContentItemsByDatasetIDs, callInfo)
+	mock.lockListContentItemsByDatasetIDs.Unlock()
+	return mock.ListContentItemsByDatasetIDsFunc(ctx, datasetIDs, excludeBundleID)
+}
+
+// ListContentItemsByDatasetIDsCalls gets all the calls that were made to ListContentItemsByDatasetIDs.
+// Check the length with:
+//
+//	len(mockedStorer.ListContentItemsByDatasetIDsCalls())
+func (mock *StorerMock) ListContentItemsByDatasetIDsCalls() []struct {
+	Ctx             context.Context
+	DatasetIDs      []string
+	ExcludeBundleID string
+} {
+	var calls []struct {
+		Ctx             context.Context
+		DatasetIDs      []string
+		ExcludeBundleID string
+	}
+	mock.lockListContentItemsByDatasetIDs.RLock()
+	calls = mock.calls.ListContentItemsByDatasetIDs
+	mock.lockListContentItemsByDatasetIDs.RUnlock()
+	return calls
+}
+
+// ListContents calls ListContentsFunc.
+func (mock *StorerMock) ListContents(ctx context.Context, offset int, limit int, contentFilters *filters.ContentFilters) ([]*models.ContentItem, int, string, error) {
+	if mock.ListContentsFunc == nil {
+		panic("StorerMock.ListContentsFunc: method is nil but Storer.ListContents was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		Offset         int
+		Limit          int
+		ContentFilters *filters.ContentFilters
+	}{
+		Ctx:            ctx,
+		Offset:         offset,
+		Limit:          limit,
+		ContentFilters: contentFilters,
+	}
+	mock.lockListContents.Lock()
+	mock.calls.ListContents = append(mock.calls.ListContents, callInfo)
+	mock.lockListContents.Unlock()
+	return mock.ListContentsFunc(ctx, offset, limit, contentFilters)
+}
+
+// ListContentsCalls gets all the calls that were made to ListContents.
+// Check the length with:
+//
+//	len(mockedStorer.ListContentsCalls())
+func (mock *StorerMock) ListContentsCalls() []struct {
+	Ctx            context.Context
+	Offset         int
+	Limit          int
+	ContentFilters *filters.ContentFilters
+} {
+	var calls []struct {
+		Ctx            context.Context
+		Offset         int
+		Limit          int
+		ContentFilters *filters.ContentFilters
+	}
+	mock.lockListContents.RLock()
+	calls = mock.calls.ListContents
+	mock.lockListContents.RUnlock()
+	return calls
+}
+
+// ListPublishRuns calls ListPublishRunsFunc.
+func (mock *StorerMock) ListPublishRuns(ctx context.Context, bundleID string, offset int, limit int) ([]*models.PublishRun, int, error) {
+	if mock.ListPublishRunsFunc == nil {
+		panic("StorerMock.ListPublishRunsFunc: method is nil but Storer.ListPublishRuns was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		BundleID string
+		Offset   int
+		Limit    int
+	}{
+		Ctx:      ctx,
+		BundleID: bundleID,
+		Offset:   offset,
+		Limit:    limit,
+	}
+	mock.lockListPublishRuns.Lock()
+	mock.calls.ListPublishRuns = append(mock.calls.ListPublishRuns, callInfo)
+	mock.lockListPublishRuns.Unlock()
+	return mock.ListPublishRunsFunc(ctx, bundleID, offset, limit)
+}
+
+// ListPublishRunsCalls gets all the calls that were made to ListPublishRuns.
+// Check the length with:
+//
+//	len(mockedStorer.ListPublishRunsCalls())
+func (mock *StorerMock) ListPublishRunsCalls() []struct {
+	Ctx      context.Context
+	BundleID string
+	Offset   int
+	Limit    int
+} {
+	var calls []struct {
+		Ctx      context.Context
+		BundleID string
+		Offset   int
+		Limit    int
+	}
+	mock.lockListPublishRuns.RLock()
+	calls = mock.calls.ListPublishRuns
+	mock.lockListPublishRuns.RUnlock()
+	return calls
+}
+
+// ListScheduledBundles calls ListScheduledBundlesFunc.
+func (mock *StorerMock) ListScheduledBundles(ctx context.Context, offset int, limit int) ([]*models.Bundle, int, error) {
+	if mock.ListScheduledBundlesFunc == nil {
+		panic("StorerMock.ListScheduledBundlesFunc: method is nil but Storer.ListScheduledBundles was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockListScheduledBundles.Lock()
+	mock.calls.ListScheduledBundles = append(mock.calls.ListScheduledBundles, callInfo)
+	mock.lockListScheduledBundles.Unlock()
+	return mock.ListScheduledBundlesFunc(ctx, offset, limit)
+}
+
+// ListScheduledBundlesCalls gets all the calls that were made to ListScheduledBundles.
+// Check the length with:
+//
+//	len(mockedStorer.ListScheduledBundlesCalls())
+func (mock *StorerMock) ListScheduledBundlesCalls() []struct {
+	Ctx    context.Context
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Offset int
+		Limit  int
+	}
+	mock.lockListScheduledBundles.RLock()
+	calls = mock.calls.ListScheduledBundles
+	mock.lockListScheduledBundles.RUnlock()
+	return calls
+}
+
+// ReleaseScheduledBundleClaim calls ReleaseScheduledBundleClaimFunc.
+func (mock *StorerMock) ReleaseScheduledBundleClaim(ctx context.Context, bundleID string, owner string) error {
+	if mock.ReleaseScheduledBundleClaimFunc == nil {
+		panic("StorerMock.ReleaseScheduledBundleClaimFunc: method is nil but Storer.ReleaseScheduledBundleClaim was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		BundleID string
+		Owner    string
+	}{
+		Ctx:      ctx,
+		BundleID: bundleID,
+		Owner:    owner,
+	}
+	mock.lockReleaseScheduledBundleClaim.Lock()
+	mock.calls.ReleaseScheduledBundleClaim = append(mock.calls.ReleaseScheduledBundleClaim, callInfo)
+	mock.lockReleaseScheduledBundleClaim.Unlock()
+	return mock.ReleaseScheduledBundleClaimFunc(ctx, bundleID, owner)
+}
+
+// ReleaseScheduledBundleClaimCalls gets all the calls that were made to ReleaseScheduledBundleClaim.
+// Check the length with:
+//
+//	len(mockedStorer.ReleaseScheduledBundleClaimCalls())
+func (mock *StorerMock) ReleaseScheduledBundleClaimCalls() []struct {
+	Ctx      context.Context
+	BundleID string
+	Owner    string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		BundleID string
+		Owner    string
+	}
+	mock.lockReleaseScheduledBundleClaim.RLock()
+	calls = mock.calls.ReleaseScheduledBundleClaim
+	mock.lockReleaseScheduledBundleClaim.RUnlock()
+	return calls
+}
+
+// RenewPublishRun calls RenewPublishRunFunc.
+func (mock *StorerMock) RenewPublishRun(ctx context.Context, publishRunID string) error {
+	if mock.RenewPublishRunFunc == nil {
+		panic("StorerMock.RenewPublishRunFunc: method is nil but Storer.RenewPublishRun was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		PublishRunID string
+	}{
+		Ctx:          ctx,
+		PublishRunID: publishRunID,
+	}
+	mock.lockRenewPublishRun.Lock()
+	mock.calls.RenewPublishRun = append(mock.calls.RenewPublishRun, callInfo)
+	mock.lockRenewPublishRun.Unlock()
+	return mock.RenewPublishRunFunc(ctx, publishRunID)
+}
+
+// RenewPublishRunCalls gets all the calls that were made to RenewPublishRun.
+// Check the length with:
+//
+//	len(mockedStorer.RenewPublishRunCalls())
+func (mock *StorerMock) RenewPublishRunCalls() []struct {
+	Ctx          context.Context
+	PublishRunID string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		PublishRunID string
+	}
+	mock.lockRenewPublishRun.RLock()
+	calls = mock.calls.RenewPublishRun
+	mock.lockRenewPublishRun.RUnlock()
+	return calls
+}
+
+// RenewScheduledBundleClaim calls RenewScheduledBundleClaimFunc.
+func (mock *StorerMock) RenewScheduledBundleClaim(ctx context.Context, bundleID string, owner string, expiresAt time.Time) (bool, error) {
+	if mock.RenewScheduledBundleClaimFunc == nil {
+		panic("StorerMock.RenewScheduledBundleClaimFunc: method is nil but Storer.RenewScheduledBundleClaim was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		BundleID  string
+		Owner     string
+		ExpiresAt time.Time
+	}{
+		Ctx:       ctx,
+		BundleID:  bundleID,
+		Owner:     owner,
+		ExpiresAt: expiresAt,
+	}
+	mock.lockRenewScheduledBundleClaim.Lock()
+	mock.calls.RenewScheduledBundleClaim = append(mock.calls.RenewScheduledBundleClaim, callInfo)
+	mock.lockRenewScheduledBundleClaim.Unlock()
+	return mock.RenewScheduledBundleClaimFunc(ctx, bundleID, owner, expiresAt)
+}
+
+// RenewScheduledBundleClaimCalls gets all the calls that were made to RenewScheduledBundleClaim.
+// Check the length with:
+//
+//	len(mockedStorer.RenewScheduledBundleClaimCalls())
+func (mock *StorerMock) RenewScheduledBundleClaimCalls() []struct {
+	Ctx       context.Context
+	BundleID  string
+	Owner     string
+	ExpiresAt time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		BundleID  string
+		Owner     string
+		ExpiresAt time.Time
+	}
+	mock.lockRenewScheduledBundleClaim.RLock()
+	calls = mock.calls.RenewScheduledBundleClaim
+	mock.lockRenewScheduledBundleClaim.RUnlock()
+	return calls
+}
+
+// SearchBundles calls SearchBundlesFunc.
+func (mock *StorerMock) SearchBundles(ctx context.Context, text string, offset int, limit int) ([]*models.BundleSearchResult, int, error) {
+	if mock.SearchBundlesFunc == nil {
+		panic("StorerMock.SearchBundlesFunc: method is nil but Storer.SearchBundles was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Text   string
+		Offset int
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Text:   text,
+		Offset: offset,
+		Limit:  limit,
+	}
+	mock.lockSearchBundles.Lock()
+	mock.calls.SearchBundles = append(mock.calls.SearchBundles, callInfo)
+	mock.lockSearchBundles.Unlock()
+	return mock.SearchBundlesFunc(ctx, text, offset, limit)
+}
+
+// SearchBundlesCalls gets all the calls that were made to SearchBundles.
+// Check the length with:
+//
+//	len(mockedStorer.SearchBundlesCalls())
+func (mock *StorerMock) SearchBundlesCalls() []struct {
+	Ctx    context.Context
+	Text   string
+	Offset int
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Text   string
+		Offset int
+		Limit  int
+	}
+	mock.lockSearchBundles.RLock()
+	calls = mock.calls.SearchBundles
+	mock.lockSearchBundles.RUnlock()
+	return calls
+}
+
 // UpdateBundle calls UpdateBundleFunc.
 func (mock *StorerMock) UpdateBundle(ctx context.Context, id string, update *models.Bundle) (*models.Bundle, error) {
 	if mock.UpdateBundleFunc == nil {
@@ -1369,6 +2819,50 @@ func (mock *StorerMock) UpdateBundleETagCalls() []struct {
 	return calls
 }
 
+// UpdateCommentResolution calls UpdateCommentResolutionFunc.
+func (mock *StorerMock) UpdateCommentResolution(ctx context.Context, commentID string, resolvedBy *models.User, resolvedAt *time.Time) error {
+	if mock.UpdateCommentResolutionFunc == nil {
+		panic("StorerMock.UpdateCommentResolutionFunc: method is nil but Storer.UpdateCommentResolution was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		CommentID  string
+		ResolvedBy *models.User
+		ResolvedAt *time.Time
+	}{
+		Ctx:        ctx,
+		CommentID:  commentID,
+		ResolvedBy: resolvedBy,
+		ResolvedAt: resolvedAt,
+	}
+	mock.lockUpdateCommentResolution.Lock()
+	mock.calls.UpdateCommentResolution = append(mock.calls.UpdateCommentResolution, callInfo)
+	mock.lockUpdateCommentResolution.Unlock()
+	return mock.UpdateCommentResolutionFunc(ctx, commentID, resolvedBy, resolvedAt)
+}
+
+// UpdateCommentResolutionCalls gets all the calls that were made to UpdateCommentResolution.
+// Check the length with:
+//
+//	len(mockedStorer.UpdateCommentResolutionCalls())
+func (mock *StorerMock) UpdateCommentResolutionCalls() []struct {
+	Ctx        context.Context
+	CommentID  string
+	ResolvedBy *models.User
+	ResolvedAt *time.Time
+} {
+	var calls []struct {
+		Ctx        context.Context
+		CommentID  string
+		ResolvedBy *models.User
+		ResolvedAt *time.Time
+	}
+	mock.lockUpdateCommentResolution.RLock()
+	calls = mock.calls.UpdateCommentResolution
+	mock.lockUpdateCommentResolution.RUnlock()
+	return calls
+}
+
 // UpdateContentItemDatasetInfo calls UpdateContentItemDatasetInfoFunc.
 func (mock *StorerMock) UpdateContentItemDatasetInfo(ctx context.Context, contentItemID string, title string, state string) error {
 	if mock.UpdateContentItemDatasetInfoFunc == nil {
@@ -1504,3 +2998,51 @@ func (mock *StorerMock) UpdateContentItemStateCalls() []struct {
 	mock.lockUpdateContentItemState.RUnlock()
 	return calls
 }
+
+// UpdatePublishRunItem calls UpdatePublishRunItemFunc.
+func (mock *StorerMock) UpdatePublishRunItem(ctx context.Context, publishRunID string, contentItemID string, state models.PublishRunItemState, lastError string) error {
+	if mock.UpdatePublishRunItemFunc == nil {
+		panic("StorerMock.UpdatePublishRunItemFunc: method is nil but Storer.UpdatePublishRunItem was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		PublishRunID  string
+		ContentItemID string
+		State         models.PublishRunItemState
+		LastError     string
+	}{
+		Ctx:           ctx,
+		PublishRunID:  publishRunID,
+		ContentItemID: contentItemID,
+		State:         state,
+		LastError:     lastError,
+	}
+	mock.lockUpdatePublishRunItem.Lock()
+	mock.calls.UpdatePublishRunItem = append(mock.calls.UpdatePublishRunItem, callInfo)
+	mock.lockUpdatePublishRunItem.Unlock()
+	return mock.UpdatePublishRunItemFunc(ctx, publishRunID, contentItemID, state, lastError)
+}
+
+// UpdatePublishRunItemCalls gets all the calls that were made to UpdatePublishRunItem.
+// Check the length with:
+//
+//	len(mockedStorer.UpdatePublishRunItemCalls())
+func (mock *StorerMock) UpdatePublishRunItemCalls() []struct {
+	Ctx           context.Context
+	PublishRunID  string
+	ContentItemID string
+	State         models.PublishRunItemState
+	LastError     string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		PublishRunID  string
+		ContentItemID string
+		State         models.PublishRunItemState
+		LastError     string
+	}
+	mock.lockUpdatePublishRunItem.RLock()
+	calls = mock.calls.UpdatePublishRunItem
+	mock.lockUpdatePublishRunItem.RUnlock()
+	return calls
+}